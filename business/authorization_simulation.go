@@ -0,0 +1,86 @@
+package business
+
+import (
+	"context"
+
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/business/checkers/authorization"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// SimulateAuthorization evaluates the AuthorizationPolicies that apply to the destination workload of the request
+// and returns if the request would be allowed, with the policy and rule that decided.
+func (in *IstioConfigService) SimulateAuthorization(ctx context.Context, namespace string, request models.AuthorizationSimulationRequest) (models.AuthorizationSimulationResult, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SimulateAuthorization",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", request.Destination.Workload),
+	)
+	defer end()
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(ctx, namespace); err != nil {
+		return models.AuthorizationSimulationResult{}, err
+	}
+	request.Destination.Namespace = namespace
+
+	if request.Destination.Workload != "" && len(request.Destination.Labels) == 0 {
+		workload, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{Namespace: namespace, WorkloadName: request.Destination.Workload})
+		if err != nil {
+			return models.AuthorizationSimulationResult{}, err
+		}
+		request.Destination.Labels = workload.Labels
+	}
+
+	// Only the policies of the destination namespace and the root namespace can apply to the destination workload
+	rootNamespace := config.Get().ExternalServices.Istio.RootNamespace
+	namespaces := []string{namespace}
+	if rootNamespace != namespace {
+		namespaces = append(namespaces, rootNamespace)
+	}
+	policies := make([]*security_v1beta.AuthorizationPolicy, 0)
+	for _, ns := range namespaces {
+		istioConfigList, err := in.GetIstioConfigList(ctx, IstioConfigCriteria{Namespace: ns, IncludeAuthorizationPolicies: true})
+		if err != nil {
+			return models.AuthorizationSimulationResult{}, err
+		}
+		policies = append(policies, istioConfigList.AuthorizationPolicies...)
+	}
+
+	simulator := authorization.AccessSimulator{
+		AuthorizationPolicies: policies,
+		RootNamespace:         rootNamespace,
+		TrustDomain:           in.getTrustDomain(),
+	}
+	return simulator.Simulate(request), nil
+}
+
+// getTrustDomain returns the trust domain of the mesh, empty when it can't be read from the Istio ConfigMap
+func (in *IstioConfigService) getTrustDomain() string {
+	cfg := config.Get()
+	var istioConfig *core_v1.ConfigMap
+	var err error
+	if IsNamespaceCached(cfg.IstioNamespace) {
+		istioConfig, err = kialiCache.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	} else {
+		istioConfig, err = in.k8s.GetConfigMap(cfg.IstioNamespace, cfg.ExternalServices.Istio.ConfigMapName)
+	}
+	if err != nil {
+		log.Debugf("Unable to read the Istio ConfigMap for the trust domain: %s", err)
+		return ""
+	}
+	meshConfig, err := kubernetes.GetIstioConfigMap(istioConfig)
+	if err != nil {
+		log.Debugf("Unable to parse the Istio ConfigMap for the trust domain: %s", err)
+		return ""
+	}
+	return meshConfig.TrustDomain
+}
@@ -0,0 +1,68 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	osproject_v1 "github.com/openshift/api/project/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestSimulateAuthorization(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	allow := data.AddRuleToAuthorizationPolicy(&api_security_v1beta1.Rule{
+		From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{Principals: []string{"example.org/ns/bookinfo/sa/bookinfo-productpage"}}}},
+	}, data.CreateAuthorizationPolicyWithMetaAndSelector("allow-productpage", "bookinfo", map[string]string{"app": "reviews"}))
+	deny := data.AddActionToAuthorizationPolicy(api_security_v1beta1.AuthorizationPolicy_DENY,
+		data.AddRuleToAuthorizationPolicy(&api_security_v1beta1.Rule{
+			To: []*api_security_v1beta1.Rule_To{{Operation: &api_security_v1beta1.Operation{Methods: []string{"DELETE"}}}},
+		}, data.CreateEmptyMeshAuthorizationPolicy("deny-delete")))
+	istioConfigMap := &core_v1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace},
+		Data:       map[string]string{"mesh": "trustDomain: example.org\n"},
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "bookinfo"}},
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "istio-system"}},
+		istioConfigMap, allow, deny,
+	)
+	k8s.OpenShift = true
+	layer := NewWithBackends(k8s, new(prometheustest.PromClientMock), nil)
+
+	request := models.AuthorizationSimulationRequest{
+		Source: models.AuthorizationSimulationSource{Namespace: "bookinfo", ServiceAccount: "bookinfo-productpage"},
+		Destination: models.AuthorizationSimulationDestination{
+			Labels: map[string]string{"app": "reviews"},
+		},
+		Request: models.AuthorizationSimulationOperation{Method: "GET", Path: "/reviews/1", Port: 9080},
+	}
+	result, err := layer.IstioConfig.SimulateAuthorization(context.TODO(), "bookinfo", request)
+	require.NoError(err)
+	assert.True(result.Allowed)
+	require.NotNil(result.DecidedBy)
+	assert.Equal("allow-productpage", result.DecidedBy.Name)
+
+	request.Request.Method = "DELETE"
+	result, err = layer.IstioConfig.SimulateAuthorization(context.TODO(), "bookinfo", request)
+	require.NoError(err)
+	assert.False(result.Allowed)
+	require.NotNil(result.DecidedBy)
+	assert.Equal("deny-delete", result.DecidedBy.Name)
+	assert.Equal("istio-system", result.DecidedBy.Namespace)
+}
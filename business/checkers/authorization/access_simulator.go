@@ -0,0 +1,290 @@
+package authorization
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	api_security_v1beta "istio.io/api/security/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/models"
+)
+
+// AccessSimulator evaluates the AuthorizationPolicies that apply to a destination workload for a given request,
+// following the order used by the Istio proxies: CUSTOM, DENY and ALLOW. AUDIT policies don't affect the decision.
+type AccessSimulator struct {
+	AuthorizationPolicies []*security_v1beta.AuthorizationPolicy
+	RootNamespace         string
+	// TrustDomain is used to build the source principal when it is not given in the request
+	TrustDomain string
+}
+
+// simulatedRequest holds the attributes of the request that the policies can match
+type simulatedRequest struct {
+	models.AuthorizationSimulationRequest
+	principal   string
+	unsupported []string
+}
+
+// Simulate returns the decision for the request, with the policy and rule that decided
+func (s AccessSimulator) Simulate(request models.AuthorizationSimulationRequest) models.AuthorizationSimulationResult {
+	result := models.AuthorizationSimulationResult{
+		Custom:      []models.AuthorizationPolicyMatch{},
+		Audited:     []models.AuthorizationPolicyMatch{},
+		Unsupported: []string{},
+	}
+
+	req := &simulatedRequest{AuthorizationSimulationRequest: request, principal: request.Source.Principal}
+	if req.principal == "" && request.Source.ServiceAccount != "" {
+		trustDomain := s.TrustDomain
+		if trustDomain == "" {
+			trustDomain = "cluster.local"
+		}
+		req.principal = fmt.Sprintf("%s/ns/%s/sa/%s", trustDomain, request.Source.Namespace, request.Source.ServiceAccount)
+	}
+
+	policies := s.applicablePolicies(request.Destination)
+	hasAllowPolicies := false
+	var denied, allowed *models.AuthorizationPolicyMatch
+	for _, ap := range policies {
+		action := ap.Spec.Action
+		if action == api_security_v1beta.AuthorizationPolicy_ALLOW {
+			hasAllowPolicies = true
+		}
+		match := req.matchPolicy(ap)
+		if match == nil {
+			continue
+		}
+		switch action {
+		case api_security_v1beta.AuthorizationPolicy_CUSTOM:
+			result.Custom = append(result.Custom, *match)
+		case api_security_v1beta.AuthorizationPolicy_AUDIT:
+			result.Audited = append(result.Audited, *match)
+		case api_security_v1beta.AuthorizationPolicy_DENY:
+			if denied == nil {
+				denied = match
+			}
+		case api_security_v1beta.AuthorizationPolicy_ALLOW:
+			if allowed == nil {
+				allowed = match
+			}
+		}
+	}
+	result.Unsupported = append(result.Unsupported, req.unsupported...)
+
+	switch {
+	case denied != nil:
+		result.Allowed = false
+		result.DecidedBy = denied
+		result.Reason = fmt.Sprintf("denied by DENY policy %s/%s", denied.Namespace, denied.Name)
+	case !hasAllowPolicies:
+		result.Allowed = true
+		result.Reason = "allowed, no ALLOW policy applies to the destination workload"
+	case allowed != nil:
+		result.Allowed = true
+		result.DecidedBy = allowed
+		result.Reason = fmt.Sprintf("allowed by ALLOW policy %s/%s", allowed.Namespace, allowed.Name)
+	default:
+		result.Allowed = false
+		result.Reason = "denied, no ALLOW policy matches the request"
+	}
+	if len(result.Custom) > 0 {
+		result.Reason += ", assuming the CUSTOM authorizer allows it"
+	}
+
+	return result
+}
+
+// applicablePolicies returns the policies of the root namespace and the destination namespace
+// whose selector matches the destination workload
+func (s AccessSimulator) applicablePolicies(destination models.AuthorizationSimulationDestination) []*security_v1beta.AuthorizationPolicy {
+	policies := make([]*security_v1beta.AuthorizationPolicy, 0)
+	for _, ap := range s.AuthorizationPolicies {
+		if ap.Namespace != destination.Namespace && ap.Namespace != s.RootNamespace {
+			continue
+		}
+		if ap.Spec.Selector != nil && !labels.SelectorFromSet(ap.Spec.Selector.MatchLabels).Matches(labels.Set(destination.Labels)) {
+			continue
+		}
+		policies = append(policies, ap)
+	}
+	return policies
+}
+
+// matchPolicy returns the first rule of the policy that matches the request, nil if none matches.
+// A policy without rules never matches.
+func (r *simulatedRequest) matchPolicy(ap *security_v1beta.AuthorizationPolicy) *models.AuthorizationPolicyMatch {
+	for ruleIdx, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+		if r.matchRule(ap, rule) {
+			return &models.AuthorizationPolicyMatch{
+				Name:      ap.Name,
+				Namespace: ap.Namespace,
+				Action:    ap.Spec.Action.String(),
+				Rule:      ruleIdx,
+				Path:      fmt.Sprintf("spec/rules[%d]", ruleIdx),
+			}
+		}
+	}
+	return nil
+}
+
+// matchRule returns true when any of the "from", any of the "to" and all the "when" conditions match
+func (r *simulatedRequest) matchRule(ap *security_v1beta.AuthorizationPolicy, rule *api_security_v1beta.Rule) bool {
+	if len(rule.From) > 0 {
+		matched := false
+		for _, from := range rule.From {
+			if from != nil && r.matchSource(from.Source) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(rule.To) > 0 {
+		matched := false
+		for _, to := range rule.To {
+			if to != nil && r.matchOperation(to.Operation) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, condition := range rule.When {
+		if condition != nil && !r.matchCondition(ap, condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *simulatedRequest) matchSource(source *api_security_v1beta.Source) bool {
+	if source == nil {
+		return true
+	}
+	ns := r.Source.Namespace
+	ip := r.Source.IP
+	return matchField(r.principal, source.Principals, source.NotPrincipals, matchString) &&
+		matchField(r.Source.RequestPrincipal, source.RequestPrincipals, source.NotRequestPrincipals, matchString) &&
+		matchField(ns, source.Namespaces, source.NotNamespaces, matchString) &&
+		matchField(ip, source.IpBlocks, source.NotIpBlocks, matchIP) &&
+		matchField(ip, source.RemoteIpBlocks, source.NotRemoteIpBlocks, matchIP)
+}
+
+func (r *simulatedRequest) matchOperation(operation *api_security_v1beta.Operation) bool {
+	if operation == nil {
+		return true
+	}
+	port := ""
+	if r.Request.Port > 0 {
+		port = strconv.Itoa(r.Request.Port)
+	}
+	return matchField(r.Request.Host, operation.Hosts, operation.NotHosts, matchHost) &&
+		matchField(port, operation.Ports, operation.NotPorts, matchString) &&
+		matchField(r.Request.Method, operation.Methods, operation.NotMethods, matchString) &&
+		matchField(r.Request.Path, operation.Paths, operation.NotPaths, matchString)
+}
+
+// matchCondition evaluates a "when" condition. Unsupported keys are recorded and considered as not matching.
+func (r *simulatedRequest) matchCondition(ap *security_v1beta.AuthorizationPolicy, condition *api_security_v1beta.Condition) bool {
+	key := condition.Key
+	var value string
+	matcher := matchString
+	switch {
+	case strings.HasPrefix(key, "request.headers[") && strings.HasSuffix(key, "]"):
+		header := key[len("request.headers[") : len(key)-1]
+		for k, v := range r.Request.Headers {
+			if strings.EqualFold(k, header) {
+				value = v
+			}
+		}
+	case key == "source.ip" || key == "remote.ip":
+		value, matcher = r.Source.IP, matchIP
+	case key == "source.namespace":
+		value = r.Source.Namespace
+	case key == "source.principal":
+		value = r.principal
+	case key == "request.auth.principal":
+		value = r.Source.RequestPrincipal
+	case key == "destination.ip":
+		value, matcher = r.Destination.IP, matchIP
+	case key == "destination.port":
+		if r.Request.Port > 0 {
+			value = strconv.Itoa(r.Request.Port)
+		}
+	default:
+		r.unsupported = append(r.unsupported, fmt.Sprintf("%s/%s: condition on key [%s]", ap.Namespace, ap.Name, key))
+		return false
+	}
+	return matchField(value, condition.Values, condition.NotValues, matcher)
+}
+
+// matchField returns true when the value matches any of the values, if any, and none of the notValues.
+// An empty attribute never matches a non empty list of values.
+func matchField(value string, values, notValues []string, matcher func(value, pattern string) bool) bool {
+	if len(values) > 0 {
+		if value == "" {
+			return false
+		}
+		matched := false
+		for _, v := range values {
+			if matcher(value, v) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if value != "" {
+		for _, v := range notValues {
+			if matcher(value, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchString supports the exact, prefix ("abc*"), suffix ("*abc") and presence ("*") matches of the Istio policies
+func matchString(value, pattern string) bool {
+	switch {
+	case pattern == "*":
+		return value != ""
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(value, pattern[1:])
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(value, pattern[:len(pattern)-1])
+	default:
+		return value == pattern
+	}
+}
+
+func matchHost(value, pattern string) bool {
+	return matchString(strings.ToLower(value), strings.ToLower(pattern))
+}
+
+// matchIP matches an IP against a single IP or a CIDR
+func matchIP(value, pattern string) bool {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return false
+	}
+	if _, cidr, err := net.ParseCIDR(pattern); err == nil {
+		return cidr.Contains(ip)
+	}
+	return ip.Equal(net.ParseIP(pattern))
+}
@@ -0,0 +1,186 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_security_v1beta "istio.io/api/security/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func simulationRequest(method, path string) models.AuthorizationSimulationRequest {
+	return models.AuthorizationSimulationRequest{
+		Source: models.AuthorizationSimulationSource{
+			Namespace:      "bookinfo",
+			ServiceAccount: "bookinfo-productpage",
+		},
+		Destination: models.AuthorizationSimulationDestination{
+			Namespace: "bookinfo",
+			Labels:    map[string]string{"app": "reviews", "version": "v1"},
+		},
+		Request: models.AuthorizationSimulationOperation{
+			Method: method,
+			Path:   path,
+			Port:   9080,
+		},
+	}
+}
+
+func principalRule(principals []string, methods []string) *api_security_v1beta.Rule {
+	return &api_security_v1beta.Rule{
+		From: []*api_security_v1beta.Rule_From{{Source: &api_security_v1beta.Source{Principals: principals}}},
+		To:   []*api_security_v1beta.Rule_To{{Operation: &api_security_v1beta.Operation{Methods: methods}}},
+	}
+}
+
+func TestSimulateNoPolicies(t *testing.T) {
+	assert := assert.New(t)
+
+	result := AccessSimulator{RootNamespace: "istio-system"}.Simulate(simulationRequest("GET", "/reviews/1"))
+
+	assert.True(result.Allowed)
+	assert.Nil(result.DecidedBy)
+}
+
+func TestSimulateAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	ap := data.AddRuleToAuthorizationPolicy(principalRule([]string{"cluster.local/ns/bookinfo/sa/bookinfo-productpage"}, []string{"GET"}),
+		data.AddRuleToAuthorizationPolicy(principalRule([]string{"cluster.local/ns/bookinfo/sa/other"}, nil),
+			data.CreateAuthorizationPolicyWithMetaAndSelector("allow-productpage", "bookinfo", map[string]string{"app": "reviews"})))
+
+	result := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{ap},
+		RootNamespace:         "istio-system",
+	}.Simulate(simulationRequest("GET", "/reviews/1"))
+
+	assert.True(result.Allowed)
+	assert.NotNil(result.DecidedBy)
+	assert.Equal("allow-productpage", result.DecidedBy.Name)
+	assert.Equal("ALLOW", result.DecidedBy.Action)
+	assert.Equal(1, result.DecidedBy.Rule)
+	assert.Equal("spec/rules[1]", result.DecidedBy.Path)
+}
+
+func TestSimulateNoAllowMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	ap := data.AddRuleToAuthorizationPolicy(principalRule([]string{"cluster.local/ns/bookinfo/sa/bookinfo-productpage"}, []string{"GET"}),
+		data.CreateAuthorizationPolicyWithMetaAndSelector("allow-productpage", "bookinfo", map[string]string{"app": "reviews"}))
+
+	result := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{ap},
+		RootNamespace:         "istio-system",
+	}.Simulate(simulationRequest("DELETE", "/reviews/1"))
+
+	assert.False(result.Allowed)
+	assert.Nil(result.DecidedBy)
+}
+
+func TestSimulateDenyBeforeAllow(t *testing.T) {
+	assert := assert.New(t)
+
+	allow := data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{},
+		data.CreateEmptyAuthorizationPolicy("allow-all", "bookinfo"))
+	deny := data.AddActionToAuthorizationPolicy(api_security_v1beta.AuthorizationPolicy_DENY,
+		data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{
+			To: []*api_security_v1beta.Rule_To{{Operation: &api_security_v1beta.Operation{Paths: []string{"/admin/*"}}}},
+		}, data.CreateEmptyMeshAuthorizationPolicy("deny-admin")))
+	audit := data.AddActionToAuthorizationPolicy(api_security_v1beta.AuthorizationPolicy_AUDIT,
+		data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{},
+			data.CreateEmptyAuthorizationPolicy("audit-all", "bookinfo")))
+
+	simulator := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{allow, deny, audit},
+		RootNamespace:         "istio-system",
+	}
+
+	result := simulator.Simulate(simulationRequest("GET", "/admin/users"))
+	assert.False(result.Allowed)
+	assert.Equal("deny-admin", result.DecidedBy.Name)
+	assert.Equal("istio-system", result.DecidedBy.Namespace)
+	assert.Len(result.Audited, 1)
+
+	result = simulator.Simulate(simulationRequest("GET", "/reviews/1"))
+	assert.True(result.Allowed)
+	assert.Equal("allow-all", result.DecidedBy.Name)
+}
+
+func TestSimulateSelectorAndNamespace(t *testing.T) {
+	assert := assert.New(t)
+
+	// None of these policies apply to the reviews workload of bookinfo
+	otherWorkload := data.CreateAuthorizationPolicyWithMetaAndSelector("deny-ratings", "bookinfo", map[string]string{"app": "ratings"})
+	otherNamespace := data.CreateEmptyAuthorizationPolicy("deny-all", "bookinfo2")
+
+	result := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{otherWorkload, otherNamespace},
+		RootNamespace:         "istio-system",
+	}.Simulate(simulationRequest("GET", "/reviews/1"))
+
+	assert.True(result.Allowed)
+}
+
+func TestSimulateConditions(t *testing.T) {
+	assert := assert.New(t)
+
+	ap := data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{
+		When: []*api_security_v1beta.Condition{
+			{Key: "request.headers[X-Version]", Values: []string{"v2*"}},
+			{Key: "source.namespace", NotValues: []string{"untrusted"}},
+		},
+	}, data.CreateEmptyAuthorizationPolicy("allow-v2", "bookinfo"))
+	unsupported := data.AddActionToAuthorizationPolicy(api_security_v1beta.AuthorizationPolicy_DENY,
+		data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{
+			When: []*api_security_v1beta.Condition{{Key: "connection.sni", Values: []string{"*"}}},
+		}, data.CreateEmptyAuthorizationPolicy("deny-sni", "bookinfo")))
+
+	simulator := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{ap, unsupported},
+		RootNamespace:         "istio-system",
+	}
+
+	request := simulationRequest("GET", "/reviews/1")
+	request.Request.Headers = map[string]string{"x-version": "v2.1"}
+	result := simulator.Simulate(request)
+	assert.True(result.Allowed)
+	assert.Equal("allow-v2", result.DecidedBy.Name)
+	assert.Len(result.Unsupported, 1)
+
+	request.Request.Headers = map[string]string{"x-version": "v1"}
+	result = simulator.Simulate(request)
+	assert.False(result.Allowed)
+}
+
+func TestSimulateCustom(t *testing.T) {
+	assert := assert.New(t)
+
+	custom := data.AddActionToAuthorizationPolicy(api_security_v1beta.AuthorizationPolicy_CUSTOM,
+		data.AddRuleToAuthorizationPolicy(&api_security_v1beta.Rule{
+			From: []*api_security_v1beta.Rule_From{{Source: &api_security_v1beta.Source{Namespaces: []string{"bookinfo"}}}},
+		}, data.CreateEmptyAuthorizationPolicy("ext-authz", "bookinfo")))
+
+	result := AccessSimulator{
+		AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{custom},
+		RootNamespace:         "istio-system",
+	}.Simulate(simulationRequest("GET", "/reviews/1"))
+
+	assert.True(result.Allowed)
+	assert.Len(result.Custom, 1)
+	assert.Equal("ext-authz", result.Custom[0].Name)
+}
+
+func TestMatchString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(matchString("/reviews/1", "/reviews/*"))
+	assert.True(matchString("reviews.bookinfo.svc.cluster.local", "*.cluster.local"))
+	assert.True(matchString("GET", "*"))
+	assert.False(matchString("", "*"))
+	assert.False(matchString("/ratings", "/reviews"))
+	assert.True(matchIP("10.0.0.12", "10.0.0.0/24"))
+	assert.False(matchIP("10.0.1.12", "10.0.0.0/24"))
+}
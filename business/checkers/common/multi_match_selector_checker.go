@@ -3,6 +3,7 @@ package common
 import (
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/models"
@@ -103,6 +104,31 @@ func SidecarSelectorMultiMatchChecker(subjectType string, sc []*networking_v1bet
 	}
 }
 
+func TelemetryMultiMatchChecker(subjectType string, tm []*telemetry_v1alpha1.Telemetry, workloadsPerNamespace map[string]models.WorkloadList) GenericMultiMatchChecker {
+	keys := []models.IstioValidationKey{}
+	selectors := make(map[int]map[string]string, len(tm))
+	for i, t := range tm {
+		key := models.IstioValidationKey{
+			ObjectType: subjectType,
+			Name:       t.Name,
+			Namespace:  t.Namespace,
+		}
+		keys = append(keys, key)
+		selectors[i] = make(map[string]string)
+		if t.Spec.Selector != nil {
+			selectors[i] = t.Spec.Selector.MatchLabels
+		}
+	}
+	return GenericMultiMatchChecker{
+		SubjectType:           subjectType,
+		Keys:                  keys,
+		Selectors:             selectors,
+		WorkloadsPerNamespace: workloadsPerNamespace,
+		Path:                  "spec/selector",
+		skipSelSubj:           false,
+	}
+}
+
 type KeyWithIndex struct {
 	Index int
 	Key   *models.IstioValidationKey
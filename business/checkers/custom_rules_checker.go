@@ -0,0 +1,289 @@
+package checkers
+
+import (
+	"encoding/json"
+	"strings"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business/checkers/customrules"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// CustomRulesChecker runs the custom validation rules defined in the Kiali config.
+type CustomRulesChecker struct {
+	Rules                 []config.ValidationCustomRule
+	IstioConfigList       *models.IstioConfigList
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	RegistryServices      []*kubernetes.RegistryService
+}
+
+// Check runs each custom rule on the objects of its type
+func (in CustomRulesChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+	if len(in.Rules) == 0 || in.IstioConfigList == nil {
+		return validations
+	}
+
+	rules, _ := customrules.CompileAll(in.Rules)
+	for _, rule := range rules {
+		objectType := rule.ObjectType
+		if singular, ok := models.ObjectTypeSingular[objectType]; ok {
+			objectType = singular
+		}
+		for _, object := range in.objectsOfType(objectType) {
+			validations.MergeValidations(in.runRule(rule, objectType, object))
+		}
+	}
+
+	return validations
+}
+
+func (in CustomRulesChecker) runRule(rule *customrules.Rule, objectType string, object meta_v1.Object) models.IstioValidations {
+	key, validation := EmptyValidValidation(object.GetName(), object.GetNamespace(), objectType)
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		log.Errorf("Unable to marshal %s [%s/%s] for the custom rules: %s", objectType, object.GetNamespace(), object.GetName(), err)
+		return models.IstioValidations{}
+	}
+	content := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &content); err != nil {
+		log.Errorf("Unable to unmarshal %s [%s/%s] for the custom rules: %s", objectType, object.GetNamespace(), object.GetName(), err)
+		return models.IstioValidations{}
+	}
+
+	checker := customrules.RuleChecker{
+		Rule:      rule,
+		Object:    content,
+		Workloads: in.selectedWorkloads(objectType, object.GetNamespace(), content),
+		Services:  in.referencedServices(object.GetNamespace(), content),
+	}
+	checks, valid := checker.Check()
+	validation.Checks = append(validation.Checks, checks...)
+	validation.Valid = valid
+
+	return models.IstioValidations{key: validation}
+}
+
+func (in CustomRulesChecker) objectsOfType(objectType string) []meta_v1.Object {
+	objects := make([]meta_v1.Object, 0)
+	switch objectType {
+	case "authorizationpolicy":
+		for _, o := range in.IstioConfigList.AuthorizationPolicies {
+			objects = append(objects, o)
+		}
+	case "destinationrule":
+		for _, o := range in.IstioConfigList.DestinationRules {
+			objects = append(objects, o)
+		}
+	case "envoyfilter":
+		for _, o := range in.IstioConfigList.EnvoyFilters {
+			objects = append(objects, o)
+		}
+	case "gateway":
+		for _, o := range in.IstioConfigList.Gateways {
+			objects = append(objects, o)
+		}
+	case "k8sgateway":
+		for _, o := range in.IstioConfigList.K8sGateways {
+			objects = append(objects, o)
+		}
+	case "k8shttproute":
+		for _, o := range in.IstioConfigList.K8sHTTPRoutes {
+			objects = append(objects, o)
+		}
+	case "k8sgrpcroute":
+		for _, o := range in.IstioConfigList.K8sGRPCRoutes {
+			objects = append(objects, o)
+		}
+	case "k8stcproute":
+		for _, o := range in.IstioConfigList.K8sTCPRoutes {
+			objects = append(objects, o)
+		}
+	case "k8stlsroute":
+		for _, o := range in.IstioConfigList.K8sTLSRoutes {
+			objects = append(objects, o)
+		}
+	case "k8sreferencegrant":
+		for _, o := range in.IstioConfigList.K8sReferenceGrants {
+			objects = append(objects, o)
+		}
+	case "peerauthentication":
+		for _, o := range in.IstioConfigList.PeerAuthentications {
+			objects = append(objects, o)
+		}
+	case "requestauthentication":
+		for _, o := range in.IstioConfigList.RequestAuthentications {
+			objects = append(objects, o)
+		}
+	case "serviceentry":
+		for _, o := range in.IstioConfigList.ServiceEntries {
+			objects = append(objects, o)
+		}
+	case "sidecar":
+		for _, o := range in.IstioConfigList.Sidecars {
+			objects = append(objects, o)
+		}
+	case "telemetry":
+		for _, o := range in.IstioConfigList.Telemetries {
+			objects = append(objects, o)
+		}
+	case "virtualservice":
+		for _, o := range in.IstioConfigList.VirtualServices {
+			objects = append(objects, o)
+		}
+	case "wasmplugin":
+		for _, o := range in.IstioConfigList.WasmPlugins {
+			objects = append(objects, o)
+		}
+	case "workloadentry":
+		for _, o := range in.IstioConfigList.WorkloadEntries {
+			objects = append(objects, o)
+		}
+	case "workloadgroup":
+		for _, o := range in.IstioConfigList.WorkloadGroups {
+			objects = append(objects, o)
+		}
+	default:
+		log.Errorf("Custom validation rules are not supported for object type [%s]", objectType)
+	}
+	return objects
+}
+
+// workloadSelectingTypes are the object types that apply to workloads,
+// either through a selector or to the whole namespace when no selector is defined.
+var workloadSelectingTypes = map[string]bool{
+	"authorizationpolicy":   true,
+	"envoyfilter":           true,
+	"gateway":               true,
+	"peerauthentication":    true,
+	"requestauthentication": true,
+	"sidecar":               true,
+	"telemetry":             true,
+	"wasmplugin":            true,
+}
+
+func (in CustomRulesChecker) selectedWorkloads(objectType, namespace string, object map[string]interface{}) []map[string]interface{} {
+	workloads := make([]map[string]interface{}, 0)
+	if !workloadSelectingTypes[objectType] {
+		return workloads
+	}
+
+	spec, _ := object["spec"].(map[string]interface{})
+	var selector map[string]interface{}
+	switch objectType {
+	case "gateway":
+		selector, _ = spec["selector"].(map[string]interface{})
+	case "envoyfilter", "sidecar":
+		if ws, ok := spec["workloadSelector"].(map[string]interface{}); ok {
+			selector, _ = ws["labels"].(map[string]interface{})
+		}
+	default:
+		if s, ok := spec["selector"].(map[string]interface{}); ok {
+			selector, _ = s["matchLabels"].(map[string]interface{})
+		}
+	}
+
+	selectorLabels := labels.Set{}
+	for k, v := range selector {
+		if value, ok := v.(string); ok {
+			selectorLabels[k] = value
+		}
+	}
+	// An empty selector applies to all the workloads of the namespace
+	wkSelector := labels.SelectorFromSet(selectorLabels)
+
+	for _, wk := range in.WorkloadsPerNamespace[namespace].Workloads {
+		if !wkSelector.Matches(labels.Set(wk.Labels)) {
+			continue
+		}
+		workloads = append(workloads, map[string]interface{}{
+			"name":      wk.Name,
+			"namespace": namespace,
+			"type":      wk.Type,
+			"labels":    toInterfaceMap(wk.Labels),
+		})
+	}
+	return workloads
+}
+
+// referencedServices returns the registry services of the "host" and "hosts" fields found in the spec of the object
+func (in CustomRulesChecker) referencedServices(namespace string, object map[string]interface{}) []map[string]interface{} {
+	services := make([]map[string]interface{}, 0)
+	seen := make(map[string]bool)
+	for _, host := range collectHosts(object["spec"]) {
+		// Gateway and Sidecar hosts are prefixed with a namespace
+		if i := strings.Index(host, "/"); i >= 0 {
+			host = host[i+1:]
+		}
+		if host == "" || strings.Contains(host, "*") {
+			continue
+		}
+		hostname := kubernetes.ParseHost(host, namespace).String()
+		if seen[hostname] {
+			continue
+		}
+		seen[hostname] = true
+		for _, rs := range in.RegistryServices {
+			if !kubernetes.FilterByRegistryService(namespace, hostname, rs) {
+				continue
+			}
+			ports := make([]interface{}, 0, len(rs.Ports))
+			for _, p := range rs.Ports {
+				ports = append(ports, map[string]interface{}{"name": p.Name, "port": p.Port, "protocol": p.Protocol})
+			}
+			services = append(services, map[string]interface{}{
+				"name":      rs.Attributes.Name,
+				"namespace": rs.Attributes.Namespace,
+				"hostname":  rs.Hostname,
+				"labels":    toInterfaceMap(rs.Attributes.Labels),
+				"ports":     ports,
+			})
+		}
+	}
+	return services
+}
+
+func collectHosts(value interface{}) []string {
+	hosts := make([]string, 0)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			switch k {
+			case "host":
+				if host, ok := field.(string); ok {
+					hosts = append(hosts, host)
+					continue
+				}
+			case "hosts":
+				if list, ok := field.([]interface{}); ok {
+					for _, h := range list {
+						if host, ok := h.(string); ok {
+							hosts = append(hosts, host)
+						}
+					}
+					continue
+				}
+			}
+			hosts = append(hosts, collectHosts(field)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			hosts = append(hosts, collectHosts(item)...)
+		}
+	}
+	return hosts
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
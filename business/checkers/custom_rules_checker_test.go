@@ -0,0 +1,106 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestCustomRulesVirtualServiceTimeout(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", -1),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}))
+
+	vals := CustomRulesChecker{
+		Rules: []config.ValidationCustomRule{
+			{
+				Code:       "CUSTOM001",
+				Expression: "object.spec.http.all(r, has(r.timeout))",
+				Message:    "All the routes must define a timeout",
+				ObjectType: "virtualservices",
+				Path:       "spec/http",
+				Severity:   "error",
+			},
+		},
+		IstioConfigList: &models.IstioConfigList{VirtualServices: []*networking_v1beta1.VirtualService{vs}},
+	}.Check()
+
+	validation, ok := vals[models.BuildKey("virtualservice", "reviews", "bookinfo")]
+	assert.True(ok)
+	assert.False(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.Equal("CUSTOM001", validation.Checks[0].Code)
+	assert.Equal("spec/http", validation.Checks[0].Path)
+}
+
+func TestCustomRulesWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ap := data.CreateAuthorizationPolicyWithMetaAndSelector("ratings", "bookinfo", map[string]string{"app": "ratings"})
+	nsWide := data.CreateEmptyAuthorizationPolicy("allow-nothing", "bookinfo")
+
+	vals := CustomRulesChecker{
+		Rules: []config.ValidationCustomRule{
+			{
+				Code:       "CUSTOM002",
+				Expression: "workloads.all(w, w.labels.version == 'v1')",
+				Message:    "Policies only apply to v1 workloads",
+				ObjectType: "authorizationpolicy",
+				Severity:   "warning",
+			},
+		},
+		IstioConfigList: &models.IstioConfigList{AuthorizationPolicies: []*security_v1beta.AuthorizationPolicy{ap, nsWide}},
+		WorkloadsPerNamespace: data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+			data.CreateWorkloadListItem("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+			data.CreateWorkloadListItem("reviews-v2", map[string]string{"app": "reviews", "version": "v2"}),
+		),
+	}.Check()
+
+	validation, ok := vals[models.BuildKey("authorizationpolicy", "ratings", "bookinfo")]
+	assert.True(ok)
+	assert.True(validation.Valid)
+	assert.Empty(validation.Checks)
+
+	// Without selector, the policy applies to all the workloads of the namespace
+	validation, ok = vals[models.BuildKey("authorizationpolicy", "allow-nothing", "bookinfo")]
+	assert.True(ok)
+	assert.True(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+}
+
+func TestCustomRulesServices(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("reviews", "v1", -1),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}))
+	missing := data.AddHttpRoutesToVirtualService(data.CreateHttpRouteDestination("details", "v1", -1),
+		data.CreateEmptyVirtualService("details", "bookinfo", []string{"details"}))
+
+	vals := CustomRulesChecker{
+		Rules: []config.ValidationCustomRule{
+			{
+				Code:       "CUSTOM003",
+				Expression: "size(services) > 0",
+				Message:    "The VirtualService must route to a known service",
+				ObjectType: "virtualservice",
+				Severity:   "error",
+			},
+		},
+		IstioConfigList:  &models.IstioConfigList{VirtualServices: []*networking_v1beta1.VirtualService{vs, missing}},
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+	}.Check()
+
+	assert.True(vals[models.BuildKey("virtualservice", "reviews", "bookinfo")].Valid)
+	assert.False(vals[models.BuildKey("virtualservice", "details", "bookinfo")].Valid)
+}
@@ -0,0 +1,135 @@
+package customrules
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// Variables available in the CEL expressions of the custom rules
+const (
+	ObjectVariable    = "object"
+	ServicesVariable  = "services"
+	WorkloadsVariable = "workloads"
+)
+
+// Rule is a custom validation rule with its CEL expression compiled.
+type Rule struct {
+	config.ValidationCustomRule
+	program cel.Program
+}
+
+// compiledRules holds the rules of a configuration, compiled once
+type compiledRules struct {
+	rules    []config.ValidationCustomRule
+	compiled []*Rule
+	errs     []error
+}
+
+var (
+	env     *cel.Env
+	envErr  error
+	envOnce sync.Once
+
+	compiledLock sync.Mutex
+	compiled     *compiledRules
+)
+
+func celEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(ObjectVariable, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(WorkloadsVariable, cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+			cel.Variable(ServicesVariable, cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		)
+	})
+	return env, envErr
+}
+
+// Compile validates the custom rule and compiles its expression.
+func Compile(rule config.ValidationCustomRule) (*Rule, error) {
+	if rule.Code == "" {
+		return nil, fmt.Errorf("custom rule without code")
+	}
+	if models.IsCheckCode(rule.Code) {
+		return nil, fmt.Errorf("custom rule [%s] uses the code of a Kiali validation", rule.Code)
+	}
+	if rule.ObjectType == "" {
+		return nil, fmt.Errorf("custom rule [%s] without object type", rule.Code)
+	}
+	if severity := models.SeverityLevel(rule.Severity); severity != models.ErrorSeverity && severity != models.WarningSeverity {
+		return nil, fmt.Errorf("custom rule [%s] with invalid severity [%s], use error or warning", rule.Code, rule.Severity)
+	}
+
+	e, err := celEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(rule.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("custom rule [%s] has an invalid expression: %s", rule.Code, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("custom rule [%s] expression must return a bool, not %s", rule.Code, ast.OutputType())
+	}
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("custom rule [%s] has an invalid expression: %s", rule.Code, err)
+	}
+	return &Rule{ValidationCustomRule: rule, program: program}, nil
+}
+
+// CompileAll compiles the given custom rules. Invalid rules, and rules reusing the code of a previous rule, are
+// skipped and returned as errors. The rules of a configuration are compiled, and their errors logged, only once:
+// later calls with the same rules return the rules already compiled.
+func CompileAll(rules []config.ValidationCustomRule) ([]*Rule, []error) {
+	compiledLock.Lock()
+	defer compiledLock.Unlock()
+	if compiled != nil && reflect.DeepEqual(compiled.rules, rules) {
+		return compiled.compiled, compiled.errs
+	}
+
+	cr := &compiledRules{
+		rules:    append([]config.ValidationCustomRule{}, rules...),
+		compiled: make([]*Rule, 0, len(rules)),
+	}
+	codes := make(map[string]bool)
+	for _, rule := range rules {
+		r, err := Compile(rule)
+		if err == nil && codes[rule.Code] {
+			err = fmt.Errorf("custom rule [%s] uses the code of another custom rule", rule.Code)
+		}
+		if err != nil {
+			log.Errorf("Skipping validation custom rule: %s", err)
+			cr.errs = append(cr.errs, err)
+			continue
+		}
+		codes[rule.Code] = true
+		cr.compiled = append(cr.compiled, r)
+	}
+	compiled = cr
+	return cr.compiled, cr.errs
+}
+
+// eval returns true when the object is valid for the rule.
+func (r *Rule) eval(object map[string]interface{}, workloads, services []map[string]interface{}) (bool, error) {
+	out, _, err := r.program.Eval(map[string]interface{}{
+		ObjectVariable:    object,
+		WorkloadsVariable: workloads,
+		ServicesVariable:  services,
+	})
+	if err != nil {
+		return false, err
+	}
+	valid, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned a %s instead of a bool", out.Type().TypeName())
+	}
+	return valid, nil
+}
@@ -0,0 +1,40 @@
+package customrules
+
+import (
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// RuleChecker evaluates a custom rule over an object, its selected workloads and its referenced services.
+type RuleChecker struct {
+	Rule *Rule
+	// Object is the JSON representation of the Istio object
+	Object    map[string]interface{}
+	Workloads []map[string]interface{}
+	Services  []map[string]interface{}
+}
+
+func (rc RuleChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	passed, err := rc.Rule.eval(rc.Object, rc.Workloads, rc.Services)
+	if err != nil {
+		// Usually a missing field, the expression should guard it with has()
+		log.Debugf("Unable to evaluate validation custom rule [%s]: %s", rc.Rule.Code, err)
+		return checks, valid
+	}
+	if passed {
+		return checks, valid
+	}
+
+	check := models.IstioCheck{
+		Code:     rc.Rule.Code,
+		Message:  rc.Rule.Message,
+		Severity: models.SeverityLevel(rc.Rule.Severity),
+		Path:     rc.Rule.Path,
+	}
+	checks = append(checks, &check)
+	valid = check.Severity != models.ErrorSeverity
+
+	return checks, valid
+}
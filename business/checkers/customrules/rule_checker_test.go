@@ -0,0 +1,135 @@
+package customrules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func timeoutRule(severity string) config.ValidationCustomRule {
+	return config.ValidationCustomRule{
+		Code:       "CUSTOM001",
+		Expression: "has(object.spec.http) && object.spec.http.all(r, has(r.timeout))",
+		Message:    "All the routes must define a timeout",
+		ObjectType: "virtualservices",
+		Path:       "spec/http",
+		Severity:   severity,
+	}
+}
+
+func TestCompileInvalidRules(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Compile(config.ValidationCustomRule{Expression: "true", ObjectType: "virtualservice", Severity: "error"})
+	assert.Error(err)
+
+	_, err = Compile(config.ValidationCustomRule{Code: "CUSTOM001", Expression: "true", Severity: "error"})
+	assert.Error(err)
+
+	_, err = Compile(config.ValidationCustomRule{Code: "CUSTOM001", Expression: "true", ObjectType: "virtualservice", Severity: "info"})
+	assert.Error(err)
+
+	_, err = Compile(config.ValidationCustomRule{Code: "CUSTOM001", Expression: "object.spec.", ObjectType: "virtualservice", Severity: "error"})
+	assert.Error(err)
+
+	_, err = Compile(config.ValidationCustomRule{Code: "CUSTOM001", Expression: "size(workloads)", ObjectType: "virtualservice", Severity: "error"})
+	assert.Error(err)
+
+	// the code of a Kiali validation
+	_, err = Compile(config.ValidationCustomRule{Code: "KIA1101", Expression: "true", ObjectType: "virtualservice", Severity: "error"})
+	assert.Error(err)
+
+	rules, errs := CompileAll([]config.ValidationCustomRule{
+		{Code: "CUSTOM001", Expression: "object.spec.", ObjectType: "virtualservice", Severity: "error"},
+		timeoutRule("warning"),
+		timeoutRule("error"),
+	})
+	assert.Len(rules, 1)
+	assert.Equal("CUSTOM001", rules[0].Code)
+	assert.Len(errs, 2)
+
+	// the rules are compiled once
+	cached, _ := CompileAll([]config.ValidationCustomRule{
+		{Code: "CUSTOM001", Expression: "object.spec.", ObjectType: "virtualservice", Severity: "error"},
+		timeoutRule("warning"),
+		timeoutRule("error"),
+	})
+	assert.Same(rules[0], cached[0])
+}
+
+func TestRulePassed(t *testing.T) {
+	assert := assert.New(t)
+
+	rule, err := Compile(timeoutRule("error"))
+	assert.NoError(err)
+
+	checks, valid := RuleChecker{
+		Rule: rule,
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"http": []interface{}{map[string]interface{}{"timeout": "5s"}},
+			},
+		},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(checks)
+}
+
+func TestRuleFailed(t *testing.T) {
+	assert := assert.New(t)
+
+	rule, err := Compile(timeoutRule("error"))
+	assert.NoError(err)
+
+	checks, valid := RuleChecker{
+		Rule: rule,
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"http": []interface{}{map[string]interface{}{"timeout": "5s"}, map[string]interface{}{}},
+			},
+		},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(checks, 1)
+	assert.Equal("CUSTOM001", checks[0].Code)
+	assert.Equal("All the routes must define a timeout", checks[0].Message)
+	assert.Equal(models.ErrorSeverity, checks[0].Severity)
+	assert.Equal("spec/http", checks[0].Path)
+}
+
+func TestRuleFailedWarning(t *testing.T) {
+	assert := assert.New(t)
+
+	rule, err := Compile(timeoutRule("warning"))
+	assert.NoError(err)
+
+	checks, valid := RuleChecker{
+		Rule:   rule,
+		Object: map[string]interface{}{"spec": map[string]interface{}{}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(checks, 1)
+	assert.Equal(models.WarningSeverity, checks[0].Severity)
+}
+
+func TestRuleEvaluationError(t *testing.T) {
+	assert := assert.New(t)
+
+	rule, err := Compile(config.ValidationCustomRule{Code: "CUSTOM002", Expression: "object.spec.gateways.size() > 0", ObjectType: "virtualservice", Severity: "error"})
+	assert.NoError(err)
+
+	// Missing fields are not reported, the expression should guard them with has()
+	checks, valid := RuleChecker{
+		Rule:   rule,
+		Object: map[string]interface{}{"spec": map[string]interface{}{}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(checks)
+}
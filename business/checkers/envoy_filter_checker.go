@@ -0,0 +1,86 @@
+package checkers
+
+import (
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/envoyfilters"
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = "envoyfilter"
+
+type EnvoyFilterChecker struct {
+	EnvoyFilters          []*networking_v1alpha3.EnvoyFilter
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	// Proxies is optional, the checks based on the proxies configuration are skipped when it is empty
+	Proxies []*models.ProxyConfig
+}
+
+// Check runs individual checks for each EnvoyFilter and group checks between EnvoyFilters
+func (in EnvoyFilterChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	validations = validations.MergeValidations(in.runIndividualChecks())
+	validations = validations.MergeValidations(in.runGroupChecks())
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		envoyfilters.PriorityChecker{EnvoyFilters: in.EnvoyFilters, WorkloadsPerNamespace: in.WorkloadsPerNamespace},
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, envoyFilter := range in.EnvoyFilters {
+		validations.MergeValidations(in.runChecks(envoyFilter))
+	}
+
+	return validations
+}
+
+func (in EnvoyFilterChecker) runChecks(envoyFilter *networking_v1alpha3.EnvoyFilter) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(envoyFilter.Name, envoyFilter.Namespace, EnvoyFilterCheckerType)
+
+	selectorLabels := make(map[string]string)
+	if envoyFilter.Spec.WorkloadSelector != nil {
+		selectorLabels = envoyFilter.Spec.WorkloadSelector.Labels
+	}
+	proxies := in.selectedProxies(envoyFilter)
+
+	enabledCheckers := []Checker{
+		common.WorkloadSelectorNoWorkloadFoundChecker(EnvoyFilterCheckerType, selectorLabels, in.WorkloadsPerNamespace),
+		envoyfilters.PatchMatchChecker{EnvoyFilter: envoyFilter, Proxies: proxies},
+		envoyfilters.ProxyVersionChecker{EnvoyFilter: envoyFilter, Proxies: proxies},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
+
+func (in EnvoyFilterChecker) selectedProxies(envoyFilter *networking_v1alpha3.EnvoyFilter) []*models.ProxyConfig {
+	proxies := make([]*models.ProxyConfig, 0)
+	for _, proxy := range in.Proxies {
+		if envoyfilters.AppliesTo(envoyFilter, proxy.Namespace, proxy.Labels) {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
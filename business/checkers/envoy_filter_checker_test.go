@@ -0,0 +1,65 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestEnvoyFilterNoCrashOnEmpty(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := EnvoyFilterChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{},
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestEnvoyFilterValidations(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	workloads := data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+		data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+	)
+	proxies := []*models.ProxyConfig{
+		{
+			Namespace:    "bookinfo",
+			Pod:          "reviews-v1-7f8d9c6b5-abcde",
+			Labels:       map[string]string{"app": "reviews", "version": "v1"},
+			ConfigDump:   data.CreateConfigDump(map[string]float64{"0.0.0.0_9080": 9080}, []string{}),
+			IstioVersion: "1.16.1",
+		},
+	}
+
+	vals := EnvoyFilterChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{
+			data.AddSelectorToEnvoyFilter(map[string]string{"app": "ratings"}, data.CreateEnvoyFilter("ratings-lua", "bookinfo")),
+			data.AddSelectorToEnvoyFilter(map[string]string{"app": "reviews"},
+				data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, data.CreateEnvoyFilter("reviews-lua", "bookinfo"))),
+		},
+		WorkloadsPerNamespace: workloads,
+		Proxies:               proxies,
+	}.Check()
+
+	assert.Len(vals, 2)
+
+	ratings := vals[models.BuildKey(EnvoyFilterCheckerType, "ratings-lua", "bookinfo")]
+	assert.True(ratings.Valid)
+	assert.Len(ratings.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("generic.selector.workloadnotfound", ratings.Checks[0]))
+
+	reviews := vals[models.BuildKey(EnvoyFilterCheckerType, "reviews-lua", "bookinfo")]
+	assert.True(reviews.Valid)
+	assert.Len(reviews.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.patch.listenernotfound", reviews.Checks[0]))
+}
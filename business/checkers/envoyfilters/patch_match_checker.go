@@ -0,0 +1,202 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// PatchMatchChecker validates that the listeners and clusters matched by the EnvoyFilter patches
+// are present in the configuration of the selected proxies.
+type PatchMatchChecker struct {
+	EnvoyFilter *networking_v1alpha3.EnvoyFilter
+	// Proxies selected by the EnvoyFilter
+	Proxies []*models.ProxyConfig
+}
+
+const virtualInboundListener = "virtualInbound"
+
+// clusterDirections are the directions of the cluster names the sidecar contexts apply to
+var clusterDirections = map[api_networking_v1alpha3.EnvoyFilter_PatchContext]string{
+	api_networking_v1alpha3.EnvoyFilter_SIDECAR_INBOUND:  "inbound",
+	api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND: "outbound",
+	api_networking_v1alpha3.EnvoyFilter_GATEWAY:          "outbound",
+}
+
+type proxyObjects struct {
+	gateway       bool
+	listenerNames map[string]bool
+	// inboundPorts are the ports of the filter chains of the virtualInbound listener
+	inboundPorts  map[uint32]bool
+	outboundPorts map[uint32]bool
+	clusterNames  []string
+}
+
+func (pmc PatchMatchChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	dumps := pmc.proxyObjects()
+	// Nothing to compare with, skip the validation
+	if len(dumps) == 0 {
+		return checks, valid
+	}
+
+	for i, cp := range pmc.EnvoyFilter.Spec.ConfigPatches {
+		if cp == nil || cp.Match == nil || isAddOperation(cp) {
+			continue
+		}
+		contextDumps := dumpsOfContext(dumps, cp.Match.Context)
+		// No proxy of the patch context to compare with
+		if len(contextDumps) == 0 {
+			continue
+		}
+		if listener := cp.Match.GetListener(); listener != nil && !anyProxy(contextDumps, func(po proxyObjects) bool { return po.hasListener(listener, cp.Match.Context) }) {
+			check := models.Build("envoyfilter.patch.listenernotfound", fmt.Sprintf("spec/configPatches[%d]/match/listener", i))
+			checks = append(checks, &check)
+		}
+		if cluster := cp.Match.GetCluster(); cluster != nil && !anyProxy(contextDumps, func(po proxyObjects) bool { return po.hasCluster(cluster, cp.Match.Context) }) {
+			check := models.Build("envoyfilter.patch.clusternotfound", fmt.Sprintf("spec/configPatches[%d]/match/cluster", i))
+			checks = append(checks, &check)
+		}
+	}
+
+	return checks, valid
+}
+
+// dumpsOfContext returns the proxies the patch context applies to, the gateways or the sidecars
+func dumpsOfContext(dumps []proxyObjects, context api_networking_v1alpha3.EnvoyFilter_PatchContext) []proxyObjects {
+	if context == api_networking_v1alpha3.EnvoyFilter_ANY {
+		return dumps
+	}
+	gateway := context == api_networking_v1alpha3.EnvoyFilter_GATEWAY
+	contextDumps := make([]proxyObjects, 0, len(dumps))
+	for _, po := range dumps {
+		if po.gateway == gateway {
+			contextDumps = append(contextDumps, po)
+		}
+	}
+	return contextDumps
+}
+
+// isAddOperation returns true when the patch creates the listener or cluster, so it is not expected to be found.
+func isAddOperation(cp *api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch) bool {
+	if cp.Patch == nil || cp.Patch.Operation != api_networking_v1alpha3.EnvoyFilter_Patch_ADD {
+		return false
+	}
+	return cp.ApplyTo == api_networking_v1alpha3.EnvoyFilter_LISTENER || cp.ApplyTo == api_networking_v1alpha3.EnvoyFilter_CLUSTER
+}
+
+func anyProxy(dumps []proxyObjects, matches func(po proxyObjects) bool) bool {
+	for _, po := range dumps {
+		if matches(po) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pmc PatchMatchChecker) proxyObjects() []proxyObjects {
+	dumps := make([]proxyObjects, 0, len(pmc.Proxies))
+	for _, proxy := range pmc.Proxies {
+		if proxy == nil || proxy.ConfigDump == nil {
+			continue
+		}
+		po := proxyObjects{
+			gateway:       proxy.Gateway,
+			listenerNames: make(map[string]bool),
+			inboundPorts:  make(map[uint32]bool),
+			outboundPorts: make(map[uint32]bool),
+		}
+		listenerDump, err := proxy.ConfigDump.GetListeners()
+		if err != nil {
+			log.Debugf("Unable to parse the listeners of proxy [%s/%s]: %s", proxy.Namespace, proxy.Pod, err)
+			continue
+		}
+		clusterDump, err := proxy.ConfigDump.GetClusters()
+		if err != nil {
+			log.Debugf("Unable to parse the clusters of proxy [%s/%s]: %s", proxy.Namespace, proxy.Pod, err)
+			continue
+		}
+		for _, dl := range listenerDump.DynamicListeners {
+			po.listenerNames[dl.Name] = true
+			po.addListenerPorts(dl.ActiveState.Listener)
+		}
+		for _, sl := range listenerDump.StaticListeners {
+			po.listenerNames[sl.Listener.Name] = true
+			po.addListenerPorts(sl.Listener)
+		}
+		for _, cluster := range append(clusterDump.DynamicClusters, clusterDump.StaticClusters...) {
+			po.clusterNames = append(po.clusterNames, cluster.Cluster.Name)
+		}
+		dumps = append(dumps, po)
+	}
+	return dumps
+}
+
+// addListenerPorts adds the listener port, or the destination ports of the filter chains of the virtualInbound
+// listener, which handles the inbound traffic with a filter chain per port.
+func (po proxyObjects) addListenerPorts(listener kubernetes.EnvoyListener) {
+	if listener.Name != virtualInboundListener {
+		po.outboundPorts[uint32(listener.Address.SocketAddress.PortValue)] = true
+		return
+	}
+	for _, fc := range listener.FilterChains {
+		if fc.FilterChainMatch != nil && fc.FilterChainMatch.DestinationPort != nil {
+			po.inboundPorts[uint32(*fc.FilterChainMatch.DestinationPort)] = true
+		}
+	}
+}
+
+func (po proxyObjects) hasListener(match *api_networking_v1alpha3.EnvoyFilter_ListenerMatch, context api_networking_v1alpha3.EnvoyFilter_PatchContext) bool {
+	if match.Name != "" && !po.listenerNames[match.Name] {
+		return false
+	}
+	if match.PortNumber == 0 {
+		return true
+	}
+	switch context {
+	case api_networking_v1alpha3.EnvoyFilter_SIDECAR_INBOUND:
+		return po.inboundPorts[match.PortNumber]
+	case api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND, api_networking_v1alpha3.EnvoyFilter_GATEWAY:
+		return po.outboundPorts[match.PortNumber]
+	default:
+		return po.inboundPorts[match.PortNumber] || po.outboundPorts[match.PortNumber]
+	}
+}
+
+// hasCluster compares the match with the cluster names, formatted as <direction>|<port>|<subset>|<service>
+func (po proxyObjects) hasCluster(match *api_networking_v1alpha3.EnvoyFilter_ClusterMatch, context api_networking_v1alpha3.EnvoyFilter_PatchContext) bool {
+	for _, name := range po.clusterNames {
+		if match.Name != "" && match.Name != name {
+			continue
+		}
+		if match.Service == "" && match.PortNumber == 0 && match.Subset == "" {
+			return true
+		}
+		parts := strings.Split(name, "|")
+		if len(parts) != 4 {
+			continue
+		}
+		if direction, ok := clusterDirections[context]; ok && direction != parts[0] {
+			continue
+		}
+		if match.Service != "" && match.Service != parts[3] {
+			continue
+		}
+		if match.PortNumber != 0 && strconv.FormatUint(uint64(match.PortNumber), 10) != strings.TrimSuffix(parts[1], "_") {
+			continue
+		}
+		if match.Subset != "" && match.Subset != parts[2] {
+			continue
+		}
+		return true
+	}
+	return false
+}
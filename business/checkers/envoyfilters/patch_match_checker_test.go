@@ -0,0 +1,123 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func proxies() []*models.ProxyConfig {
+	return []*models.ProxyConfig{
+		{
+			Namespace: "bookinfo",
+			Pod:       "reviews-v1-7f8d9c6b5-abcde",
+			Labels:    map[string]string{"app": "reviews", "version": "v1"},
+			ConfigDump: data.CreateConfigDump(
+				map[string]float64{"virtualInbound": 15006, "0.0.0.0_9080": 9080},
+				[]string{"outbound|9080||ratings.bookinfo.svc.cluster.local", "inbound|9080||"},
+			),
+			IstioVersion: "1.16.1",
+		},
+	}
+}
+
+func TestPatchMatchFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef = data.AddListenerPatchToEnvoyFilter("virtualInbound", 0, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef = data.AddClusterPatchToEnvoyFilter("ratings.bookinfo.svc.cluster.local", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+
+	vals, valid := PatchMatchChecker{EnvoyFilter: ef, Proxies: proxies()}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPatchMatchNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef = data.AddClusterPatchToEnvoyFilter("details.bookinfo.svc.cluster.local", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+
+	vals, valid := PatchMatchChecker{EnvoyFilter: ef, Proxies: proxies()}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.patch.listenernotfound", vals[0]))
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/configPatches[0]/match/listener", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.patch.clusternotfound", vals[1]))
+	assert.Equal(models.WarningSeverity, vals[1].Severity)
+	assert.Equal("spec/configPatches[1]/match/cluster", vals[1].Path)
+}
+
+func TestPatchMatchAddOperation(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("new-listener", "bookinfo")
+	ef = data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_ADD, ef)
+
+	vals, valid := PatchMatchChecker{EnvoyFilter: ef, Proxies: proxies()}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPatchMatchWithoutProxies(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+
+	vals, valid := PatchMatchChecker{EnvoyFilter: ef}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPatchMatchContext(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	gateway := &models.ProxyConfig{
+		Namespace:  "istio-system",
+		Pod:        "istio-ingressgateway-5d9c6b5f7-fghij",
+		Labels:     map[string]string{"istio": "ingressgateway"},
+		Gateway:    true,
+		ConfigDump: data.CreateConfigDump(map[string]float64{"0.0.0.0_8080": 8080}, []string{"outbound|9080||productpage.bookinfo.svc.cluster.local"}),
+	}
+
+	ef := data.CreateEnvoyFilter("mesh-wide", "istio-system")
+	// a gateway listener
+	ef = data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef.Spec.ConfigPatches[0].Match.Context = api_networking_v1alpha3.EnvoyFilter_GATEWAY
+	// the gateway listener is not a sidecar listener
+	ef = data.AddListenerPatchToEnvoyFilter("", 8080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef.Spec.ConfigPatches[1].Match.Context = api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND
+	// an outbound cluster is not an inbound one
+	ef = data.AddClusterPatchToEnvoyFilter("ratings.bookinfo.svc.cluster.local", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef.Spec.ConfigPatches[2].Match.Context = api_networking_v1alpha3.EnvoyFilter_SIDECAR_INBOUND
+	// an outbound listener of the sidecar
+	ef = data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef)
+	ef.Spec.ConfigPatches[3].Match.Context = api_networking_v1alpha3.EnvoyFilter_SIDECAR_OUTBOUND
+
+	vals, valid := PatchMatchChecker{EnvoyFilter: ef, Proxies: append(proxies(), gateway)}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 2)
+	assert.Equal("spec/configPatches[1]/match/listener", vals[0].Path)
+	assert.Equal("spec/configPatches[2]/match/cluster", vals[1].Path)
+}
@@ -0,0 +1,150 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"strings"
+
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const EnvoyFilterCheckerType = "envoyfilter"
+
+// PriorityChecker looks for EnvoyFilters with the same priority that patch the same object of the same workload.
+// In that case the order of the patches depends on the creation time of the EnvoyFilters.
+type PriorityChecker struct {
+	EnvoyFilters          []*networking_v1alpha3.EnvoyFilter
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+func (pc PriorityChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	workloads := make([]map[models.IstioValidationKey]bool, len(pc.EnvoyFilters))
+	patchedObjects := make([]map[string]int, len(pc.EnvoyFilters))
+	for i, ef := range pc.EnvoyFilters {
+		workloads[i] = pc.selectedWorkloads(ef)
+		patchedObjects[i] = patchedObjectKeys(ef)
+	}
+
+	for i, ef := range pc.EnvoyFilters {
+		for j, other := range pc.EnvoyFilters {
+			if i == j || ef.Spec.Priority != other.Spec.Priority || !sameTargets(ef, other, workloads[i], workloads[j]) {
+				continue
+			}
+			for object, patchIndex := range patchedObjects[i] {
+				if _, found := patchedObjects[j][object]; !found {
+					continue
+				}
+				check := models.Build("envoyfilter.priority.conflict", fmt.Sprintf("spec/configPatches[%d]", patchIndex))
+				key := models.BuildKey(EnvoyFilterCheckerType, ef.Name, ef.Namespace)
+				validations.MergeValidations(models.IstioValidations{
+					key: &models.IstioValidation{
+						Name:       ef.Name,
+						ObjectType: EnvoyFilterCheckerType,
+						Valid:      true,
+						Checks:     []*models.IstioCheck{&check},
+						References: []models.IstioValidationKey{models.BuildKey(EnvoyFilterCheckerType, other.Name, other.Namespace)},
+					},
+				})
+			}
+		}
+	}
+
+	return validations
+}
+
+// sameTargets returns true when both EnvoyFilters are applied to at least one common workload.
+// Selector-less EnvoyFilters of the same namespace are considered to share targets even without workloads.
+func sameTargets(ef, other *networking_v1alpha3.EnvoyFilter, efWorkloads, otherWorkloads map[models.IstioValidationKey]bool) bool {
+	if ef.Namespace == other.Namespace && !hasSelector(ef) && !hasSelector(other) {
+		return true
+	}
+	for wk := range efWorkloads {
+		if otherWorkloads[wk] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSelector(ef *networking_v1alpha3.EnvoyFilter) bool {
+	return ef.Spec.WorkloadSelector != nil && len(ef.Spec.WorkloadSelector.Labels) > 0
+}
+
+// AppliesTo returns true when the EnvoyFilter is applied to a workload of the namespace with the given labels.
+// EnvoyFilters in the root namespace are applied to all namespaces.
+func AppliesTo(ef *networking_v1alpha3.EnvoyFilter, namespace string, workloadLabels map[string]string) bool {
+	if ef.Namespace != namespace && !config.IsRootNamespace(ef.Namespace) {
+		return false
+	}
+	if !hasSelector(ef) {
+		return true
+	}
+	return labels.SelectorFromSet(ef.Spec.WorkloadSelector.Labels).Matches(labels.Set(workloadLabels))
+}
+
+func (pc PriorityChecker) selectedWorkloads(ef *networking_v1alpha3.EnvoyFilter) map[models.IstioValidationKey]bool {
+	selected := make(map[models.IstioValidationKey]bool)
+	for _, wls := range pc.WorkloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			if AppliesTo(ef, wls.Namespace.Name, wl.Labels) {
+				selected[models.BuildKey(wl.Type, wl.Name, wls.Namespace.Name)] = true
+			}
+		}
+	}
+	return selected
+}
+
+// patchedObjectKeys returns a key per object patched by the EnvoyFilter, with the index of the first patch
+// The key is built from the applyTo, the context and the match fields of the patch
+func patchedObjectKeys(ef *networking_v1alpha3.EnvoyFilter) map[string]int {
+	keys := make(map[string]int)
+	for i, cp := range ef.Spec.ConfigPatches {
+		if cp == nil {
+			continue
+		}
+		key := patchedObjectKey(cp)
+		if _, found := keys[key]; !found {
+			keys[key] = i
+		}
+	}
+	return keys
+}
+
+func patchedObjectKey(cp *api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch) string {
+	parts := []string{cp.ApplyTo.String()}
+	if cp.Match == nil {
+		return strings.Join(parts, "|")
+	}
+	parts = append(parts, cp.Match.Context.String())
+	if l := cp.Match.GetListener(); l != nil {
+		parts = append(parts, "listener", l.Name, fmt.Sprint(l.PortNumber))
+		if fc := l.FilterChain; fc != nil {
+			parts = append(parts, fc.Name, fc.Sni, fmt.Sprint(fc.DestinationPort))
+			if f := fc.Filter; f != nil {
+				parts = append(parts, f.Name)
+				if f.SubFilter != nil {
+					parts = append(parts, f.SubFilter.Name)
+				}
+			}
+		}
+	}
+	if rc := cp.Match.GetRouteConfiguration(); rc != nil {
+		parts = append(parts, "routeConfiguration", rc.Name, fmt.Sprint(rc.PortNumber), rc.PortName, rc.Gateway)
+		if rc.Vhost != nil {
+			parts = append(parts, rc.Vhost.Name)
+			if rc.Vhost.Route != nil {
+				parts = append(parts, rc.Vhost.Route.Name)
+			}
+		}
+	}
+	if c := cp.Match.GetCluster(); c != nil {
+		parts = append(parts, "cluster", c.Name, c.Service, fmt.Sprint(c.PortNumber), c.Subset)
+	}
+	return strings.Join(parts, "|")
+}
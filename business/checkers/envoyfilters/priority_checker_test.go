@@ -0,0 +1,100 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func workloadList() map[string]models.WorkloadList {
+	return data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+		data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		data.CreateWorkloadListItem("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+	)
+}
+
+func listenerFilter(name string, selector map[string]string, priority int32) *networking_v1alpha3.EnvoyFilter {
+	ef := data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, data.CreateEnvoyFilter(name, "bookinfo"))
+	if selector != nil {
+		ef = data.AddSelectorToEnvoyFilter(selector, ef)
+	}
+	return data.AddPriorityToEnvoyFilter(priority, ef)
+}
+
+func TestSamePriorityDifferentWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PriorityChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{
+			listenerFilter("reviews-lua", map[string]string{"app": "reviews"}, 0),
+			listenerFilter("ratings-lua", map[string]string{"app": "ratings"}, 0),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestDifferentPrioritySameWorkload(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PriorityChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{
+			listenerFilter("reviews-lua", map[string]string{"app": "reviews"}, 0),
+			listenerFilter("reviews-ratelimit", map[string]string{"app": "reviews"}, 10),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestSamePrioritySameObjectDifferentFilters(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PriorityChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{
+			listenerFilter("reviews-lua", map[string]string{"app": "reviews"}, 0),
+			data.AddPriorityToEnvoyFilter(0, data.AddClusterPatchToEnvoyFilter("ratings.bookinfo.svc.cluster.local", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE,
+				data.AddSelectorToEnvoyFilter(map[string]string{"app": "reviews"}, data.CreateEnvoyFilter("reviews-cluster", "bookinfo")))),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestSamePrioritySameWorkload(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PriorityChecker{
+		EnvoyFilters: []*networking_v1alpha3.EnvoyFilter{
+			listenerFilter("reviews-lua", map[string]string{"app": "reviews"}, 0),
+			listenerFilter("bookinfo-lua", nil, 0),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Len(vals, 2)
+	for _, name := range []string{"reviews-lua", "bookinfo-lua"} {
+		validation, ok := vals[models.BuildKey(EnvoyFilterCheckerType, name, "bookinfo")]
+		assert.True(ok)
+		assert.True(validation.Valid)
+		assert.Len(validation.Checks, 1)
+		assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.priority.conflict", validation.Checks[0]))
+		assert.Equal(models.WarningSeverity, validation.Checks[0].Severity)
+		assert.Equal("spec/configPatches[0]", validation.Checks[0].Path)
+		assert.Len(validation.References, 1)
+	}
+}
@@ -0,0 +1,61 @@
+package envoyfilters
+
+import (
+	"fmt"
+	"regexp"
+
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ProxyVersionChecker validates the match.proxy.proxyVersion regexes of the EnvoyFilter patches.
+// Patches pinned to a proxy version are usually left behind after an Istio upgrade and stop being applied.
+type ProxyVersionChecker struct {
+	EnvoyFilter *networking_v1alpha3.EnvoyFilter
+	// Proxies selected by the EnvoyFilter
+	Proxies []*models.ProxyConfig
+}
+
+func (pvc ProxyVersionChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	versions := make([]string, 0, len(pvc.Proxies))
+	for _, proxy := range pvc.Proxies {
+		if proxy != nil && proxy.IstioVersion != "" {
+			versions = append(versions, proxy.IstioVersion)
+		}
+	}
+
+	for i, cp := range pvc.EnvoyFilter.Spec.ConfigPatches {
+		if cp == nil || cp.Match == nil || cp.Match.Proxy == nil || cp.Match.Proxy.ProxyVersion == "" {
+			continue
+		}
+		path := fmt.Sprintf("spec/configPatches[%d]/match/proxy/proxyVersion", i)
+		// Istio uses a partial match of the regex against the ISTIO_VERSION of the proxy
+		versionRegex, err := regexp.Compile(cp.Match.Proxy.ProxyVersion)
+		if err != nil {
+			check := models.Build("envoyfilter.proxyversion.invalidregex", path)
+			checks = append(checks, &check)
+			valid = false
+			continue
+		}
+		// Without known versions there is nothing to compare with
+		if len(versions) == 0 {
+			continue
+		}
+		matched := false
+		for _, version := range versions {
+			if versionRegex.MatchString(version) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			check := models.Build("envoyfilter.proxyversion.nomatch", path)
+			checks = append(checks, &check)
+		}
+	}
+
+	return checks, valid
+}
@@ -0,0 +1,59 @@
+package envoyfilters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestProxyVersionMatch(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddProxyVersionToEnvoyFilter(`^1\.16.*`, data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef))
+
+	vals, valid := ProxyVersionChecker{EnvoyFilter: ef, Proxies: proxies()}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestProxyVersionNoMatch(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddProxyVersionToEnvoyFilter(`^1\.9.*`, data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef))
+
+	vals, valid := ProxyVersionChecker{EnvoyFilter: ef, Proxies: proxies()}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.proxyversion.nomatch", vals[0]))
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/configPatches[0]/match/proxy/proxyVersion", vals[0].Path)
+}
+
+func TestProxyVersionInvalidRegex(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	ef := data.CreateEnvoyFilter("reviews-lua", "bookinfo")
+	ef = data.AddProxyVersionToEnvoyFilter(`^1\.(16`, data.AddListenerPatchToEnvoyFilter("", 9080, api_networking_v1alpha3.EnvoyFilter_Patch_MERGE, ef))
+
+	// The regex is validated even if the proxy versions are unknown
+	vals, valid := ProxyVersionChecker{EnvoyFilter: ef}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("envoyfilter.proxyversion.invalidregex", vals[0]))
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.Equal("spec/configPatches[0]/match/proxy/proxyVersion", vals[0].Path)
+}
@@ -0,0 +1,82 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestGRPCRouteWithoutK8sGateway(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sGRPCRouteChecker{
+		K8sGRPCRoutes: []*k8s_networking_v1alpha2.GRPCRoute{
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("route1", "bookinfo", "gatewayapi")),
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("route2", "bookinfo", "gatewayapi2"))},
+		K8sGateways: []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	route2 := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "route2"}]
+	assert.False(route2.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", route2.Checks[0]))
+}
+
+func TestGRPCRouteBackendWithoutService(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	istioConfigList := emptyIstioConfigList()
+	istioConfigList.K8sGRPCRoutes = append(istioConfigList.K8sGRPCRoutes,
+		data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("valid-route", "bookinfo", "gatewayapi")),
+		data.AddBackendRefToGRPCRoute("ratings", "bookinfo", data.CreateGRPCRoute("invalid-route", "bookinfo", "gatewayapi")))
+
+	vals := NoServiceChecker{
+		IstioConfigList:  istioConfigList,
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+	}.Check()
+
+	assert.True(vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "valid-route"}].Valid)
+	invalid := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "invalid-route"}]
+	assert.False(invalid.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", invalid.Checks[0]))
+}
+
+func TestGRPCRouteWithoutReferenceGrant(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sGRPCRouteChecker{
+		K8sGRPCRoutes: []*k8s_networking_v1alpha2.GRPCRoute{
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo2", data.CreateGRPCRoute("route1", "bookinfo", "gatewayapi")),
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo3", data.CreateGRPCRoute("route2", "bookinfo", "gatewayapi")),
+			data.AddBackendRefToGRPCRoute("reviews", "bookinfo4", data.CreateGRPCRoute("route3", "bookinfo", "gatewayapi"))},
+		K8sGateways: []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{
+			data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualGRPCRouteType),
+			// granted to another kind of route
+			data.CreateReferenceGrant("grant", "bookinfo4", "bookinfo", kubernetes.K8sActualHTTPRouteType)},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	for _, name := range []string{"route2", "route3"} {
+		route := vals[models.IstioValidationKey{ObjectType: "k8sgrpcroute", Namespace: "bookinfo", Name: name}]
+		assert.False(route.Valid)
+		assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.referencegrantnotfound", route.Checks[0]))
+	}
+}
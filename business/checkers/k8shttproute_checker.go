@@ -3,7 +3,7 @@ package checkers
 import (
 	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
-	"github.com/kiali/kiali/business/checkers/k8shttproutes"
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
@@ -11,8 +11,9 @@ import (
 const K8sHTTPRouteCheckerType = "k8shttproute"
 
 type K8sHTTPRouteChecker struct {
-	K8sHTTPRoutes []*k8s_networking_v1alpha2.HTTPRoute
-	K8sGateways   []*k8s_networking_v1alpha2.Gateway
+	K8sHTTPRoutes      []*k8s_networking_v1alpha2.HTTPRoute
+	K8sGateways        []*k8s_networking_v1alpha2.Gateway
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
 }
 
 // Check runs checks for the all namespaces actions as well as for the single namespace validations
@@ -41,10 +42,17 @@ func (in K8sHTTPRouteChecker) runChecks(rt *k8s_networking_v1alpha2.HTTPRoute, g
 	key, validations := EmptyValidValidation(rt.Name, rt.Namespace, K8sHTTPRouteCheckerType)
 
 	enabledCheckers := []Checker{
-		k8shttproutes.NoK8sGatewayChecker{
-			K8sHTTPRoute: rt,
+		k8sroutes.NoK8sGatewayChecker{
+			Namespace:    rt.Namespace,
+			ParentRefs:   rt.Spec.ParentRefs,
 			GatewayNames: gatewayNames,
 		},
+		k8sroutes.NoReferenceGrantChecker{
+			Namespace:       rt.Namespace,
+			RouteKind:       kubernetes.K8sActualHTTPRouteType,
+			BackendRefs:     k8sroutes.HTTPRouteBackendRefs(rt),
+			ReferenceGrants: in.K8sReferenceGrants,
+		},
 	}
 
 	for _, checker := range enabledCheckers {
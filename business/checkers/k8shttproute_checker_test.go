@@ -7,6 +7,7 @@ import (
 	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/tests/data"
 	"github.com/kiali/kiali/tests/testutils/validations"
@@ -39,8 +40,29 @@ func TestWithoutK8sGateway(t *testing.T) {
 
 	route1 := vals[models.IstioValidationKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route1"}]
 	assert.False(route1.Valid)
-	assert.NoError(validations.ConfirmIstioCheckMessage("k8shttproutes.nok8sgateway", route1.Checks[0]))
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", route1.Checks[0]))
 	route2 := vals[models.IstioValidationKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route2"}]
 	assert.False(route2.Valid)
-	assert.NoError(validations.ConfirmIstioCheckMessage("k8shttproutes.nok8sgateway", route2.Checks[0]))
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", route2.Checks[0]))
+}
+
+func TestCrossNamespaceBackendWithoutReferenceGrant(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sHTTPRouteChecker{
+		K8sHTTPRoutes: []*k8s_networking_v1alpha2.HTTPRoute{
+			data.AddBackendRefToHTTPRoute("reviews", "bookinfo2", data.CreateHTTPRoute("route1", "bookinfo", "gatewayapi", []string{"bookinfo"})),
+			data.AddBackendRefToHTTPRoute("reviews", "bookinfo3", data.CreateHTTPRoute("route2", "bookinfo", "gatewayapi", []string{"bookinfo"}))},
+		K8sGateways:        []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualHTTPRouteType)},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	route2 := vals[models.IstioValidationKey{ObjectType: "k8shttproute", Namespace: "bookinfo", Name: "route2"}]
+	assert.False(route2.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.referencegrantnotfound", route2.Checks[0]))
 }
@@ -0,0 +1,45 @@
+package checkers
+
+import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/business/checkers/k8sreferencegrants"
+	"github.com/kiali/kiali/models"
+)
+
+const K8sReferenceGrantCheckerType = "k8sreferencegrant"
+
+type K8sReferenceGrantChecker struct {
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+	Namespaces         models.Namespaces
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sReferenceGrantChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, rg := range in.K8sReferenceGrants {
+		validations.MergeValidations(in.runChecks(rg))
+	}
+
+	return validations
+}
+
+func (in K8sReferenceGrantChecker) runChecks(rg *k8s_networking_v1alpha2.ReferenceGrant) models.IstioValidations {
+	key, validations := EmptyValidValidation(rg.Name, rg.Namespace, K8sReferenceGrantCheckerType)
+
+	enabledCheckers := []Checker{
+		k8sreferencegrants.NamespaceChecker{
+			ReferenceGrant: rg,
+			Namespaces:     in.Namespaces,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
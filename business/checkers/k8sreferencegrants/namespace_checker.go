@@ -0,0 +1,29 @@
+package k8sreferencegrants
+
+import (
+	"fmt"
+
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/models"
+)
+
+type NamespaceChecker struct {
+	ReferenceGrant *k8s_networking_v1alpha2.ReferenceGrant
+	Namespaces     models.Namespaces
+}
+
+// Check validates that the namespaces of the from list of the ReferenceGrant exist
+func (n NamespaceChecker) Check() ([]*models.IstioCheck, bool) {
+	checks := make([]*models.IstioCheck, 0)
+
+	for i, from := range n.ReferenceGrant.Spec.From {
+		if string(from.Namespace) == "" || n.Namespaces.Includes(string(from.Namespace)) {
+			continue
+		}
+		validation := models.Build("k8sreferencegrants.from.namespacenotfound", fmt.Sprintf("spec/from[%d]/namespace", i))
+		checks = append(checks, &validation)
+	}
+
+	return checks, true
+}
@@ -0,0 +1,42 @@
+package k8sreferencegrants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestFromNamespaceFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := NamespaceChecker{
+		ReferenceGrant: data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualHTTPRouteType),
+		Namespaces:     models.Namespaces{{Name: "bookinfo"}, {Name: "bookinfo2"}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestFromNamespaceNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals, valid := NamespaceChecker{
+		ReferenceGrant: data.CreateReferenceGrant("grant", "bookinfo2", "wrong", kubernetes.K8sActualHTTPRouteType),
+		Namespaces:     models.Namespaces{{Name: "bookinfo"}, {Name: "bookinfo2"}},
+	}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sreferencegrants.from.namespacenotfound", vals[0]))
+	assert.Equal("spec/from[0]/namespace", vals[0].Path)
+}
@@ -0,0 +1,61 @@
+package k8sroutes
+
+import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// BackendRefs holds the backendRefs of a route, indexed by rule, as the different route kinds wrap them in different types
+type BackendRefs [][]k8s_networking_v1alpha2.BackendRef
+
+func HTTPRouteBackendRefs(rt *k8s_networking_v1alpha2.HTTPRoute) BackendRefs {
+	refs := make(BackendRefs, len(rt.Spec.Rules))
+	for i, rule := range rt.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			refs[i] = append(refs[i], ref.BackendRef)
+		}
+	}
+	return refs
+}
+
+func GRPCRouteBackendRefs(rt *k8s_networking_v1alpha2.GRPCRoute) BackendRefs {
+	refs := make(BackendRefs, len(rt.Spec.Rules))
+	for i, rule := range rt.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			// The inline BackendRef of a GRPCBackendRef is named BackendRefs
+			refs[i] = append(refs[i], ref.BackendRefs)
+		}
+	}
+	return refs
+}
+
+func TCPRouteBackendRefs(rt *k8s_networking_v1alpha2.TCPRoute) BackendRefs {
+	refs := make(BackendRefs, len(rt.Spec.Rules))
+	for i, rule := range rt.Spec.Rules {
+		refs[i] = rule.BackendRefs
+	}
+	return refs
+}
+
+func TLSRouteBackendRefs(rt *k8s_networking_v1alpha2.TLSRoute) BackendRefs {
+	refs := make(BackendRefs, len(rt.Spec.Rules))
+	for i, rule := range rt.Spec.Rules {
+		refs[i] = rule.BackendRefs
+	}
+	return refs
+}
+
+// isServiceRef returns true when the backendRef points to a core Service, which is the default when group and kind are empty
+func isServiceRef(ref k8s_networking_v1alpha2.BackendRef) bool {
+	if ref.Group != nil && string(*ref.Group) != "" {
+		return false
+	}
+	return ref.Kind == nil || string(*ref.Kind) == "" || string(*ref.Kind) == "Service"
+}
+
+// backendNamespace returns the namespace of the backendRef, which defaults to the namespace of the route
+func backendNamespace(ref k8s_networking_v1alpha2.BackendRef, routeNamespace string) string {
+	if ref.Namespace != nil && string(*ref.Namespace) != "" {
+		return string(*ref.Namespace)
+	}
+	return routeNamespace
+}
@@ -0,0 +1,39 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// NoHostChecker validates that the Services referenced by the backendRefs of a route exist
+type NoHostChecker struct {
+	Namespace        string
+	BackendRefs      BackendRefs
+	RegistryServices []*kubernetes.RegistryService
+}
+
+// Check validates that every Service of the backendRefs is found in the registry
+func (n NoHostChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+	domain := config.Get().ExternalServices.Istio.IstioIdentityDomain
+
+	for ruleIdx, refs := range n.BackendRefs {
+		for refIdx, ref := range refs {
+			if string(ref.Name) == "" || !isServiceRef(ref) {
+				continue
+			}
+			host := fmt.Sprintf("%s.%s.%s", ref.Name, backendNamespace(ref, n.Namespace), domain)
+			if !kubernetes.HasMatchingRegistryService(n.Namespace, host, n.RegistryServices) {
+				path := fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/name", ruleIdx, refIdx)
+				validation := models.Build("k8sroutes.nohost.namenotfound", path)
+				validations = append(validations, &validation)
+				valid = false
+			}
+		}
+	}
+	return validations, valid
+}
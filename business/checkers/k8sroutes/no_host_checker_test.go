@@ -0,0 +1,46 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestValidBackendRefHost(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddBackendRefToTCPRoute("reviews", "bookinfo", data.CreateTCPRoute("route", "bookinfo", "gatewayapi"))
+	vals, valid := NoHostChecker{
+		Namespace:        rt.Namespace,
+		BackendRefs:      TCPRouteBackendRefs(rt),
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestMissingBackendRefHost(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddBackendRefToGRPCRoute("ratings", "bookinfo",
+		data.AddBackendRefToGRPCRoute("reviews", "bookinfo", data.CreateGRPCRoute("route", "bookinfo", "gatewayapi")))
+	vals, valid := NoHostChecker{
+		Namespace:        rt.Namespace,
+		BackendRefs:      GRPCRouteBackendRefs(rt),
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", vals[0]))
+	assert.Equal("spec/rules[1]/backendRefs[0]/name", vals[0].Path)
+}
@@ -0,0 +1,58 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// NoK8sGatewayChecker validates the parentRefs of the HTTPRoutes, GRPCRoutes, TCPRoutes and TLSRoutes
+type NoK8sGatewayChecker struct {
+	Namespace    string
+	ParentRefs   []k8s_networking_v1alpha2.ParentReference
+	GatewayNames map[string]struct{}
+}
+
+// Check validates that the route is pointing to existing Gateways
+func (s NoK8sGatewayChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for index, parentRef := range s.ParentRefs {
+		if string(parentRef.Name) == "" || !isGatewayRef(parentRef) {
+			continue
+		}
+		namespace := s.Namespace
+		if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		if !s.hasGateway(string(parentRef.Name), namespace) {
+			validation := models.Build("k8sroutes.nok8sgateway", fmt.Sprintf("spec/parentRefs[%d]/name/%s", index, string(parentRef.Name)))
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+	return validations, valid
+}
+
+func (s NoK8sGatewayChecker) hasGateway(name, namespace string) bool {
+	hostname := kubernetes.ParseGatewayAsHost(name, namespace)
+	for gw := range s.GatewayNames {
+		gwHostname := kubernetes.ParseHost(gw, namespace)
+		if found := kubernetes.FilterByHost(hostname.String(), hostname.Namespace, gw, gwHostname.Namespace); found {
+			return true
+		}
+	}
+	return false
+}
+
+// isGatewayRef returns true when the parentRef points to a Gateway, which is the default when group and kind are empty
+func isGatewayRef(parentRef k8s_networking_v1alpha2.ParentReference) bool {
+	if parentRef.Group != nil && string(*parentRef.Group) != kubernetes.K8sNetworkingGroupVersionV1Beta1.Group {
+		return false
+	}
+	return parentRef.Kind == nil || string(*parentRef.Kind) == kubernetes.K8sActualGatewayType
+}
@@ -0,0 +1,117 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestMissingK8sGateway(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.CreateTCPRoute("route", "bookinfo", "gatewayapi")
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    rt.Namespace,
+		ParentRefs:   rt.Spec.ParentRefs,
+		GatewayNames: make(map[string]struct{}),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name/gatewayapi", vals[0].Path)
+}
+
+func TestExistingK8sGateway(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.CreateGRPCRoute("route", "bookinfo", "gatewayapi")
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    rt.Namespace,
+		ParentRefs:   rt.Spec.ParentRefs,
+		GatewayNames: kubernetes.K8sGatewayNames([]*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")}),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestNonGatewayParentRef(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	kind := k8s_networking_v1alpha2.Kind("Service")
+	group := k8s_networking_v1alpha2.Group("")
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    "bookinfo",
+		ParentRefs:   []k8s_networking_v1alpha2.ParentReference{{Name: "reviews", Kind: &kind, Group: &group}},
+		GatewayNames: make(map[string]struct{}),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestMissingK8sGatewaysOfHTTPRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddParentRefToHTTPRoute("gateway2", "bookinfo2",
+		data.CreateHTTPRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo"}))
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    rt.Namespace,
+		ParentRefs:   rt.Spec.ParentRefs,
+		GatewayNames: make(map[string]struct{}),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 2)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[0]))
+	assert.Equal(models.ErrorSeverity, vals[1].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[1]))
+}
+
+func TestValidAndMissingK8sGatewayOfHTTPRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	var empty struct{}
+	rt := data.AddParentRefToHTTPRoute("correctgw", "bookinfo2",
+		data.CreateHTTPRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo"}))
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    rt.Namespace,
+		ParentRefs:   rt.Spec.ParentRefs,
+		GatewayNames: map[string]struct{}{"correctgw": empty},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name/gatewayapi", vals[0].Path)
+}
+
+func TestFoundK8sGatewayOfHTTPRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.CreateHTTPRoute("route", "bookinfo", "my-gateway", []string{"bookinfo"})
+	vals, valid := NoK8sGatewayChecker{
+		Namespace:    rt.Namespace,
+		ParentRefs:   rt.Spec.ParentRefs,
+		GatewayNames: kubernetes.K8sGatewayNames([]*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("my-gateway", "bookinfo")}),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
@@ -0,0 +1,72 @@
+package k8sroutes
+
+import (
+	"fmt"
+
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// NoReferenceGrantChecker validates that the backendRefs to Services of other namespaces are allowed by a ReferenceGrant
+type NoReferenceGrantChecker struct {
+	Namespace string
+	// RouteKind is the actual kind of the route, as used in the from of the ReferenceGrants, i.e. HTTPRoute
+	RouteKind       string
+	BackendRefs     BackendRefs
+	ReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+}
+
+// Check validates that a ReferenceGrant of the namespace of each cross-namespace backendRef allows the route to reference it
+func (n NoReferenceGrantChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for ruleIdx, refs := range n.BackendRefs {
+		for refIdx, ref := range refs {
+			if string(ref.Name) == "" || !isServiceRef(ref) {
+				continue
+			}
+			namespace := backendNamespace(ref, n.Namespace)
+			if namespace == n.Namespace || n.isGranted(namespace, string(ref.Name)) {
+				continue
+			}
+			path := fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/namespace", ruleIdx, refIdx)
+			validation := models.Build("k8sroutes.nohost.referencegrantnotfound", path)
+			validations = append(validations, &validation)
+			valid = false
+		}
+	}
+	return validations, valid
+}
+
+// isGranted returns true when a ReferenceGrant of the namespace allows the routes of the kind to reference the Service
+func (n NoReferenceGrantChecker) isGranted(namespace, service string) bool {
+	for _, rg := range n.ReferenceGrants {
+		if rg.Namespace != namespace || !n.grantsFrom(rg) {
+			continue
+		}
+		for _, to := range rg.Spec.To {
+			if string(to.Group) != "" {
+				continue
+			}
+			if string(to.Kind) != kubernetes.ServiceType {
+				continue
+			}
+			if to.Name == nil || string(*to.Name) == "" || string(*to.Name) == service {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n NoReferenceGrantChecker) grantsFrom(rg *k8s_networking_v1alpha2.ReferenceGrant) bool {
+	for _, from := range rg.Spec.From {
+		if string(from.Group) == kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group && string(from.Kind) == n.RouteKind && string(from.Namespace) == n.Namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package k8sroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestSameNamespaceBackendRef(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddBackendRefToHTTPRoute("reviews", "bookinfo", data.CreateHTTPRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo"}))
+	vals, valid := NoReferenceGrantChecker{
+		Namespace:   rt.Namespace,
+		RouteKind:   kubernetes.K8sActualHTTPRouteType,
+		BackendRefs: HTTPRouteBackendRefs(rt),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestCrossNamespaceBackendRefWithoutGrant(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddBackendRefToTLSRoute("reviews", "bookinfo2", data.CreateTLSRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo"}))
+	vals, valid := NoReferenceGrantChecker{
+		Namespace:   rt.Namespace,
+		RouteKind:   kubernetes.K8sActualTLSRouteType,
+		BackendRefs: TLSRouteBackendRefs(rt),
+		ReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{
+			// Grants of other namespaces or route kinds don't apply
+			data.CreateReferenceGrant("grant", "bookinfo3", "bookinfo", kubernetes.K8sActualTLSRouteType),
+			data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualHTTPRouteType),
+		},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.referencegrantnotfound", vals[0]))
+	assert.Equal("spec/rules[0]/backendRefs[0]/namespace", vals[0].Path)
+}
+
+func TestCrossNamespaceBackendRefWithGrant(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rt := data.AddBackendRefToTCPRoute("reviews", "bookinfo2", data.CreateTCPRoute("route", "bookinfo", "gatewayapi"))
+	vals, valid := NoReferenceGrantChecker{
+		Namespace:       rt.Namespace,
+		RouteKind:       kubernetes.K8sActualTCPRouteType,
+		BackendRefs:     TCPRouteBackendRefs(rt),
+		ReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualTCPRouteType)},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestCrossNamespaceBackendRefWithNamedGrant(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	rg := data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualTCPRouteType)
+	ratings := k8s_networking_v1alpha2.ObjectName("ratings")
+	rg.Spec.To[0].Name = &ratings
+
+	rt := data.AddBackendRefToTCPRoute("reviews", "bookinfo2",
+		data.AddBackendRefToTCPRoute("ratings", "bookinfo2", data.CreateTCPRoute("route", "bookinfo", "gatewayapi")))
+	vals, valid := NoReferenceGrantChecker{
+		Namespace:       rt.Namespace,
+		RouteKind:       kubernetes.K8sActualTCPRouteType,
+		BackendRefs:     TCPRouteBackendRefs(rt),
+		ReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{rg},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal("spec/rules[1]/backendRefs[0]/namespace", vals[0].Path)
+}
@@ -0,0 +1,56 @@
+package checkers
+
+import (
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const K8sTCPRouteCheckerType = "k8stcproute"
+
+type K8sTCPRouteChecker struct {
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute
+	K8sGateways        []*k8s_networking_v1alpha2.Gateway
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sTCPRouteChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	gatewayNames := kubernetes.K8sGatewayNames(in.K8sGateways)
+
+	for _, rt := range in.K8sTCPRoutes {
+		validations.MergeValidations(in.runChecks(rt, gatewayNames))
+	}
+
+	return validations
+}
+
+func (in K8sTCPRouteChecker) runChecks(rt *k8s_networking_v1alpha2.TCPRoute, gatewayNames map[string]struct{}) models.IstioValidations {
+	key, validations := EmptyValidValidation(rt.Name, rt.Namespace, K8sTCPRouteCheckerType)
+
+	enabledCheckers := []Checker{
+		k8sroutes.NoK8sGatewayChecker{
+			Namespace:    rt.Namespace,
+			ParentRefs:   rt.Spec.ParentRefs,
+			GatewayNames: gatewayNames,
+		},
+		k8sroutes.NoReferenceGrantChecker{
+			Namespace:       rt.Namespace,
+			RouteKind:       kubernetes.K8sActualTCPRouteType,
+			BackendRefs:     k8sroutes.TCPRouteBackendRefs(rt),
+			ReferenceGrants: in.K8sReferenceGrants,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
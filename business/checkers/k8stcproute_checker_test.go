@@ -0,0 +1,33 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTCPRouteWithoutK8sGateway(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sTCPRouteChecker{
+		K8sTCPRoutes: []*k8s_networking_v1alpha2.TCPRoute{
+			data.AddBackendRefToTCPRoute("reviews", "bookinfo", data.CreateTCPRoute("route1", "bookinfo", "gatewayapi")),
+			data.AddBackendRefToTCPRoute("reviews", "bookinfo", data.CreateTCPRoute("route2", "bookinfo", "gatewayapi2"))},
+		K8sGateways: []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8stcproute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	route2 := vals[models.IstioValidationKey{ObjectType: "k8stcproute", Namespace: "bookinfo", Name: "route2"}]
+	assert.False(route2.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", route2.Checks[0]))
+}
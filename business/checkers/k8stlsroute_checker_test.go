@@ -0,0 +1,82 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTLSRouteWithoutK8sGateway(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sTLSRouteChecker{
+		K8sTLSRoutes: []*k8s_networking_v1alpha2.TLSRoute{
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("route1", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"})),
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("route2", "bookinfo", "gatewayapi2", []string{"bookinfo.example.com"}))},
+		K8sGateways: []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	route2 := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "route2"}]
+	assert.False(route2.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", route2.Checks[0]))
+}
+
+func TestTLSRouteBackendWithoutService(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	istioConfigList := emptyIstioConfigList()
+	istioConfigList.K8sTLSRoutes = append(istioConfigList.K8sTLSRoutes,
+		data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("valid-route", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"})),
+		data.AddBackendRefToTLSRoute("ratings", "bookinfo", data.CreateTLSRoute("invalid-route", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"})))
+
+	vals := NoServiceChecker{
+		IstioConfigList:  istioConfigList,
+		RegistryServices: data.CreateFakeRegistryServices("reviews.bookinfo.svc.cluster.local", "bookinfo", "*"),
+	}.Check()
+
+	assert.True(vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "valid-route"}].Valid)
+	invalid := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "invalid-route"}]
+	assert.False(invalid.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", invalid.Checks[0]))
+}
+
+func TestTLSRouteWithoutReferenceGrant(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	vals := K8sTLSRouteChecker{
+		K8sTLSRoutes: []*k8s_networking_v1alpha2.TLSRoute{
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo2", data.CreateTLSRoute("route1", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"})),
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo3", data.CreateTLSRoute("route2", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"})),
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo4", data.CreateTLSRoute("route3", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"}))},
+		K8sGateways: []*k8s_networking_v1alpha2.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{
+			data.CreateReferenceGrant("grant", "bookinfo2", "bookinfo", kubernetes.K8sActualTLSRouteType),
+			// granted to another kind of route
+			data.CreateReferenceGrant("grant", "bookinfo4", "bookinfo", kubernetes.K8sActualHTTPRouteType)},
+	}.Check()
+
+	route1 := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: "route1"}]
+	assert.True(route1.Valid)
+	assert.Empty(route1.Checks)
+	for _, name := range []string{"route2", "route3"} {
+		route := vals[models.IstioValidationKey{ObjectType: "k8stlsroute", Namespace: "bookinfo", Name: name}]
+		assert.False(route.Valid)
+		assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.referencegrantnotfound", route.Checks[0]))
+	}
+}
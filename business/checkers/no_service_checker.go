@@ -4,6 +4,7 @@ import (
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 
 	"github.com/kiali/kiali/business/checkers/destinationrules"
+	"github.com/kiali/kiali/business/checkers/k8sroutes"
 	"github.com/kiali/kiali/business/checkers/virtualservices"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
@@ -38,6 +39,18 @@ func (in NoServiceChecker) Check() models.IstioValidations {
 	for _, destinationRule := range in.IstioConfigList.DestinationRules {
 		validations.MergeValidations(runDestinationRuleCheck(destinationRule, in.WorkloadsPerNamespace, in.IstioConfigList.ServiceEntries, in.Namespaces, in.RegistryServices, in.IstioConfigList.VirtualServices, in.PolicyAllowAny))
 	}
+	for _, rt := range in.IstioConfigList.K8sHTTPRoutes {
+		validations.MergeValidations(runK8sRouteCheck(rt.Name, rt.Namespace, K8sHTTPRouteCheckerType, k8sroutes.HTTPRouteBackendRefs(rt), in.RegistryServices))
+	}
+	for _, rt := range in.IstioConfigList.K8sGRPCRoutes {
+		validations.MergeValidations(runK8sRouteCheck(rt.Name, rt.Namespace, K8sGRPCRouteCheckerType, k8sroutes.GRPCRouteBackendRefs(rt), in.RegistryServices))
+	}
+	for _, rt := range in.IstioConfigList.K8sTCPRoutes {
+		validations.MergeValidations(runK8sRouteCheck(rt.Name, rt.Namespace, K8sTCPRouteCheckerType, k8sroutes.TCPRouteBackendRefs(rt), in.RegistryServices))
+	}
+	for _, rt := range in.IstioConfigList.K8sTLSRoutes {
+		validations.MergeValidations(runK8sRouteCheck(rt.Name, rt.Namespace, K8sTLSRouteCheckerType, k8sroutes.TLSRouteBackendRefs(rt), in.RegistryServices))
+	}
 	return validations
 }
 
@@ -91,3 +104,18 @@ func runDestinationRuleCheck(destinationRule *networking_v1beta1.DestinationRule
 
 	return models.IstioValidations{key: validations}
 }
+
+func runK8sRouteCheck(name, namespace, checkerType string, backendRefs k8sroutes.BackendRefs, registryStatus []*kubernetes.RegistryService) models.IstioValidations {
+	key, validations := EmptyValidValidation(name, namespace, checkerType)
+
+	result, valid := k8sroutes.NoHostChecker{
+		Namespace:        namespace,
+		BackendRefs:      backendRefs,
+		RegistryServices: registryStatus,
+	}.Check()
+
+	validations.Valid = valid
+	validations.Checks = result
+
+	return models.IstioValidations{key: validations}
+}
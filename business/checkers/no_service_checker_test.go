@@ -197,3 +197,25 @@ func appVersionLabel(app, version string) map[string]string {
 		"version": version,
 	}
 }
+
+func TestK8sRouteBackendWithoutService(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	assert := assert.New(t)
+
+	istioConfigList := emptyIstioConfigList()
+	istioConfigList.K8sTCPRoutes = append(istioConfigList.K8sTCPRoutes,
+		data.AddBackendRefToTCPRoute("reviews", "test", data.CreateTCPRoute("valid-route", "test", "gatewayapi")),
+		data.AddBackendRefToTCPRoute("ratings", "test", data.CreateTCPRoute("invalid-route", "test", "gatewayapi")))
+
+	vals := NoServiceChecker{
+		IstioConfigList:  istioConfigList,
+		RegistryServices: data.CreateFakeRegistryServices("reviews.test.svc.cluster.local", "test", "*"),
+	}.Check()
+
+	assert.True(vals[models.IstioValidationKey{ObjectType: "k8stcproute", Namespace: "test", Name: "valid-route"}].Valid)
+	invalid := vals[models.IstioValidationKey{ObjectType: "k8stcproute", Namespace: "test", Name: "invalid-route"}]
+	assert.False(invalid.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", invalid.Checks[0]))
+}
@@ -0,0 +1,127 @@
+package telemetries
+
+import (
+	"sort"
+	"strings"
+
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const TelemetryCheckerType = "telemetry"
+
+// MeshWideOverrideChecker looks for namespace-wide Telemetries that replace the providers configured by a
+// mesh-wide Telemetry (a selector-less Telemetry in the root namespace).
+// Istio does not merge provider lists: the most specific Telemetry wins, which usually is not what users expect.
+type MeshWideOverrideChecker struct {
+	Telemetries []*v1alpha1.Telemetry
+}
+
+func (m MeshWideOverrideChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	meshWide := make([]*v1alpha1.Telemetry, 0)
+	for _, tm := range m.Telemetries {
+		if config.IsRootNamespace(tm.Namespace) && !hasSelector(tm) {
+			meshWide = append(meshWide, tm)
+		}
+	}
+	if len(meshWide) == 0 {
+		return validations
+	}
+
+	for _, tm := range m.Telemetries {
+		if config.IsRootNamespace(tm.Namespace) || hasSelector(tm) {
+			continue
+		}
+		for _, mwTm := range meshWide {
+			if overrides(tracingProviders(tm), tracingProviders(mwTm)) {
+				addOverride(validations, tm, mwTm, "spec/tracing")
+			}
+			if overrides(accessLoggingProviders(tm), accessLoggingProviders(mwTm)) {
+				addOverride(validations, tm, mwTm, "spec/accessLogging")
+			}
+			if overrides(metricsProviders(tm), metricsProviders(mwTm)) {
+				addOverride(validations, tm, mwTm, "spec/metrics")
+			}
+		}
+	}
+
+	return validations
+}
+
+func hasSelector(tm *v1alpha1.Telemetry) bool {
+	return tm.Spec.Selector != nil && len(tm.Spec.Selector.MatchLabels) > 0
+}
+
+// overrides returns true when both Telemetries configure providers for the same section but with a different set.
+// An empty list means the section doesn't set providers, so the mesh-wide ones are inherited.
+func overrides(nsProviders, meshProviders []string) bool {
+	if len(nsProviders) == 0 || len(meshProviders) == 0 {
+		return false
+	}
+	return strings.Join(nsProviders, ",") != strings.Join(meshProviders, ",")
+}
+
+func tracingProviders(tm *v1alpha1.Telemetry) []string {
+	refs := make([]*api_telemetry_v1alpha1.ProviderRef, 0)
+	for _, t := range tm.Spec.Tracing {
+		if t != nil {
+			refs = append(refs, t.Providers...)
+		}
+	}
+	return providerNames(refs)
+}
+
+func accessLoggingProviders(tm *v1alpha1.Telemetry) []string {
+	refs := make([]*api_telemetry_v1alpha1.ProviderRef, 0)
+	for _, al := range tm.Spec.AccessLogging {
+		if al != nil {
+			refs = append(refs, al.Providers...)
+		}
+	}
+	return providerNames(refs)
+}
+
+func metricsProviders(tm *v1alpha1.Telemetry) []string {
+	refs := make([]*api_telemetry_v1alpha1.ProviderRef, 0)
+	for _, mt := range tm.Spec.Metrics {
+		if mt != nil {
+			refs = append(refs, mt.Providers...)
+		}
+	}
+	return providerNames(refs)
+}
+
+// providerNames returns the sorted and de-duplicated names of the provider references
+func providerNames(refs []*api_telemetry_v1alpha1.ProviderRef) []string {
+	seen := make(map[string]bool, len(refs))
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if ref == nil || seen[ref.Name] {
+			continue
+		}
+		seen[ref.Name] = true
+		names = append(names, ref.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func addOverride(validations models.IstioValidations, tm, meshWideTm *v1alpha1.Telemetry, path string) {
+	key := models.BuildKey(TelemetryCheckerType, tm.Name, tm.Namespace)
+	meshWideKey := models.BuildKey(TelemetryCheckerType, meshWideTm.Name, meshWideTm.Namespace)
+	check := models.Build("telemetry.meshwide.override", path)
+	validations.MergeValidations(models.IstioValidations{
+		key: &models.IstioValidation{
+			Name:       tm.Name,
+			ObjectType: TelemetryCheckerType,
+			Valid:      true,
+			Checks:     []*models.IstioCheck{&check},
+			References: []models.IstioValidationKey{meshWideKey},
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package telemetries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestNamespaceWideOverridesMeshWide(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	meshWide := data.AddTracingToTelemetry([]string{"zipkin"}, data.CreateTelemetry("mesh-default", conf.ExternalServices.Istio.RootNamespace))
+	nsWide := data.AddTracingToTelemetry([]string{"otel"}, data.CreateTelemetry("bookinfo-default", "bookinfo"))
+
+	vals := MeshWideOverrideChecker{
+		Telemetries: []*v1alpha1.Telemetry{meshWide, nsWide},
+	}.Check()
+
+	assert.Len(vals, 1)
+	validation, ok := vals[models.BuildKey(TelemetryCheckerType, "bookinfo-default", "bookinfo")]
+	assert.True(ok)
+	assert.True(validation.Valid)
+	assert.Len(validation.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("telemetry.meshwide.override", validation.Checks[0]))
+	assert.Equal("spec/tracing", validation.Checks[0].Path)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(TelemetryCheckerType, "mesh-default", conf.ExternalServices.Istio.RootNamespace)}, validation.References)
+}
+
+func TestNamespaceWideSameProviders(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	meshWide := data.AddTracingToTelemetry([]string{"zipkin"}, data.CreateTelemetry("mesh-default", conf.ExternalServices.Istio.RootNamespace))
+	nsWide := data.AddTracingToTelemetry([]string{"zipkin"}, data.CreateTelemetry("bookinfo-default", "bookinfo"))
+	// Different section than the mesh-wide one: providers are inherited, no override
+	nsWide = data.AddAccessLoggingToTelemetry([]string{"envoy"}, nsWide)
+
+	vals := MeshWideOverrideChecker{
+		Telemetries: []*v1alpha1.Telemetry{meshWide, nsWide},
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestWorkloadTelemetryNotOverride(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	meshWide := data.AddTracingToTelemetry([]string{"zipkin"}, data.CreateTelemetry("mesh-default", conf.ExternalServices.Istio.RootNamespace))
+	wkTm := data.AddTracingToTelemetry([]string{"otel"}, data.CreateTelemetry("reviews", "bookinfo"))
+	wkTm = data.AddSelectorToTelemetry(map[string]string{"app": "reviews"}, wkTm)
+
+	vals := MeshWideOverrideChecker{
+		Telemetries: []*v1alpha1.Telemetry{meshWide, wkTm},
+	}.Check()
+
+	assert.Empty(vals)
+}
@@ -0,0 +1,50 @@
+package telemetries
+
+import (
+	"fmt"
+
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ProviderChecker validates that the tracing and access logging providers referenced by a Telemetry
+// are defined as extension providers in the MeshConfig.
+type ProviderChecker struct {
+	Telemetry          *v1alpha1.Telemetry
+	ExtensionProviders map[string]bool
+}
+
+func (pc ProviderChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	for i, tracing := range pc.Telemetry.Spec.Tracing {
+		if tracing == nil {
+			continue
+		}
+		for j, provider := range tracing.Providers {
+			if provider == nil || pc.ExtensionProviders[provider.Name] {
+				continue
+			}
+			check := models.Build("telemetry.tracing.providernotfound", fmt.Sprintf("spec/tracing[%d]/providers[%d]/name", i, j))
+			checks = append(checks, &check)
+			valid = false
+		}
+	}
+
+	for i, accessLogging := range pc.Telemetry.Spec.AccessLogging {
+		if accessLogging == nil {
+			continue
+		}
+		for j, provider := range accessLogging.Providers {
+			if provider == nil || pc.ExtensionProviders[provider.Name] {
+				continue
+			}
+			check := models.Build("telemetry.accesslogging.providernotfound", fmt.Sprintf("spec/accessLogging[%d]/providers[%d]/name", i, j))
+			checks = append(checks, &check)
+			valid = false
+		}
+	}
+
+	return checks, valid
+}
@@ -0,0 +1,75 @@
+package telemetries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestDefaultProvidersFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	tm := data.CreateTelemetry("default", "bookinfo")
+	tm = data.AddAccessLoggingToTelemetry([]string{"envoy"}, tm)
+	tm = data.AddMetricsToTelemetry([]string{"prometheus"}, tm)
+
+	vals, valid := ProviderChecker{
+		Telemetry:          tm,
+		ExtensionProviders: kubernetes.IstioMeshConfig{}.GetExtensionProviderNames(),
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestExtensionProvidersFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	tm := data.CreateTelemetry("default", "bookinfo")
+	tm = data.AddTracingToTelemetry([]string{"zipkin"}, tm)
+	tm = data.AddAccessLoggingToTelemetry([]string{"otel"}, tm)
+
+	meshConfig := kubernetes.IstioMeshConfig{
+		ExtensionProviders: []kubernetes.IstioExtensionProvider{{Name: "zipkin"}, {Name: "otel"}},
+	}
+
+	vals, valid := ProviderChecker{
+		Telemetry:          tm,
+		ExtensionProviders: meshConfig.GetExtensionProviderNames(),
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestProvidersNotFound(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	tm := data.CreateTelemetry("default", "bookinfo")
+	tm = data.AddTracingToTelemetry([]string{"zipkin", "jaeger"}, tm)
+	tm = data.AddAccessLoggingToTelemetry([]string{"envoy", "otel"}, tm)
+
+	meshConfig := kubernetes.IstioMeshConfig{
+		ExtensionProviders: []kubernetes.IstioExtensionProvider{{Name: "zipkin"}},
+	}
+
+	vals, valid := ProviderChecker{
+		Telemetry:          tm,
+		ExtensionProviders: meshConfig.GetExtensionProviderNames(),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("telemetry.tracing.providernotfound", vals[0]))
+	assert.Equal("spec/tracing[0]/providers[1]/name", vals[0].Path)
+	assert.NoError(validations.ConfirmIstioCheckMessage("telemetry.accesslogging.providernotfound", vals[1]))
+	assert.Equal("spec/accessLogging[0]/providers[1]/name", vals[1].Path)
+}
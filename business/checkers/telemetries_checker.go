@@ -3,14 +3,19 @@ package checkers
 import (
 	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
 
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/telemetries"
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 )
 
 const TelemetryCheckerType = "telemetry"
 
 type TelemetryChecker struct {
-	Namespaces  models.Namespaces
-	Telemetries []*v1alpha1.Telemetry
+	Namespaces            models.Namespaces
+	Telemetries           []*v1alpha1.Telemetry
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	MeshConfig            kubernetes.IstioMeshConfig
 }
 
 // An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
@@ -20,5 +25,57 @@ type TelemetryChecker struct {
 func (in TelemetryChecker) Check() models.IstioValidations {
 	validations := models.IstioValidations{}
 
+	validations = validations.MergeValidations(in.runIndividualChecks())
+	validations = validations.MergeValidations(in.runGroupChecks())
+
 	return validations
 }
+
+func (in TelemetryChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		common.TelemetryMultiMatchChecker(TelemetryCheckerType, in.Telemetries, in.WorkloadsPerNamespace),
+		telemetries.MeshWideOverrideChecker{Telemetries: in.Telemetries},
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
+	return validations
+}
+
+func (in TelemetryChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	extensionProviders := in.MeshConfig.GetExtensionProviderNames()
+
+	for _, telemetry := range in.Telemetries {
+		validations.MergeValidations(in.runChecks(telemetry, extensionProviders))
+	}
+
+	return validations
+}
+
+func (in TelemetryChecker) runChecks(telemetry *v1alpha1.Telemetry, extensionProviders map[string]bool) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(telemetry.Name, telemetry.Namespace, TelemetryCheckerType)
+
+	matchLabels := make(map[string]string)
+	if telemetry.Spec.Selector != nil {
+		matchLabels = telemetry.Spec.Selector.MatchLabels
+	}
+
+	enabledCheckers := []Checker{
+		common.SelectorNoWorkloadFoundChecker(TelemetryCheckerType, matchLabels, in.WorkloadsPerNamespace),
+		telemetries.ProviderChecker{Telemetry: telemetry, ExtensionProviders: extensionProviders},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
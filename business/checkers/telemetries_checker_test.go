@@ -0,0 +1,66 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTelemetryNoCrashOnEmpty(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := TelemetryChecker{
+		Telemetries: []*v1alpha1.Telemetry{},
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestTelemetryValidations(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	workloads := data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+		data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+	)
+
+	vals := TelemetryChecker{
+		Telemetries: []*v1alpha1.Telemetry{
+			data.AddSelectorToTelemetry(map[string]string{"app": "reviews"}, data.CreateTelemetry("reviews-1", "bookinfo")),
+			data.AddSelectorToTelemetry(map[string]string{"app": "reviews", "version": "v1"}, data.CreateTelemetry("reviews-2", "bookinfo")),
+			data.AddSelectorToTelemetry(map[string]string{"app": "ratings"}, data.CreateTelemetry("ratings", "bookinfo")),
+			data.AddTracingToTelemetry([]string{"zipkin"}, data.CreateTelemetry("default", "bookinfo")),
+		},
+		WorkloadsPerNamespace: workloads,
+		MeshConfig:            kubernetes.IstioMeshConfig{},
+	}.Check()
+
+	assert.Len(vals, 4)
+
+	reviews1 := vals[models.BuildKey(TelemetryCheckerType, "reviews-1", "bookinfo")]
+	assert.False(reviews1.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("generic.multimatch.selector", reviews1.Checks[0]))
+	assert.Len(reviews1.References, 1)
+
+	reviews2 := vals[models.BuildKey(TelemetryCheckerType, "reviews-2", "bookinfo")]
+	assert.False(reviews2.Valid)
+	assert.NoError(validations.ConfirmIstioCheckMessage("generic.multimatch.selector", reviews2.Checks[0]))
+
+	ratings := vals[models.BuildKey(TelemetryCheckerType, "ratings", "bookinfo")]
+	assert.True(ratings.Valid)
+	assert.Len(ratings.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("generic.selector.workloadnotfound", ratings.Checks[0]))
+
+	def := vals[models.BuildKey(TelemetryCheckerType, "default", "bookinfo")]
+	assert.False(def.Valid)
+	assert.Len(def.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("telemetry.tracing.providernotfound", def.Checks[0]))
+}
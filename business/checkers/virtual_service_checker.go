@@ -62,6 +62,7 @@ func (in VirtualServiceChecker) runChecks(virtualService *networking_v1beta1.Vir
 
 	enabledCheckers := []Checker{
 		virtualservices.RouteChecker{VirtualService: virtualService, Namespaces: in.Namespaces.GetNames()},
+		virtualservices.ShadowedRouteChecker{VirtualService: virtualService},
 		virtualservices.SubsetPresenceChecker{Namespaces: in.Namespaces.GetNames(), VirtualService: virtualService, DestinationRules: in.DestinationRules},
 		common.ExportToNamespaceChecker{ExportTo: virtualService.Spec.ExportTo, Namespaces: in.Namespaces},
 	}
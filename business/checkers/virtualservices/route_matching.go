@@ -0,0 +1,285 @@
+package virtualservices
+
+import (
+	"regexp"
+	"strings"
+
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// MeshGateway is the reserved gateway name of the sidecars of the mesh
+const MeshGateway = "mesh"
+
+// matchHTTPRequest returns true when the request matches all the conditions of the match block.
+// gateway is the gateway that receives the request, already resolved as in resolveGateway.
+func matchHTTPRequest(match *api_networking_v1beta1.HTTPMatchRequest, request models.RouteSimulationRequest, gateway string, vsNamespace string) bool {
+	if match == nil {
+		return true
+	}
+	if len(match.Gateways) > 0 && !containsGateway(match.Gateways, vsNamespace, gateway) {
+		return false
+	}
+	if match.Port != 0 && match.Port != request.Port {
+		return false
+	}
+	if match.SourceNamespace != "" && match.SourceNamespace != request.SourceNamespace {
+		return false
+	}
+	if len(match.SourceLabels) > 0 && !labels.SelectorFromSet(match.SourceLabels).Matches(labels.Set(request.SourceLabels)) {
+		return false
+	}
+
+	uri := request.Uri
+	if uri == "" {
+		uri = "/"
+	}
+	if !matchStringMatch(match.Uri, uri, match.IgnoreUriCase) ||
+		!matchStringMatch(match.Scheme, request.Scheme, false) ||
+		!matchStringMatch(match.Method, request.Method, false) ||
+		!matchStringMatch(match.Authority, request.Host, false) {
+		return false
+	}
+
+	for name, sm := range match.Headers {
+		value, ok := lookupHeader(request.Headers, name)
+		if !ok || !matchStringMatch(sm, value, false) {
+			return false
+		}
+	}
+	for name, sm := range match.WithoutHeaders {
+		if value, ok := lookupHeader(request.Headers, name); ok && matchStringMatch(sm, value, false) {
+			return false
+		}
+	}
+	for name, sm := range match.QueryParams {
+		value, ok := request.QueryParams[name]
+		if !ok || !matchStringMatch(sm, value, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchStringMatch evaluates a StringMatch as Envoy does: regex must match the whole value.
+// A nil StringMatch matches any value, and an empty one only checks the presence of the value.
+func matchStringMatch(sm *api_networking_v1beta1.StringMatch, value string, ignoreCase bool) bool {
+	if sm == nil {
+		return true
+	}
+	switch m := sm.MatchType.(type) {
+	case *api_networking_v1beta1.StringMatch_Exact:
+		if ignoreCase {
+			return strings.EqualFold(value, m.Exact)
+		}
+		return value == m.Exact
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		if ignoreCase {
+			return strings.HasPrefix(strings.ToLower(value), strings.ToLower(m.Prefix))
+		}
+		return strings.HasPrefix(value, m.Prefix)
+	case *api_networking_v1beta1.StringMatch_Regex:
+		expr := "^(?:" + m.Regex + ")$"
+		if ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		return err == nil && re.MatchString(value)
+	default:
+		return value != ""
+	}
+}
+
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// matchCovers returns true when every request matched by b is also matched by a,
+// i.e. a route with the match a placed before a route with the match b shadows it.
+// It is conservative: when the coverage can't be decided, like for different regexes, it returns false.
+func matchCovers(a, b *api_networking_v1beta1.HTTPMatchRequest, vsNamespace string) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		b = &api_networking_v1beta1.HTTPMatchRequest{}
+	}
+
+	if len(a.Gateways) > 0 {
+		if len(b.Gateways) == 0 {
+			return false
+		}
+		for _, gw := range b.Gateways {
+			if !containsGateway(a.Gateways, vsNamespace, resolveGateway(gw, vsNamespace)) {
+				return false
+			}
+		}
+	}
+	if a.Port != 0 && a.Port != b.Port {
+		return false
+	}
+	if a.SourceNamespace != "" && a.SourceNamespace != b.SourceNamespace {
+		return false
+	}
+	for k, v := range a.SourceLabels {
+		if bv, ok := b.SourceLabels[k]; !ok || bv != v {
+			return false
+		}
+	}
+
+	// A case sensitive uri match doesn't cover a case insensitive one
+	if a.Uri != nil && b.IgnoreUriCase && !a.IgnoreUriCase {
+		return false
+	}
+	// Every path starts with "/", so the prefix "/" matches any uri
+	if !(b.Uri == nil && a.Uri.GetPrefix() == "/") && !stringMatchCovers(a.Uri, b.Uri, a.IgnoreUriCase) {
+		return false
+	}
+	if !stringMatchCovers(a.Scheme, b.Scheme, false) ||
+		!stringMatchCovers(a.Method, b.Method, false) ||
+		!stringMatchCovers(a.Authority, b.Authority, false) ||
+		!stringMatchesCover(a.Headers, b.Headers, true) ||
+		!stringMatchesCover(a.QueryParams, b.QueryParams, false) {
+		return false
+	}
+
+	// b must exclude at least the same headers than a
+	for name, sm := range a.WithoutHeaders {
+		bsm, ok := lookupStringMatch(b.WithoutHeaders, name, true)
+		if !ok || !stringMatchCovers(bsm, sm, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// stringMatchesCover returns true when each condition of a is covered by the condition of b with the same name
+func stringMatchesCover(a, b map[string]*api_networking_v1beta1.StringMatch, ignoreKeyCase bool) bool {
+	for name, sm := range a {
+		bsm, ok := lookupStringMatch(b, name, ignoreKeyCase)
+		if !ok || !stringMatchCovers(sm, bsm, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func lookupStringMatch(matches map[string]*api_networking_v1beta1.StringMatch, name string, ignoreKeyCase bool) (*api_networking_v1beta1.StringMatch, bool) {
+	for k, sm := range matches {
+		if k == name || (ignoreKeyCase && strings.EqualFold(k, name)) {
+			if sm == nil {
+				sm = &api_networking_v1beta1.StringMatch{}
+			}
+			return sm, true
+		}
+	}
+	return nil, false
+}
+
+// stringMatchCovers returns true when every value matched by b is also matched by a
+func stringMatchCovers(a, b *api_networking_v1beta1.StringMatch, ignoreCase bool) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		return false
+	}
+	normalize := func(s string) string {
+		if ignoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	switch am := a.MatchType.(type) {
+	case *api_networking_v1beta1.StringMatch_Exact:
+		bm, ok := b.MatchType.(*api_networking_v1beta1.StringMatch_Exact)
+		return ok && normalize(bm.Exact) == normalize(am.Exact)
+	case *api_networking_v1beta1.StringMatch_Prefix:
+		switch bm := b.MatchType.(type) {
+		case *api_networking_v1beta1.StringMatch_Exact:
+			return strings.HasPrefix(normalize(bm.Exact), normalize(am.Prefix))
+		case *api_networking_v1beta1.StringMatch_Prefix:
+			return strings.HasPrefix(normalize(bm.Prefix), normalize(am.Prefix))
+		}
+		return false
+	case *api_networking_v1beta1.StringMatch_Regex:
+		switch bm := b.MatchType.(type) {
+		case *api_networking_v1beta1.StringMatch_Regex:
+			return bm.Regex == am.Regex
+		case *api_networking_v1beta1.StringMatch_Exact:
+			return matchStringMatch(a, bm.Exact, ignoreCase)
+		}
+		return false
+	default:
+		// Presence match covers any other match
+		return true
+	}
+}
+
+// shadowingRoute returns the index of the earlier route that matches all the requests that the route can match,
+// -1 when the route is reachable.
+func shadowingRoute(routes []*api_networking_v1beta1.HTTPRoute, routeIdx int, vsNamespace string) int {
+	route := routes[routeIdx]
+	if route == nil {
+		return -1
+	}
+	matches := route.Match
+	if len(matches) == 0 {
+		// A catch-all route is only shadowed by another catch-all
+		matches = []*api_networking_v1beta1.HTTPMatchRequest{nil}
+	}
+
+	shadowedBy := -1
+	for _, m := range matches {
+		coveredBy := -1
+		for i := 0; i < routeIdx && coveredBy < 0; i++ {
+			if routes[i] == nil {
+				continue
+			}
+			if len(routes[i].Match) == 0 {
+				coveredBy = i
+				break
+			}
+			for _, earlier := range routes[i].Match {
+				if matchCovers(earlier, m, vsNamespace) {
+					coveredBy = i
+					break
+				}
+			}
+		}
+		if coveredBy < 0 {
+			return -1
+		}
+		if coveredBy > shadowedBy {
+			shadowedBy = coveredBy
+		}
+	}
+	return shadowedBy
+}
+
+// resolveGateway returns the FQDN of a gateway reference as used in the VirtualServices,
+// or MeshGateway for the sidecars of the mesh
+func resolveGateway(gateway, namespace string) string {
+	if gateway == "" || gateway == MeshGateway {
+		return MeshGateway
+	}
+	return kubernetes.ParseGatewayAsHost(gateway, namespace).String()
+}
+
+// containsGateway returns true when the resolved gateway is referenced by any of the gateways of a VirtualService
+func containsGateway(gateways []string, vsNamespace, gateway string) bool {
+	for _, gw := range gateways {
+		if resolveGateway(gw, vsNamespace) == gateway {
+			return true
+		}
+	}
+	return false
+}
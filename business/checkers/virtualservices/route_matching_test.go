@@ -0,0 +1,56 @@
+package virtualservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestMatchCovers(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	assert.True(matchCovers(data.CreateUriPrefixMatch("/"), data.CreateUriPrefixMatch("/api"), "bookinfo"))
+	assert.True(matchCovers(data.CreateUriPrefixMatch("/api"), data.CreateUriExactMatch("/api/v1"), "bookinfo"))
+	assert.True(matchCovers(data.CreateUriPrefixMatch("/"), &api_networking_v1beta1.HTTPMatchRequest{}, "bookinfo"))
+	assert.True(matchCovers(&api_networking_v1beta1.HTTPMatchRequest{}, data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriExactMatch("/")), "bookinfo"))
+	assert.True(matchCovers(data.CreateUriPrefixMatch("/api"), data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/api/v1")), "bookinfo"))
+
+	assert.False(matchCovers(data.CreateUriPrefixMatch("/api"), data.CreateUriPrefixMatch("/"), "bookinfo"))
+	assert.False(matchCovers(data.CreateUriExactMatch("/api"), data.CreateUriPrefixMatch("/api"), "bookinfo"))
+	assert.False(matchCovers(data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/")), data.CreateUriPrefixMatch("/api"), "bookinfo"))
+
+	withPort := data.CreateUriPrefixMatch("/")
+	withPort.Port = 9080
+	assert.False(matchCovers(withPort, data.CreateUriPrefixMatch("/api"), "bookinfo"))
+
+	withGateway := data.CreateUriPrefixMatch("/")
+	withGateway.Gateways = []string{"bookinfo-gateway"}
+	otherGateway := data.CreateUriPrefixMatch("/api")
+	otherGateway.Gateways = []string{"bookinfo/bookinfo-gateway"}
+	assert.True(matchCovers(withGateway, otherGateway, "bookinfo"))
+	otherGateway.Gateways = []string{"mesh"}
+	assert.False(matchCovers(withGateway, otherGateway, "bookinfo"))
+}
+
+func TestShadowingRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	routes := []*api_networking_v1beta1.HTTPRoute{
+		data.CreateHttpRoute("api", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/api")}),
+		data.CreateHttpRoute("login", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriExactMatch("/login"), data.CreateUriPrefixMatch("/api/v2")}),
+		data.CreateHttpRoute("default", nil),
+		data.CreateHttpRoute("unreachable", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriExactMatch("/help")}),
+	}
+
+	assert.Equal(-1, shadowingRoute(routes, 0, "bookinfo"))
+	// Only one of the match blocks is shadowed
+	assert.Equal(-1, shadowingRoute(routes, 1, "bookinfo"))
+	assert.Equal(-1, shadowingRoute(routes, 2, "bookinfo"))
+	assert.Equal(2, shadowingRoute(routes, 3, "bookinfo"))
+}
@@ -0,0 +1,288 @@
+package virtualservices
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// RouteSimulator resolves where a request is sent, walking the http routes of the VirtualService of the host
+// in order, as Envoy does, and resolving the traffic policy of the destinations from the DestinationRules.
+type RouteSimulator struct {
+	VirtualServices  []*networking_v1beta1.VirtualService
+	DestinationRules []*networking_v1beta1.DestinationRule
+	Namespaces       []string
+	RootNamespace    string
+}
+
+// Simulate returns the route matched by the request and its destinations
+func (s RouteSimulator) Simulate(request models.RouteSimulationRequest) models.RouteSimulationResult {
+	result := models.RouteSimulationResult{
+		Destinations: []models.RouteSimulationDestination{},
+		Unreachable:  []models.RouteSimulationRoute{},
+	}
+	host := kubernetes.GetHost(request.Host, request.SourceNamespace, s.Namespaces).String()
+	gateway := resolveGateway(request.Gateway, request.SourceNamespace)
+
+	vs := s.virtualServiceFor(request.Host, host, gateway, request.SourceNamespace)
+	if vs == nil {
+		if gateway != MeshGateway {
+			result.Reason = fmt.Sprintf("no VirtualService bound to gateway %s handles host %s", gateway, request.Host)
+			return result
+		}
+		result.Destinations = append(result.Destinations, s.destination(host, "", request.Port, 100, request.SourceNamespace))
+		result.Reason = fmt.Sprintf("no VirtualService handles host %s, the request is sent to the host", request.Host)
+		return result
+	}
+	result.VirtualService = &models.IstioObjectReference{Name: vs.Name, Namespace: vs.Namespace}
+
+	routes := vs.Spec.Http
+	for routeIdx := range routes {
+		if by := shadowingRoute(routes, routeIdx, vs.Namespace); by >= 0 {
+			unreachable := httpRouteRef(routes[routeIdx], routeIdx, -1)
+			unreachable.ShadowedBy = fmt.Sprintf("spec/http[%d]", by)
+			result.Unreachable = append(result.Unreachable, unreachable)
+		}
+	}
+
+	for routeIdx, route := range routes {
+		if route == nil {
+			continue
+		}
+		matchIdx, matched := -1, len(route.Match) == 0
+		for i, match := range route.Match {
+			if matchHTTPRequest(match, request, gateway, vs.Namespace) {
+				matchIdx, matched = i, true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		ref := httpRouteRef(route, routeIdx, matchIdx)
+		result.Route = &ref
+		if matchIdx >= 0 {
+			result.Reason = fmt.Sprintf("matched %s/match[%d] of VirtualService %s/%s", ref.Path, matchIdx, vs.Namespace, vs.Name)
+		} else {
+			result.Reason = fmt.Sprintf("matched %s of VirtualService %s/%s, a route without match", ref.Path, vs.Namespace, vs.Name)
+		}
+		for _, dw := range route.Route {
+			if dw == nil || dw.Destination == nil {
+				continue
+			}
+			weight := dw.Weight
+			if len(route.Route) == 1 && weight == 0 {
+				weight = 100
+			}
+			port := request.Port
+			if dw.Destination.Port != nil && dw.Destination.Port.Number != 0 {
+				port = dw.Destination.Port.Number
+			}
+			destinationHost := kubernetes.GetHost(dw.Destination.Host, vs.Namespace, s.Namespaces).String()
+			result.Destinations = append(result.Destinations, s.destination(destinationHost, dw.Destination.Subset, port, weight, request.SourceNamespace))
+		}
+		return result
+	}
+
+	result.Reason = fmt.Sprintf("no http route of VirtualService %s/%s matches the request", vs.Namespace, vs.Name)
+	return result
+}
+
+func httpRouteRef(route *api_networking_v1beta1.HTTPRoute, routeIdx, matchIdx int) models.RouteSimulationRoute {
+	ref := models.RouteSimulationRoute{
+		Index: routeIdx,
+		Match: matchIdx,
+		Path:  fmt.Sprintf("spec/http[%d]", routeIdx),
+	}
+	if route != nil {
+		ref.Name = route.Name
+		ref.Redirect = route.Redirect != nil
+		ref.DirectResponse = route.DirectResponse != nil
+	}
+	return ref
+}
+
+// virtualServiceFor returns the VirtualService that handles the host for the gateway.
+// An exact host is preferred to a wildcard one, and the oldest VirtualService wins a tie, as in Istio.
+func (s RouteSimulator) virtualServiceFor(requestHost, host, gateway, sourceNamespace string) *networking_v1beta1.VirtualService {
+	type candidate struct {
+		vs    *networking_v1beta1.VirtualService
+		score int
+	}
+	candidates := make([]candidate, 0)
+	for _, vs := range s.VirtualServices {
+		if gateway == MeshGateway && !isExportedTo(vs.Spec.ExportTo, vs.Namespace, sourceNamespace) {
+			continue
+		}
+		gateways := vs.Spec.Gateways
+		if len(gateways) == 0 {
+			gateways = []string{MeshGateway}
+		}
+		if !containsGateway(gateways, vs.Namespace, gateway) {
+			continue
+		}
+		if score := s.hostScore(vs.Spec.Hosts, vs.Namespace, requestHost, host); score > 0 {
+			candidates = append(candidates, candidate{vs: vs, score: score})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		ti, tj := candidates[i].vs.CreationTimestamp, candidates[j].vs.CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return candidates[i].vs.Namespace+"/"+candidates[i].vs.Name < candidates[j].vs.Namespace+"/"+candidates[j].vs.Name
+	})
+	return candidates[0].vs
+}
+
+// hostScore returns 0 when none of the hosts matches, and a higher score for a more specific match
+func (s RouteSimulator) hostScore(hosts []string, namespace, requestHost, host string) int {
+	best := 0
+	for _, h := range hosts {
+		switch {
+		case h == requestHost || kubernetes.GetHost(h, namespace, s.Namespaces).String() == host:
+			return 1 << 20
+		case h == "*":
+			if best < 1 {
+				best = 1
+			}
+		case kubernetes.HostWithinWildcardHost(requestHost, h) || kubernetes.HostWithinWildcardHost(host, h):
+			if len(h) > best {
+				best = len(h)
+			}
+		}
+	}
+	return best
+}
+
+// destination resolves the DestinationRule and the traffic policy that apply to the destination
+func (s RouteSimulator) destination(host, subset string, port uint32, weight int32, sourceNamespace string) models.RouteSimulationDestination {
+	destination := models.RouteSimulationDestination{
+		Host:   host,
+		Subset: subset,
+		Port:   port,
+		Weight: weight,
+	}
+	if dr := s.destinationRuleFor(host, sourceNamespace); dr != nil {
+		destination.DestinationRule = &models.IstioObjectReference{Name: dr.Name, Namespace: dr.Namespace}
+		destination.TrafficPolicy = resolveTrafficPolicy(dr, subset, port)
+	}
+	return destination
+}
+
+// destinationRuleFor returns the DestinationRule of the host, looked up in the namespace of the client,
+// then in the namespace of the service and then in the root namespace, as in Istio. The most specific
+// host wins within a namespace.
+func (s RouteSimulator) destinationRuleFor(host, sourceNamespace string) *networking_v1beta1.DestinationRule {
+	serviceNamespace := ""
+	if parts := strings.Split(host, "."); len(parts) > 1 {
+		serviceNamespace = parts[1]
+	}
+	for _, namespace := range []string{sourceNamespace, serviceNamespace, s.RootNamespace} {
+		if namespace == "" {
+			continue
+		}
+		var best *networking_v1beta1.DestinationRule
+		bestScore := 0
+		for _, dr := range s.DestinationRules {
+			if dr.Namespace != namespace || !isExportedTo(dr.Spec.ExportTo, dr.Namespace, sourceNamespace) {
+				continue
+			}
+			if score := s.hostScore([]string{dr.Spec.Host}, dr.Namespace, host, host); score > bestScore {
+				best, bestScore = dr, score
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// resolveTrafficPolicy merges the traffic policy of the DestinationRule with the one of the subset,
+// and then with the port level settings of the port
+func resolveTrafficPolicy(dr *networking_v1beta1.DestinationRule, subset string, port uint32) *api_networking_v1beta1.TrafficPolicy {
+	var policy *api_networking_v1beta1.TrafficPolicy
+	if dr.Spec.TrafficPolicy != nil {
+		policy = proto.Clone(dr.Spec.TrafficPolicy).(*api_networking_v1beta1.TrafficPolicy)
+	}
+	for _, ss := range dr.Spec.Subsets {
+		if ss == nil || ss.Name != subset || ss.TrafficPolicy == nil {
+			continue
+		}
+		if policy == nil {
+			policy = &api_networking_v1beta1.TrafficPolicy{}
+		}
+		sp := ss.TrafficPolicy
+		if sp.LoadBalancer != nil {
+			policy.LoadBalancer = sp.LoadBalancer
+		}
+		if sp.ConnectionPool != nil {
+			policy.ConnectionPool = sp.ConnectionPool
+		}
+		if sp.OutlierDetection != nil {
+			policy.OutlierDetection = sp.OutlierDetection
+		}
+		if sp.Tls != nil {
+			policy.Tls = sp.Tls
+		}
+		if sp.Tunnel != nil {
+			policy.Tunnel = sp.Tunnel
+		}
+		if len(sp.PortLevelSettings) > 0 {
+			policy.PortLevelSettings = sp.PortLevelSettings
+		}
+	}
+	if policy == nil {
+		return nil
+	}
+
+	for _, pls := range policy.PortLevelSettings {
+		if pls == nil || pls.Port == nil || pls.Port.Number != port {
+			continue
+		}
+		if pls.LoadBalancer != nil {
+			policy.LoadBalancer = pls.LoadBalancer
+		}
+		if pls.ConnectionPool != nil {
+			policy.ConnectionPool = pls.ConnectionPool
+		}
+		if pls.OutlierDetection != nil {
+			policy.OutlierDetection = pls.OutlierDetection
+		}
+		if pls.Tls != nil {
+			policy.Tls = pls.Tls
+		}
+	}
+	// The port level settings are already applied
+	policy.PortLevelSettings = nil
+	return policy
+}
+
+// isExportedTo returns true when an object with the given exportTo is visible from the namespace.
+// Every object is visible when the namespace is unknown.
+func isExportedTo(exportTo []string, objectNamespace, namespace string) bool {
+	if len(exportTo) == 0 || namespace == "" {
+		return true
+	}
+	for _, e := range exportTo {
+		if e == "*" || e == namespace || (e == "." && objectNamespace == namespace) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package virtualservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func canaryVirtualService() *networking_v1beta1.VirtualService {
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("jason",
+		[]*api_networking_v1beta1.HTTPMatchRequest{data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/"))},
+		data.CreateHttpRouteDestination("reviews", "v2", 0)), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("default", nil,
+		data.CreateHttpRouteDestination("reviews", "v1", 90),
+		data.CreateHttpRouteDestination("reviews.bookinfo.svc.cluster.local", "v3", 10)), vs)
+	return vs
+}
+
+func TestSimulateHeaderMatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	dr := data.AddSubsetToDestinationRule(data.CreateSubset("v2", "v2"),
+		data.AddTrafficPolicyToDestinationRule(data.CreatePortLevelTrafficPolicyForDestinationRules(),
+			data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")))
+
+	result := RouteSimulator{
+		VirtualServices:  []*networking_v1beta1.VirtualService{canaryVirtualService()},
+		DestinationRules: []*networking_v1beta1.DestinationRule{dr},
+		Namespaces:       []string{"bookinfo"},
+		RootNamespace:    "istio-system",
+	}.Simulate(models.RouteSimulationRequest{
+		Host:            "reviews",
+		Uri:             "/reviews/1",
+		Port:            9080,
+		Headers:         map[string]string{"End-User": "jason"},
+		SourceNamespace: "bookinfo",
+	})
+
+	require.NotNil(result.VirtualService)
+	assert.Equal("reviews", result.VirtualService.Name)
+	require.NotNil(result.Route)
+	assert.Equal("jason", result.Route.Name)
+	assert.Equal("spec/http[0]", result.Route.Path)
+	assert.Equal(0, result.Route.Match)
+	require.Len(result.Destinations, 1)
+	assert.Equal("reviews.bookinfo.svc.cluster.local", result.Destinations[0].Host)
+	assert.Equal("v2", result.Destinations[0].Subset)
+	assert.Equal(int32(100), result.Destinations[0].Weight)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("reviews", result.Destinations[0].DestinationRule.Name)
+	// The port level load balancer is applied
+	require.NotNil(result.Destinations[0].TrafficPolicy)
+	assert.Equal(api_networking_v1beta1.LoadBalancerSettings_ROUND_ROBIN, result.Destinations[0].TrafficPolicy.LoadBalancer.GetSimple())
+	assert.Empty(result.Destinations[0].TrafficPolicy.PortLevelSettings)
+	assert.Empty(result.Unreachable)
+}
+
+func TestSimulateWeightedDestinations(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	result := RouteSimulator{
+		VirtualServices: []*networking_v1beta1.VirtualService{canaryVirtualService()},
+		Namespaces:      []string{"bookinfo"},
+	}.Simulate(models.RouteSimulationRequest{
+		Host:            "reviews.bookinfo.svc.cluster.local",
+		Uri:             "/reviews/1",
+		SourceNamespace: "productpage",
+	})
+
+	require.NotNil(result.Route)
+	assert.Equal(1, result.Route.Index)
+	assert.Equal(-1, result.Route.Match)
+	require.Len(result.Destinations, 2)
+	assert.Equal("v1", result.Destinations[0].Subset)
+	assert.Equal(int32(90), result.Destinations[0].Weight)
+	assert.Equal("v3", result.Destinations[1].Subset)
+	assert.Equal(int32(10), result.Destinations[1].Weight)
+	assert.Nil(result.Destinations[0].DestinationRule)
+}
+
+func TestSimulateNoVirtualService(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	simulator := RouteSimulator{
+		VirtualServices: []*networking_v1beta1.VirtualService{canaryVirtualService()},
+		Namespaces:      []string{"bookinfo"},
+	}
+
+	result := simulator.Simulate(models.RouteSimulationRequest{Host: "ratings", SourceNamespace: "bookinfo"})
+	assert.Nil(result.VirtualService)
+	require.Len(result.Destinations, 1)
+	assert.Equal("ratings.bookinfo.svc.cluster.local", result.Destinations[0].Host)
+
+	// The VirtualService is not bound to the gateway
+	result = simulator.Simulate(models.RouteSimulationRequest{Host: "reviews", Gateway: "bookinfo/bookinfo-gateway", SourceNamespace: "bookinfo"})
+	assert.Nil(result.VirtualService)
+	assert.Empty(result.Destinations)
+}
+
+func TestSimulateGateway(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.AddHttpRouteToVirtualService(data.CreateHttpRoute("", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriExactMatch("/productpage")},
+		data.CreateHttpRouteDestination("productpage", "", 0)),
+		data.CreateEmptyVirtualService("bookinfo", "bookinfo", []string{"*"}))
+	vs.Spec.Gateways = []string{"bookinfo-gateway"}
+
+	simulator := RouteSimulator{
+		VirtualServices: []*networking_v1beta1.VirtualService{vs},
+		Namespaces:      []string{"bookinfo"},
+	}
+
+	result := simulator.Simulate(models.RouteSimulationRequest{Host: "bookinfo.example.com", Gateway: "bookinfo/bookinfo-gateway", Uri: "/productpage", SourceNamespace: "istio-system"})
+	require.NotNil(result.VirtualService)
+	require.NotNil(result.Route)
+	require.Len(result.Destinations, 1)
+	assert.Equal("productpage.bookinfo.svc.cluster.local", result.Destinations[0].Host)
+
+	result = simulator.Simulate(models.RouteSimulationRequest{Host: "bookinfo.example.com", Gateway: "bookinfo/bookinfo-gateway", Uri: "/login", SourceNamespace: "istio-system"})
+	require.NotNil(result.VirtualService)
+	assert.Nil(result.Route)
+	assert.Empty(result.Destinations)
+}
+
+func TestSimulateUnreachableRoutes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("all", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/")},
+		data.CreateHttpRouteDestination("reviews", "v1", 0)), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("api", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/api")},
+		data.CreateHttpRouteDestination("reviews", "v2", 0)), vs)
+
+	result := RouteSimulator{
+		VirtualServices: []*networking_v1beta1.VirtualService{vs},
+		Namespaces:      []string{"bookinfo"},
+	}.Simulate(models.RouteSimulationRequest{Host: "reviews", Uri: "/api/v1", SourceNamespace: "bookinfo"})
+
+	require.NotNil(result.Route)
+	assert.Equal("all", result.Route.Name)
+	require.Len(result.Unreachable, 1)
+	assert.Equal("spec/http[1]", result.Unreachable[0].Path)
+	assert.Equal("spec/http[0]", result.Unreachable[0].ShadowedBy)
+}
+
+func TestSimulateDestinationRuleLookup(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	config.Set(config.NewConfig())
+
+	serviceDR := data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews")
+	clientDR := data.CreateEmptyDestinationRule("productpage", "bookinfo-wildcard", "*.bookinfo.svc.cluster.local")
+	rootDR := data.CreateEmptyDestinationRule("istio-system", "reviews-root", "reviews.bookinfo.svc.cluster.local")
+	simulator := RouteSimulator{
+		VirtualServices:  []*networking_v1beta1.VirtualService{canaryVirtualService()},
+		DestinationRules: []*networking_v1beta1.DestinationRule{serviceDR, clientDR, rootDR},
+		Namespaces:       []string{"bookinfo", "productpage"},
+		RootNamespace:    "istio-system",
+	}
+	request := models.RouteSimulationRequest{
+		Host:            "reviews.bookinfo.svc.cluster.local",
+		Uri:             "/reviews/1",
+		SourceNamespace: "productpage",
+	}
+
+	// the wildcard DestinationRule of the client namespace wins over the exact one of the service namespace
+	result := simulator.Simulate(request)
+	require.NotEmpty(result.Destinations)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("bookinfo-wildcard", result.Destinations[0].DestinationRule.Name)
+
+	// then the service namespace wins over the root namespace
+	request.SourceNamespace = "ratings"
+	result = simulator.Simulate(request)
+	require.NotEmpty(result.Destinations)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("reviews", result.Destinations[0].DestinationRule.Name)
+
+	// and the root namespace is the last resort
+	simulator.DestinationRules = []*networking_v1beta1.DestinationRule{clientDR, rootDR}
+	result = simulator.Simulate(request)
+	require.NotEmpty(result.Destinations)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("reviews-root", result.Destinations[0].DestinationRule.Name)
+}
@@ -0,0 +1,75 @@
+package virtualservices
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ShadowedRouteChecker flags the http routes that can never match because the match of an earlier route
+// is a superset of theirs, and the match blocks that are identical to a previous one.
+type ShadowedRouteChecker struct {
+	VirtualService *networking_v1beta1.VirtualService
+}
+
+func (src ShadowedRouteChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	routes := src.VirtualService.Spec.Http
+	seen := make([]*api_networking_v1beta1.HTTPMatchRequest, 0)
+	for routeIdx, route := range routes {
+		if route == nil {
+			continue
+		}
+
+		duplicates := 0
+		for matchIdx, match := range route.Match {
+			if match == nil {
+				continue
+			}
+			if containsMatch(seen, match) {
+				duplicates++
+				path := fmt.Sprintf("spec/http[%d]/match[%d]", routeIdx, matchIdx)
+				check := models.Build("virtualservices.route.duplicatematch", path)
+				checks = append(checks, &check)
+				continue
+			}
+			seen = append(seen, match)
+		}
+
+		// A route whose matches are all duplicated is already reported
+		if duplicates > 0 && duplicates == len(route.Match) {
+			continue
+		}
+		if shadowingRoute(routes, routeIdx, src.VirtualService.Namespace) >= 0 {
+			path := fmt.Sprintf("spec/http[%d]", routeIdx)
+			check := models.Build("virtualservices.route.shadowed", path)
+			checks = append(checks, &check)
+		}
+	}
+
+	return checks, valid
+}
+
+// containsMatch returns true when any of the matches has the same conditions than the given one
+func containsMatch(matches []*api_networking_v1beta1.HTTPMatchRequest, match *api_networking_v1beta1.HTTPMatchRequest) bool {
+	for _, m := range matches {
+		if sameConditions(m, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameConditions compares two match blocks ignoring the fields that don't affect the matching
+func sameConditions(a, b *api_networking_v1beta1.HTTPMatchRequest) bool {
+	ac := proto.Clone(a).(*api_networking_v1beta1.HTTPMatchRequest)
+	bc := proto.Clone(b).(*api_networking_v1beta1.HTTPMatchRequest)
+	ac.Name, bc.Name = "", ""
+	ac.StatPrefix, bc.StatPrefix = "", ""
+	return proto.Equal(ac, bc)
+}
@@ -0,0 +1,101 @@
+package virtualservices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestNoShadowedRoutes(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("api", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/api")}), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("canary",
+		[]*api_networking_v1beta1.HTTPMatchRequest{data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/"))}), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("default", nil), vs)
+
+	vals, valid := ShadowedRouteChecker{VirtualService: vs}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestPrefixShadowsRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("all", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/")}), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("api", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriPrefixMatch("/api")}), vs)
+
+	vals, valid := ShadowedRouteChecker{VirtualService: vs}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.shadowed", vals[0]))
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.Equal("spec/http[1]", vals[0].Path)
+}
+
+func TestCatchAllShadowsHeaderMatch(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("default", nil), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("canary",
+		[]*api_networking_v1beta1.HTTPMatchRequest{data.AddHeaderExactToMatch("end-user", "jason", &api_networking_v1beta1.HTTPMatchRequest{})}), vs)
+
+	vals, valid := ShadowedRouteChecker{VirtualService: vs}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.shadowed", vals[0]))
+	assert.Equal("spec/http[1]", vals[0].Path)
+}
+
+func TestDuplicateMatches(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	first := data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/reviews"))
+	first.Name = "jason"
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("jason", []*api_networking_v1beta1.HTTPMatchRequest{first}), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("canary", []*api_networking_v1beta1.HTTPMatchRequest{
+		data.CreateUriExactMatch("/canary"),
+		data.AddHeaderExactToMatch("end-user", "jason", data.CreateUriPrefixMatch("/reviews")),
+	}), vs)
+
+	vals, valid := ShadowedRouteChecker{VirtualService: vs}.Check()
+
+	// Only the duplicated match is reported, the route is still reachable through the other match
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.duplicatematch", vals[0]))
+	assert.Equal("spec/http[1]/match[1]", vals[0].Path)
+}
+
+func TestDuplicateMatchesWholeRoute(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("v1", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriExactMatch("/v1")}), vs)
+	vs = data.AddHttpRouteToVirtualService(data.CreateHttpRoute("v1-copy", []*api_networking_v1beta1.HTTPMatchRequest{data.CreateUriExactMatch("/v1")}), vs)
+
+	vals, valid := ShadowedRouteChecker{VirtualService: vs}.Check()
+
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("virtualservices.route.duplicatematch", vals[0]))
+	assert.Equal("spec/http[1]/match[0]", vals[0].Path)
+}
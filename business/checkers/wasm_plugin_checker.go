@@ -3,14 +3,19 @@ package checkers
 import (
 	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 
+	"github.com/kiali/kiali/business/checkers/common"
+	"github.com/kiali/kiali/business/checkers/wasmplugins"
 	"github.com/kiali/kiali/models"
 )
 
 const WasmPluginCheckerType = "wasmplugin"
 
 type WasmPluginChecker struct {
-	Namespaces  models.Namespaces
-	WasmPlugins []*extentions_v1alpha1.WasmPlugin
+	Namespaces            models.Namespaces
+	WasmPlugins           []*extentions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+	// Secrets holds the names of the image pull secrets found, grouped by namespace
+	Secrets map[string][]string
 }
 
 // An Object Checker runs all checkers for an specific object type (i.e.: pod, route rule,...)
@@ -20,5 +25,55 @@ type WasmPluginChecker struct {
 func (in WasmPluginChecker) Check() models.IstioValidations {
 	validations := models.IstioValidations{}
 
+	validations = validations.MergeValidations(in.runIndividualChecks())
+	validations = validations.MergeValidations(in.runGroupChecks())
+
+	return validations
+}
+
+func (in WasmPluginChecker) runGroupChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	enabledCheckers := []GroupChecker{
+		wasmplugins.PhasePriorityChecker{WasmPlugins: in.WasmPlugins, WorkloadsPerNamespace: in.WorkloadsPerNamespace},
+	}
+
+	for _, checker := range enabledCheckers {
+		validations = validations.MergeValidations(checker.Check())
+	}
+
+	return validations
+}
+
+func (in WasmPluginChecker) runIndividualChecks() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wasmPlugin := range in.WasmPlugins {
+		validations.MergeValidations(in.runChecks(wasmPlugin))
+	}
+
 	return validations
 }
+
+func (in WasmPluginChecker) runChecks(wasmPlugin *extentions_v1alpha1.WasmPlugin) models.IstioValidations {
+	key, rrValidation := EmptyValidValidation(wasmPlugin.Name, wasmPlugin.Namespace, WasmPluginCheckerType)
+
+	matchLabels := make(map[string]string)
+	if wasmPlugin.Spec.Selector != nil {
+		matchLabels = wasmPlugin.Spec.Selector.MatchLabels
+	}
+
+	enabledCheckers := []Checker{
+		common.SelectorNoWorkloadFoundChecker(WasmPluginCheckerType, matchLabels, in.WorkloadsPerNamespace),
+		wasmplugins.URLChecker{WasmPlugin: wasmPlugin},
+		wasmplugins.ImagePullSecretChecker{WasmPlugin: wasmPlugin, Secrets: in.Secrets},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		rrValidation.Checks = append(rrValidation.Checks, checks...)
+		rrValidation.Valid = rrValidation.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: rrValidation}
+}
@@ -0,0 +1,33 @@
+package wasmplugins
+
+import (
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+// ImagePullSecretChecker validates that the imagePullSecret referenced by a WasmPlugin exists in its namespace.
+type ImagePullSecretChecker struct {
+	WasmPlugin *extentions_v1alpha1.WasmPlugin
+	// Secrets holds the names of the secrets found, grouped by namespace
+	Secrets map[string][]string
+}
+
+func (ipc ImagePullSecretChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	secretName := ipc.WasmPlugin.Spec.ImagePullSecret
+	if secretName == "" {
+		return checks, valid
+	}
+
+	for _, name := range ipc.Secrets[ipc.WasmPlugin.Namespace] {
+		if name == secretName {
+			return checks, valid
+		}
+	}
+
+	check := models.Build("wasmplugin.imagepullsecret.notfound", "spec/imagePullSecret")
+	checks = append(checks, &check)
+	return checks, false
+}
@@ -0,0 +1,49 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestNoImagePullSecret(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ImagePullSecretChecker{
+		WasmPlugin: data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0"),
+		Secrets:    map[string][]string{},
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestImagePullSecretFound(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ImagePullSecretChecker{
+		WasmPlugin: data.AddImagePullSecretToWasmPlugin("ghcr-creds", data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0")),
+		Secrets:    map[string][]string{"bookinfo": {"ghcr-creds"}},
+	}.Check()
+
+	assert.Empty(vals)
+	assert.True(valid)
+}
+
+func TestImagePullSecretNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	vals, valid := ImagePullSecretChecker{
+		WasmPlugin: data.AddImagePullSecretToWasmPlugin("ghcr-creds", data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0")),
+		// Secret exists, but in another namespace
+		Secrets: map[string][]string{"istio-system": {"ghcr-creds"}},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.imagepullsecret.notfound", vals[0]))
+	assert.Equal("spec/imagePullSecret", vals[0].Path)
+}
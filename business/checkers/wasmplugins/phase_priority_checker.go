@@ -0,0 +1,86 @@
+package wasmplugins
+
+import (
+	"fmt"
+
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+const WasmPluginCheckerType = "wasmplugin"
+
+// PhasePriorityChecker looks for WasmPlugins sharing the same phase and priority that are applied to the same workload.
+// Istio then falls back to order them by name and namespace, which is rarely intended.
+type PhasePriorityChecker struct {
+	WasmPlugins           []*extentions_v1alpha1.WasmPlugin
+	WorkloadsPerNamespace map[string]models.WorkloadList
+}
+
+func (pc PhasePriorityChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, wls := range pc.WorkloadsPerNamespace {
+		for _, wl := range wls.Workloads {
+			// [phase/priority] -> WasmPlugins applied to the workload
+			applied := make(map[string][]*extentions_v1alpha1.WasmPlugin)
+			for _, wp := range pc.WasmPlugins {
+				if !appliesTo(wp, wls.Namespace.Name, wl.Labels) {
+					continue
+				}
+				order := fmt.Sprintf("%s/%d", wp.Spec.Phase.String(), priority(wp))
+				applied[order] = append(applied[order], wp)
+			}
+			for _, wps := range applied {
+				if len(wps) > 1 {
+					addConflicts(validations, wps)
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+// appliesTo returns true when the WasmPlugin targets a workload of the namespace with the given labels.
+// WasmPlugins in the root namespace are applied to all namespaces.
+func appliesTo(wp *extentions_v1alpha1.WasmPlugin, namespace string, workloadLabels map[string]string) bool {
+	if wp.Namespace != namespace && !config.IsRootNamespace(wp.Namespace) {
+		return false
+	}
+	if wp.Spec.Selector == nil || len(wp.Spec.Selector.MatchLabels) == 0 {
+		return true
+	}
+	return labels.SelectorFromSet(wp.Spec.Selector.MatchLabels).Matches(labels.Set(workloadLabels))
+}
+
+// priority returns the priority of the WasmPlugin, Istio defaults it to 0
+func priority(wp *extentions_v1alpha1.WasmPlugin) int64 {
+	if wp.Spec.Priority == nil {
+		return 0
+	}
+	return wp.Spec.Priority.Value
+}
+
+func addConflicts(validations models.IstioValidations, wps []*extentions_v1alpha1.WasmPlugin) {
+	for i, wp := range wps {
+		refs := make([]models.IstioValidationKey, 0, len(wps)-1)
+		for j, ref := range wps {
+			if i != j {
+				refs = append(refs, models.BuildKey(WasmPluginCheckerType, ref.Name, ref.Namespace))
+			}
+		}
+		check := models.Build("wasmplugin.phasepriority.conflict", "spec/priority")
+		validations.MergeValidations(models.IstioValidations{
+			models.BuildKey(WasmPluginCheckerType, wp.Name, wp.Namespace): &models.IstioValidation{
+				Name:       wp.Name,
+				ObjectType: WasmPluginCheckerType,
+				Valid:      true,
+				Checks:     []*models.IstioCheck{&check},
+				References: refs,
+			},
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func workloadList() map[string]models.WorkloadList {
+	return data.CreateWorkloadsPerNamespace([]string{"bookinfo"},
+		data.CreateWorkloadListItem("reviews-v1", map[string]string{"app": "reviews", "version": "v1"}),
+		data.CreateWorkloadListItem("ratings-v1", map[string]string{"app": "ratings", "version": "v1"}),
+	)
+}
+
+func TestSamePhaseDifferentPriority(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PhasePriorityChecker{
+		WasmPlugins: []*extentions_v1alpha1.WasmPlugin{
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 10, data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0")),
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 20, data.CreateWasmPlugin("basic-auth", "bookinfo", "oci://ghcr.io/basic-auth:1.0")),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestSamePhasePriorityDifferentWorkloads(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	vals := PhasePriorityChecker{
+		WasmPlugins: []*extentions_v1alpha1.WasmPlugin{
+			data.AddSelectorToWasmPlugin(map[string]string{"app": "reviews"},
+				data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 10, data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0"))),
+			data.AddSelectorToWasmPlugin(map[string]string{"app": "ratings"},
+				data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 10, data.CreateWasmPlugin("basic-auth", "bookinfo", "oci://ghcr.io/basic-auth:1.0"))),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Empty(vals)
+}
+
+func TestSamePhasePrioritySameWorkload(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals := PhasePriorityChecker{
+		WasmPlugins: []*extentions_v1alpha1.WasmPlugin{
+			data.AddSelectorToWasmPlugin(map[string]string{"app": "reviews"},
+				data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 10, data.CreateWasmPlugin("openid", "bookinfo", "oci://ghcr.io/openid:1.0"))),
+			// Mesh-wide plugin, applied to all workloads
+			data.AddPhaseToWasmPlugin(api_extensions_v1alpha1.PluginPhase_AUTHN, 10, data.CreateWasmPlugin("basic-auth", conf.ExternalServices.Istio.RootNamespace, "oci://ghcr.io/basic-auth:1.0")),
+		},
+		WorkloadsPerNamespace: workloadList(),
+	}.Check()
+
+	assert.Len(vals, 2)
+
+	openid := vals[models.BuildKey(WasmPluginCheckerType, "openid", "bookinfo")]
+	assert.True(openid.Valid)
+	assert.Len(openid.Checks, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.phasepriority.conflict", openid.Checks[0]))
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(WasmPluginCheckerType, "basic-auth", conf.ExternalServices.Istio.RootNamespace)}, openid.References)
+
+	basicAuth := vals[models.BuildKey(WasmPluginCheckerType, "basic-auth", conf.ExternalServices.Istio.RootNamespace)]
+	assert.Len(basicAuth.Checks, 1)
+	assert.Equal([]models.IstioValidationKey{models.BuildKey(WasmPluginCheckerType, "openid", "bookinfo")}, basicAuth.References)
+}
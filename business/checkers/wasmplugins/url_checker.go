@@ -0,0 +1,56 @@
+package wasmplugins
+
+import (
+	"net/url"
+	"strings"
+
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+
+	"github.com/kiali/kiali/models"
+)
+
+var supportedSchemes = map[string]bool{
+	"oci":   true,
+	"file":  true,
+	"http":  true,
+	"https": true,
+}
+
+// URLChecker validates that the WasmPlugin url uses one of the schemes supported by Istio.
+// An url without scheme is valid as Istio defaults it to oci://
+type URLChecker struct {
+	WasmPlugin *extentions_v1alpha1.WasmPlugin
+}
+
+func (uc URLChecker) Check() ([]*models.IstioCheck, bool) {
+	checks, valid := make([]*models.IstioCheck, 0), true
+
+	if !isValidURL(uc.WasmPlugin.Spec.Url) {
+		check := models.Build("wasmplugin.url.invalidscheme", "spec/url")
+		checks = append(checks, &check)
+		valid = false
+	}
+
+	return checks, valid
+}
+
+func isValidURL(wasmURL string) bool {
+	if wasmURL == "" {
+		return false
+	}
+	if !strings.Contains(wasmURL, "://") {
+		// No scheme, Istio assumes oci://
+		return true
+	}
+	u, err := url.Parse(wasmURL)
+	if err != nil {
+		return false
+	}
+	if !supportedSchemes[u.Scheme] {
+		return false
+	}
+	if u.Scheme == "file" {
+		return u.Path != ""
+	}
+	return u.Host != ""
+}
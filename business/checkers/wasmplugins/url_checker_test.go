@@ -0,0 +1,44 @@
+package wasmplugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestValidURLs(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, url := range []string{
+		"oci://ghcr.io/istio-ecosystem/wasm-extensions/basic_auth:1.12.0",
+		"ghcr.io/istio-ecosystem/wasm-extensions/basic_auth:1.12.0",
+		"file:///opt/filters/openid.wasm",
+		"http://example.com/filters/openid.wasm",
+		"https://example.com/filters/openid.wasm",
+	} {
+		vals, valid := URLChecker{WasmPlugin: data.CreateWasmPlugin("openid", "bookinfo", url)}.Check()
+		assert.Empty(vals, url)
+		assert.True(valid, url)
+	}
+}
+
+func TestInvalidURLs(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, url := range []string{
+		"",
+		"ftp://example.com/filters/openid.wasm",
+		"s3://bucket/openid.wasm",
+		"https://",
+		"file://",
+	} {
+		vals, valid := URLChecker{WasmPlugin: data.CreateWasmPlugin("openid", "bookinfo", url)}.Check()
+		assert.False(valid, url)
+		assert.Len(vals, 1, url)
+		assert.NoError(validations.ConfirmIstioCheckMessage("wasmplugin.url.invalidscheme", vals[0]))
+		assert.Equal("spec/url", vals[0].Path)
+	}
+}
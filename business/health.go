@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/common/model"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/observability"
@@ -27,6 +28,19 @@ type NamespaceHealthCriteria struct {
 	RateInterval   string
 }
 
+// cluster returns the cluster of the workloads, selected by the request rates queries to route them to the
+// Prometheus of that cluster when the clusters have their own Prometheus. It is empty otherwise.
+func (in *HealthService) cluster() string {
+	if len(config.Get().ExternalServices.Prometheus.Clusters) == 0 {
+		return ""
+	}
+	cluster, err := in.businessLayer.Mesh.ResolveKialiControlPlaneCluster(nil)
+	if err != nil || cluster == nil {
+		return ""
+	}
+	return cluster.Name
+}
+
 // Annotation Filter for Health
 var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation}
 
@@ -162,7 +176,7 @@ func (in *HealthService) getNamespaceAppHealth(appEntities namespaceApps, criter
 
 	if sidecarPresent && criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, err := in.prom.GetAllRequestRates(namespace, rateInterval, queryTime)
+		rates, err := in.prom.GetAllRequestRates(namespace, in.cluster(), rateInterval, queryTime)
 		if err != nil {
 			return allHealth, errors.NewServiceUnavailable(err.Error())
 		}
@@ -225,7 +239,7 @@ func (in *HealthService) getNamespaceServiceHealth(services *models.ServiceList,
 
 	if criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, _ := in.prom.GetNamespaceServicesRequestRates(namespace, rateInterval, queryTime)
+		rates, _ := in.prom.GetNamespaceServicesRequestRates(namespace, in.cluster(), rateInterval, queryTime)
 		// Fill with collected request rates
 		lblDestSvc := model.LabelName("destination_service_name")
 		for _, sample := range rates {
@@ -282,7 +296,7 @@ func (in *HealthService) getNamespaceWorkloadHealth(ws models.Workloads, criteri
 
 	if hasSidecar && criteria.IncludeMetrics {
 		// Fetch services requests rates
-		rates, err := in.prom.GetAllRequestRates(namespace, rateInterval, queryTime)
+		rates, err := in.prom.GetAllRequestRates(namespace, in.cluster(), rateInterval, queryTime)
 		if err != nil {
 			return allHealth, errors.NewServiceUnavailable(err.Error())
 		}
@@ -339,7 +353,7 @@ func (in *HealthService) getServiceRequestsHealth(namespace, service, rateInterv
 		// Telemetry doesn't collect a namespace
 		namespace = "unknown"
 	}
-	inbound, err := in.prom.GetServiceRequestRates(namespace, service, rateInterval, queryTime)
+	inbound, err := in.prom.GetServiceRequestRates(namespace, in.cluster(), service, rateInterval, queryTime)
 	if err != nil {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
@@ -354,7 +368,7 @@ func (in *HealthService) getServiceRequestsHealth(namespace, service, rateInterv
 func (in *HealthService) getAppRequestsHealth(namespace, app, rateInterval string, queryTime time.Time) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
 
-	inbound, outbound, err := in.prom.GetAppRequestRates(namespace, app, rateInterval, queryTime)
+	inbound, outbound, err := in.prom.GetAppRequestRates(namespace, in.cluster(), app, rateInterval, queryTime)
 	if err != nil {
 		return rqHealth, errors.NewServiceUnavailable(err.Error())
 	}
@@ -370,7 +384,7 @@ func (in *HealthService) getAppRequestsHealth(namespace, app, rateInterval strin
 
 func (in *HealthService) getWorkloadRequestsHealth(namespace, workload, rateInterval string, queryTime time.Time, w *models.Workload) (models.RequestHealth, error) {
 	rqHealth := models.NewEmptyRequestHealth()
-	inbound, outbound, err := in.prom.GetWorkloadRequestRates(namespace, workload, rateInterval, queryTime)
+	inbound, outbound, err := in.prom.GetWorkloadRequestRates(namespace, in.cluster(), workload, rateInterval, queryTime)
 	if err != nil {
 		return rqHealth, err
 	}
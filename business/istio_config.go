@@ -42,6 +42,10 @@ type IstioConfigCriteria struct {
 	IncludeGateways               bool
 	IncludeK8sGateways            bool
 	IncludeK8sHTTPRoutes          bool
+	IncludeK8sGRPCRoutes          bool
+	IncludeK8sTCPRoutes           bool
+	IncludeK8sTLSRoutes           bool
+	IncludeK8sReferenceGrants     bool
 	IncludeVirtualServices        bool
 	IncludeDestinationRules       bool
 	IncludeServiceEntries         bool
@@ -68,6 +72,14 @@ func (icc IstioConfigCriteria) Include(resource string) bool {
 		return icc.IncludeK8sGateways
 	case kubernetes.K8sHTTPRoutes:
 		return icc.IncludeK8sHTTPRoutes
+	case kubernetes.K8sGRPCRoutes:
+		return icc.IncludeK8sGRPCRoutes
+	case kubernetes.K8sTCPRoutes:
+		return icc.IncludeK8sTCPRoutes
+	case kubernetes.K8sTLSRoutes:
+		return icc.IncludeK8sTLSRoutes
+	case kubernetes.K8sReferenceGrants:
+		return icc.IncludeK8sReferenceGrants
 	case kubernetes.VirtualServices:
 		return icc.IncludeVirtualServices && !isWorkloadSelector
 	case kubernetes.DestinationRules:
@@ -142,8 +154,12 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		WasmPlugins:      []*extentions_v1alpha1.WasmPlugin{},
 		Telemetries:      []*v1alpha1.Telemetry{},
 
-		K8sGateways:   []*k8s_networking_v1alpha2.Gateway{},
-		K8sHTTPRoutes: []*k8s_networking_v1alpha2.HTTPRoute{},
+		K8sGateways:        []*k8s_networking_v1alpha2.Gateway{},
+		K8sHTTPRoutes:      []*k8s_networking_v1alpha2.HTTPRoute{},
+		K8sGRPCRoutes:      []*k8s_networking_v1alpha2.GRPCRoute{},
+		K8sTCPRoutes:       []*k8s_networking_v1alpha2.TCPRoute{},
+		K8sTLSRoutes:       []*k8s_networking_v1alpha2.TLSRoute{},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{},
 
 		AuthorizationPolicies:  []*security_v1beta1.AuthorizationPolicy{},
 		PeerAuthentications:    []*security_v1beta1.PeerAuthentication{},
@@ -181,6 +197,18 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		if criteria.Include(kubernetes.K8sHTTPRoutes) {
 			istioConfigList.K8sHTTPRoutes = registryConfiguration.K8sHTTPRoutes
 		}
+		if criteria.Include(kubernetes.K8sGRPCRoutes) {
+			istioConfigList.K8sGRPCRoutes = registryConfiguration.K8sGRPCRoutes
+		}
+		if criteria.Include(kubernetes.K8sTCPRoutes) {
+			istioConfigList.K8sTCPRoutes = registryConfiguration.K8sTCPRoutes
+		}
+		if criteria.Include(kubernetes.K8sTLSRoutes) {
+			istioConfigList.K8sTLSRoutes = registryConfiguration.K8sTLSRoutes
+		}
+		if criteria.Include(kubernetes.K8sReferenceGrants) {
+			istioConfigList.K8sReferenceGrants = registryConfiguration.K8sReferenceGrants
+		}
 		if criteria.Include(kubernetes.VirtualServices) {
 			istioConfigList.VirtualServices = registryConfiguration.VirtualServices
 		}
@@ -227,10 +255,10 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		workloadSelector = criteria.WorkloadSelector
 	}
 
-	errChan := make(chan error, 15)
+	errChan := make(chan error, 19)
 
 	var wg sync.WaitGroup
-	wg.Add(15)
+	wg.Add(19)
 
 	listOpts := meta_v1.ListOptions{LabelSelector: criteria.LabelSelector}
 
@@ -337,6 +365,62 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, criteria I
 		}
 	}(ctx, errChan)
 
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if in.k8s.IsGatewayAPI() && criteria.Include(kubernetes.K8sGRPCRoutes) {
+			var err error
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sGRPCRoutes) {
+				istioConfigList.K8sGRPCRoutes, err = kialiCache.GetK8sGRPCRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if in.k8s.IsGatewayAPI() && criteria.Include(kubernetes.K8sTCPRoutes) {
+			var err error
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sTCPRoutes) {
+				istioConfigList.K8sTCPRoutes, err = kialiCache.GetK8sTCPRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if in.k8s.IsGatewayAPI() && criteria.Include(kubernetes.K8sTLSRoutes) {
+			var err error
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sTLSRoutes) {
+				istioConfigList.K8sTLSRoutes, err = kialiCache.GetK8sTLSRoutes(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
+	go func(ctx context.Context, errChan chan error) {
+		defer wg.Done()
+		if in.k8s.IsGatewayAPI() && criteria.Include(kubernetes.K8sReferenceGrants) {
+			var err error
+			// Check if namespace is cached
+			if IsResourceCached(criteria.Namespace, kubernetes.K8sReferenceGrants) {
+				istioConfigList.K8sReferenceGrants, err = kialiCache.GetK8sReferenceGrants(criteria.Namespace, criteria.LabelSelector)
+			}
+			if err != nil {
+				errChan <- err
+			}
+		}
+	}(ctx, errChan)
+
 	go func(ctx context.Context, errChan chan error) {
 		defer wg.Done()
 		if criteria.Include(kubernetes.ServiceEntries) {
@@ -611,6 +695,30 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, namespa
 			istioConfigDetail.K8sHTTPRoute.Kind = kubernetes.K8sActualHTTPRouteType
 			istioConfigDetail.K8sHTTPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
 		}
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sGRPCRoute.Kind = kubernetes.K8sActualGRPCRouteType
+			istioConfigDetail.K8sGRPCRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sTCPRoute.Kind = kubernetes.K8sActualTCPRouteType
+			istioConfigDetail.K8sTCPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sTLSRoute.Kind = kubernetes.K8sActualTLSRouteType
+			istioConfigDetail.K8sTLSRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant, err = in.k8s.GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sReferenceGrant.Kind = kubernetes.K8sActualReferenceGrantType
+			istioConfigDetail.K8sReferenceGrant.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+		}
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry, err = in.k8s.Istio().NetworkingV1beta1().ServiceEntries(namespace).Get(ctx, object, getOpts)
 		if err == nil {
@@ -761,6 +869,46 @@ func (in *IstioConfigService) GetIstioConfigDetailsFromRegistry(ctx context.Cont
 				return istioConfigDetail, nil
 			}
 		}
+	case kubernetes.K8sGRPCRoutes:
+		configs := registryConfiguration.K8sGRPCRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sGRPCRoute = cfg
+				istioConfigDetail.K8sGRPCRoute.Kind = kubernetes.K8sGRPCRouteType
+				istioConfigDetail.K8sGRPCRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sTCPRoutes:
+		configs := registryConfiguration.K8sTCPRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sTCPRoute = cfg
+				istioConfigDetail.K8sTCPRoute.Kind = kubernetes.K8sTCPRouteType
+				istioConfigDetail.K8sTCPRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sTLSRoutes:
+		configs := registryConfiguration.K8sTLSRoutes
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sTLSRoute = cfg
+				istioConfigDetail.K8sTLSRoute.Kind = kubernetes.K8sTLSRouteType
+				istioConfigDetail.K8sTLSRoute.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
+	case kubernetes.K8sReferenceGrants:
+		configs := registryConfiguration.K8sReferenceGrants
+		for _, cfg := range configs {
+			if cfg.Name == object && cfg.Namespace == namespace {
+				istioConfigDetail.K8sReferenceGrant = cfg
+				istioConfigDetail.K8sReferenceGrant.Kind = kubernetes.K8sReferenceGrantType
+				istioConfigDetail.K8sReferenceGrant.APIVersion = kubernetes.K8sApiNetworkingVersionV1Alpha2
+				return istioConfigDetail, nil
+			}
+		}
 	case kubernetes.ServiceEntries:
 		configs := registryConfiguration.ServiceEntries
 		for _, cfg := range configs {
@@ -894,6 +1042,14 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(namespace, resourceType, n
 		err = in.k8s.GatewayAPI().GatewayV1alpha2().Gateways(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sHTTPRoutes:
 		err = in.k8s.GatewayAPI().GatewayV1alpha2().HTTPRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sGRPCRoutes:
+		err = in.k8s.GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sTCPRoutes:
+		err = in.k8s.GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sTLSRoutes:
+		err = in.k8s.GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sReferenceGrants:
+		err = in.k8s.GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.ServiceEntries:
 		err = in.k8s.Istio().NetworkingV1beta1().ServiceEntries(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.Sidecars:
@@ -952,6 +1108,18 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(namespace, resourceType, n
 	case kubernetes.K8sHTTPRoutes:
 		istioConfigDetail.K8sHTTPRoute = &k8s_networking_v1alpha2.HTTPRoute{}
 		istioConfigDetail.K8sHTTPRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().HTTPRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute = &k8s_networking_v1alpha2.GRPCRoute{}
+		istioConfigDetail.K8sGRPCRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &k8s_networking_v1alpha2.TCPRoute{}
+		istioConfigDetail.K8sTCPRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute = &k8s_networking_v1alpha2.TLSRoute{}
+		istioConfigDetail.K8sTLSRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1alpha2.ReferenceGrant{}
+		istioConfigDetail.K8sReferenceGrant, err = in.k8s.GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &networking_v1beta1.ServiceEntry{}
 		istioConfigDetail.ServiceEntry, err = in.k8s.Istio().NetworkingV1beta1().ServiceEntries(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
@@ -1038,6 +1206,34 @@ func (in *IstioConfigService) CreateIstioConfigDetail(namespace, resourceType st
 			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
 		}
 		istioConfigDetail.K8sHTTPRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().HTTPRoutes(namespace).Create(ctx, istioConfigDetail.K8sHTTPRoute, createOpts)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigDetail.K8sGRPCRoute = &k8s_networking_v1alpha2.GRPCRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sGRPCRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sGRPCRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().GRPCRoutes(namespace).Create(ctx, istioConfigDetail.K8sGRPCRoute, createOpts)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigDetail.K8sTCPRoute = &k8s_networking_v1alpha2.TCPRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sTCPRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sTCPRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TCPRoutes(namespace).Create(ctx, istioConfigDetail.K8sTCPRoute, createOpts)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigDetail.K8sTLSRoute = &k8s_networking_v1alpha2.TLSRoute{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sTLSRoute)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sTLSRoute, err = in.k8s.GatewayAPI().GatewayV1alpha2().TLSRoutes(namespace).Create(ctx, istioConfigDetail.K8sTLSRoute, createOpts)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1alpha2.ReferenceGrant{}
+		err = json.Unmarshal(body, istioConfigDetail.K8sReferenceGrant)
+		if err != nil {
+			return istioConfigDetail, api_errors.NewBadRequest(err.Error())
+		}
+		istioConfigDetail.K8sReferenceGrant, err = in.k8s.GatewayAPI().GatewayV1alpha2().ReferenceGrants(namespace).Create(ctx, istioConfigDetail.K8sReferenceGrant, createOpts)
 	case kubernetes.ServiceEntries:
 		istioConfigDetail.ServiceEntry = &networking_v1beta1.ServiceEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
@@ -1273,6 +1469,10 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	criteria.IncludeGateways = defaultInclude
 	criteria.IncludeK8sGateways = defaultInclude
 	criteria.IncludeK8sHTTPRoutes = defaultInclude
+	criteria.IncludeK8sGRPCRoutes = defaultInclude
+	criteria.IncludeK8sTCPRoutes = defaultInclude
+	criteria.IncludeK8sTLSRoutes = defaultInclude
+	criteria.IncludeK8sReferenceGrants = defaultInclude
 	criteria.IncludeVirtualServices = defaultInclude
 	criteria.IncludeDestinationRules = defaultInclude
 	criteria.IncludeServiceEntries = defaultInclude
@@ -1308,6 +1508,18 @@ func ParseIstioConfigCriteria(namespace, objects, labelSelector, workloadSelecto
 	if checkType(types, kubernetes.K8sHTTPRoutes) {
 		criteria.IncludeK8sHTTPRoutes = true
 	}
+	if checkType(types, kubernetes.K8sGRPCRoutes) {
+		criteria.IncludeK8sGRPCRoutes = true
+	}
+	if checkType(types, kubernetes.K8sTCPRoutes) {
+		criteria.IncludeK8sTCPRoutes = true
+	}
+	if checkType(types, kubernetes.K8sTLSRoutes) {
+		criteria.IncludeK8sTLSRoutes = true
+	}
+	if checkType(types, kubernetes.K8sReferenceGrants) {
+		criteria.IncludeK8sReferenceGrants = true
+	}
 	if checkType(types, kubernetes.VirtualServices) {
 		criteria.IncludeVirtualServices = true
 	}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"sync"
 
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business/checkers"
 	"github.com/kiali/kiali/business/references"
@@ -67,6 +71,11 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 	timer := internalmetrics.GetValidationProcessingTimePrometheusTimer(namespace, service)
 	defer timer.ObserveDuration()
 
+	return in.getValidations(ctx, namespace, service, workload, nil)
+}
+
+// getValidations runs the enabled checkers on the Istio config, with the given overlay applied to it when present.
+func (in *IstioValidationsService) getValidations(ctx context.Context, namespace, service, workload string, overlay *istioConfigOverlay) (models.IstioValidations, error) {
 	wg := sync.WaitGroup{}
 	errChan := make(chan error, 1)
 
@@ -76,6 +85,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 	var workloadsPerNamespace map[string]models.WorkloadList
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var meshConfig kubernetes.IstioMeshConfig
 	var registryServices []*kubernetes.RegistryService
 
 	wg.Add(4) // We need to add these here to make sure we don't execute wg.Wait() before scheduler has started goroutines
@@ -84,7 +94,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 	}
 
 	// We fetch without target service as some validations will require full-namespace details
-	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, namespace, errChan, &wg)
+	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, namespace, overlay, errChan, &wg)
 
 	if workload != "" {
 		// load only requested workload
@@ -92,7 +102,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 	} else {
 		go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, &namespaces, errChan, &wg)
 	}
-	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, errChan, &wg)
+	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, &meshConfig, errChan, &wg)
 	if service != "" {
 		go in.fetchServices(ctx, &services, namespace, errChan, &wg)
 	}
@@ -106,7 +116,9 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 		}
 	}
 
-	objectCheckers := in.getAllObjectCheckers(istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, namespaces, registryServices)
+	secrets := in.fetchWasmPluginSecrets(istioConfigList.WasmPlugins)
+
+	objectCheckers := in.getAllObjectCheckers(istioConfigList, workloadsPerNamespace, mtlsDetails, rbacDetails, meshConfig, namespaces, registryServices, secrets)
 
 	// Get group validations for same kind istio objects
 	validations := runObjectCheckers(objectCheckers)
@@ -125,7 +137,7 @@ func (in *IstioValidationsService) GetValidations(ctx context.Context, namespace
 	return validations, nil
 }
 
-func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService) []ObjectChecker {
+func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.IstioConfigList, workloadsPerNamespace map[string]models.WorkloadList, mtlsDetails kubernetes.MTLSDetails, rbacDetails kubernetes.RBACDetails, meshConfig kubernetes.IstioMeshConfig, namespaces []models.Namespace, registryServices []*kubernetes.RegistryService, secrets map[string][]string) []ObjectChecker {
 	return []ObjectChecker{
 		checkers.NoServiceChecker{Namespaces: namespaces, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, AuthorizationDetails: &rbacDetails, RegistryServices: registryServices, PolicyAllowAny: in.isPolicyAllowAny()},
 		checkers.VirtualServiceChecker{Namespaces: namespaces, VirtualServices: istioConfigList.VirtualServices, DestinationRules: istioConfigList.DestinationRules},
@@ -138,9 +150,16 @@ func (in *IstioValidationsService) getAllObjectCheckers(istioConfigList models.I
 		checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.WorkloadChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.K8sGatewayChecker{K8sGateways: istioConfigList.K8sGateways},
-		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces},
-		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces},
-		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways},
+		checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, Secrets: secrets},
+		checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, MeshConfig: meshConfig},
+		checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+		checkers.K8sGRPCRouteChecker{K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+		checkers.K8sTCPRouteChecker{K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+		checkers.K8sTLSRouteChecker{K8sTLSRoutes: istioConfigList.K8sTLSRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
+		checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
+		// The checks of the proxies configuration need config dumps, they only run when a single EnvoyFilter is validated
+		checkers.EnvoyFilterChecker{EnvoyFilters: istioConfigList.EnvoyFilters, WorkloadsPerNamespace: workloadsPerNamespace},
+		checkers.CustomRulesChecker{Rules: config.Get().KialiFeatureFlags.Validations.CustomRules, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, RegistryServices: registryServices},
 	}
 }
 
@@ -160,6 +179,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	var workloadsPerNamespace map[string]models.WorkloadList
 	var mtlsDetails kubernetes.MTLSDetails
 	var rbacDetails kubernetes.RBACDetails
+	var meshConfig kubernetes.IstioMeshConfig
 	var registryServices []*kubernetes.RegistryService
 	var err error
 	var objectCheckers []ObjectChecker
@@ -181,9 +201,9 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 
 	// Get all the Istio objects from a Namespace and all gateways from every namespace
 	wg.Add(4)
-	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, namespace, errChan, &wg)
+	go in.fetchIstioConfigList(ctx, &istioConfigList, &mtlsDetails, &rbacDetails, namespace, nil, errChan, &wg)
 	go in.fetchAllWorkloads(ctx, &workloadsPerNamespace, &namespaces, errChan, &wg)
-	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, errChan, &wg)
+	go in.fetchNonLocalmTLSConfigs(&mtlsDetails, &meshConfig, errChan, &wg)
 	go in.fetchRegistryServices(&registryServices, errChan, &wg)
 	wg.Wait()
 
@@ -232,19 +252,41 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 		requestAuthnChecker := checkers.RequestAuthenticationChecker{RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace}
 		objectCheckers = []ObjectChecker{requestAuthnChecker}
 	case kubernetes.EnvoyFilters:
-		// Validation on EnvoyFilters are not yet in place
+		// Only the proxies of the validated EnvoyFilter are fetched, as config dumps are expensive
+		var envoyFilters []*networking_v1alpha3.EnvoyFilter
+		for _, ef := range istioConfigList.EnvoyFilters {
+			if ef.Name == object && ef.Namespace == namespace {
+				envoyFilters = append(envoyFilters, ef)
+			}
+		}
+		envoyFilterChecker := checkers.EnvoyFilterChecker{EnvoyFilters: istioConfigList.EnvoyFilters, WorkloadsPerNamespace: workloadsPerNamespace, Proxies: in.fetchEnvoyFilterProxies(envoyFilters, workloadsPerNamespace)}
+		objectCheckers = []ObjectChecker{envoyFilterChecker}
 	case kubernetes.WasmPlugins:
-		// Validation on WasmPlugins is not expected
+		wasmPluginChecker := checkers.WasmPluginChecker{WasmPlugins: istioConfigList.WasmPlugins, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, Secrets: in.fetchWasmPluginSecrets(istioConfigList.WasmPlugins)}
+		objectCheckers = []ObjectChecker{wasmPluginChecker}
 	case kubernetes.Telemetries:
-		// Validation on Telemetries is not expected
+		telemetryChecker := checkers.TelemetryChecker{Telemetries: istioConfigList.Telemetries, Namespaces: namespaces, WorkloadsPerNamespace: workloadsPerNamespace, MeshConfig: meshConfig}
+		objectCheckers = []ObjectChecker{telemetryChecker}
 	case kubernetes.K8sGateways:
 		// Validations on K8sGateways
 		objectCheckers = []ObjectChecker{
 			checkers.K8sGatewayChecker{K8sGateways: istioConfigList.K8sGateways},
 		}
 	case kubernetes.K8sHTTPRoutes:
-		httpRouteChecker := checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways}
+		httpRouteChecker := checkers.K8sHTTPRouteChecker{K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants}
 		objectCheckers = []ObjectChecker{noServiceChecker, httpRouteChecker}
+	case kubernetes.K8sGRPCRoutes:
+		grpcRouteChecker := checkers.K8sGRPCRouteChecker{K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants}
+		objectCheckers = []ObjectChecker{noServiceChecker, grpcRouteChecker}
+	case kubernetes.K8sTCPRoutes:
+		tcpRouteChecker := checkers.K8sTCPRouteChecker{K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants}
+		objectCheckers = []ObjectChecker{noServiceChecker, tcpRouteChecker}
+	case kubernetes.K8sTLSRoutes:
+		tlsRouteChecker := checkers.K8sTLSRouteChecker{K8sTLSRoutes: istioConfigList.K8sTLSRoutes, K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants}
+		objectCheckers = []ObjectChecker{noServiceChecker, tlsRouteChecker}
+	case kubernetes.K8sReferenceGrants:
+		referenceGrantChecker := checkers.K8sReferenceGrantChecker{K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces}
+		objectCheckers = []ObjectChecker{referenceGrantChecker}
 	default:
 		err = fmt.Errorf("object type not found: %v", objectType)
 	}
@@ -263,6 +305,7 @@ func (in *IstioValidationsService) GetIstioObjectValidations(ctx context.Context
 	if objectCheckers == nil {
 		return models.IstioValidations{}, istioReferences, err
 	}
+	objectCheckers = append(objectCheckers, checkers.CustomRulesChecker{Rules: config.Get().KialiFeatureFlags.Validations.CustomRules, IstioConfigList: &istioConfigList, WorkloadsPerNamespace: workloadsPerNamespace, RegistryServices: registryServices})
 
 	return runObjectCheckers(objectCheckers).FilterByKey(models.ObjectTypeSingular[objectType], object), istioReferences, nil
 }
@@ -361,7 +404,7 @@ func (in *IstioValidationsService) fetchWorkload(ctx context.Context, rValue *ma
 	}
 }
 
-func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rValue *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, namespace string, errChan chan error, wg *sync.WaitGroup) {
+func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rValue *models.IstioConfigList, mtlsDetails *kubernetes.MTLSDetails, rbacDetails *kubernetes.RBACDetails, namespace string, overlay *istioConfigOverlay, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) > 0 {
 		return
@@ -379,13 +422,26 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 		IncludeAuthorizationPolicies:  true,
 		IncludePeerAuthentications:    true,
 		IncludeK8sHTTPRoutes:          true,
+		IncludeK8sGRPCRoutes:          true,
+		IncludeK8sTCPRoutes:           true,
+		IncludeK8sTLSRoutes:           true,
+		IncludeK8sReferenceGrants:     true,
 		IncludeK8sGateways:            true,
+		IncludeTelemetry:              true,
+		IncludeWasmPlugins:            true,
+		IncludeEnvoyFilters:           true,
 	}
 	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, criteria)
 	if err != nil {
 		errChan <- err
 		return
 	}
+	if overlay != nil {
+		if err := overlay.apply(&istioConfigList); err != nil {
+			errChan <- err
+			return
+		}
+	}
 	// Filter VS
 	filteredVSs := in.filterVSExportToNamespaces(namespace, istioConfigList.VirtualServices)
 	rValue.VirtualServices = append(rValue.VirtualServices, filteredVSs...)
@@ -408,6 +464,18 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All K8sHTTPRoutes
 	rValue.K8sHTTPRoutes = append(rValue.K8sHTTPRoutes, istioConfigList.K8sHTTPRoutes...)
 
+	// All K8sGRPCRoutes
+	rValue.K8sGRPCRoutes = append(rValue.K8sGRPCRoutes, istioConfigList.K8sGRPCRoutes...)
+
+	// All K8sTCPRoutes
+	rValue.K8sTCPRoutes = append(rValue.K8sTCPRoutes, istioConfigList.K8sTCPRoutes...)
+
+	// All K8sTLSRoutes
+	rValue.K8sTLSRoutes = append(rValue.K8sTLSRoutes, istioConfigList.K8sTLSRoutes...)
+
+	// All K8sReferenceGrants
+	rValue.K8sReferenceGrants = append(rValue.K8sReferenceGrants, istioConfigList.K8sReferenceGrants...)
+
 	// All Sidecars
 	rValue.Sidecars = append(rValue.Sidecars, istioConfigList.Sidecars...)
 
@@ -417,9 +485,18 @@ func (in *IstioValidationsService) fetchIstioConfigList(ctx context.Context, rVa
 	// All WorkloadEntries
 	rValue.WorkloadEntries = append(rValue.WorkloadEntries, istioConfigList.WorkloadEntries...)
 
+	// All Telemetries
+	rValue.Telemetries = append(rValue.Telemetries, istioConfigList.Telemetries...)
+
+	// All WasmPlugins
+	rValue.WasmPlugins = append(rValue.WasmPlugins, istioConfigList.WasmPlugins...)
+
 	// All K8sGateways
 	rValue.K8sGateways = append(rValue.K8sGateways, istioConfigList.K8sGateways...)
 
+	// All EnvoyFilters
+	rValue.EnvoyFilters = append(rValue.EnvoyFilters, istioConfigList.EnvoyFilters...)
+
 	in.filterPeerAuths(namespace, mtlsDetails, istioConfigList.PeerAuthentications)
 
 	in.filterAuthPolicies(namespace, rbacDetails, istioConfigList.AuthorizationPolicies)
@@ -511,7 +588,7 @@ func (in *IstioValidationsService) filterSEExportToNamespaces(namespace string,
 	return result
 }
 
-func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, errChan chan error, wg *sync.WaitGroup) {
+func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kubernetes.MTLSDetails, meshConfig *kubernetes.IstioMeshConfig, errChan chan error, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if len(errChan) > 0 {
 		return
@@ -535,6 +612,7 @@ func (in *IstioValidationsService) fetchNonLocalmTLSConfigs(mtlsDetails *kuberne
 		errChan <- err
 	} else {
 		mtlsDetails.EnabledAutoMtls = icm.GetEnableAutoMtls()
+		*meshConfig = *icm
 	}
 }
 
@@ -552,6 +630,155 @@ func (in *IstioValidationsService) fetchRegistryServices(rValue *[]*kubernetes.R
 	}
 }
 
+// fetchWasmPluginSecrets returns the names of the image pull secrets referenced by the WasmPlugins that exist, grouped by namespace.
+// The secrets are listed once per namespace. When they can't be listed for other reasons than the namespace not being found,
+// the referenced secrets are considered present to avoid false positives.
+func (in *IstioValidationsService) fetchWasmPluginSecrets(wasmPlugins []*extentions_v1alpha1.WasmPlugin) map[string][]string {
+	referenced := make(map[string]map[string]bool)
+	for _, wp := range wasmPlugins {
+		if wp.Spec.ImagePullSecret == "" {
+			continue
+		}
+		if referenced[wp.Namespace] == nil {
+			referenced[wp.Namespace] = make(map[string]bool)
+		}
+		referenced[wp.Namespace][wp.Spec.ImagePullSecret] = true
+	}
+
+	secrets := make(map[string][]string)
+	for namespace, names := range referenced {
+		nsSecrets, err := in.k8s.GetSecrets(namespace, "")
+		if err != nil {
+			if api_errors.IsNotFound(err) {
+				continue
+			}
+			log.Debugf("Unable to list secrets [%s] to validate WasmPlugins: %s", namespace, err)
+			for name := range names {
+				secrets[namespace] = append(secrets[namespace], name)
+			}
+			continue
+		}
+		for _, secret := range nsSecrets {
+			if names[secret.Name] {
+				secrets[namespace] = append(secrets[namespace], secret.Name)
+			}
+		}
+	}
+	return secrets
+}
+
+// fetchEnvoyFilterProxies returns the configuration of the proxies selected by the given EnvoyFilters. An EnvoyFilter of
+// the root namespace selects the proxies of every namespace. Only one proxy per workload is fetched, and config dumps
+// are only requested for the EnvoyFilters matching listeners or clusters, to limit the number of config dumps requested.
+func (in *IstioValidationsService) fetchEnvoyFilterProxies(envoyFilters []*networking_v1alpha3.EnvoyFilter, workloadsPerNamespace map[string]models.WorkloadList) []*models.ProxyConfig {
+	proxies := make([]*models.ProxyConfig, 0)
+	ownerProxies := make(map[string]*models.ProxyConfig)
+	for _, ef := range envoyFilters {
+		matchesObjects, matchesVersion := envoyFilterProxyMatches(ef)
+		if !matchesObjects && !matchesVersion {
+			continue
+		}
+
+		labelSelector := ""
+		if ef.Spec.WorkloadSelector != nil {
+			labelSelector = labels.SelectorFromSet(ef.Spec.WorkloadSelector.Labels).String()
+		}
+		namespaces := []string{ef.Namespace}
+		if config.IsRootNamespace(ef.Namespace) {
+			namespaces = make([]string, 0, len(workloadsPerNamespace))
+			for ns := range workloadsPerNamespace {
+				namespaces = append(namespaces, ns)
+			}
+		}
+
+		for _, namespace := range namespaces {
+			var pods []core_v1.Pod
+			var err error
+			if IsNamespaceCached(namespace) {
+				pods, err = kialiCache.GetPods(namespace, labelSelector)
+			} else {
+				pods, err = in.k8s.GetPods(namespace, labelSelector)
+			}
+			if err != nil {
+				log.Debugf("Unable to fetch pods [%s] to validate EnvoyFilter [%s/%s]: %s", namespace, ef.Namespace, ef.Name, err)
+				continue
+			}
+
+			for _, pod := range pods {
+				mPod := &models.Pod{}
+				mPod.Parse(&pod)
+				gateway := !mPod.HasIstioSidecar() && isGatewayProxy(&pod)
+				if pod.Status.Phase != core_v1.PodRunning || (!mPod.HasIstioSidecar() && !gateway) {
+					continue
+				}
+				owner := pod.Namespace + "/" + pod.Name
+				if len(pod.OwnerReferences) > 0 {
+					owner = pod.Namespace + "/" + pod.OwnerReferences[0].Kind + "/" + pod.OwnerReferences[0].Name
+				}
+				if proxy, ok := ownerProxies[owner]; ok {
+					if matchesObjects && proxy.ConfigDump == nil {
+						proxy.ConfigDump = in.fetchConfigDump(proxy.Namespace, proxy.Pod)
+					}
+					continue
+				}
+
+				proxy := &models.ProxyConfig{Namespace: pod.Namespace, Pod: pod.Name, Labels: pod.Labels, Gateway: gateway}
+				if kialiCache != nil {
+					if ps := kialiCache.GetPodProxyStatus(pod.Namespace, pod.Name); ps != nil {
+						proxy.IstioVersion = ps.IstioVersion
+						if proxy.IstioVersion == "" {
+							proxy.IstioVersion = ps.ProxyVersion
+						}
+					}
+				}
+				if matchesObjects {
+					proxy.ConfigDump = in.fetchConfigDump(pod.Namespace, pod.Name)
+				}
+				ownerProxies[owner] = proxy
+				proxies = append(proxies, proxy)
+			}
+		}
+	}
+	return proxies
+}
+
+func (in *IstioValidationsService) fetchConfigDump(namespace, pod string) *kubernetes.ConfigDump {
+	dump, err := in.k8s.GetConfigDump(namespace, pod)
+	if err != nil {
+		log.Debugf("Unable to fetch config dump [%s/%s] to validate EnvoyFilters: %s", namespace, pod, err)
+		return nil
+	}
+	return dump
+}
+
+// envoyFilterProxyMatches returns whether the patches of the EnvoyFilter match listeners or clusters, which are
+// validated against the config dump of the proxies, and whether they match proxy versions.
+func envoyFilterProxyMatches(ef *networking_v1alpha3.EnvoyFilter) (matchesObjects bool, matchesVersion bool) {
+	for _, cp := range ef.Spec.ConfigPatches {
+		if cp == nil || cp.Match == nil {
+			continue
+		}
+		matchesObjects = matchesObjects || cp.Match.GetListener() != nil || cp.Match.GetCluster() != nil
+		matchesVersion = matchesVersion || (cp.Match.Proxy != nil && cp.Match.Proxy.ProxyVersion != "")
+	}
+	return matchesObjects, matchesVersion
+}
+
+// isGatewayProxy returns true when the pod runs a standalone proxy, as the Istio gateways do
+func isGatewayProxy(pod *core_v1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "istio-proxy" {
+			continue
+		}
+		for _, arg := range c.Args {
+			if arg == "router" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (in *IstioValidationsService) isGatewayToNamespace() bool {
 	gatewayToNamespace := false
 	if in.businessLayer != nil {
@@ -0,0 +1,208 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// istioConfigOverlay describes a proposed change of an Istio object that is applied
+// on top of the Istio config fetched for the validations, without persisting it.
+type istioConfigOverlay struct {
+	objectType string
+	namespace  string
+	name       string
+	// body is the whole object for a create, jsonPatch is a merge patch of the existing object for an update
+	body      []byte
+	jsonPatch []byte
+}
+
+// GetCreateDryRunValidations returns the validations the mesh would have after creating the given Istio object.
+// The object is not persisted.
+func (in *IstioValidationsService) GetCreateDryRunValidations(ctx context.Context, namespace, objectType string, body []byte) (models.IstioValidationsDryRun, error) {
+	object := struct {
+		meta_v1.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return models.IstioValidationsDryRun{}, api_errors.NewBadRequest(fmt.Sprintf("invalid %s: %s", objectType, err))
+	}
+	if object.Name == "" {
+		return models.IstioValidationsDryRun{}, api_errors.NewBadRequest(fmt.Sprintf("invalid %s: metadata.name is required", objectType))
+	}
+	return in.getDryRunValidations(ctx, &istioConfigOverlay{objectType: objectType, namespace: namespace, name: object.Name, body: body})
+}
+
+// GetUpdateDryRunValidations returns the validations the mesh would have after patching the given Istio object
+// with the Json Merge Patch strategy. The object is not persisted.
+func (in *IstioValidationsService) GetUpdateDryRunValidations(ctx context.Context, namespace, objectType, object, jsonPatch string) (models.IstioValidationsDryRun, error) {
+	return in.getDryRunValidations(ctx, &istioConfigOverlay{objectType: objectType, namespace: namespace, name: object, jsonPatch: []byte(jsonPatch)})
+}
+
+func (in *IstioValidationsService) getDryRunValidations(ctx context.Context, overlay *istioConfigOverlay) (models.IstioValidationsDryRun, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "getDryRunValidations",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", overlay.namespace),
+		observability.Attribute("objectType", overlay.objectType),
+		observability.Attribute("object", overlay.name),
+	)
+	defer end()
+
+	dryRun := models.IstioValidationsDryRun{
+		Validations: models.IstioValidations{},
+		NewlyBroken: models.IstioValidations{},
+	}
+	if _, err := in.businessLayer.Namespace.GetNamespace(ctx, overlay.namespace); err != nil {
+		return dryRun, err
+	}
+
+	// The whole mesh is validated, as the change may break objects of other namespaces
+	current, err := in.getValidations(ctx, "", "", "", nil)
+	if err != nil {
+		return dryRun, err
+	}
+	proposed, err := in.getValidations(ctx, "", "", "", overlay)
+	if err != nil {
+		return dryRun, err
+	}
+
+	key := models.BuildKey(models.ObjectTypeSingular[overlay.objectType], overlay.name, overlay.namespace)
+	if validation, ok := proposed[key]; ok {
+		dryRun.Validations[key] = validation
+	}
+
+	// Only the objects of the namespaces accessible by the user are reported
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return dryRun, err
+	}
+	accessible := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		accessible[ns.Name] = true
+	}
+	for k, validation := range proposed {
+		if k == key || !accessible[k.Namespace] {
+			continue
+		}
+		if hasNewChecks(current[k], validation) {
+			dryRun.NewlyBroken[k] = validation
+		}
+	}
+
+	return dryRun, nil
+}
+
+// hasNewChecks returns true when the proposed validation has a check that is not present in the current one.
+func hasNewChecks(current, proposed *models.IstioValidation) bool {
+	existing := make(map[string]bool)
+	if current != nil {
+		for _, check := range current.Checks {
+			existing[check.Code+"/"+check.Path] = true
+		}
+	}
+	for _, check := range proposed.Checks {
+		if !existing[check.Code+"/"+check.Path] {
+			return true
+		}
+	}
+	return false
+}
+
+// apply replaces the object of the overlay in the Istio config list, or adds it when it's created.
+func (o *istioConfigOverlay) apply(istioConfigList *models.IstioConfigList) error {
+	var err error
+	switch o.objectType {
+	case kubernetes.DestinationRules:
+		istioConfigList.DestinationRules, err = overlayObjects(istioConfigList.DestinationRules, o)
+	case kubernetes.EnvoyFilters:
+		istioConfigList.EnvoyFilters, err = overlayObjects(istioConfigList.EnvoyFilters, o)
+	case kubernetes.Gateways:
+		istioConfigList.Gateways, err = overlayObjects(istioConfigList.Gateways, o)
+	case kubernetes.K8sGateways:
+		istioConfigList.K8sGateways, err = overlayObjects(istioConfigList.K8sGateways, o)
+	case kubernetes.K8sHTTPRoutes:
+		istioConfigList.K8sHTTPRoutes, err = overlayObjects(istioConfigList.K8sHTTPRoutes, o)
+	case kubernetes.K8sGRPCRoutes:
+		istioConfigList.K8sGRPCRoutes, err = overlayObjects(istioConfigList.K8sGRPCRoutes, o)
+	case kubernetes.K8sTCPRoutes:
+		istioConfigList.K8sTCPRoutes, err = overlayObjects(istioConfigList.K8sTCPRoutes, o)
+	case kubernetes.K8sTLSRoutes:
+		istioConfigList.K8sTLSRoutes, err = overlayObjects(istioConfigList.K8sTLSRoutes, o)
+	case kubernetes.K8sReferenceGrants:
+		istioConfigList.K8sReferenceGrants, err = overlayObjects(istioConfigList.K8sReferenceGrants, o)
+	case kubernetes.ServiceEntries:
+		istioConfigList.ServiceEntries, err = overlayObjects(istioConfigList.ServiceEntries, o)
+	case kubernetes.Sidecars:
+		istioConfigList.Sidecars, err = overlayObjects(istioConfigList.Sidecars, o)
+	case kubernetes.VirtualServices:
+		istioConfigList.VirtualServices, err = overlayObjects(istioConfigList.VirtualServices, o)
+	case kubernetes.WorkloadEntries:
+		istioConfigList.WorkloadEntries, err = overlayObjects(istioConfigList.WorkloadEntries, o)
+	case kubernetes.WorkloadGroups:
+		istioConfigList.WorkloadGroups, err = overlayObjects(istioConfigList.WorkloadGroups, o)
+	case kubernetes.WasmPlugins:
+		istioConfigList.WasmPlugins, err = overlayObjects(istioConfigList.WasmPlugins, o)
+	case kubernetes.Telemetries:
+		istioConfigList.Telemetries, err = overlayObjects(istioConfigList.Telemetries, o)
+	case kubernetes.AuthorizationPolicies:
+		istioConfigList.AuthorizationPolicies, err = overlayObjects(istioConfigList.AuthorizationPolicies, o)
+	case kubernetes.PeerAuthentications:
+		istioConfigList.PeerAuthentications, err = overlayObjects(istioConfigList.PeerAuthentications, o)
+	case kubernetes.RequestAuthentications:
+		istioConfigList.RequestAuthentications, err = overlayObjects(istioConfigList.RequestAuthentications, o)
+	default:
+		err = api_errors.NewBadRequest(fmt.Sprintf("object type not found: %v", o.objectType))
+	}
+	return err
+}
+
+// overlayObjects returns a copy of the objects where the object of the overlay is replaced by the proposed one.
+// The objects of the list are not modified, as they may be shared with the Kiali cache.
+func overlayObjects[T any, PT interface {
+	*T
+	meta_v1.Object
+}](objects []PT, o *istioConfigOverlay) ([]PT, error) {
+	resource := schema.GroupResource{Resource: o.objectType}
+	result := make([]PT, 0, len(objects)+1)
+	var existing PT
+	for _, object := range objects {
+		if object.GetName() == o.name && object.GetNamespace() == o.namespace {
+			existing = object
+			continue
+		}
+		result = append(result, object)
+	}
+
+	body := o.body
+	if o.jsonPatch != nil {
+		if existing == nil {
+			return nil, api_errors.NewNotFound(resource, o.name)
+		}
+		current, err := json.Marshal(existing)
+		if err != nil {
+			return nil, err
+		}
+		if body, err = jsonpatch.MergePatch(current, o.jsonPatch); err != nil {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("invalid patch for %s [%s]: %s", o.objectType, o.name, err))
+		}
+	} else if existing != nil {
+		return nil, api_errors.NewAlreadyExists(resource, o.name)
+	}
+
+	proposed := PT(new(T))
+	if err := json.Unmarshal(body, proposed); err != nil {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("invalid %s [%s]: %s", o.objectType, o.name, err))
+	}
+	proposed.SetName(o.name)
+	proposed.SetNamespace(o.namespace)
+	return append(result, proposed), nil
+}
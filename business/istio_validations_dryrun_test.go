@@ -0,0 +1,101 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func TestUpdateDryRunBreaksOtherObjects(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+
+	// Removing the subset used by product-vs
+	dryRun, err := vs.GetUpdateDryRunValidations(context.TODO(), "test", kubernetes.DestinationRules, "product-dr", `{"spec":{"subsets":null}}`)
+	require.NoError(err)
+
+	drKey := models.BuildKey("destinationrule", "product-dr", "test")
+	assert.Len(dryRun.Validations, 1)
+	assert.Contains(dryRun.Validations, drKey)
+
+	vsKey := models.BuildKey("virtualservice", "product-vs", "test")
+	validation, ok := dryRun.NewlyBroken[vsKey]
+	require.True(ok)
+	assert.True(hasCheck(validation, "KIA1107", "spec/http[0]/route[0]/destination"))
+	assert.NotContains(dryRun.NewlyBroken, drKey)
+
+	// The registry is not modified by the dry run
+	validations, err := vs.GetValidations(context.TODO(), "test", "", "")
+	require.NoError(err)
+	assert.False(hasCheck(validations[vsKey], "KIA1107", "spec/http[0]/route[0]/destination"))
+}
+
+func hasCheck(validation *models.IstioValidation, code, path string) bool {
+	for _, check := range validation.Checks {
+		if check.Code == code && check.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestUpdateDryRunNotFound(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeIstioConfigList(),
+		[]string{"product.test.svc.cluster.local"}, "test", fakePods())
+
+	_, err := vs.GetUpdateDryRunValidations(context.TODO(), "test", kubernetes.DestinationRules, "unknown-dr", `{"spec":{"subsets":null}}`)
+	require.Error(err)
+	require.True(api_errors.IsNotFound(err))
+}
+
+func TestCreateDryRun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeIstioConfigList(),
+		[]string{"product.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+
+	body := []byte(`{
+  "apiVersion": "networking.istio.io/v1beta1",
+  "kind": "VirtualService",
+  "metadata": {"name": "customer-vs"},
+  "spec": {
+    "hosts": ["customer"],
+    "http": [{"route": [{"destination": {"host": "customer", "subset": "v2"}}]}]
+  }
+}`)
+	dryRun, err := vs.GetCreateDryRunValidations(context.TODO(), "test", kubernetes.VirtualServices, body)
+	require.NoError(err)
+
+	validation, ok := dryRun.Validations[models.BuildKey("virtualservice", "customer-vs", "test")]
+	require.True(ok)
+	assert.NotEmpty(validation.Checks)
+	assert.Empty(dryRun.NewlyBroken)
+
+	// Objects that already exist can't be created
+	_, err = vs.GetCreateDryRunValidations(context.TODO(), "test", kubernetes.VirtualServices, []byte(`{"metadata": {"name": "product-vs"}}`))
+	require.Error(err)
+	assert.True(api_errors.IsAlreadyExists(err))
+
+	_, err = vs.GetCreateDryRunValidations(context.TODO(), "test", kubernetes.VirtualServices, []byte(`{"spec": {}}`))
+	require.Error(err)
+	assert.True(api_errors.IsBadRequest(err))
+}
@@ -8,6 +8,7 @@ import (
 	osapps_v1 "github.com/openshift/api/apps/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
 	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
 	apps_v1 "k8s.io/api/apps/v1"
@@ -74,6 +75,28 @@ func TestGatewayValidation(t *testing.T) {
 	assert.NotEmpty(validations)
 }
 
+func TestWasmPluginValidations(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeIstioConfigList(),
+		[]string{"details.test.svc.cluster.local", "product.test.svc.cluster.local", "product2.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+	kialiCache.GetRegistryStatus().Configuration.WasmPlugins = []*extentions_v1alpha1.WasmPlugin{
+		data.CreateWasmPlugin("openid", "test", "ftp://example.com/openid.wasm"),
+	}
+
+	validations, err := vs.GetValidations(context.TODO(), "test", "", "")
+	assert.NoError(err)
+	wasmPlugin, found := validations[models.IstioValidationKey{ObjectType: "wasmplugin", Namespace: "test", Name: "openid"}]
+	assert.True(found)
+	assert.False(wasmPlugin.Valid)
+
+	validations, _, err = vs.GetIstioObjectValidations(context.TODO(), "test", kubernetes.WasmPlugins, "openid")
+	assert.NoError(err)
+	assert.Len(validations, 1)
+}
+
 func TestFilterExportToNamespacesVS(t *testing.T) {
 	assert := assert.New(t)
 	conf := config.NewConfig()
@@ -168,6 +168,12 @@ func SetWithBackends(cf kubernetes.ClientFactory, prom prometheus.ClientInterfac
 	prometheusClient = prom
 }
 
+// SetKialiCache allows for specifying the Kiali Cache used by the business layer.
+// Used by tools that run the business layer without a cluster.
+func SetKialiCache(cache cache.KialiCache) {
+	kialiCache = cache
+}
+
 // NewWithBackends creates the business layer using the passed k8s and prom clients
 func NewWithBackends(k8s kubernetes.ClientInterface, prom prometheus.ClientInterface, jaegerClient JaegerLoader) *Layer {
 	temporaryLayer := &Layer{}
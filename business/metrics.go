@@ -52,6 +52,9 @@ func createMetricsLabelsBuilder(q *models.IstioMetricsQuery) *MetricsLabelsBuild
 	if !namespaceSet && q.Namespace != "" {
 		lb.Namespace(q.Namespace)
 	}
+	if q.Cluster != "" {
+		lb.Cluster(q.Cluster)
+	}
 	if q.RequestProtocol != "" {
 		lb.Protocol(q.RequestProtocol)
 	}
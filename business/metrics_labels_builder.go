@@ -111,6 +111,12 @@ func (lb *MetricsLabelsBuilder) PeerApp(name, namespace string) *MetricsLabelsBu
 	return lb.addSided("canonical_service", name, lb.peerSide)
 }
 
+// Cluster selects the cluster of the workloads, routing the query to the Prometheus of that cluster when
+// the clusters have their own Prometheus and the workloads are the reporter.
+func (lb *MetricsLabelsBuilder) Cluster(name string) *MetricsLabelsBuilder {
+	return lb.addSided("cluster", name, lb.side)
+}
+
 func (lb *MetricsLabelsBuilder) Protocol(name string) *MetricsLabelsBuilder {
 	lb.protocol = strings.ToLower(name)
 	return lb.Add("request_protocol", name)
@@ -37,6 +37,16 @@ func TestMetricsLabelsBuilderOutboundGrpc(t *testing.T) {
 	assert.Equal(`{source_workload_namespace="ns",source_workload="test",reporter="destination",request_protocol="grpc",grpc_response_status=~"^[1-9]$|^1[0-6]$",response_code!~"^0$|^[4-5]\\d\\d$"}`, errs[1])
 }
 
+func TestMetricsLabelsBuilderCluster(t *testing.T) {
+	assert := assert.New(t)
+
+	lb := NewMetricsLabelsBuilder("inbound")
+	lb.Reporter("destination")
+	lb.Workload("test", "ns")
+	lb.Cluster("east")
+	assert.Equal(`{reporter="destination",destination_workload_namespace="ns",destination_workload="test",destination_cluster="east"}`, lb.Build())
+}
+
 func TestMetricsLabelsBuilderInboundPeerLabels(t *testing.T) {
 	assert := assert.New(t)
 
@@ -103,6 +103,30 @@ func filterRegistryConfiguration(registryStatus *kubernetes.RegistryStatus, crit
 		}
 	}
 
+	for _, o := range registryStatus.Configuration.K8sGRPCRoutes {
+		if o.Namespace == criteria.Namespace {
+			filtered.K8sGRPCRoutes = append(filtered.K8sGRPCRoutes, o)
+		}
+	}
+
+	for _, o := range registryStatus.Configuration.K8sTCPRoutes {
+		if o.Namespace == criteria.Namespace {
+			filtered.K8sTCPRoutes = append(filtered.K8sTCPRoutes, o)
+		}
+	}
+
+	for _, o := range registryStatus.Configuration.K8sTLSRoutes {
+		if o.Namespace == criteria.Namespace {
+			filtered.K8sTLSRoutes = append(filtered.K8sTLSRoutes, o)
+		}
+	}
+
+	for _, o := range registryStatus.Configuration.K8sReferenceGrants {
+		if o.Namespace == criteria.Namespace {
+			filtered.K8sReferenceGrants = append(filtered.K8sReferenceGrants, o)
+		}
+	}
+
 	for _, se := range registryStatus.Configuration.ServiceEntries {
 		if se.Namespace == criteria.Namespace {
 			filtered.ServiceEntries = append(filtered.ServiceEntries, se)
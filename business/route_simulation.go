@@ -0,0 +1,56 @@
+package business
+
+import (
+	"context"
+
+	"github.com/kiali/kiali/business/checkers/virtualservices"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// SimulateRoute resolves the route that a request sent from the namespace to a host would take,
+// with the weighted destinations and their traffic policies.
+func (in *IstioConfigService) SimulateRoute(ctx context.Context, namespace string, request models.RouteSimulationRequest) (models.RouteSimulationResult, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SimulateRoute",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("host", request.Host),
+	)
+	defer end()
+
+	// Check if user has access to the namespace (RBAC) in cache scenarios and/or
+	// if namespace is accessible from Kiali (Deployment.AccessibleNamespaces)
+	if _, err := in.businessLayer.Namespace.GetNamespace(ctx, namespace); err != nil {
+		return models.RouteSimulationResult{}, err
+	}
+	request.SourceNamespace = namespace
+
+	namespaces, err := in.businessLayer.Namespace.GetNamespaces(ctx)
+	if err != nil {
+		return models.RouteSimulationResult{}, err
+	}
+	nsNames := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		nsNames = append(nsNames, ns.Name)
+	}
+
+	// The VirtualServices and DestinationRules of other namespaces may apply to the request
+	istioConfigList, err := in.GetIstioConfigList(ctx, IstioConfigCriteria{
+		AllNamespaces:           true,
+		IncludeVirtualServices:  true,
+		IncludeDestinationRules: true,
+	})
+	if err != nil {
+		return models.RouteSimulationResult{}, err
+	}
+
+	simulator := virtualservices.RouteSimulator{
+		VirtualServices:  istioConfigList.VirtualServices,
+		DestinationRules: istioConfigList.DestinationRules,
+		Namespaces:       nsNames,
+		RootNamespace:    config.Get().ExternalServices.Istio.RootNamespace,
+	}
+	return simulator.Simulate(request), nil
+}
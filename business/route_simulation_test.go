@@ -0,0 +1,34 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func TestSimulateRoute(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vs := mockCombinedValidationService(fakeIstioConfigList(),
+		[]string{"product.test.svc.cluster.local", "customer.test.svc.cluster.local"}, "test", fakePods())
+
+	result, err := vs.businessLayer.IstioConfig.SimulateRoute(context.TODO(), "test", models.RouteSimulationRequest{Host: "product", Uri: "/"})
+	require.NoError(err)
+
+	require.NotNil(result.VirtualService)
+	assert.Equal("product-vs", result.VirtualService.Name)
+	require.NotNil(result.Route)
+	require.Len(result.Destinations, 1)
+	assert.Equal("product.test.svc.cluster.local", result.Destinations[0].Host)
+	assert.Equal("v1", result.Destinations[0].Subset)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("product-dr", result.Destinations[0].DestinationRule.Name)
+}
@@ -280,6 +280,7 @@ func (in *SvcService) buildKubernetesServices(svcs []core_v1.Service, pods []cor
 			AdditionalDetailSample: models.GetFirstAdditionalIcon(conf, item.ObjectMeta.Annotations),
 			Health:                 models.EmptyServiceHealth(),
 			HealthAnnotations:      models.GetHealthAnnotation(item.Annotations, models.GetHealthConfigAnnotation()),
+			SLOAnnotations:         models.GetHealthAnnotation(item.Annotations, models.GetSLOAnnotation()),
 			Labels:                 item.Labels,
 			Selector:               item.Spec.Selector,
 			IstioReferences:        svcReferences,
@@ -1798,6 +1798,71 @@ func (in *WorkloadService) GetWorkloadAppName(ctx context.Context, namespace, wo
 	return app, nil
 }
 
+// GetNodeZones returns the zone of each node of the cluster (key=node name). The zone of a node is its
+// topology.kubernetes.io/zone label, or the deprecated failure-domain.beta.kubernetes.io/zone label, the nodes
+// without a zone are omitted. The users are usually not allowed to list the nodes, so they are listed with the
+// Kiali service account, which needs a ClusterRole rule allowing it: apiGroups [""], resources ["nodes"],
+// verbs ["list"]. The nodes should be read once per request, they are not cached.
+func (in *WorkloadService) GetNodeZones(ctx context.Context) (map[string]string, error) {
+	var end observability.EndFunc
+	_, end = observability.StartSpan(ctx, "GetNodeZones",
+		observability.Attribute("package", "business"),
+	)
+	defer end()
+
+	k8s, err := getSAClient()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := k8s.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	return nodeZones(nodes), nil
+}
+
+func nodeZones(nodes []core_v1.Node) map[string]string {
+	zones := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		if zone, ok := node.Labels[core_v1.LabelTopologyZone]; ok {
+			zones[node.Name] = zone
+		} else if zone, ok := node.Labels[core_v1.LabelFailureDomainBetaZone]; ok {
+			zones[node.Name] = zone
+		}
+	}
+	return zones
+}
+
+// GetWorkloadZones returns the sorted zones of the nodes running the pods of each workload of a namespace
+// (key=workload name), given the zones of the nodes returned by GetNodeZones. The workloads whose pods run on
+// nodes without a zone are omitted.
+func (in *WorkloadService) GetWorkloadZones(ctx context.Context, namespace string, nodeZones map[string]string) (map[string][]string, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetWorkloadZones",
+		observability.Attribute("package", "business"),
+		observability.Attribute("namespace", namespace),
+	)
+	defer end()
+
+	ws, err := fetchWorkloads(ctx, in.businessLayer, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string][]string)
+	for _, w := range ws {
+		seen := make(map[string]bool)
+		for _, pod := range w.Pods {
+			if zone, ok := nodeZones[pod.NodeName]; ok && !seen[zone] {
+				seen[zone] = true
+				zones[w.Name] = append(zones[w.Name], zone)
+			}
+		}
+		sort.Strings(zones[w.Name])
+	}
+	return zones, nil
+}
+
 // streamParsedLogs fetches logs from a container in a pod, parses and decorates each log line with some metadata (of possible) and
 // sends the processed lines to the client in JSON format. Results are sent as processing is performed, so in case of any error when
 // doing processing the JSON document will be truncated.
@@ -369,6 +369,41 @@ func TestGetWorkloadListFromPods(t *testing.T) {
 	assert.Equal(true, workloads[0].VersionLabel)
 }
 
+func TestGetWorkloadZones(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Setup mocks
+	kubeObjs := []runtime.Object{
+		&osproject_v1.Project{ObjectMeta: v1.ObjectMeta{Name: "Namespace"}},
+	}
+	nodes := []core_v1.Node{
+		{ObjectMeta: v1.ObjectMeta{Name: "node-a", Labels: map[string]string{core_v1.LabelTopologyZone: "us-east-1a"}}},
+		{ObjectMeta: v1.ObjectMeta{Name: "node-b"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "node-c", Labels: map[string]string{core_v1.LabelFailureDomainBetaZone: "us-east-1c"}}},
+	}
+	pods := FakePodsNoController()
+	pods = append(pods, *pods[0].DeepCopy())
+	pods[0].Spec.NodeName = "node-a"
+	pods[1].Name = "orphan-pod-without-zone"
+	pods[1].Spec.NodeName = "node-b"
+	for _, obj := range pods {
+		o := obj
+		kubeObjs = append(kubeObjs, &o)
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	k8s.OpenShift = true
+	svc := setupWorkloadService(k8s, config.NewConfig())
+
+	nodeZones := nodeZones(nodes)
+	assert.Equal(map[string]string{"node-a": "us-east-1a", "node-c": "us-east-1c"}, nodeZones)
+
+	zones, err := svc.GetWorkloadZones(context.TODO(), "Namespace", nodeZones)
+	require.NoError(err)
+
+	assert.Equal(map[string][]string{"orphan-pod": {"us-east-1a"}}, zones)
+}
+
 func TestGetWorkloadFromDeployment(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -142,16 +142,40 @@ type ThanosProxy struct {
 
 // PrometheusConfig describes configuration of the Prometheus component
 type PrometheusConfig struct {
-	Auth            Auth              `yaml:"auth,omitempty"`
-	CacheDuration   int               `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
-	CacheEnabled    bool              `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
-	CacheExpiration int               `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
-	CustomHeaders   map[string]string `yaml:"custom_headers,omitempty"`
-	HealthCheckUrl  string            `yaml:"health_check_url,omitempty"`
-	IsCore          bool              `yaml:"is_core,omitempty"`
-	QueryScope      map[string]string `yaml:"query_scope,omitempty"`
-	ThanosProxy     ThanosProxy       `yaml:"thanos_proxy,omitempty"`
-	URL             string            `yaml:"url,omitempty"`
+	Auth            Auth                      `yaml:"auth,omitempty"`
+	CacheDuration   int                       `yaml:"cache_duration,omitempty"`   // Cache duration per query expressed in seconds
+	CacheEnabled    bool                      `yaml:"cache_enabled,omitempty"`    // Enable cache for Prometheus queries
+	CacheExpiration int                       `yaml:"cache_expiration,omitempty"` // Global cache expiration expressed in seconds
+	Clusters        []PrometheusClusterConfig `yaml:"clusters,omitempty"`         // The Prometheus of each cluster, when the clusters don't share a Prometheus
+	CustomHeaders   map[string]string         `yaml:"custom_headers,omitempty"`
+	HealthCheckUrl  string                    `yaml:"health_check_url,omitempty"`
+	IsCore          bool                      `yaml:"is_core,omitempty"`
+	QueryScope      map[string]string         `yaml:"query_scope,omitempty"`
+	ThanosProxy     ThanosProxy               `yaml:"thanos_proxy,omitempty"`
+	URL             string                    `yaml:"url,omitempty"`
+}
+
+// PrometheusClusterConfig describes the Prometheus of a cluster of a multi-cluster mesh. The unset fields
+// default to the ones of the enclosing PrometheusConfig.
+type PrometheusClusterConfig struct {
+	Auth          Auth              `yaml:"auth,omitempty"`
+	CustomHeaders map[string]string `yaml:"custom_headers,omitempty"`
+	Name          string            `yaml:"name,omitempty"` // The cluster name, as in the source_cluster and destination_cluster labels
+	URL           string            `yaml:"url,omitempty"`
+}
+
+// ForCluster returns the config of the Prometheus of the given cluster
+func (pc PrometheusConfig) ForCluster(cluster PrometheusClusterConfig) PrometheusConfig {
+	clusterConfig := pc
+	clusterConfig.Clusters = nil
+	if cluster.Auth.Type != "" {
+		clusterConfig.Auth = cluster.Auth
+	}
+	if len(cluster.CustomHeaders) > 0 {
+		clusterConfig.CustomHeaders = cluster.CustomHeaders
+	}
+	clusterConfig.URL = cluster.URL
+	return clusterConfig
 }
 
 // CustomDashboardsConfig describes configuration specific to Custom Dashboards
@@ -210,6 +234,7 @@ type RegistryConfig struct {
 
 // IstioConfig describes configuration used for istio links
 type IstioConfig struct {
+	AmbientEnabled                    bool                `yaml:"ambient_enabled,omitempty"` // query the ambient mesh (ztunnel and waypoint) telemetry
 	ComponentStatuses                 ComponentStatuses   `yaml:"component_status,omitempty"`
 	ConfigMapName                     string              `yaml:"config_map_name,omitempty"`
 	EnvoyAdminLocalPort               int                 `yaml:"envoy_admin_local_port,omitempty"`
@@ -305,9 +330,62 @@ type KubernetesConfig struct {
 
 // ApiConfig contains API specific configuration.
 type ApiConfig struct {
+	Graph      ApiGraphConfig `yaml:"graph,omitempty"`
 	Namespaces ApiNamespacesConfig
 }
 
+// ApiGraphConfig provides the configuration of the graph API.
+type ApiGraphConfig struct {
+	Anomaly   ApiGraphAnomalyConfig   `yaml:"anomaly,omitempty"`
+	Cache     ApiGraphCacheConfig     `yaml:"cache,omitempty"`
+	Cost      ApiGraphCostConfig      `yaml:"cost,omitempty"`
+	Snapshots ApiGraphSnapshotsConfig `yaml:"snapshots,omitempty"`
+}
+
+// ApiGraphAnomalyConfig configures the anomaly appender, flagging the traffic deviating from its baseline. The
+// thresholds are z-scores, the number of standard deviations from the baseline above which a metric is an
+// anomaly. A zero threshold disables the evaluation of the metric.
+type ApiGraphAnomalyConfig struct {
+	ErrorRateThreshold    float64 `yaml:"error_rate_threshold,omitempty"`
+	RequestRateThreshold  float64 `yaml:"request_rate_threshold,omitempty"`
+	ResponseTimeThreshold float64 `yaml:"response_time_threshold,omitempty"`
+}
+
+// ApiGraphCacheConfig configures the cache of the generated graphs, shared by the identical graph requests of
+// users with access to the same namespaces.
+type ApiGraphCacheConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Maximum estimated size of the cached graphs, expressed in megabytes. The oldest graphs are evicted first.
+	MaxMemory int `yaml:"max_memory,omitempty"`
+	// Time a graph is cached, expressed in seconds. The query time of the requests is bucketed to the refresh
+	// interval of the requests, or to this duration when it is shorter, so that the graphs requested within the
+	// same bucket are shared.
+	TTL int `yaml:"ttl,omitempty"`
+}
+
+// ApiGraphCostConfig configures the cost appender, estimating the cost of the bytes transferred by the edges from
+// their locality. The rates are the price of a GB (10^9 bytes) of transfer, a zero rate estimates no cost.
+// The zones are read from the nodes, so the Kiali service account needs a ClusterRole rule allowing to list
+// them: apiGroups [""], resources ["nodes"], verbs ["list"].
+type ApiGraphCostConfig struct {
+	CrossClusterRate float64 `yaml:"cross_cluster_rate,omitempty"`
+	CrossZoneRate    float64 `yaml:"cross_zone_rate,omitempty"`
+	IntraZoneRate    float64 `yaml:"intra_zone_rate,omitempty"`
+}
+
+// ApiGraphSnapshotsConfig configures the storage of the graphs saved under a name, to be replayed later.
+type ApiGraphSnapshotsConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Directory holding the snapshots when the storage is filesystem.
+	Directory string `yaml:"directory,omitempty"`
+	// Namespace holding the snapshot ConfigMaps when the storage is configmap. Defaults to the Kiali deployment
+	// namespace. The Kiali service account needs a Role rule of the namespace allowing it: apiGroups [""],
+	// resources ["configmaps"], verbs ["create", "get", "list", "delete"].
+	Namespace string `yaml:"namespace,omitempty"`
+	// Storage of the snapshots: filesystem | configmap
+	Storage string `yaml:"storage,omitempty"`
+}
+
 // ApiNamespacesConfig provides a list of regex strings defining namespaces to include or exclude.
 type ApiNamespacesConfig struct {
 	Exclude              []string `yaml:"exclude,omitempty" json:"exclude"`
@@ -418,7 +496,20 @@ type UIDefaults struct {
 
 // Validations defines default settings configured for the Validations subsystem
 type Validations struct {
-	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+	CustomRules []ValidationCustomRule `yaml:"custom_rules,omitempty" json:"customRules,omitempty"`
+	Ignore      []string               `yaml:"ignore,omitempty" json:"ignore,omitempty"`
+}
+
+// ValidationCustomRule defines a validation rule on the objects of a type, written as a CEL expression.
+// The expression is evaluated over the object, its selected workloads and its referenced services, and
+// must return true when the object is valid. Otherwise, a check is added with the code, severity and message of the rule.
+type ValidationCustomRule struct {
+	Code       string `yaml:"code,omitempty" json:"code,omitempty"`
+	Expression string `yaml:"expression,omitempty" json:"expression,omitempty"`
+	Message    string `yaml:"message,omitempty" json:"message,omitempty"`
+	ObjectType string `yaml:"object_type,omitempty" json:"objectType,omitempty"`
+	Path       string `yaml:"path,omitempty" json:"path,omitempty"`
+	Severity   string `yaml:"severity,omitempty" json:"severity,omitempty"`
 }
 
 // CertificatesInformationIndicators defines configuration to enable the feature and to grant read permissions to a list of secrets
@@ -454,9 +545,18 @@ type Rate struct {
 	Tolerance []Tolerance `yaml:"tolerance,omitempty" json:"tolerance"`
 }
 
+// SLO config, the service level objectives of the services matching the namespace and name regexes
+type SLO struct {
+	Namespace    string  `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Name         string  `yaml:"name,omitempty" json:"name,omitempty"`
+	Availability float64 `yaml:"availability,omitempty" json:"availability,omitempty"` // percentage of successful requests, e.g. 99.9
+	LatencyP99   float64 `yaml:"latency_p99,omitempty" json:"latencyP99,omitempty"`    // 99th percentile response time, in millis
+}
+
 // HealthConfig rates
 type HealthConfig struct {
 	Rate []Rate `yaml:"rate,omitempty" json:"rate,omitempty"`
+	SLO  []SLO  `yaml:"slo,omitempty" json:"slo,omitempty"`
 }
 
 //go:embed *
@@ -518,6 +618,23 @@ func NewConfig() (c *Config) {
 		InCluster:      true,
 		IstioNamespace: "istio-system",
 		API: ApiConfig{
+			Graph: ApiGraphConfig{
+				Anomaly: ApiGraphAnomalyConfig{
+					ErrorRateThreshold:    3,
+					RequestRateThreshold:  3,
+					ResponseTimeThreshold: 3,
+				},
+				Cache: ApiGraphCacheConfig{
+					Enabled:   false,
+					MaxMemory: 100,
+					TTL:       10,
+				},
+				Snapshots: ApiGraphSnapshotsConfig{
+					Enabled:   false,
+					Directory: "/var/lib/kiali/graph-snapshots",
+					Storage:   "filesystem",
+				},
+			},
 			Namespaces: ApiNamespacesConfig{
 				Exclude: []string{
 					"^istio-operator",
@@ -713,14 +830,15 @@ func NewConfig() (c *Config) {
 				RefreshInterval:   "60s",
 			},
 			Validations: Validations{
-				Ignore: make([]string, 0),
+				CustomRules: make([]ValidationCustomRule, 0),
+				Ignore:      make([]string, 0),
 			},
 		},
 		KubernetesConfig: KubernetesConfig{
 			Burst:                       200,
 			CacheDuration:               5 * 60,
 			CacheEnabled:                true,
-			CacheIstioTypes:             []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute"},
+			CacheIstioTypes:             []string{"AuthorizationPolicy", "DestinationRule", "EnvoyFilter", "Gateway", "PeerAuthentication", "RequestAuthentication", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "WasmPlugin", "Telemetry", "K8sGateway", "K8sHTTPRoute", "K8sGRPCRoute", "K8sTCPRoute", "K8sTLSRoute", "K8sReferenceGrant"},
 			CacheNamespaces:             []string{".*"},
 			CacheTokenNamespaceDuration: 10,
 			ExcludeWorkloads:            []string{"CronJob", "DeploymentConfig", "Job", "ReplicationController"},
@@ -832,6 +950,11 @@ func (conf Config) String() (str string) {
 	obf := conf
 	obf.ExternalServices.Grafana.Auth.Obfuscate()
 	obf.ExternalServices.Prometheus.Auth.Obfuscate()
+	// copy the clusters, to not obfuscate the actual config
+	obf.ExternalServices.Prometheus.Clusters = append([]PrometheusClusterConfig{}, conf.ExternalServices.Prometheus.Clusters...)
+	for i := range obf.ExternalServices.Prometheus.Clusters {
+		obf.ExternalServices.Prometheus.Clusters[i].Auth.Obfuscate()
+	}
 	obf.ExternalServices.Tracing.Auth.Obfuscate()
 	obf.Identity.Obfuscate()
 	obf.LoginToken.Obfuscate()
@@ -80,6 +80,7 @@ func TestSensitiveDataObfuscation(t *testing.T) {
 	conf.ExternalServices.Prometheus.Auth.Username = "my-username"
 	conf.ExternalServices.Prometheus.Auth.Password = "my-password"
 	conf.ExternalServices.Prometheus.Auth.Token = "my-token"
+	conf.ExternalServices.Prometheus.Clusters = []PrometheusClusterConfig{{Name: "east", Auth: Auth{Type: AuthTypeBearer, Token: "my-token"}}}
 	conf.ExternalServices.Tracing.Auth.Username = "my-username"
 	conf.ExternalServices.Tracing.Auth.Password = "my-password"
 	conf.ExternalServices.Tracing.Auth.Token = "my-token"
@@ -98,9 +99,29 @@ func TestSensitiveDataObfuscation(t *testing.T) {
 	assert.Equal(t, "my-username", conf.ExternalServices.Grafana.Auth.Username)
 	assert.Equal(t, "my-password", conf.ExternalServices.Prometheus.Auth.Password)
 	assert.Equal(t, "my-token", conf.ExternalServices.Tracing.Auth.Token)
+	assert.Equal(t, "my-token", conf.ExternalServices.Prometheus.Clusters[0].Auth.Token)
 	assert.Equal(t, "my-signkey", conf.LoginToken.SigningKey)
 }
 
+func TestPrometheusForCluster(t *testing.T) {
+	conf := NewConfig()
+	conf.ExternalServices.Prometheus.Auth = Auth{Type: AuthTypeBearer, Token: "my-token"}
+	conf.ExternalServices.Prometheus.Clusters = []PrometheusClusterConfig{
+		{Name: "east", URL: "http://prometheus.east:9090"},
+		{Name: "west", URL: "http://prometheus.west:9090", Auth: Auth{Type: AuthTypeNone}},
+	}
+
+	east := conf.ExternalServices.Prometheus.ForCluster(conf.ExternalServices.Prometheus.Clusters[0])
+	assert.Equal(t, "http://prometheus.east:9090", east.URL)
+	assert.Equal(t, "my-token", east.Auth.Token)
+	assert.Empty(t, east.Clusters)
+
+	west := conf.ExternalServices.Prometheus.ForCluster(conf.ExternalServices.Prometheus.Clusters[1])
+	assert.Equal(t, "http://prometheus.west:9090", west.URL)
+	assert.Equal(t, AuthTypeNone, west.Auth.Type)
+	assert.Equal(t, conf.ExternalServices.Prometheus.CacheDuration, west.CacheDuration)
+}
+
 func TestMarshalUnmarshalStaticContentRootDirectory(t *testing.T) {
 	testConf := Config{
 		Server: Server{
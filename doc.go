@@ -4,7 +4,9 @@ import (
 	jaegerModels "github.com/kiali/kiali/jaeger/model/json"
 
 	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/snapshot"
 	"github.com/kiali/kiali/jaeger"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
@@ -79,7 +81,25 @@ type LoggingParam struct {
 	Level ProxyLogLevel `json:"level"`
 }
 
-// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging
+// swagger:parameters authorizationPoliciesSimulate
+type AuthorizationSimulationParam struct {
+	// The request to evaluate, from a source workload to a workload of the namespace.
+	//
+	// in: body
+	// required: true
+	Body models.AuthorizationSimulationRequest
+}
+
+// swagger:parameters virtualServicesSimulate
+type RouteSimulationParam struct {
+	// The request to resolve, sent from the namespace to a host.
+	//
+	// in: body
+	// required: true
+	Body models.RouteSimulationRequest
+}
+
+// swagger:parameters istioConfigList workloadList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations appList serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype serviceList appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype istioConfigCreateDryRun istioConfigUpdateDryRun authorizationPoliciesSimulate virtualServicesSimulate namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging
 type NamespaceParam struct {
 	// The namespace name.
 	//
@@ -88,7 +108,7 @@ type NamespaceParam struct {
 	Name string `json:"namespace"`
 }
 
-// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype
+// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigUpdateDryRun
 type ObjectNameParam struct {
 	// The Istio object name.
 	//
@@ -97,7 +117,7 @@ type ObjectNameParam struct {
 	Name string `json:"object"`
 }
 
-// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigCreate istioConfigCreateSubtype
+// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigCreate istioConfigCreateSubtype istioConfigCreateDryRun istioConfigUpdateDryRun
 type ObjectTypeParam struct {
 	// The Istio object type.
 	//
@@ -194,9 +214,9 @@ type WorkloadParam struct {
 // - keep this alphabetized
 /////////////////////
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type AppendersParam struct {
-	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, deadNode, healthConfig, idleNode, istio, responseTime, securityPolicy, serviceEntry, sidecarsCheck, throughput].
+	// Comma-separated list of Appenders to run. Available appenders: [aggregateNode, anomaly, cost, deadNode, healthConfig, idleNode, istio, responseTime, securityPolicy, serviceEntry, sidecarsCheck, slo, throughput]. The anomaly, cost and slo appenders only run when requested.
 	//
 	// in: query
 	// required: false
@@ -204,16 +224,63 @@ type AppendersParam struct {
 	Name string `json:"appenders"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphNamespacesDiff
+type BaselineDurationParam struct {
+	// Query time-range duration of the baseline graph (Golang string duration).
+	//
+	// in: query
+	// required: false
+	// default: duration
+	Name string `json:"baselineDuration"`
+}
+
+// swagger:parameters graphNamespacesDiff
+type BaselineQueryTimeParam struct {
+	// Unix time (seconds) for the query of the baseline graph. Default is the end of the time range preceding the graph, queryTime-duration.
+	//
+	// in: query
+	// required: false
+	Name string `json:"baselineQueryTime"`
+}
+
+// swagger:parameters graphSnapshot
+type BaselineSnapshotParam struct {
+	// Name of a saved graph snapshot, the graph is the diff of this baseline snapshot and the snapshot.
+	//
+	// in: query
+	// required: false
+	Name string `json:"baseline"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshot graphSnapshotSave graphWorkload
 type BoxByParam struct {
-	// Comma-separated list of desired node boxing. Available boxings: [app, cluster, namespace].
+	// Comma-separated list of desired node boxing. Available boxings: [app, cluster, namespace, label:<key>]. At most one label boxing, boxing by the value of the label of the workloads, or of their namespace.
 	//
 	// in: query
 	// required: false
 	Name string `json:"boxBy"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphPaths
+type DestinationNodeParam struct {
+	// Selector of the destination nodes, written as <kind>:<namespace>/<name> with kind one of app, service or workload.
+	//
+	// in: query
+	// required: true
+	Name string `json:"destination"`
+}
+
+// swagger:parameters graphDependencies
+type DownstreamDepthParam struct {
+	// Maximum number of edges from the node to its dependencies, 0 is unlimited.
+	//
+	// in: query
+	// required: false
+	// default: 0
+	Name string `json:"downstreamDepth"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type DurationGraphParam struct {
 	// Query time-range duration (Golang string duration).
 	//
@@ -223,7 +290,17 @@ type DurationGraphParam struct {
 	Name string `json:"duration"`
 }
 
-// swagger:parameters graphNamespaces graphService graphWorkload
+// swagger:parameters graphNamespacesDiff graphSnapshot
+type DiffThresholdParam struct {
+	// Percentage of change of the rate, error rate or response time of an edge to report it as changed.
+	//
+	// in: query
+	// required: false
+	// default: 10
+	Name string `json:"diffThreshold"`
+}
+
+// swagger:parameters graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type GraphTypeParam struct {
 	// Graph type. Available graph types: [app, service, versionedApp, workload].
 	//
@@ -242,7 +319,36 @@ type AppGraphTypeParam struct {
 	Name string `json:"graphType"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
+type HideParam struct {
+	// Expression of the nodes and edges to remove from the graph, using the language of the graph hide field of the UI, e.g. "rt > 1000 OR node = service". The nodes left without edges are also removed.
+	//
+	// in: query
+	// required: false
+	Name string `json:"hide"`
+}
+
+// swagger:parameters graphCost
+type LimitCostParam struct {
+	// Maximum number of edges returned for each namespace, 0 is unlimited.
+	//
+	// in: query
+	// required: false
+	// default: 10
+	Name string `json:"limit"`
+}
+
+// swagger:parameters graphPaths
+type LimitPathsParam struct {
+	// Maximum number of paths returned, 0 is unlimited.
+	//
+	// in: query
+	// required: false
+	// default: 20
+	Name string `json:"limit"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphSnapshotSave graphWorkload
 type IncludeIdleEdges struct {
 	// Flag for including edges that have no request traffic for the time period.
 	//
@@ -252,7 +358,7 @@ type IncludeIdleEdges struct {
 	Name string `json:"includeIdleEdges"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphWorkload
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphSnapshotSave graphWorkload
 type InjectServiceNodes struct {
 	// Flag for injecting the requested service node between source and destination nodes.
 	//
@@ -262,7 +368,17 @@ type InjectServiceNodes struct {
 	Name string `json:"injectServiceNodes"`
 }
 
-// swagger:parameters graphNamespaces
+// swagger:parameters graphPaths
+type MaxDepthParam struct {
+	// Maximum number of edges of a path, 0 is unlimited.
+	//
+	// in: query
+	// required: false
+	// default: 10
+	Name string `json:"maxDepth"`
+}
+
+// swagger:parameters graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphSnapshotSave
 type NamespacesParam struct {
 	// Comma-separated list of namespaces to include in the graph. The namespaces must be accessible to the client.
 	//
@@ -271,7 +387,16 @@ type NamespacesParam struct {
 	Name string `json:"namespaces"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphDependencies
+type NodeSelectorParam struct {
+	// Selector of the node, written as <kind>:<namespace>/<name> with kind one of app, service or workload.
+	//
+	// in: query
+	// required: true
+	Name string `json:"node"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphPaths graphService graphSnapshotSave graphWorkload
 type QueryTimeParam struct {
 	// Unix time (seconds) for query such that time range is [queryTime-duration..queryTime]. Default is now.
 	//
@@ -281,7 +406,17 @@ type QueryTimeParam struct {
 	Name string `json:"queryTime"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphPaths
+type RankByParam struct {
+	// Ranking of the paths, by decreasing error rate or response time. One of: errorRate | responseTime.
+	//
+	// in: query
+	// required: false
+	// default: errorRate
+	Name string `json:"rankBy"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type RateGrpcParam struct {
 	// How to calculate gRPC traffic rate. One of: none | received (i.e. response_messages) | requests | sent (i.e. request_messages) | total (i.e. sent+received).
 	//
@@ -291,7 +426,7 @@ type RateGrpcParam struct {
 	Name string `json:"rateGrpc"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type RateHttpParam struct {
 	// How to calculate HTTP traffic rate. One of: none | requests.
 	//
@@ -301,7 +436,7 @@ type RateHttpParam struct {
 	Name string `json:"rateHttp"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphApp graphAppVersion graphCost graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type RateTcpParam struct {
 	// How to calculate TCP traffic rate. One of: none | received (i.e. received_bytes) | sent (i.e. sent_bytes) | total (i.e. sent+received).
 	//
@@ -311,7 +446,18 @@ type RateTcpParam struct {
 	Name string `json:"rateTcp"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphNamespacesStream
+type RefreshIntervalParam struct {
+	// Time between two graphs of a graph stream (Golang string duration), at least 5s and less than 25s. A stream
+	// lasts 25s, so it holds 25s / refreshInterval deltas before the client reconnects and gets the full graph again.
+	//
+	// in: query
+	// required: false
+	// default: 10s
+	Name string `json:"refreshInterval"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type ResponseTimeParam struct {
 	// Used only with responseTime appender. One of: avg | 50 | 95 | 99.
 	//
@@ -321,7 +467,25 @@ type ResponseTimeParam struct {
 	Name string `json:"responseTime"`
 }
 
-// swagger:parameters graphApp graphAppVersion graphNamespaces graphService graphWorkload
+// swagger:parameters graphSnapshot graphSnapshotDelete graphSnapshotSave
+type SnapshotParam struct {
+	// The name of the graph snapshot, a DNS label: at most 63 lower case alphanumeric characters or '-'.
+	//
+	// in: path
+	// required: true
+	Name string `json:"snapshot"`
+}
+
+// swagger:parameters graphPaths
+type SourceNodeParam struct {
+	// Selector of the source nodes, written as <kind>:<namespace>/<name> with kind one of app, service or workload.
+	//
+	// in: query
+	// required: true
+	Name string `json:"source"`
+}
+
+// swagger:parameters graphApp graphAppVersion graphDependencies graphNamespaces graphNamespacesDiff graphNamespacesStream graphPaths graphService graphSnapshotSave graphWorkload
 type ThroughputParam struct {
 	// Used only with throughput appender. One of: request | response.
 	//
@@ -331,6 +495,16 @@ type ThroughputParam struct {
 	Name string `json:"throughput"`
 }
 
+// swagger:parameters graphDependencies
+type UpstreamDepthParam struct {
+	// Maximum number of edges from the node to its callers, 0 is unlimited.
+	//
+	// in: query
+	// required: false
+	// default: 0
+	Name string `json:"upstreamDepth"`
+}
+
 /////////////////////
 // SWAGGER PARAMETERS - METRICS
 // - keep this alphabetized
@@ -435,6 +609,16 @@ type RateIntervalParam struct {
 	Name string `json:"rateInterval"`
 }
 
+// swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
+type MetricsClusterParam struct {
+	// The cluster of the telemetry, querying the Prometheus of that cluster when the clusters have their own Prometheus.
+	//
+	// in: query
+	// required: false
+	// default: all clusters
+	Name string `json:"cluster"`
+}
+
 // swagger:parameters serviceMetrics aggregateMetrics appMetrics workloadMetrics appDashboard serviceDashboard workloadDashboard
 type RequestProtocolParam struct {
 	// Desired request protocol for the telemetry: For example, 'http' or 'grpc'.
@@ -582,6 +766,41 @@ type GraphResponse struct {
 	Body cytoscape.Config
 }
 
+// HTTP status code 200 and the costliest edges of each namespace of a graph in data
+// swagger:response graphCostResponse
+type GraphCostResponse struct {
+	// in:body
+	Body graph.CostSummary
+}
+
+// HTTP status code 200 and the upstream and downstream nodes of a graph node in data
+// swagger:response graphDependenciesResponse
+type GraphDependenciesResponse struct {
+	// in:body
+	Body graph.Dependencies
+}
+
+// HTTP status code 200 and the ranked paths between graph nodes in data
+// swagger:response graphPathsResponse
+type GraphPathsResponse struct {
+	// in:body
+	Body graph.Paths
+}
+
+// HTTP status code 200 and the description of a graph snapshot in data
+// swagger:response graphSnapshotResponse
+type GraphSnapshotResponse struct {
+	// in:body
+	Body snapshot.Info
+}
+
+// HTTP status code 200 and the descriptions of the graph snapshots in data
+// swagger:response graphSnapshotsResponse
+type GraphSnapshotsResponse struct {
+	// in:body
+	Body []snapshot.Info
+}
+
 // HTTP status code 200 and IstioConfigList model in data
 // swagger:response istioConfigList
 type IstioConfigResponse struct {
@@ -680,6 +899,27 @@ type IstioConfigDetailsResponse struct {
 	Body models.IstioConfigDetails
 }
 
+// Validations of a proposed change of an Istio Object
+// swagger:response istioValidationsDryRunResponse
+type IstioValidationsDryRunResponse struct {
+	// in:body
+	Body models.IstioValidationsDryRun
+}
+
+// Decision of the AuthorizationPolicies for a simulated request
+// swagger:response authorizationSimulationResponse
+type AuthorizationSimulationResponse struct {
+	// in:body
+	Body models.AuthorizationSimulationResult
+}
+
+// Route of a simulated request
+// swagger:response routeSimulationResponse
+type RouteSimulationResponse struct {
+	// in:body
+	Body models.RouteSimulationResult
+}
+
 // Detailed information of an specific app
 // swagger:response appDetails
 type AppDetailsResponse struct {
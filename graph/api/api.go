@@ -2,13 +2,22 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/config/dot"
+	"github.com/kiali/kiali/graph/config/graphml"
+	"github.com/kiali/kiali/graph/snapshot"
 	"github.com/kiali/kiali/graph/telemetry/istio"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	"github.com/kiali/kiali/graph/telemetry/jaeger"
+	"github.com/kiali/kiali/graph/telemetry/topology"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/observability"
 	"github.com/kiali/kiali/prometheus"
@@ -31,6 +40,10 @@ func GraphNamespaces(ctx context.Context, business *business.Layer, o graph.Opti
 		prom, err := prometheus.NewClient()
 		graph.CheckError(err)
 		code, config = graphNamespacesIstio(ctx, business, prom, o)
+	case graph.VendorJaeger:
+		code, config = graphNamespacesJaeger(ctx, business, o)
+	case graph.VendorTopology:
+		code, config = graphNamespacesTopology(ctx, business, o)
 	default:
 		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
 	}
@@ -49,12 +62,257 @@ func graphNamespacesIstio(ctx context.Context, business *business.Layer, prom *p
 	globalInfo.Business = business
 	globalInfo.Context = ctx
 
-	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+	})
+	code, config = generateGraph(trafficMap, o)
+
+	return code, config
+}
+
+// graphNamespacesJaeger generates the graph of the calls of the sampled traces, it does not query Prometheus
+func graphNamespacesJaeger(ctx context.Context, business *business.Layer, o graph.Options) (code int, config interface{}) {
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return jaeger.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+	})
+	code, config = generateGraph(trafficMap, o)
+
+	return code, config
+}
+
+// graphNamespacesTopology generates the graph of the configured routes, it does not query Prometheus
+func graphNamespacesTopology(ctx context.Context, business *business.Layer, o graph.Options) (code int, config interface{}) {
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return topology.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+	})
 	code, config = generateGraph(trafficMap, o)
 
 	return code, config
 }
 
+// GraphNamespacesDiff generates a namespaces graph annotated with the changes from a baseline graph,
+// using the provided options
+func GraphNamespacesDiff(ctx context.Context, business *business.Layer, o graph.DiffOptions) (code int, config interface{}) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GraphNamespacesDiff",
+		observability.Attribute("package", "api"),
+	)
+	defer end()
+	// time how long it takes to generate this graph
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClient()
+		graph.CheckError(err)
+		code, config = graphNamespacesDiffIstio(ctx, business, prom, o)
+	default:
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+
+	// update metrics
+	internalmetrics.SetGraphNodes(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes, 0)
+
+	return code, config
+}
+
+// graphNamespacesDiffIstio provides a test hook that accepts mock clients
+func graphNamespacesDiffIstio(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.DiffOptions) (code int, config interface{}) {
+
+	// Each graph gets its own 'global' object, the appenders cache information for a single time window
+	baselineInfo := graph.NewAppenderGlobalInfo()
+	baselineInfo.Business = business
+	baselineInfo.Context = ctx
+	baselineMap := istio.BuildNamespacesTrafficMap(ctx, o.Baseline, prom, baselineInfo)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+	trafficMap := istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+
+	trafficMap = graph.DiffTrafficMaps(baselineMap, trafficMap, o.Threshold)
+	code, config = generateGraph(trafficMap, o.Options)
+
+	return code, config
+}
+
+// The events of a graph stream
+const (
+	StreamEventDelta = "delta" // the cytoscape.Delta from the previous graph
+	StreamEventError = "error" // the message of a failed refresh, the stream goes on with the next refresh
+	StreamEventGraph = "graph" // the first graph, a cytoscape.Config
+)
+
+// GraphNamespacesStream generates a namespaces graph using the provided options, then regenerates it on every
+// refresh interval until the context is done. The first graph is sent whole, the following ones as the delta
+// from the previous graph. It returns when the context is done or when send fails, usually because the client
+// went away. A failure to generate the first graph panics like the other graph APIs, so that it is reported as
+// the response of the request.
+func GraphNamespacesStream(ctx context.Context, business *business.Layer, o graph.StreamOptions, send func(event string, payload interface{}) error) error {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GraphNamespacesStream",
+		observability.Attribute("package", "api"),
+	)
+	defer end()
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClient()
+		graph.CheckError(err)
+		ticker := time.NewTicker(o.RefreshInterval)
+		defer ticker.Stop()
+		return graphNamespacesStreamIstio(ctx, business, prom, o, ticker.C, send)
+	default:
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+	return nil
+}
+
+// graphNamespacesStreamIstio provides a test hook that accepts mock clients and refresh ticks
+func graphNamespacesStreamIstio(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.StreamOptions, ticks <-chan time.Time, send func(event string, payload interface{}) error) error {
+	_, config := graphNamespacesIstio(ctx, business, prom, o.Options)
+	previous := config.(cytoscape.Config)
+	if err := send(StreamEventGraph, previous); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case tick := <-ticks:
+			current, err := refreshGraphNamespaces(ctx, business, prom, o.WithQueryTime(tick.Unix()))
+			if err != nil {
+				log.Debugf("Graph stream refresh failed: %s", err)
+				if err := send(StreamEventError, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := send(StreamEventDelta, cytoscape.NewDelta(previous, current)); err != nil {
+				return err
+			}
+			previous = current
+		}
+	}
+}
+
+// refreshGraphNamespaces regenerates the graph of a stream, turning the panics of the graph generation into
+// an error, as the stream has already started and can't respond with an error status.
+func refreshGraphNamespaces(ctx context.Context, business *business.Layer, prom *prometheus.Client, o graph.Options) (config cytoscape.Config, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case graph.Response:
+				err = fmt.Errorf("%s", e.Message)
+			default:
+				err = fmt.Errorf("%v", e)
+			}
+		}
+	}()
+
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	_, vendorConfig := graphNamespacesIstio(ctx, business, prom, o)
+	return vendorConfig.(cytoscape.Config), nil
+}
+
+// GraphDependencies generates a namespaces graph using the provided options, and returns the transitive
+// callers and dependencies of the requested node
+func GraphDependencies(ctx context.Context, business *business.Layer, o graph.DependenciesOptions) (code int, dependencies interface{}) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GraphDependencies",
+		observability.Attribute("package", "api"),
+	)
+	defer end()
+
+	trafficMap := buildNamespacesTrafficMap(ctx, business, o.Options)
+	nodes := graph.SelectNodes(trafficMap, o.Node)
+	if len(nodes) == 0 {
+		graph.Panic(fmt.Sprintf("No node of the graph matches [%s]", o.Node), http.StatusNotFound)
+	}
+
+	return http.StatusOK, graph.FindDependencies(trafficMap, nodes, o.UpstreamDepth, o.DownstreamDepth)
+}
+
+// GraphPaths generates a namespaces graph using the provided options, and returns the ranked paths between
+// the requested source and destination nodes
+func GraphPaths(ctx context.Context, business *business.Layer, o graph.PathsOptions) (code int, paths interface{}) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GraphPaths",
+		observability.Attribute("package", "api"),
+	)
+	defer end()
+
+	trafficMap := buildNamespacesTrafficMap(ctx, business, o.Options)
+	sources := graph.SelectNodes(trafficMap, o.Source)
+	if len(sources) == 0 {
+		graph.Panic(fmt.Sprintf("No node of the graph matches source [%s]", o.Source), http.StatusNotFound)
+	}
+	destinations := graph.SelectNodes(trafficMap, o.Destination)
+	if len(destinations) == 0 {
+		graph.Panic(fmt.Sprintf("No node of the graph matches destination [%s]", o.Destination), http.StatusNotFound)
+	}
+
+	return http.StatusOK, graph.FindPaths(sources, destinations, o.MaxDepth, o.RankBy, o.Limit)
+}
+
+// GraphCost generates a namespaces graph using the provided options, with the cost of its edges, and returns the
+// costliest edges of each namespace
+func GraphCost(ctx context.Context, business *business.Layer, o graph.CostOptions) (code int, summary interface{}) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GraphCost",
+		observability.Attribute("package", "api"),
+	)
+	defer end()
+
+	o.Appenders = graph.RequestedAppenders{All: false, AppenderNames: []string{appender.CostAppenderName}}
+	trafficMap := buildNamespacesTrafficMap(ctx, business, o.Options)
+
+	return http.StatusOK, graph.SummarizeCost(trafficMap, o.Namespaces, o.Limit)
+}
+
+// buildNamespacesTrafficMap returns the traffic map of a namespaces graph, for the APIs querying the graph
+func buildNamespacesTrafficMap(ctx context.Context, business *business.Layer, o graph.Options) graph.TrafficMap {
+	// time how long it takes to generate this graph
+	promtimer := internalmetrics.GetGraphGenerationTimePrometheusTimer(o.GetGraphKind(), o.TelemetryOptions.GraphType, o.InjectServiceNodes)
+	defer promtimer.ObserveDuration()
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	switch o.TelemetryVendor {
+	case graph.VendorIstio:
+		prom, err := prometheus.NewClient()
+		graph.CheckError(err)
+		return graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+			return istio.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, prom, globalInfo)
+		})
+	case graph.VendorJaeger:
+		return graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+			return jaeger.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+		})
+	case graph.VendorTopology:
+		return graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+			return topology.BuildNamespacesTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+		})
+	default:
+		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
+	}
+	return nil
+}
+
 // GraphNode generates a node graph using the provided options
 func GraphNode(ctx context.Context, business *business.Layer, o graph.Options) (code int, config interface{}) {
 	if len(o.Namespaces) != 1 {
@@ -70,6 +328,10 @@ func GraphNode(ctx context.Context, business *business.Layer, o graph.Options) (
 		prom, err := prometheus.NewClient()
 		graph.CheckError(err)
 		code, config = graphNodeIstio(ctx, business, prom, o)
+	case graph.VendorJaeger:
+		code, config = graphNodeJaeger(ctx, business, o)
+	case graph.VendorTopology:
+		code, config = graphNodeTopology(ctx, business, o)
 	default:
 		graph.Error(fmt.Sprintf("TelemetryVendor [%s] not supported", o.TelemetryVendor))
 	}
@@ -87,7 +349,37 @@ func graphNodeIstio(ctx context.Context, business *business.Layer, client *prome
 	globalInfo.Business = business
 	globalInfo.Context = ctx
 
-	trafficMap := istio.BuildNodeTrafficMap(o.TelemetryOptions, client, globalInfo)
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return istio.BuildNodeTrafficMap(o.TelemetryOptions, client, globalInfo)
+	})
+	code, config = generateGraph(trafficMap, o)
+
+	return code, config
+}
+
+// graphNodeJaeger generates the node graph of the calls of the sampled traces, it does not query Prometheus
+func graphNodeJaeger(ctx context.Context, business *business.Layer, o graph.Options) (code int, config interface{}) {
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return jaeger.BuildNodeTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+	})
+	code, config = generateGraph(trafficMap, o)
+
+	return code, config
+}
+
+// graphNodeTopology generates the node graph of the configured routes, it does not query Prometheus
+func graphNodeTopology(ctx context.Context, business *business.Layer, o graph.Options) (code int, config interface{}) {
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = business
+	globalInfo.Context = ctx
+
+	trafficMap := graph.GetTrafficMapCache().GetOrBuild(o.TelemetryOptions, func() graph.TrafficMap {
+		return topology.BuildNodeTrafficMap(ctx, o.TelemetryOptions, globalInfo)
+	})
 	code, config = generateGraph(trafficMap, o)
 
 	return code, config
@@ -103,6 +395,10 @@ func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, interface
 	switch o.ConfigVendor {
 	case graph.VendorCytoscape:
 		vendorConfig = cytoscape.NewConfig(trafficMap, o.ConfigOptions)
+	case graph.VendorDOT:
+		vendorConfig = dot.NewConfig(trafficMap, o.ConfigOptions)
+	case graph.VendorGraphML:
+		vendorConfig = graphml.NewConfig(trafficMap, o.ConfigOptions)
 	default:
 		graph.Error(fmt.Sprintf("ConfigVendor [%s] not supported", o.ConfigVendor))
 	}
@@ -110,3 +406,161 @@ func generateGraph(trafficMap graph.TrafficMap, o graph.Options) (int, interface
 	log.Tracef("Done generating config for [%s] graph", o.ConfigVendor)
 	return http.StatusOK, vendorConfig
 }
+
+// GraphSnapshotSave generates the traffic map of a namespaces graph using the provided options, and saves it under
+// the requested name, with the options, for a later replay
+func GraphSnapshotSave(ctx context.Context, business *business.Layer, o graph.SnapshotOptions) (code int, info interface{}) {
+	store, err := snapshot.GetStore()
+	graph.CheckUnavailable(err)
+
+	trafficMap := buildNamespacesTrafficMap(ctx, business, o.Options)
+	trafficMapData, err := graph.EncodeTrafficMap(trafficMap)
+	graph.CheckError(err)
+
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for name := range o.Namespaces {
+		namespaces = append(namespaces, name)
+	}
+	sort.Strings(namespaces)
+
+	s := &snapshot.Snapshot{
+		Info: snapshot.Info{
+			BoxBy:      o.ConfigOptions.BoxBy,
+			Created:    time.Now(),
+			Duration:   o.TelemetryOptions.Duration.String(),
+			GraphType:  o.TelemetryOptions.GraphType,
+			Name:       o.Name,
+			Namespaces: namespaces,
+			Params:     o.TelemetryOptions.Params,
+			QueryTime:  o.TelemetryOptions.QueryTime,
+		},
+		TrafficMap: trafficMapData,
+	}
+	err = store.Save(s)
+	if errors.Is(err, snapshot.ErrExists) {
+		graph.Panic(fmt.Sprintf("Graph snapshot [%s] already exists", o.Name), http.StatusConflict)
+	}
+	graph.CheckError(err)
+
+	return http.StatusOK, s.Info
+}
+
+// GraphSnapshots returns the saved graph snapshots of the namespaces accessible to the user
+func GraphSnapshots(ctx context.Context, business *business.Layer) (code int, infos interface{}) {
+	store, err := snapshot.GetStore()
+	graph.CheckUnavailable(err)
+
+	all, err := store.List()
+	graph.CheckError(err)
+
+	accessible := getAccessibleNamespaceNames(ctx, business)
+	snapshots := []snapshot.Info{}
+	for _, info := range all {
+		if isSnapshotAccessible(info, accessible) {
+			snapshots = append(snapshots, info)
+		}
+	}
+
+	return http.StatusOK, snapshots
+}
+
+// GraphSnapshot renders the traffic map of a saved graph snapshot with the requested config vendor and boxing, by
+// default the boxing of the snapshot. When a baseline snapshot is requested, the graph is the diff of the baseline
+// snapshot and the snapshot.
+func GraphSnapshot(ctx context.Context, business *business.Layer, o graph.SnapshotReadOptions) (code int, config interface{}) {
+	store, err := snapshot.GetStore()
+	graph.CheckUnavailable(err)
+
+	return graphSnapshot(store, getAccessibleNamespaceNames(ctx, business), o)
+}
+
+// graphSnapshot provides a test hook that accepts a store and the accessible namespaces
+func graphSnapshot(store snapshot.Store, accessible map[string]bool, o graph.SnapshotReadOptions) (code int, config interface{}) {
+	s, trafficMap := getSnapshotTrafficMap(store, o.Name, accessible)
+	if o.Baseline != "" {
+		_, baselineMap := getSnapshotTrafficMap(store, o.Baseline, accessible)
+		trafficMap = graph.DiffTrafficMaps(baselineMap, trafficMap, o.DiffThreshold)
+	}
+
+	boxBy := o.BoxBy
+	if boxBy == "" {
+		boxBy = s.BoxBy
+	}
+	// the label values of the nodes are set for the label boxing of the snapshot only
+	boxByLabel, _ := graph.ParseBoxBy(boxBy)
+	if snapshotBoxByLabel, _ := graph.ParseBoxBy(s.BoxBy); boxByLabel != "" && boxByLabel != snapshotBoxByLabel {
+		graph.BadRequest(fmt.Sprintf("Invalid boxBy [%s]. Graph snapshot [%s] can only be boxed by the label of its boxBy [%s].", boxBy, o.Name, s.BoxBy))
+	}
+	duration, err := time.ParseDuration(s.Duration)
+	graph.CheckError(err)
+
+	code, config = generateGraph(trafficMap, graph.Options{
+		ConfigVendor: o.ConfigVendor,
+		ConfigOptions: graph.ConfigOptions{
+			BoxBy: boxBy,
+			CommonOptions: graph.CommonOptions{
+				BoxByLabel: boxByLabel,
+				Duration:   duration,
+				GraphType:  s.GraphType,
+				Params:     s.Params,
+				QueryTime:  s.QueryTime,
+			},
+		},
+	})
+	return code, config
+}
+
+// GraphSnapshotDelete deletes a saved graph snapshot, all its namespaces must be accessible to the user
+func GraphSnapshotDelete(ctx context.Context, business *business.Layer, name string) (code int, info interface{}) {
+	store, err := snapshot.GetStore()
+	graph.CheckUnavailable(err)
+
+	s, _ := getSnapshotTrafficMap(store, name, getAccessibleNamespaceNames(ctx, business))
+	err = store.Delete(name)
+	if errors.Is(err, snapshot.ErrNotFound) {
+		graph.Panic(fmt.Sprintf("Graph snapshot [%s] not found", name), http.StatusNotFound)
+	}
+	graph.CheckError(err)
+
+	return http.StatusOK, s.Info
+}
+
+// getSnapshotTrafficMap returns a saved graph snapshot and its decoded traffic map, the namespaces of the snapshot
+// must be accessible
+func getSnapshotTrafficMap(store snapshot.Store, name string, accessible map[string]bool) (*snapshot.Snapshot, graph.TrafficMap) {
+	s, err := store.Get(name)
+	if errors.Is(err, snapshot.ErrNotFound) {
+		graph.Panic(fmt.Sprintf("Graph snapshot [%s] not found", name), http.StatusNotFound)
+	}
+	graph.CheckError(err)
+
+	if !isSnapshotAccessible(s.Info, accessible) {
+		graph.Forbidden(fmt.Sprintf("Graph snapshot [%s] includes namespaces not accessible to the user", name))
+	}
+
+	trafficMap, err := graph.DecodeTrafficMap(s.TrafficMap)
+	graph.CheckError(err)
+	return s, trafficMap
+}
+
+// getAccessibleNamespaceNames returns the names of the namespaces accessible to the user
+func getAccessibleNamespaceNames(ctx context.Context, business *business.Layer) map[string]bool {
+	namespaces, err := business.Namespace.GetNamespaces(ctx)
+	graph.CheckError(err)
+
+	names := make(map[string]bool, len(namespaces))
+	for _, ns := range namespaces {
+		names[ns.Name] = true
+	}
+	return names
+}
+
+// isSnapshotAccessible returns true when all the namespaces of the snapshot are accessible
+func isSnapshotAccessible(info snapshot.Info, accessible map[string]bool) bool {
+	for _, ns := range info.Namespaces {
+		if !accessible[ns] {
+			return false
+		}
+	}
+	return true
+}
@@ -11,12 +11,14 @@ import (
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	osproject_v1 "github.com/openshift/api/project/v1"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	core_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd/api"
@@ -25,6 +27,9 @@ import (
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/graph/config/dot"
+	"github.com/kiali/kiali/graph/snapshot"
 	"github.com/kiali/kiali/kubernetes/kubetest"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
@@ -3448,3 +3453,162 @@ func TestMultiClusterSourceGraph(t *testing.T) {
 	}
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestGraphStream(t *testing.T) {
+	client, _, err := mockNamespaceGraph(t)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	type event struct {
+		name    string
+		payload interface{}
+	}
+	events := []event{}
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/graph/stream", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			authContext := authentication.SetAuthInfoContext(r.Context(), &api.AuthInfo{Token: "test"})
+			ctx, cancel := context.WithCancel(authContext)
+			defer cancel()
+			o := graph.StreamOptions{RefreshInterval: 10 * time.Second, Options: graph.NewOptions(r.WithContext(authContext))}
+
+			ticks := make(chan time.Time, 2)
+			ticks <- time.Unix(1523364085, 0)
+			ticks <- time.Unix(1523364095, 0)
+			send := func(name string, payload interface{}) error {
+				events = append(events, event{name: name, payload: payload})
+				if len(events) == 3 {
+					cancel()
+				}
+				return nil
+			}
+			err := graphNamespacesStreamIstio(ctx, nil, client, o, ticks, send)
+			assert.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	ts := httptest.NewServer(mr)
+	defer ts.Close()
+
+	url := ts.URL + "/api/namespaces/graph/stream?namespaces=bookinfo&graphType=app&appenders&queryTime=1523364075"
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 200, resp.StatusCode)
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, StreamEventGraph, events[0].name)
+	config := events[0].payload.(cytoscape.Config)
+	assert.Equal(t, int64(1523364075), config.Timestamp)
+	assert.NotEmpty(t, config.Elements.Nodes)
+
+	// the telemetry is the same for every refresh, only the time window moves
+	for i, queryTime := range []int64{1523364085, 1523364095} {
+		assert.Equal(t, StreamEventDelta, events[i+1].name)
+		delta := events[i+1].payload.(cytoscape.Delta)
+		assert.Equal(t, queryTime, delta.Timestamp)
+		assert.Equal(t, config.Duration, delta.Duration)
+		assert.True(t, delta.IsEmpty())
+	}
+}
+
+func saveTestSnapshot(t *testing.T, store snapshot.Store, name string, versions ...string) {
+	addEdge := func(source, dest *graph.Node) {
+		e := source.AddEdge(dest)
+		e.Metadata[graph.ProtocolKey] = graph.HTTP.Name
+		e.Metadata[graph.HTTP.EdgeResponses] = graph.Responses{}
+	}
+
+	trafficMap := graph.NewTrafficMap()
+	ingress := graph.NewNode("east", "istio-system", "", "istio-system", "istio-ingressgateway", "istio-ingressgateway", "latest", graph.GraphTypeVersionedApp)
+	productpage := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	trafficMap[ingress.ID] = &ingress
+	trafficMap[productpage.ID] = &productpage
+	addEdge(&ingress, &productpage)
+	for _, version := range versions {
+		reviews := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-"+version, "reviews", version, graph.GraphTypeVersionedApp)
+		trafficMap[reviews.ID] = &reviews
+		addEdge(&productpage, &reviews)
+	}
+
+	data, err := graph.EncodeTrafficMap(trafficMap)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&snapshot.Snapshot{
+		Info: snapshot.Info{
+			BoxBy:      graph.BoxByNamespace,
+			Duration:   "10m0s",
+			GraphType:  graph.GraphTypeVersionedApp,
+			Name:       name,
+			Namespaces: []string{"bookinfo"},
+			QueryTime:  1523364075,
+		},
+		TrafficMap: data,
+	}))
+}
+
+func TestGraphSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	store, err := snapshot.NewFileStore(t.TempDir())
+	require.NoError(err)
+	saveTestSnapshot(t, store, "baseline", "v1")
+	saveTestSnapshot(t, store, "incident", "v1", "v2")
+	accessible := map[string]bool{"bookinfo": true}
+
+	countNamespaceBoxes := func(config cytoscape.Config) int {
+		boxes := 0
+		for _, n := range config.Elements.Nodes {
+			if n.Data.IsBox == graph.BoxByNamespace {
+				boxes++
+			}
+		}
+		return boxes
+	}
+
+	// by default, the snapshot is rendered with its boxing
+	code, config := graphSnapshot(store, accessible, graph.SnapshotReadOptions{ConfigVendor: graph.VendorCytoscape, Name: "incident"})
+	assert.Equal(http.StatusOK, code)
+	cyConfig := config.(cytoscape.Config)
+	assert.Equal(int64(1523364075), cyConfig.Timestamp)
+	assert.Equal(int64(600), cyConfig.Duration)
+	assert.Len(cyConfig.Elements.Nodes, 6) // with the reviews app box and the bookinfo namespace box
+	assert.Equal(1, countNamespaceBoxes(cyConfig))
+	assert.Len(cyConfig.Elements.Edges, 3)
+
+	// re-boxed
+	_, config = graphSnapshot(store, accessible, graph.SnapshotReadOptions{BoxBy: graph.BoxByNone, ConfigVendor: graph.VendorCytoscape, Name: "incident"})
+	assert.Zero(countNamespaceBoxes(config.(cytoscape.Config)))
+
+	// re-rendered by another config vendor
+	_, config = graphSnapshot(store, accessible, graph.SnapshotReadOptions{ConfigVendor: graph.VendorDOT, Name: "incident"})
+	assert.Contains(string(config.(dot.Config)), "digraph")
+
+	// diffed with a baseline snapshot
+	_, config = graphSnapshot(store, accessible, graph.SnapshotReadOptions{Baseline: "baseline", BoxBy: graph.BoxByNone, ConfigVendor: graph.VendorCytoscape, Name: "incident"})
+	statuses := map[string]string{}
+	for _, n := range config.(cytoscape.Config).Elements.Nodes {
+		if n.Data.IsBox == "" {
+			statuses[n.Data.Workload] = n.Data.Diff.Status
+		}
+	}
+	assert.Equal(map[string]string{
+		"istio-ingressgateway": graph.DiffUnchanged,
+		"productpage-v1":       graph.DiffChanged, // an edge was added
+		"reviews-v1":           graph.DiffUnchanged,
+		"reviews-v2":           graph.DiffAdded,
+	}, statuses)
+
+	// the snapshot holds the label values of its label boxing only
+	assert.Panics(func() {
+		graphSnapshot(store, accessible, graph.SnapshotReadOptions{BoxBy: "label:team", ConfigVendor: graph.VendorCytoscape, Name: "incident"})
+	})
+	// the namespaces of the snapshot must be accessible
+	assert.Panics(func() {
+		graphSnapshot(store, map[string]bool{}, graph.SnapshotReadOptions{ConfigVendor: graph.VendorCytoscape, Name: "incident"})
+	})
+}
@@ -0,0 +1,250 @@
+package graph
+
+// Cache.go holds the cache of the generated traffic maps, shared by the identical graph requests.
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// Rough sizes used to estimate the memory held by a traffic map
+const (
+	estimatedEdgeSize     int64 = 256
+	estimatedMetadataSize int64 = 64
+	estimatedNodeSize     int64 = 512
+)
+
+// The query params normalized by the cache key, or not used to generate the traffic map. The other params
+// are vendor-specific and are added to the key as is.
+var cacheKeyIgnoredParams = map[string]bool{
+	"appenders":          true,
+	"boxBy":              true,
+	"cluster":            true,
+	"configVendor":       true,
+	"duration":           true,
+	"graphType":          true,
+	"hide":               true,
+	"includeIdleEdges":   true,
+	"injectServiceNodes": true,
+	"namespaces":         true,
+	"queryTime":          true,
+	"rateGrpc":           true,
+	"rateHttp":           true,
+	"rateTcp":            true,
+	"refreshInterval":    true,
+}
+
+var (
+	trafficMapCache     *TrafficMapCache
+	trafficMapCacheOnce sync.Once
+)
+
+type cacheEntry struct {
+	expiration time.Time
+	key        string
+	size       int64
+	trafficMap TrafficMap
+}
+
+// TrafficMapCache caches the traffic maps generated for the same telemetry options, so that identical graph
+// requests, usually the same graph refreshed by many users, query Prometheus and run the appenders once per
+// time bucket. The query time of the requests is bucketed to their refresh interval, the refreshInterval query
+// param or else 10s, or to the TTL when it is shorter. The traffic maps are shared by the requests and must not
+// be modified once cached.
+type TrafficMapCache struct {
+	entries map[string]*list.Element
+	lock    sync.Mutex
+	maxSize int64
+	order   *list.List // oldest entry first, as every entry has the same TTL it is also the first to expire
+	size    int64
+	ttl     time.Duration
+}
+
+// NewTrafficMapCache returns a cache holding the traffic maps for ttl, up to an estimated maxSize in bytes
+func NewTrafficMapCache(ttl time.Duration, maxSize int64) *TrafficMapCache {
+	return &TrafficMapCache{
+		entries: make(map[string]*list.Element),
+		maxSize: maxSize,
+		order:   list.New(),
+		ttl:     ttl,
+	}
+}
+
+// GetTrafficMapCache returns the cache configured by the graph API config, nil when it is disabled
+func GetTrafficMapCache() *TrafficMapCache {
+	trafficMapCacheOnce.Do(func() {
+		cacheConfig := config.Get().API.Graph.Cache
+		if !cacheConfig.Enabled || cacheConfig.TTL <= 0 || cacheConfig.MaxMemory <= 0 {
+			log.Debugf("Graph cache is disabled")
+			return
+		}
+		trafficMapCache = NewTrafficMapCache(time.Duration(cacheConfig.TTL)*time.Second, int64(cacheConfig.MaxMemory)*1024*1024)
+	})
+	return trafficMapCache
+}
+
+// GetOrBuild returns the cached traffic map of the options, or builds and caches it. A nil cache always builds
+// the traffic map.
+func (c *TrafficMapCache) GetOrBuild(o TelemetryOptions, build func() TrafficMap) TrafficMap {
+	if c == nil {
+		return build()
+	}
+
+	key := c.Key(o)
+	if trafficMap, ok := c.get(key); ok {
+		internalmetrics.CountGraphCacheRequest(o.GetGraphKind(), true)
+		return trafficMap
+	}
+	internalmetrics.CountGraphCacheRequest(o.GetGraphKind(), false)
+
+	trafficMap := build()
+	c.put(key, trafficMap)
+	return trafficMap
+}
+
+// Key returns the normalized options, identical for the requests sharing a traffic map. It includes the
+// accessible namespaces, as they change the generated traffic map.
+func (c *TrafficMapCache) Key(o TelemetryOptions) string {
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for name := range o.Namespaces {
+		namespaces = append(namespaces, name)
+	}
+	sort.Strings(namespaces)
+
+	accessibleNamespaces := make([]string, 0, len(o.AccessibleNamespaces))
+	for name := range o.AccessibleNamespaces {
+		accessibleNamespaces = append(accessibleNamespaces, name)
+	}
+	sort.Strings(accessibleNamespaces)
+
+	appenders := "all"
+	if !o.Appenders.All {
+		names := append([]string{}, o.Appenders.AppenderNames...)
+		sort.Strings(names)
+		appenders = strings.Join(names, ",")
+	}
+
+	params := []string{}
+	for name, values := range o.Params {
+		if !cacheKeyIgnoredParams[name] {
+			params = append(params, fmt.Sprintf("%s=%s", name, strings.Join(values, ",")))
+		}
+	}
+	sort.Strings(params)
+
+	bucket := o.QueryTime
+	if bucketSeconds := int64(c.bucketDuration(o).Seconds()); bucketSeconds > 0 {
+		bucket -= bucket % bucketSeconds
+	}
+
+	return strings.Join([]string{
+		o.GetGraphKind(),
+		o.GraphType,
+		o.Duration.String(),
+		fmt.Sprintf("%d", bucket),
+		strings.Join(namespaces, ","),
+		appenders,
+		fmt.Sprintf("%s,%s,%s", o.Rates.Grpc, o.Rates.Http, o.Rates.Tcp),
+		fmt.Sprintf("%t,%t", o.IncludeIdleEdges, o.InjectServiceNodes),
+		strings.TrimSpace(o.Params.Get("hide")),
+		o.BoxByLabel,
+		fmt.Sprintf("%+v", o.NodeOptions),
+		strings.Join(params, "&"),
+		strings.Join(accessibleNamespaces, ","),
+	}, "|")
+}
+
+// bucketDuration returns the duration the query time is bucketed to: the refresh interval of the request, or
+// the TTL when it is shorter
+func (c *TrafficMapCache) bucketDuration(o TelemetryOptions) time.Duration {
+	refreshIntervalString := o.Params.Get("refreshInterval")
+	if refreshIntervalString == "" {
+		refreshIntervalString = defaultRefreshInterval
+	}
+	refreshInterval, err := model.ParseDuration(refreshIntervalString)
+	if err != nil || time.Duration(refreshInterval) <= 0 || time.Duration(refreshInterval) > c.ttl {
+		return c.ttl
+	}
+	return time.Duration(refreshInterval)
+}
+
+func (c *TrafficMapCache) get(key string) (TrafficMap, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if time.Now().After(entry.expiration) {
+		c.remove(element)
+		return nil, false
+	}
+	return entry.trafficMap, true
+}
+
+func (c *TrafficMapCache) put(key string, trafficMap TrafficMap) {
+	size := estimateSize(trafficMap)
+	if size > c.maxSize {
+		log.Debugf("Graph of estimated size [%d] exceeds the graph cache size, it is not cached", size)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// the same graph may have been built by concurrent requests
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	now := time.Now()
+	for element := c.order.Front(); element != nil; element = c.order.Front() {
+		entry := element.Value.(*cacheEntry)
+		if now.Before(entry.expiration) && c.size+size <= c.maxSize {
+			break
+		}
+		c.remove(element)
+	}
+
+	entry := &cacheEntry{
+		expiration: now.Add(c.ttl),
+		key:        key,
+		size:       size,
+		trafficMap: trafficMap,
+	}
+	c.entries[key] = c.order.PushBack(entry)
+	c.size += size
+	internalmetrics.SetGraphCacheSize(c.size)
+}
+
+// remove must be called with the lock held
+func (c *TrafficMapCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+	internalmetrics.SetGraphCacheSize(c.size)
+}
+
+// estimateSize returns a rough size in bytes of the memory held by the traffic map
+func estimateSize(trafficMap TrafficMap) int64 {
+	size := int64(0)
+	for id, n := range trafficMap {
+		size += estimatedNodeSize + int64(len(id)) + int64(len(n.Metadata))*estimatedMetadataSize
+		for _, e := range n.Edges {
+			size += estimatedEdgeSize + int64(len(e.Metadata))*estimatedMetadataSize
+		}
+	}
+	return size
+}
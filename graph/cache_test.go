@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cacheTestOptions(queryTime int64, namespaces ...string) TelemetryOptions {
+	o := TelemetryOptions{
+		AccessibleNamespaces: map[string]time.Time{},
+		Appenders:            RequestedAppenders{All: true},
+		Namespaces:           NewNamespaceInfoMap(),
+		Rates:                RequestedRates{Grpc: RateRequests, Http: RateRequests, Tcp: RateSent},
+		CommonOptions: CommonOptions{
+			Duration:  10 * time.Minute,
+			GraphType: GraphTypeVersionedApp,
+			Params:    url.Values{},
+			QueryTime: queryTime,
+		},
+	}
+	for _, ns := range namespaces {
+		o.Namespaces[ns] = NamespaceInfo{Name: ns, Duration: o.Duration}
+		o.AccessibleNamespaces[ns] = time.Time{}
+	}
+	return o
+}
+
+func cacheTestTraffic(apps ...string) TrafficMap {
+	trafficMap := NewTrafficMap()
+	for _, app := range apps {
+		n := NewNode("east", "bookinfo", "", "bookinfo", app+"-v1", app, "v1", GraphTypeVersionedApp)
+		trafficMap[n.ID] = &n
+	}
+	return trafficMap
+}
+
+func TestTrafficMapCacheKey(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewTrafficMapCache(10*time.Second, 1024*1024)
+	key := c.Key(cacheTestOptions(1000, "bookinfo", "travel"))
+
+	// the namespace order, the query time within the bucket and the config options don't matter
+	o := cacheTestOptions(1009, "travel", "bookinfo")
+	o.Params.Set("boxBy", "app")
+	o.Params.Set("configVendor", VendorDOT)
+	assert.Equal(key, c.Key(o))
+
+	o = cacheTestOptions(1000, "bookinfo", "travel")
+	o.Appenders = RequestedAppenders{AppenderNames: []string{"responseTime", "deadNode"}}
+	appendersKey := c.Key(o)
+	o.Appenders = RequestedAppenders{AppenderNames: []string{"deadNode", "responseTime"}}
+	assert.Equal(appendersKey, c.Key(o))
+	assert.NotEqual(key, appendersKey)
+
+	different := []func(o *TelemetryOptions){
+		func(o *TelemetryOptions) { o.QueryTime = 1010 },
+		func(o *TelemetryOptions) { o.Duration = time.Minute },
+		func(o *TelemetryOptions) { o.GraphType = GraphTypeWorkload },
+		func(o *TelemetryOptions) { o.Rates.Tcp = RateTotal },
+		func(o *TelemetryOptions) { o.InjectServiceNodes = true },
+		func(o *TelemetryOptions) { o.Params.Set("hide", "rt > 1000") },
+		func(o *TelemetryOptions) { o.Params.Set("responseTime", "99") },
+		func(o *TelemetryOptions) { o.NodeOptions.App = "reviews" },
+		func(o *TelemetryOptions) { o.AccessibleNamespaces["istio-system"] = time.Time{} },
+	}
+	for i, change := range different {
+		o := cacheTestOptions(1000, "bookinfo", "travel")
+		change(&o)
+		assert.NotEqual(key, c.Key(o), "change %d", i)
+	}
+}
+
+func TestTrafficMapCacheKeyBucket(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewTrafficMapCache(time.Minute, 1024*1024)
+	keyAt := func(queryTime int64, refreshInterval string) string {
+		o := cacheTestOptions(queryTime, "bookinfo")
+		if refreshInterval != "" {
+			o.Params.Set("refreshInterval", refreshInterval)
+		}
+		return c.Key(o)
+	}
+
+	// bucketed to the refresh interval, 10s by default, shorter than the TTL
+	assert.Equal(keyAt(1000, ""), keyAt(1009, ""))
+	assert.NotEqual(keyAt(1000, ""), keyAt(1010, ""))
+	assert.Equal(keyAt(1000, "15s"), keyAt(1004, "15s"))
+	assert.NotEqual(keyAt(1004, "15s"), keyAt(1005, "15s"))
+
+	// bucketed to the TTL when it is shorter than the refresh interval
+	assert.Equal(keyAt(1020, "5m"), keyAt(1079, "5m"))
+	assert.NotEqual(keyAt(1079, "5m"), keyAt(1080, "5m"))
+}
+
+func TestTrafficMapCacheGetOrBuild(t *testing.T) {
+	assert := assert.New(t)
+
+	builds := 0
+	build := func() TrafficMap {
+		builds++
+		return cacheTestTraffic("productpage", "reviews")
+	}
+
+	c := NewTrafficMapCache(10*time.Second, 1024*1024)
+	first := c.GetOrBuild(cacheTestOptions(1000, "bookinfo"), build)
+	second := c.GetOrBuild(cacheTestOptions(1005, "bookinfo"), build)
+	assert.Equal(1, builds)
+	assert.Equal(first, second)
+
+	c.GetOrBuild(cacheTestOptions(1010, "bookinfo"), build)
+	assert.Equal(2, builds)
+
+	// an expired graph is built again
+	for _, element := range c.entries {
+		element.Value.(*cacheEntry).expiration = time.Now().Add(-time.Second)
+	}
+	c.GetOrBuild(cacheTestOptions(1000, "bookinfo"), build)
+	assert.Equal(3, builds)
+	assert.Len(c.entries, 1)
+	assert.Equal(estimateSize(first), c.size)
+
+	// a nil cache always builds
+	var disabled *TrafficMapCache
+	disabled.GetOrBuild(cacheTestOptions(1000, "bookinfo"), build)
+	assert.Equal(4, builds)
+}
+
+func TestTrafficMapCacheEviction(t *testing.T) {
+	assert := assert.New(t)
+
+	trafficMap := cacheTestTraffic("productpage", "reviews")
+	size := estimateSize(trafficMap)
+	c := NewTrafficMapCache(10*time.Second, 2*size)
+
+	build := func() TrafficMap { return trafficMap }
+	c.GetOrBuild(cacheTestOptions(1000, "bookinfo"), build)
+	c.GetOrBuild(cacheTestOptions(1000, "travel"), build)
+	assert.Len(c.entries, 2)
+
+	// the oldest graph is evicted to make room
+	c.GetOrBuild(cacheTestOptions(1000, "default"), build)
+	assert.Len(c.entries, 2)
+	assert.Equal(2*size, c.size)
+	_, ok := c.get(c.Key(cacheTestOptions(1000, "bookinfo")))
+	assert.False(ok)
+	_, ok = c.get(c.Key(cacheTestOptions(1000, "travel")))
+	assert.True(ok)
+
+	// a graph larger than the cache is not cached
+	c.GetOrBuild(cacheTestOptions(1000, "large"), func() TrafficMap {
+		return cacheTestTraffic("productpage", "reviews", "ratings", "details", "mongodb")
+	})
+	assert.Len(c.entries, 2)
+}
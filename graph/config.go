@@ -8,3 +8,10 @@ type ConfigVendor interface {
 	// definitions for error handling. Refer to the Cytoscape implementation as an example.
 	NewConfig(trafficMap TrafficMap, o ConfigOptions) interface{}
 }
+
+// EncodedConfig is implemented by a Config already encoded in a format other than JSON. It is
+// returned to the caller as is, with its content type.
+type EncodedConfig interface {
+	ContentType() string
+	Bytes() []byte
+}
@@ -19,6 +19,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/kiali/kiali/graph"
@@ -50,6 +51,28 @@ type ResponseDetail struct {
 // Responses maps responseCodes to detailed information for that code
 type Responses map[string]*ResponseDetail
 
+// Summary returns the percentage of traffic of each response code, the sum of the percentages of its flags,
+// e.g. "200:80.0 503:20.0"
+func (r Responses) Summary() string {
+	codes := make([]string, 0, len(r))
+	for code := range r {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	summary := make([]string, 0, len(codes))
+	for _, code := range codes {
+		total := 0.0
+		for _, value := range r[code].Flags {
+			if pct, err := strconv.ParseFloat(value, 64); err == nil {
+				total += pct
+			}
+		}
+		summary = append(summary, fmt.Sprintf("%s:%.1f", code, total))
+	}
+	return strings.Join(summary, " ")
+}
+
 // ProtocolTraffic supplies all of the traffic information for a single protocol
 type ProtocolTraffic struct {
 	Protocol  string            `json:"protocol,omitempty"`  // protocol
@@ -79,6 +102,44 @@ type VSInfo struct {
 	Hostnames []string `json:"hostnames,omitempty"`
 }
 
+// DiffInfo describes how a node or edge of a diff graph changed from the baseline graph
+type DiffInfo struct {
+	Status       string `json:"status"`                 // added | changed | removed | unchanged
+	Rate         string `json:"rate,omitempty"`         // delta of the total rate, edges only
+	PercentErr   string `json:"percentErr,omitempty"`   // delta of the error percentage in percentage points, edges only
+	ResponseTime string `json:"responseTime,omitempty"` // delta of the response time in millis, edges only
+}
+
+// AnomalyInfo reports the metrics of a node or edge deviating from their baseline, the same window one day and one
+// week earlier
+type AnomalyInfo struct {
+	ErrorRate    *AnomalyDeltaInfo `json:"errorRate,omitempty"`    // in percentage points
+	RequestRate  *AnomalyDeltaInfo `json:"requestRate,omitempty"`  // in requests per second
+	ResponseTime *AnomalyDeltaInfo `json:"responseTime,omitempty"` // in millis
+}
+
+// AnomalyDeltaInfo is the deviation of a metric from its baseline
+type AnomalyDeltaInfo struct {
+	Delta  string `json:"delta"`  // the current value minus the baseline mean
+	ZScore string `json:"zScore"` // the delta in standard deviations of the baseline
+}
+
+// CostInfo reports the bytes transferred by an edge, the widest locality of its traffic and their estimated cost
+type CostInfo struct {
+	BytesPerSec string `json:"bytesPerSec"`
+	CostPerHour string `json:"costPerHour,omitempty"` // unset when no rate is configured for the localities
+	Locality    string `json:"locality"`              // intraZone | crossZone | crossCluster | unknown
+}
+
+// TracesInfo reports the calls of an edge found in the sampled traces of a jaeger graph
+type TracesInfo struct {
+	Calls  string `json:"calls"`  // sampled calls
+	Errors string `json:"errors"` // sampled calls in error
+	P50    string `json:"p50"`    // in millis
+	P95    string `json:"p95"`    // in millis
+	P99    string `json:"p99"`    // in millis
+}
+
 // HealthConfig maps annotations information for health
 type HealthConfig map[string]string
 
@@ -96,11 +157,15 @@ type NodeData struct {
 	Version               string              `json:"version,omitempty"`
 	Service               string              `json:"service,omitempty"`               // requested service for NodeTypeService
 	Aggregate             string              `json:"aggregate,omitempty"`             // set like "<aggregate>=<aggregateVal>"
+	Anomaly               *AnomalyInfo        `json:"anomaly,omitempty"`               // largest deviations of the incoming edges
+	BurnRate              string              `json:"burnRate,omitempty"`              // error budget burn rate of the SLO
 	DestServices          []graph.ServiceName `json:"destServices,omitempty"`          // requested services for [dest] node
+	Diff                  *DiffInfo           `json:"diff,omitempty"`                  // set for diff graphs
 	Labels                map[string]string   `json:"labels,omitempty"`                // k8s labels associated with the node
 	Traffic               []ProtocolTraffic   `json:"traffic,omitempty"`               // traffic rates for all detected protocols
 	HealthData            interface{}         `json:"healthData"`                      // data to calculate health status from configurations
 	HealthDataApp         interface{}         `json:"-"`                               // for local use to generate appBox health
+	BoxLabel              string              `json:"-"`                               // for local use to generate label boxes, the value of the boxBy label
 	HasCB                 bool                `json:"hasCB,omitempty"`                 // true (has circuit breaker) | false
 	HasFaultInjection     bool                `json:"hasFaultInjection,omitempty"`     // true (vs has fault injection) | false
 	HasHealthConfig       HealthConfig        `json:"hasHealthConfig,omitempty"`       // set to the health config override
@@ -112,6 +177,7 @@ type NodeData struct {
 	HasTrafficShifting    bool                `json:"hasTrafficShifting,omitempty"`    // true (vs has traffic shifting) | false
 	HasVS                 *VSInfo             `json:"hasVS,omitempty"`                 // it can be empty if there is a VS without hostnames
 	HasWorkloadEntry      []graph.WEInfo      `json:"hasWorkloadEntry,omitempty"`      // static workload entry information | empty if there are no workload entries
+	IsAmbient             bool                `json:"isAmbient,omitempty"`             // true (captured by the ambient mesh) | false
 	IsBox                 string              `json:"isBox,omitempty"`                 // set for NodeTypeBox, current values: [ 'app', 'cluster', 'namespace' ]
 	IsDead                bool                `json:"isDead,omitempty"`                // true (has no pods) | false
 	IsGateway             *GWInfo             `json:"isGateway,omitempty"`             // Istio ingress/egress gateway information
@@ -120,6 +186,40 @@ type NodeData struct {
 	IsOutside             bool                `json:"isOutside,omitempty"`             // true | false
 	IsRoot                bool                `json:"isRoot,omitempty"`                // true | false
 	IsServiceEntry        *graph.SEInfo       `json:"isServiceEntry,omitempty"`        // set static service entry information
+	SLOStatus             string              `json:"sloStatus,omitempty"`             // met | atRisk | violated
+}
+
+// Label returns a human readable name for the node, for the config vendors rendering a document
+func (nd *NodeData) Label() string {
+	switch nd.NodeType {
+	case graph.NodeTypeBox:
+		switch nd.IsBox {
+		case graph.BoxByApp:
+			return nd.App
+		case graph.BoxByCluster:
+			return nd.Cluster
+		case graph.BoxByLabel:
+			for k, v := range nd.Labels {
+				return fmt.Sprintf("%s=%s", k, v)
+			}
+			return ""
+		default:
+			return nd.Namespace
+		}
+	case graph.NodeTypeAggregate:
+		return nd.Aggregate
+	case graph.NodeTypeApp:
+		if nd.Version != "" {
+			return fmt.Sprintf("%s\n%s", nd.App, nd.Version)
+		}
+		return nd.App
+	case graph.NodeTypeService:
+		return nd.Service
+	case graph.NodeTypeWorkload:
+		return nd.Workload
+	default:
+		return nd.NodeType
+	}
 }
 
 type EdgeData struct {
@@ -129,11 +229,18 @@ type EdgeData struct {
 	Target string `json:"target"` // child node ID
 
 	// App Fields (not required by Cytoscape)
+	Anomaly         *AnomalyInfo    `json:"anomaly,omitempty"`         // metrics deviating from their baseline
+	BurnRate        string          `json:"burnRate,omitempty"`        // error budget burn rate of the destination SLO
+	ConfiguredBy    []string        `json:"configuredBy,omitempty"`    // topology graphs: the kinds of config defining the route
+	Cost            *CostInfo       `json:"cost,omitempty"`            // bytes transferred, their locality and cost
 	DestPrincipal   string          `json:"destPrincipal,omitempty"`   // principal used for the edge destination
+	Diff            *DiffInfo       `json:"diff,omitempty"`            // set for diff graphs
 	IsMTLS          string          `json:"isMTLS,omitempty"`          // set to the percentage of traffic using a mutual TLS connection
 	ResponseTime    string          `json:"responseTime,omitempty"`    // in millis
+	SLOStatus       string          `json:"sloStatus,omitempty"`       // met | atRisk | violated
 	SourcePrincipal string          `json:"sourcePrincipal,omitempty"` // principal used for the edge source
 	Throughput      string          `json:"throughput,omitempty"`      // in bytes/sec (request or response, depends on client request)
+	Traces          *TracesInfo     `json:"traces,omitempty"`          // set for jaeger graphs
 	Traffic         ProtocolTraffic `json:"traffic,omitempty"`         // traffic rates for the edge protocol
 }
 
@@ -173,13 +280,16 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 	buildConfig(trafficMap, &nodes, &edges, o)
 
 	// Add compound nodes as needed, inner boxes first
-	if strings.Contains(o.BoxBy, graph.BoxByApp) || o.GraphType == graph.GraphTypeApp || o.GraphType == graph.GraphTypeVersionedApp {
+	if o.HasBoxBy(graph.BoxByApp) || o.GraphType == graph.GraphTypeApp || o.GraphType == graph.GraphTypeVersionedApp {
 		boxByApp(&nodes)
 	}
-	if strings.Contains(o.BoxBy, graph.BoxByNamespace) {
+	if o.HasBoxBy(graph.BoxByLabel) {
+		boxByLabel(&nodes, o.BoxByLabel, o.HasBoxBy(graph.BoxByNamespace))
+	}
+	if o.HasBoxBy(graph.BoxByNamespace) {
 		boxByNamespace(&nodes)
 	}
-	if strings.Contains(o.BoxBy, graph.BoxByCluster) {
+	if o.HasBoxBy(graph.BoxByCluster) {
 		boxByCluster(&nodes)
 	}
 
@@ -194,10 +304,12 @@ func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) (result Confi
 					return 0
 				case graph.BoxByNamespace:
 					return 1
-				case graph.BoxByApp:
+				case graph.BoxByLabel:
 					return 2
-				default:
+				case graph.BoxByApp:
 					return 3
+				default:
+					return 4
 				}
 			}
 			return rank(nodes[i].Data.IsBox) < rank(nodes[j].Data.IsBox)
@@ -260,6 +372,9 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 		if val, ok := n.Metadata[graph.HealthDataApp]; ok {
 			nd.HealthDataApp = val
 		}
+		if val, ok := n.Metadata[graph.BoxLabel]; ok {
+			nd.BoxLabel = val.(string)
+		}
 
 		// set k8s labels, if any
 		if val, ok := n.Metadata[graph.Labels]; ok {
@@ -344,6 +459,11 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			nd.HasMissingSC = val.(bool)
 		}
 
+		// set ambient mesh capture, if available
+		if val, ok := n.Metadata[graph.IsAmbient]; ok {
+			nd.IsAmbient = val.(bool)
+		}
+
 		// check if node is on another namespace
 		if val, ok := n.Metadata[graph.IsOutside]; ok {
 			nd.IsOutside = val.(bool)
@@ -394,6 +514,24 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 			}
 		}
 
+		// node may have an SLO status
+		if val, ok := n.Metadata[graph.SLOStatus]; ok {
+			nd.SLOStatus = val.(string)
+		}
+		if val, ok := n.Metadata[graph.BurnRate]; ok {
+			nd.BurnRate = fmt.Sprintf("%.2f", val.(float64))
+		}
+
+		// node may receive traffic deviating from its baseline
+		if val, ok := n.Metadata[graph.Anomaly]; ok {
+			nd.Anomaly = buildAnomalyInfo(val.(*graph.AnomalyMetadata))
+		}
+
+		// node may be part of a diff graph
+		if val, ok := n.Metadata[graph.Diff]; ok {
+			nd.Diff = &DiffInfo{Status: val.(*graph.DiffMetadata).Status}
+		}
+
 		// node may be an aggregate
 		if n.NodeType == graph.NodeTypeAggregate {
 			nd.Aggregate = fmt.Sprintf("%s=%s", n.Metadata[graph.Aggregate].(string), n.Metadata[graph.AggregateValue].(string))
@@ -428,6 +566,7 @@ func buildConfig(trafficMap graph.TrafficMap, nodes *[]*NodeWrapper, edges *[]*E
 				ed.SourcePrincipal = e.Metadata[graph.SourcePrincipal].(string)
 			}
 			addEdgeTelemetry(e, &ed)
+			addEdgeDiff(e, &ed)
 
 			ew := EdgeWrapper{
 				Data: &ed,
@@ -469,6 +608,38 @@ func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
 		throughput := val.(float64)
 		ed.Throughput = fmt.Sprintf("%.0f", throughput)
 	}
+	if val, ok := e.Metadata[graph.SLOStatus]; ok {
+		ed.SLOStatus = val.(string)
+	}
+	if val, ok := e.Metadata[graph.BurnRate]; ok {
+		ed.BurnRate = fmt.Sprintf("%.2f", val.(float64))
+	}
+	if val, ok := e.Metadata[graph.Anomaly]; ok {
+		ed.Anomaly = buildAnomalyInfo(val.(*graph.AnomalyMetadata))
+	}
+	if val, ok := e.Metadata[graph.ConfiguredBy]; ok {
+		ed.ConfiguredBy = val.([]string)
+	}
+	if val, ok := e.Metadata[graph.Cost]; ok {
+		cost := val.(*graph.CostMetadata)
+		ed.Cost = &CostInfo{
+			BytesPerSec: fmt.Sprintf("%.0f", cost.BytesPerSec),
+			Locality:    cost.Locality,
+		}
+		if cost.CostPerHour > 0 {
+			ed.Cost.CostPerHour = fmt.Sprintf("%.4f", cost.CostPerHour)
+		}
+	}
+	if val, ok := e.Metadata[graph.Traces]; ok {
+		traces := val.(*graph.TracesMetadata)
+		ed.Traces = &TracesInfo{
+			Calls:  fmt.Sprintf("%d", traces.Calls),
+			Errors: fmt.Sprintf("%d", traces.Errors),
+			P50:    fmt.Sprintf("%.0f", traces.P50),
+			P95:    fmt.Sprintf("%.0f", traces.P95),
+			P99:    fmt.Sprintf("%.0f", traces.P99),
+		}
+	}
 
 	// an edge represents traffic for at most one protocol
 	for _, p := range graph.Protocols {
@@ -545,6 +716,22 @@ func addEdgeTelemetry(e *graph.Edge, ed *EdgeData) {
 	}
 }
 
+func addEdgeDiff(e *graph.Edge, ed *EdgeData) {
+	val, ok := e.Metadata[graph.Diff]
+	if !ok {
+		return
+	}
+	md := val.(*graph.DiffMetadata)
+	ed.Diff = &DiffInfo{
+		Status:     md.Status,
+		Rate:       fmt.Sprintf("%+.2f", md.Rate),
+		PercentErr: fmt.Sprintf("%+.1f", md.PercentErr),
+	}
+	if md.HasResponseTime {
+		ed.Diff.ResponseTime = fmt.Sprintf("%+.0f", md.ResponseTime)
+	}
+}
+
 func getRate(md graph.Metadata, k graph.MetadataKey) float64 {
 	if rate, ok := md[k]; ok {
 		return rate.(float64)
@@ -563,7 +750,26 @@ func boxByApp(nodes *[]*NodeWrapper) {
 		}
 	}
 
-	generateBoxCompoundNodes(box, nodes, graph.BoxByApp)
+	generateBoxCompoundNodes(box, nodes, graph.BoxByApp, "")
+}
+
+// boxByLabel adds compound nodes to box nodes with the same value of the label, in the same cluster. When
+// boxing by namespace, the label boxes are in the namespace boxes, otherwise they can span namespaces.
+func boxByLabel(nodes *[]*NodeWrapper, key string, inNamespace bool) {
+	box := make(map[string][]*NodeData)
+
+	for _, nw := range *nodes {
+		// never box nodes without a value
+		if nw.Data.Parent == "" && nw.Data.BoxLabel != "" {
+			k := fmt.Sprintf("box_%s_%s_%s", graph.BoxByLabel, nw.Data.Cluster, nw.Data.BoxLabel)
+			if inNamespace {
+				k = fmt.Sprintf("box_%s_%s_%s_%s", graph.BoxByLabel, nw.Data.Cluster, nw.Data.Namespace, nw.Data.BoxLabel)
+			}
+			box[k] = append(box[k], nw.Data)
+		}
+	}
+
+	generateBoxCompoundNodes(box, nodes, graph.BoxByLabel, key)
 }
 
 // boxByNamespace adds compound nodes to box nodes in the same namespace
@@ -578,7 +784,7 @@ func boxByNamespace(nodes *[]*NodeWrapper) {
 		}
 	}
 	if len(box) > 1 {
-		generateBoxCompoundNodes(box, nodes, graph.BoxByNamespace)
+		generateBoxCompoundNodes(box, nodes, graph.BoxByNamespace, "")
 	}
 }
 
@@ -594,23 +800,39 @@ func boxByCluster(nodes *[]*NodeWrapper) {
 		}
 	}
 	if len(box) > 1 {
-		generateBoxCompoundNodes(box, nodes, graph.BoxByCluster)
+		generateBoxCompoundNodes(box, nodes, graph.BoxByCluster, "")
 	}
 }
 
-func generateBoxCompoundNodes(box map[string][]*NodeData, nodes *[]*NodeWrapper, boxBy string) {
+// generateBoxCompoundNodes adds the compound nodes of the boxes with more than one member, labelKey is the label
+// key of the label boxes
+func generateBoxCompoundNodes(box map[string][]*NodeData, nodes *[]*NodeWrapper, boxBy, labelKey string) {
 	for k, members := range box {
 		if len(members) > 1 {
 			// create the compound (parent) node for the member nodes
 			nodeID := nodeHash(k)
 			namespace := ""
 			app := ""
+			boxLabel := members[0].BoxLabel
+			var labels map[string]string
 			switch boxBy {
 			case graph.BoxByNamespace:
 				namespace = members[0].Namespace
 			case graph.BoxByApp:
 				namespace = members[0].Namespace
 				app = members[0].App
+			case graph.BoxByLabel:
+				namespace = members[0].Namespace
+				labels = map[string]string{labelKey: boxLabel}
+			}
+			for _, n := range members {
+				// a label box spanning namespaces has no namespace, an app box has no label value when its members differ
+				if n.Namespace != namespace {
+					namespace = ""
+				}
+				if n.BoxLabel != boxLabel {
+					boxLabel = ""
+				}
 			}
 			nd := NodeData{
 				ID:        nodeID,
@@ -619,7 +841,9 @@ func generateBoxCompoundNodes(box map[string][]*NodeData, nodes *[]*NodeWrapper,
 				Namespace: namespace,
 				App:       app,
 				Version:   "",
+				BoxLabel:  boxLabel,
 				IsBox:     boxBy,
+				Labels:    labels,
 			}
 
 			nw := NodeWrapper{
@@ -683,3 +907,20 @@ func calcPrecision(val float64, max int) int {
 	}
 	return precision
 }
+
+func buildAnomalyInfo(anomaly *graph.AnomalyMetadata) *AnomalyInfo {
+	deltaInfo := func(delta *graph.AnomalyDelta) *AnomalyDeltaInfo {
+		if delta == nil {
+			return nil
+		}
+		return &AnomalyDeltaInfo{
+			Delta:  fmt.Sprintf("%.2f", delta.Delta),
+			ZScore: fmt.Sprintf("%.2f", delta.ZScore),
+		}
+	}
+	return &AnomalyInfo{
+		ErrorRate:    deltaInfo(anomaly.ErrorRate),
+		RequestRate:  deltaInfo(anomaly.RequestRate),
+		ResponseTime: deltaInfo(anomaly.ResponseTime),
+	}
+}
@@ -111,6 +111,56 @@ func TestHasWorkloadEntryEmpty(t *testing.T) {
 	assert.Empty(cytoNode.Data.HasWorkloadEntry)
 }
 
+func TestBoxByLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+	n0 := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	n0.Metadata[graph.BoxLabel] = "frontend"
+	n1 := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+	n1.Metadata[graph.BoxLabel] = "backend"
+	n2 := graph.NewNode("east", "ratings", "", "ratings", "ratings-v1", "ratings", "v1", graph.GraphTypeWorkload)
+	n2.Metadata[graph.BoxLabel] = "backend"
+	n3 := graph.NewNode("east", "bookinfo", "", "bookinfo", "details-v1", "details", "v1", graph.GraphTypeWorkload)
+	for _, n := range []*graph.Node{&n0, &n1, &n2, &n3} {
+		traffic[n.ID] = n
+	}
+
+	o := graph.ConfigOptions{BoxBy: "label:team"}
+	o.BoxByLabel = "team"
+	o.GraphType = graph.GraphTypeWorkload
+	cytoConfig := NewConfig(traffic, o)
+
+	// only the backend box has more than one member, it spans the namespaces
+	nodes := cytoConfig.Elements.Nodes
+	assert.Len(nodes, 5)
+	box := nodes[0].Data
+	assert.Equal(graph.NodeTypeBox, box.NodeType)
+	assert.Equal(graph.BoxByLabel, box.IsBox)
+	assert.Equal("", box.Namespace)
+	assert.Equal(map[string]string{"team": "backend"}, box.Labels)
+	assert.Equal("team=backend", box.Label())
+	for _, nw := range nodes[1:] {
+		if nw.Data.Workload == "reviews-v1" || nw.Data.Workload == "ratings-v1" {
+			assert.Equal(box.ID, nw.Data.Parent)
+		} else {
+			assert.Empty(nw.Data.Parent)
+		}
+	}
+
+	// boxing by namespace too, the label boxes are in the namespace boxes
+	o.BoxBy = "namespace,label:team"
+	cytoConfig = NewConfig(traffic, o)
+
+	boxes := 0
+	for _, nw := range cytoConfig.Elements.Nodes {
+		if nw.Data.IsBox == graph.BoxByLabel {
+			boxes++
+		}
+	}
+	assert.Equal(0, boxes)
+}
+
 func TestHTTPToTrafficRate(t *testing.T) {
 	assert := assert.New(t)
 
@@ -141,3 +191,96 @@ func TestHTTPToTrafficRate(t *testing.T) {
 	assert.NotNil(cytoNode.Data.Traffic)
 	assert.NotNil(cytoNode.Data.Traffic.Rates)
 }
+
+func TestResponsesSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	responses := Responses{
+		"503": &ResponseDetail{Flags: ResponseFlags{"UH": "5.0", "UF": "5.0"}},
+		"200": &ResponseDetail{Flags: ResponseFlags{"-": "90.0"}},
+	}
+	assert.Equal("200:90.0 503:10.0", responses.Summary())
+	assert.Equal("", Responses{}.Summary())
+}
+
+func TestDiffAddedToGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	traffic := graph.NewTrafficMap()
+
+	productpage := graph.NewNode("testCluster", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	productpage.Metadata[graph.Diff] = &graph.DiffMetadata{Status: graph.DiffChanged}
+	traffic[productpage.ID] = &productpage
+
+	reviews := graph.NewNode("testCluster", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+	reviews.Metadata[graph.Diff] = &graph.DiffMetadata{Status: graph.DiffUnchanged}
+	traffic[reviews.ID] = &reviews
+
+	e := productpage.AddEdge(&reviews)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.MetadataKey("http")] = 1.0
+	e.Metadata[graph.HTTP.EdgeResponses] = graph.Responses{}
+	e.Metadata[graph.Diff] = &graph.DiffMetadata{Status: graph.DiffChanged, Rate: -0.5, PercentErr: 2, ResponseTime: 30, HasResponseTime: true}
+
+	cytoConfig := NewConfig(traffic, graph.ConfigOptions{})
+
+	assert.Equal(&DiffInfo{Status: graph.DiffChanged}, cytoConfig.Elements.Nodes[0].Data.Diff)
+	assert.Equal(&DiffInfo{Status: graph.DiffUnchanged}, cytoConfig.Elements.Nodes[1].Data.Diff)
+	assert.Equal(&DiffInfo{Status: graph.DiffChanged, Rate: "-0.50", PercentErr: "+2.0", ResponseTime: "+30"}, cytoConfig.Elements.Edges[0].Data.Diff)
+}
+
+func TestNewDelta(t *testing.T) {
+	assert := assert.New(t)
+
+	previous := Config{
+		Timestamp: 100,
+		Duration:  60,
+		GraphType: graph.GraphTypeApp,
+		Elements: Elements{
+			Nodes: []*NodeWrapper{
+				{Data: &NodeData{ID: "n1", NodeType: graph.NodeTypeApp, App: "productpage"}},
+				{Data: &NodeData{ID: "n2", NodeType: graph.NodeTypeApp, App: "reviews"}},
+				{Data: &NodeData{ID: "n3", NodeType: graph.NodeTypeApp, App: "details"}},
+			},
+			Edges: []*EdgeWrapper{
+				{Data: &EdgeData{ID: "e1", Source: "n1", Target: "n2", ResponseTime: "10"}},
+				{Data: &EdgeData{ID: "e2", Source: "n1", Target: "n3", ResponseTime: "20"}},
+			},
+		},
+	}
+	current := Config{
+		Timestamp: 110,
+		Duration:  60,
+		GraphType: graph.GraphTypeApp,
+		Elements: Elements{
+			Nodes: []*NodeWrapper{
+				{Data: &NodeData{ID: "n1", NodeType: graph.NodeTypeApp, App: "productpage"}},
+				{Data: &NodeData{ID: "n2", NodeType: graph.NodeTypeApp, App: "reviews", HasMissingSC: true}},
+				{Data: &NodeData{ID: "n4", NodeType: graph.NodeTypeApp, App: "ratings"}},
+			},
+			Edges: []*EdgeWrapper{
+				{Data: &EdgeData{ID: "e1", Source: "n1", Target: "n2", ResponseTime: "15"}},
+				{Data: &EdgeData{ID: "e3", Source: "n2", Target: "n4", ResponseTime: "5"}},
+			},
+		},
+	}
+
+	delta := NewDelta(previous, current)
+	assert.False(delta.IsEmpty())
+	assert.Equal(int64(110), delta.Timestamp)
+	assert.Equal(int64(60), delta.Duration)
+
+	assert.Len(delta.Nodes.Added, 1)
+	assert.Equal("n4", delta.Nodes.Added[0].Data.ID)
+	assert.Len(delta.Nodes.Changed, 1)
+	assert.Equal("n2", delta.Nodes.Changed[0].Data.ID)
+	assert.Equal([]string{"n3"}, delta.Nodes.Removed)
+
+	assert.Len(delta.Edges.Added, 1)
+	assert.Equal("e3", delta.Edges.Added[0].Data.ID)
+	assert.Len(delta.Edges.Changed, 1)
+	assert.Equal("15", delta.Edges.Changed[0].Data.ResponseTime)
+	assert.Equal([]string{"e2"}, delta.Edges.Removed)
+
+	assert.True(NewDelta(current, current).IsEmpty())
+}
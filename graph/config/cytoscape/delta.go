@@ -0,0 +1,98 @@
+package cytoscape
+
+import (
+	"reflect"
+)
+
+// NodesDelta holds the node changes between two configs of the same graph
+type NodesDelta struct {
+	Added   []*NodeWrapper `json:"added"`
+	Changed []*NodeWrapper `json:"changed"` // the new data of the node
+	Removed []string       `json:"removed"` // the node IDs
+}
+
+// EdgesDelta holds the edge changes between two configs of the same graph
+type EdgesDelta struct {
+	Added   []*EdgeWrapper `json:"added"`
+	Changed []*EdgeWrapper `json:"changed"` // the new data of the edge, usually its traffic
+	Removed []string       `json:"removed"` // the edge IDs
+}
+
+// Delta holds the changes between two configs of the same graph, generated for consecutive time windows.
+// Applying the delta to the previous config gives the current config.
+type Delta struct {
+	Timestamp int64      `json:"timestamp"`
+	Duration  int64      `json:"duration"`
+	GraphType string     `json:"graphType"`
+	Nodes     NodesDelta `json:"nodes"`
+	Edges     EdgesDelta `json:"edges"`
+}
+
+// NewDelta returns the changes from the previous config to the current config. Nodes and edges are matched by
+// ID, which is stable for the same graph options, and are changed when any of their data differs.
+func NewDelta(previous, current Config) Delta {
+	delta := Delta{
+		Timestamp: current.Timestamp,
+		Duration:  current.Duration,
+		GraphType: current.GraphType,
+		Nodes: NodesDelta{
+			Added:   []*NodeWrapper{},
+			Changed: []*NodeWrapper{},
+			Removed: []string{},
+		},
+		Edges: EdgesDelta{
+			Added:   []*EdgeWrapper{},
+			Changed: []*EdgeWrapper{},
+			Removed: []string{},
+		},
+	}
+
+	previousNodes := make(map[string]*NodeData, len(previous.Elements.Nodes))
+	for _, nw := range previous.Elements.Nodes {
+		previousNodes[nw.Data.ID] = nw.Data
+	}
+	for _, nw := range current.Elements.Nodes {
+		pnd, ok := previousNodes[nw.Data.ID]
+		switch {
+		case !ok:
+			delta.Nodes.Added = append(delta.Nodes.Added, nw)
+		case !reflect.DeepEqual(pnd, nw.Data):
+			delta.Nodes.Changed = append(delta.Nodes.Changed, nw)
+		}
+		delete(previousNodes, nw.Data.ID)
+	}
+	// keep the order of the previous config, so that the delta is predictable
+	for _, nw := range previous.Elements.Nodes {
+		if _, ok := previousNodes[nw.Data.ID]; ok {
+			delta.Nodes.Removed = append(delta.Nodes.Removed, nw.Data.ID)
+		}
+	}
+
+	previousEdges := make(map[string]*EdgeData, len(previous.Elements.Edges))
+	for _, ew := range previous.Elements.Edges {
+		previousEdges[ew.Data.ID] = ew.Data
+	}
+	for _, ew := range current.Elements.Edges {
+		ped, ok := previousEdges[ew.Data.ID]
+		switch {
+		case !ok:
+			delta.Edges.Added = append(delta.Edges.Added, ew)
+		case !reflect.DeepEqual(ped, ew.Data):
+			delta.Edges.Changed = append(delta.Edges.Changed, ew)
+		}
+		delete(previousEdges, ew.Data.ID)
+	}
+	for _, ew := range previous.Elements.Edges {
+		if _, ok := previousEdges[ew.Data.ID]; ok {
+			delta.Edges.Removed = append(delta.Edges.Removed, ew.Data.ID)
+		}
+	}
+
+	return delta
+}
+
+// IsEmpty returns true when the delta holds no change
+func (d Delta) IsEmpty() bool {
+	return len(d.Nodes.Added) == 0 && len(d.Nodes.Changed) == 0 && len(d.Nodes.Removed) == 0 &&
+		len(d.Edges.Added) == 0 && len(d.Edges.Changed) == 0 && len(d.Edges.Removed) == 0
+}
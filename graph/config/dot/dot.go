@@ -0,0 +1,283 @@
+// Package dot provides conversion from our graph to the Graphviz DOT language.
+//
+// The following links are useful for understanding DOT:
+//
+// Language:   https://graphviz.org/doc/info/lang.html
+// Attributes: https://graphviz.org/doc/info/attrs.html
+//
+// Algorithm: Generate the Cytoscape config, so that telemetry and boxing are the same
+//            for every vendor, and write it as a directed graph. Boxes are written as
+//            cluster subgraphs, nested in their parent box. Node and edge information
+//            not understood by Graphviz is kept as custom attributes, ignored when
+//            rendering but available to other DOT tools.
+//
+// The package provides the DOT implementation of graph/ConfigVendor.
+
+package dot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+)
+
+// ContentType is the media type of the DOT language
+const ContentType = "text/vnd.graphviz"
+
+// Config is a graph written in the DOT language
+type Config []byte
+
+// ContentType is required by the graph/EncodedConfig interface
+func (c Config) ContentType() string {
+	return ContentType
+}
+
+// Bytes is required by the graph/EncodedConfig interface
+func (c Config) Bytes() []byte {
+	return c
+}
+
+type attribute struct {
+	name  string
+	value string
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) Config {
+	cy := cytoscape.NewConfig(trafficMap, o)
+
+	// the cytoscape nodes are sorted with the parent boxes first, so children keep a predictable order
+	children := make(map[string][]*cytoscape.NodeData)
+	for _, nw := range cy.Elements.Nodes {
+		children[nw.Data.Parent] = append(children[nw.Data.Parent], nw.Data)
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph \"kiali\" {\n")
+	writeStatement(&b, 1, "graph", []attribute{
+		{"rankdir", "LR"},
+		{"compound", "true"},
+		{"graphType", cy.GraphType},
+		{"duration", fmt.Sprintf("%d", cy.Duration)},
+		{"timestamp", fmt.Sprintf("%d", cy.Timestamp)},
+	})
+	writeNodes(&b, 1, children, "")
+	for _, ew := range cy.Elements.Edges {
+		ed := ew.Data
+		writeStatement(&b, 1, fmt.Sprintf("%s -> %s", quote(ed.Source), quote(ed.Target)), edgeAttributes(ed))
+	}
+	b.WriteString("}\n")
+
+	return Config(b.String())
+}
+
+// writeNodes writes the nodes of a parent, boxes as cluster subgraphs holding their own children
+func writeNodes(b *strings.Builder, depth int, children map[string][]*cytoscape.NodeData, parent string) {
+	indent := strings.Repeat("  ", depth)
+	for _, nd := range children[parent] {
+		if nd.IsBox == "" {
+			writeStatement(b, depth, quote(nd.ID), nodeAttributes(nd))
+			continue
+		}
+		fmt.Fprintf(b, "%ssubgraph %s {\n", indent, quote("cluster_"+nd.ID))
+		writeStatement(b, depth+1, "graph", append([]attribute{{"style", "rounded"}}, nodeAttributes(nd)...))
+		writeNodes(b, depth+1, children, nd.ID)
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+func nodeAttributes(nd *cytoscape.NodeData) []attribute {
+	attributes := []attribute{
+		{"label", nd.Label()},
+		{"nodeType", nd.NodeType},
+		{"isBox", nd.IsBox},
+		{"cluster", nd.Cluster},
+		{"namespace", nd.Namespace},
+		{"app", nd.App},
+		{"version", nd.Version},
+		{"workload", nd.Workload},
+		{"service", nd.Service},
+		{"aggregate", nd.Aggregate},
+		{"sloStatus", nd.SLOStatus},
+		{"burnRate", nd.BurnRate},
+	}
+	if nd.IsBox == "" {
+		attributes = append(attributes, attribute{"shape", shape(nd.NodeType)})
+	}
+	flags := []struct {
+		name  string
+		value bool
+	}{
+		{"isRoot", nd.IsRoot},
+		{"isDead", nd.IsDead},
+		{"isIdle", nd.IsIdle},
+		{"isOutside", nd.IsOutside},
+		{"isInaccessible", nd.IsInaccessible},
+		{"hasMissingSC", nd.HasMissingSC},
+		{"isAmbient", nd.IsAmbient},
+	}
+	for _, f := range flags {
+		if f.value {
+			attributes = append(attributes, attribute{f.name, "true"})
+		}
+	}
+	if nd.IsIdle || nd.IsDead {
+		attributes = append(attributes, attribute{"style", "dashed"})
+	}
+	if nd.Diff != nil {
+		attributes = append(attributes, attribute{"diff", nd.Diff.Status}, attribute{"color", diffColor(nd.Diff.Status)})
+	}
+	return append(attributes, anomalyAttributes(nd.Anomaly)...)
+}
+
+// anomalyAttributes returns the delta and z-score of the metrics deviating from their baseline
+func anomalyAttributes(anomaly *cytoscape.AnomalyInfo) []attribute {
+	if anomaly == nil {
+		return nil
+	}
+	attributes := []attribute{}
+	for _, m := range []struct {
+		name  string
+		delta *cytoscape.AnomalyDeltaInfo
+	}{
+		{"anomalyErrorRate", anomaly.ErrorRate},
+		{"anomalyRequestRate", anomaly.RequestRate},
+		{"anomalyResponseTime", anomaly.ResponseTime},
+	} {
+		if m.delta != nil {
+			attributes = append(attributes, attribute{m.name, m.delta.Delta}, attribute{m.name + "ZScore", m.delta.ZScore})
+		}
+	}
+	return attributes
+}
+
+func edgeAttributes(ed *cytoscape.EdgeData) []attribute {
+	attributes := []attribute{
+		{"label", edgeLabel(ed.Traffic)},
+		{"protocol", ed.Traffic.Protocol},
+	}
+	rates := make([]string, 0, len(ed.Traffic.Rates))
+	for rate := range ed.Traffic.Rates {
+		rates = append(rates, rate)
+	}
+	sort.Strings(rates)
+	for _, rate := range rates {
+		attributes = append(attributes, attribute{rate, ed.Traffic.Rates[rate]})
+	}
+
+	attributes = append(attributes,
+		attribute{"responses", ed.Traffic.Responses.Summary()},
+		attribute{"responseTime", ed.ResponseTime},
+		attribute{"throughput", ed.Throughput},
+		attribute{"isMTLS", ed.IsMTLS},
+		attribute{"sloStatus", ed.SLOStatus},
+		attribute{"burnRate", ed.BurnRate},
+		attribute{"configuredBy", strings.Join(ed.ConfiguredBy, ",")},
+	)
+	if ed.Traces != nil {
+		attributes = append(attributes,
+			attribute{"traceCalls", ed.Traces.Calls},
+			attribute{"traceErrors", ed.Traces.Errors},
+			attribute{"traceP50", ed.Traces.P50},
+			attribute{"traceP95", ed.Traces.P95},
+			attribute{"traceP99", ed.Traces.P99},
+		)
+	}
+	if ed.Cost != nil {
+		attributes = append(attributes,
+			attribute{"locality", ed.Cost.Locality},
+			attribute{"bytesPerSec", ed.Cost.BytesPerSec},
+			attribute{"costPerHour", ed.Cost.CostPerHour},
+		)
+	}
+	if ed.Diff != nil {
+		attributes = append(attributes,
+			attribute{"diff", ed.Diff.Status},
+			attribute{"diffRate", ed.Diff.Rate},
+			attribute{"diffPercentErr", ed.Diff.PercentErr},
+			attribute{"diffResponseTime", ed.Diff.ResponseTime},
+			attribute{"color", diffColor(ed.Diff.Status)},
+		)
+	}
+	return append(attributes, anomalyAttributes(ed.Anomaly)...)
+}
+
+// diffColor returns the color highlighting the status of a node or edge of a diff graph
+func diffColor(status string) string {
+	switch status {
+	case graph.DiffAdded:
+		return "green"
+	case graph.DiffChanged:
+		return "orange"
+	case graph.DiffRemoved:
+		return "red"
+	default:
+		return ""
+	}
+}
+
+// edgeLabel returns the total rate of the edge with its unit, and its error percentage if any
+func edgeLabel(traffic cytoscape.ProtocolTraffic) string {
+	for _, p := range graph.Protocols {
+		if p.Name != traffic.Protocol {
+			continue
+		}
+		labels := []string{}
+		for _, r := range p.EdgeRates {
+			value, ok := traffic.Rates[string(r.Name)]
+			if !ok {
+				continue
+			}
+			switch {
+			case r.IsTotal:
+				labels = append(labels, fmt.Sprintf("%s %s", value, p.UnitShort))
+			case r.IsPercentErr:
+				labels = append(labels, fmt.Sprintf("%s%% err", value))
+			}
+		}
+		return strings.Join(labels, "\n")
+	}
+	return ""
+}
+
+// shape returns the Graphviz shape closest to the one used by the Kiali UI for the node type
+func shape(nodeType string) string {
+	switch nodeType {
+	case graph.NodeTypeAggregate:
+		return "hexagon"
+	case graph.NodeTypeApp:
+		return "box"
+	case graph.NodeTypeService:
+		return "triangle"
+	default:
+		return "ellipse"
+	}
+}
+
+// writeStatement writes a statement with its attributes, skipping the empty ones
+func writeStatement(b *strings.Builder, depth int, statement string, attributes []attribute) {
+	fmt.Fprintf(b, "%s%s", strings.Repeat("  ", depth), statement)
+	separator := " ["
+	for _, a := range attributes {
+		if a.value == "" {
+			continue
+		}
+		fmt.Fprintf(b, "%s%s=%s", separator, a.name, quote(a.value))
+		separator = ", "
+	}
+	if separator != " [" {
+		b.WriteString("]")
+	}
+	b.WriteString(";\n")
+}
+
+// quote returns the value as a DOT quoted string
+func quote(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	value = strings.ReplaceAll(value, "\n", "\\n")
+	return "\"" + value + "\""
+}
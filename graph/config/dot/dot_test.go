@@ -0,0 +1,97 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func bookinfoTraffic() graph.TrafficMap {
+	traffic := graph.NewTrafficMap()
+
+	productpage := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	productpage.Metadata[graph.IsRoot] = true
+	productpage.Metadata[graph.MetadataKey("httpOut")] = 20.0
+	traffic[productpage.ID] = &productpage
+
+	reviewsV1 := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	traffic[reviewsV1.ID] = &reviewsV1
+
+	reviewsV2 := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeVersionedApp)
+	reviewsV2.Metadata[graph.IsIdle] = true
+	traffic[reviewsV2.ID] = &reviewsV2
+
+	cars := graph.NewNode("east", "travel", "", "travel", "cars-v1", "cars", "v1", graph.GraphTypeVersionedApp)
+	traffic[cars.ID] = &cars
+
+	hotels := graph.NewNode("east", "travel", "", "travel", "hotels-v1", "hotels", "v1", graph.GraphTypeVersionedApp)
+	traffic[hotels.ID] = &hotels
+
+	e := productpage.AddEdge(&reviewsV1)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.MetadataKey("http")] = 10.0
+	e.Metadata[graph.MetadataKey("http5xx")] = 2.0
+	e.Metadata[graph.IsMTLS] = 100.0
+	e.Metadata[graph.HTTP.EdgeResponses] = graph.Responses{
+		"200": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 8.0}, Hosts: graph.ResponseHosts{"reviews": 8.0}},
+		"503": &graph.ResponseDetail{Flags: graph.ResponseFlags{"UH": 2.0}, Hosts: graph.ResponseHosts{"reviews": 2.0}},
+	}
+
+	e = cars.AddEdge(&hotels)
+	e.Metadata[graph.ProtocolKey] = "tcp"
+	e.Metadata[graph.MetadataKey("tcp")] = 512.0
+	e.Metadata[graph.TCP.EdgeResponses] = graph.Responses{
+		"-": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 512.0}, Hosts: graph.ResponseHosts{"hotels": 512.0}},
+	}
+
+	return traffic
+}
+
+func TestDOTNodesAndEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewConfig(bookinfoTraffic(), graph.ConfigOptions{CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeWorkload}})
+	dot := string(config.Bytes())
+
+	assert.Equal(ContentType, config.ContentType())
+	assert.True(strings.HasPrefix(dot, "digraph \"kiali\" {\n"))
+	assert.True(strings.HasSuffix(dot, "}\n"))
+	assert.Contains(dot, "graphType=\"workload\"")
+	assert.NotContains(dot, "subgraph")
+
+	assert.Contains(dot, "[label=\"productpage\\nv1\", nodeType=\"app\", cluster=\"east\", namespace=\"bookinfo\", app=\"productpage\", version=\"v1\", workload=\"productpage-v1\", shape=\"box\", isRoot=\"true\"];")
+	assert.Contains(dot, "isIdle=\"true\", style=\"dashed\"")
+
+	assert.Equal(2, strings.Count(dot, " -> "))
+	assert.Contains(dot, "[label=\"10.00 rps\\n20.0% err\", protocol=\"http\", http=\"10.00\", http5xx=\"2.00\", httpPercentErr=\"20.0\", httpPercentReq=\"50.0\", responses=\"200:80.0 503:20.0\", isMTLS=\"100\"];")
+	assert.Contains(dot, "[label=\"512.00 bps\", protocol=\"tcp\", tcp=\"512.00\", responses=\"-:100.0\"];")
+}
+
+func TestDOTBoxes(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NewConfig(bookinfoTraffic(), graph.ConfigOptions{
+		BoxBy:         graph.BoxByNamespace,
+		CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeVersionedApp},
+	})
+	dot := string(config)
+
+	// namespace boxes hold the app boxes, which hold the app nodes
+	assert.Equal(3, strings.Count(dot, "subgraph \"cluster_"))
+	namespaceBox := strings.Index(dot, "[style=\"rounded\", label=\"bookinfo\", nodeType=\"box\", isBox=\"namespace\"")
+	appBox := strings.Index(dot, "    graph [style=\"rounded\", label=\"reviews\", nodeType=\"box\", isBox=\"app\"")
+	node := strings.Index(dot, "      \"d7d2de426988db482baf04ac252f49d6\" [label=\"reviews\\nv1\"")
+	assert.True(namespaceBox > 0)
+	assert.True(appBox > namespaceBox)
+	assert.True(node > appBox)
+	assert.Contains(dot, "label=\"travel\", nodeType=\"box\", isBox=\"namespace\"")
+}
+
+func TestDOTQuote(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(`"a\"b\\c\nd"`, quote("a\"b\\c\nd"))
+}
@@ -0,0 +1,274 @@
+// Package graphml provides conversion from our graph to the GraphML file format,
+// read by graph tools like yEd and Gephi.
+//
+// The following links are useful for understanding GraphML:
+//
+// Primer:        http://graphml.graphdrawing.org/primer/graphml-primer.html
+// Specification: http://graphml.graphdrawing.org/specification.html
+//
+// Algorithm: Generate the Cytoscape config, so that telemetry and boxing are the same
+//            for every vendor, and write it as a directed graph. Boxes are written as
+//            nodes holding a nested graph with their children. Node and edge information
+//            is written as data, declared by the keys of the document.
+//
+// The package provides the GraphML implementation of graph/ConfigVendor.
+
+package graphml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/config/cytoscape"
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	// ContentType is the media type of GraphML
+	ContentType = "application/graphml+xml"
+	namespace   = "http://graphml.graphdrawing.org/xmlns"
+)
+
+// Config is a graph written as a GraphML document
+type Config []byte
+
+// ContentType is required by the graph/EncodedConfig interface
+func (c Config) ContentType() string {
+	return ContentType
+}
+
+// Bytes is required by the graph/EncodedConfig interface
+func (c Config) Bytes() []byte {
+	return c
+}
+
+type Document struct {
+	XMLName xml.Name `xml:"graphml"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Keys    []Key    `xml:"key"`
+	Graph   *Graph   `xml:"graph"`
+}
+
+type Key struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type Graph struct {
+	ID          string  `xml:"id,attr"`
+	EdgeDefault string  `xml:"edgedefault,attr"`
+	Data        []Data  `xml:"data"`
+	Nodes       []*Node `xml:"node"`
+	Edges       []*Edge `xml:"edge"`
+}
+
+type Node struct {
+	ID    string `xml:"id,attr"`
+	Data  []Data `xml:"data"`
+	Graph *Graph `xml:"graph,omitempty"`
+}
+
+type Edge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Data   []Data `xml:"data"`
+}
+
+type Data struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// keys declares every data of the document, with the id prefixed by the element as the names are shared
+func keys() []Key {
+	keys := []Key{
+		{ID: "g_graphType", For: "graph", Name: "graphType", Type: "string"},
+		{ID: "g_duration", For: "graph", Name: "duration", Type: "long"},
+		{ID: "g_timestamp", For: "graph", Name: "timestamp", Type: "long"},
+	}
+	for _, name := range []string{"label", "nodeType", "isBox", "cluster", "namespace", "app", "version", "workload", "service", "aggregate", "diff", "sloStatus"} {
+		keys = append(keys, Key{ID: "n_" + name, For: "node", Name: name, Type: "string"})
+	}
+	for _, name := range []string{"isRoot", "isDead", "isIdle", "isOutside", "isInaccessible", "hasMissingSC", "isAmbient"} {
+		keys = append(keys, Key{ID: "n_" + name, For: "node", Name: name, Type: "boolean"})
+	}
+	for _, name := range append([]string{"burnRate"}, anomalyNames...) {
+		keys = append(keys, Key{ID: "n_" + name, For: "node", Name: name, Type: "double"})
+	}
+	for _, name := range []string{"protocol", "responses", "diff", "sloStatus", "configuredBy", "locality"} {
+		keys = append(keys, Key{ID: "e_" + name, For: "edge", Name: name, Type: "string"})
+	}
+	for _, name := range append([]string{"responseTime", "throughput", "isMTLS", "burnRate", "diffRate", "diffPercentErr", "diffResponseTime", "traceCalls", "traceErrors", "traceP50", "traceP95", "traceP99", "bytesPerSec", "costPerHour"}, anomalyNames...) {
+		keys = append(keys, Key{ID: "e_" + name, For: "edge", Name: name, Type: "double"})
+	}
+	for _, p := range graph.Protocols {
+		for _, r := range p.EdgeRates {
+			keys = append(keys, Key{ID: "e_" + string(r.Name), For: "edge", Name: string(r.Name), Type: "double"})
+		}
+	}
+	return keys
+}
+
+// NewConfig is required by the graph/ConfigVendor interface
+func NewConfig(trafficMap graph.TrafficMap, o graph.ConfigOptions) Config {
+	cy := cytoscape.NewConfig(trafficMap, o)
+
+	root := &Graph{
+		ID:          "G",
+		EdgeDefault: "directed",
+		Data: data(
+			"g_graphType", cy.GraphType,
+			"g_duration", fmt.Sprintf("%d", cy.Duration),
+			"g_timestamp", fmt.Sprintf("%d", cy.Timestamp),
+		),
+	}
+
+	// the cytoscape nodes are sorted with the parent boxes first, so a box is always added before its children
+	boxes := make(map[string]*Node)
+	for _, nw := range cy.Elements.Nodes {
+		nd := nw.Data
+		node := &Node{ID: nd.ID, Data: nodeData(nd)}
+		if nd.IsBox != "" {
+			node.Graph = &Graph{ID: nd.ID + ":", EdgeDefault: "directed"}
+			boxes[nd.ID] = node
+		}
+		parent := root
+		if box, ok := boxes[nd.Parent]; ok {
+			parent = box.Graph
+		}
+		parent.Nodes = append(parent.Nodes, node)
+	}
+
+	for _, ew := range cy.Elements.Edges {
+		ed := ew.Data
+		root.Edges = append(root.Edges, &Edge{ID: ed.ID, Source: ed.Source, Target: ed.Target, Data: edgeData(ed)})
+	}
+
+	doc := Document{Xmlns: namespace, Keys: keys(), Graph: root}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Errorf("Unable to marshal the GraphML document: %s", err)
+		graph.Error(err.Error())
+	}
+	return Config(append([]byte(xml.Header), append(out, '\n')...))
+}
+
+func nodeData(nd *cytoscape.NodeData) []Data {
+	d := data(
+		"n_label", nd.Label(),
+		"n_nodeType", nd.NodeType,
+		"n_isBox", nd.IsBox,
+		"n_cluster", nd.Cluster,
+		"n_namespace", nd.Namespace,
+		"n_app", nd.App,
+		"n_version", nd.Version,
+		"n_workload", nd.Workload,
+		"n_service", nd.Service,
+		"n_aggregate", nd.Aggregate,
+		"n_sloStatus", nd.SLOStatus,
+		"n_burnRate", nd.BurnRate,
+	)
+	if nd.Diff != nil {
+		d = append(d, Data{Key: "n_diff", Value: nd.Diff.Status})
+	}
+	flags := []struct {
+		key   string
+		value bool
+	}{
+		{"n_isRoot", nd.IsRoot},
+		{"n_isDead", nd.IsDead},
+		{"n_isIdle", nd.IsIdle},
+		{"n_isOutside", nd.IsOutside},
+		{"n_isInaccessible", nd.IsInaccessible},
+		{"n_hasMissingSC", nd.HasMissingSC},
+		{"n_isAmbient", nd.IsAmbient},
+	}
+	for _, f := range flags {
+		if f.value {
+			d = append(d, Data{Key: f.key, Value: "true"})
+		}
+	}
+	return append(d, anomalyData("n_", nd.Anomaly)...)
+}
+
+func edgeData(ed *cytoscape.EdgeData) []Data {
+	d := data("e_protocol", ed.Traffic.Protocol)
+
+	rates := make([]string, 0, len(ed.Traffic.Rates))
+	for rate := range ed.Traffic.Rates {
+		rates = append(rates, rate)
+	}
+	sort.Strings(rates)
+	for _, rate := range rates {
+		d = append(d, Data{Key: "e_" + rate, Value: ed.Traffic.Rates[rate]})
+	}
+
+	d = append(d, data(
+		"e_responses", ed.Traffic.Responses.Summary(),
+		"e_responseTime", ed.ResponseTime,
+		"e_throughput", ed.Throughput,
+		"e_isMTLS", ed.IsMTLS,
+		"e_sloStatus", ed.SLOStatus,
+		"e_burnRate", ed.BurnRate,
+		"e_configuredBy", strings.Join(ed.ConfiguredBy, ","),
+	)...)
+	if ed.Traces != nil {
+		d = append(d, data(
+			"e_traceCalls", ed.Traces.Calls,
+			"e_traceErrors", ed.Traces.Errors,
+			"e_traceP50", ed.Traces.P50,
+			"e_traceP95", ed.Traces.P95,
+			"e_traceP99", ed.Traces.P99,
+		)...)
+	}
+	if ed.Cost != nil {
+		d = append(d, data(
+			"e_locality", ed.Cost.Locality,
+			"e_bytesPerSec", ed.Cost.BytesPerSec,
+			"e_costPerHour", ed.Cost.CostPerHour,
+		)...)
+	}
+	if ed.Diff != nil {
+		d = append(d, data(
+			"e_diff", ed.Diff.Status,
+			"e_diffRate", ed.Diff.Rate,
+			"e_diffPercentErr", ed.Diff.PercentErr,
+			"e_diffResponseTime", ed.Diff.ResponseTime,
+		)...)
+	}
+	return append(d, anomalyData("e_", ed.Anomaly)...)
+}
+
+// anomalyNames are the names of the delta and z-score of the metrics deviating from their baseline
+var anomalyNames = []string{"anomalyErrorRate", "anomalyErrorRateZScore", "anomalyRequestRate", "anomalyRequestRateZScore", "anomalyResponseTime", "anomalyResponseTimeZScore"}
+
+// anomalyData returns the delta and z-score of the metrics deviating from their baseline
+func anomalyData(prefix string, anomaly *cytoscape.AnomalyInfo) []Data {
+	if anomaly == nil {
+		return nil
+	}
+	d := []Data{}
+	for i, delta := range []*cytoscape.AnomalyDeltaInfo{anomaly.ErrorRate, anomaly.RequestRate, anomaly.ResponseTime} {
+		if delta != nil {
+			d = append(d, data(prefix+anomalyNames[2*i], delta.Delta, prefix+anomalyNames[2*i+1], delta.ZScore)...)
+		}
+	}
+	return d
+}
+
+// data returns the non-empty values of the key/value pairs
+func data(keyValues ...string) []Data {
+	d := []Data{}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		if keyValues[i+1] != "" {
+			d = append(d, Data{Key: keyValues[i], Value: keyValues[i+1]})
+		}
+	}
+	return d
+}
@@ -0,0 +1,106 @@
+package graphml
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func bookinfoTraffic() graph.TrafficMap {
+	traffic := graph.NewTrafficMap()
+
+	productpage := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	productpage.Metadata[graph.IsRoot] = true
+	traffic[productpage.ID] = &productpage
+
+	reviewsV1 := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	traffic[reviewsV1.ID] = &reviewsV1
+
+	reviewsV2 := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v2", "reviews", "v2", graph.GraphTypeVersionedApp)
+	traffic[reviewsV2.ID] = &reviewsV2
+
+	e := productpage.AddEdge(&reviewsV1)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.MetadataKey("http")] = 10.0
+	e.Metadata[graph.MetadataKey("http5xx")] = 2.0
+	e.Metadata[graph.ResponseTime] = 25.0
+	e.Metadata[graph.HTTP.EdgeResponses] = graph.Responses{
+		"200": &graph.ResponseDetail{Flags: graph.ResponseFlags{"-": 8.0}, Hosts: graph.ResponseHosts{"reviews": 8.0}},
+		"503": &graph.ResponseDetail{Flags: graph.ResponseFlags{"UH": 2.0}, Hosts: graph.ResponseHosts{"reviews": 2.0}},
+	}
+
+	return traffic
+}
+
+func dataValue(data []Data, key string) string {
+	for _, d := range data {
+		if d.Key == key {
+			return d.Value
+		}
+	}
+	return ""
+}
+
+func TestGraphMLDocument(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := NewConfig(bookinfoTraffic(), graph.ConfigOptions{CommonOptions: graph.CommonOptions{GraphType: graph.GraphTypeVersionedApp}})
+	assert.Equal(ContentType, config.ContentType())
+	assert.True(strings.HasPrefix(string(config.Bytes()), xml.Header))
+
+	doc := Document{}
+	require.NoError(xml.Unmarshal(config.Bytes(), &doc))
+	assert.Equal("http://graphml.graphdrawing.org/xmlns", doc.Xmlns)
+	assert.Equal("versionedApp", dataValue(doc.Graph.Data, "g_graphType"))
+
+	// every data must be declared by a key
+	declared := make(map[string]bool)
+	for _, k := range doc.Keys {
+		assert.False(declared[k.ID], "duplicated key %s", k.ID)
+		declared[k.ID] = true
+	}
+
+	// the reviews app box is a node holding a nested graph with the reviews versions
+	require.Len(doc.Graph.Nodes, 2)
+	box := doc.Graph.Nodes[0]
+	assert.Equal("box", dataValue(box.Data, "n_nodeType"))
+	assert.Equal("app", dataValue(box.Data, "n_isBox"))
+	assert.Equal("reviews", dataValue(box.Data, "n_label"))
+	require.NotNil(box.Graph)
+	assert.Equal(box.ID+":", box.Graph.ID)
+	require.Len(box.Graph.Nodes, 2)
+	assert.Equal("reviews\nv1", dataValue(box.Graph.Nodes[0].Data, "n_label"))
+	assert.Equal("v2", dataValue(box.Graph.Nodes[1].Data, "n_version"))
+
+	productpage := doc.Graph.Nodes[1]
+	assert.Nil(productpage.Graph)
+	assert.Equal("app", dataValue(productpage.Data, "n_nodeType"))
+	assert.Equal("true", dataValue(productpage.Data, "n_isRoot"))
+	assert.Equal("", dataValue(productpage.Data, "n_isIdle"))
+
+	require.Len(doc.Graph.Edges, 1)
+	edge := doc.Graph.Edges[0]
+	assert.Equal(productpage.ID, edge.Source)
+	assert.Equal(box.Graph.Nodes[0].ID, edge.Target)
+	assert.Equal("http", dataValue(edge.Data, "e_protocol"))
+	assert.Equal("10.00", dataValue(edge.Data, "e_http"))
+	assert.Equal("2.00", dataValue(edge.Data, "e_http5xx"))
+	assert.Equal("20.0", dataValue(edge.Data, "e_httpPercentErr"))
+	assert.Equal("200:80.0 503:20.0", dataValue(edge.Data, "e_responses"))
+	assert.Equal("25", dataValue(edge.Data, "e_responseTime"))
+
+	for _, n := range append(doc.Graph.Nodes, box.Graph.Nodes...) {
+		for _, d := range n.Data {
+			assert.True(declared[d.Key], "undeclared key %s", d.Key)
+		}
+	}
+	for _, d := range edge.Data {
+		assert.True(declared[d.Key], "undeclared key %s", d.Key)
+	}
+}
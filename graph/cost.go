@@ -0,0 +1,127 @@
+package graph
+
+// Cost.go holds the localities of the traffic of the edges, and the summary of the costliest edges of the
+// namespaces of a graph generated with the cost appender.
+
+import (
+	"sort"
+)
+
+// The localities of the traffic of an edge, from the clusters and zones of its source and destination workloads
+const (
+	LocalityCrossCluster string = "crossCluster"
+	LocalityCrossZone    string = "crossZone"
+	LocalityIntraZone    string = "intraZone"
+	LocalityUnknown      string = "unknown" // the zone of a workload is unknown, or it is outside of the mesh
+)
+
+// localityOrder orders the localities from the narrowest to the widest. An unknown locality is wider than
+// intra-zone, as it may cross zones.
+var localityOrder = map[string]int{
+	LocalityIntraZone:    0,
+	LocalityUnknown:      1,
+	LocalityCrossZone:    2,
+	LocalityCrossCluster: 3,
+}
+
+// WidestLocality returns the widest of two localities, the empty locality is the narrowest
+func WidestLocality(l1, l2 string) string {
+	if l1 == "" {
+		return l2
+	}
+	if l2 == "" || localityOrder[l1] >= localityOrder[l2] {
+		return l1
+	}
+	return l2
+}
+
+// CostEdge is an edge of a cost summary, with the bytes it transfers and their estimated cost
+type CostEdge struct {
+	Source      NodeReference `json:"source"`
+	Dest        NodeReference `json:"dest"`
+	Protocol    string        `json:"protocol"`
+	Locality    string        `json:"locality"`
+	BytesPerSec float64       `json:"bytesPerSec"`
+	CostPerHour float64       `json:"costPerHour"`
+}
+
+// NamespaceCost holds the costliest edges of a namespace: the edges leaving its nodes, and the edges entering
+// its nodes from the namespaces not in the graph. The totals account for all of its edges.
+type NamespaceCost struct {
+	Namespace   string     `json:"namespace"`
+	BytesPerSec float64    `json:"bytesPerSec"`
+	CostPerHour float64    `json:"costPerHour"`
+	Edges       []CostEdge `json:"edges"`
+	Truncated   bool       `json:"truncated"` // true when the edges are limited
+}
+
+// CostSummary holds the costliest edges of each namespace of a graph
+type CostSummary struct {
+	Namespaces []NamespaceCost `json:"namespaces"`
+}
+
+// SummarizeCost returns the edges of each of the namespaces ranked by their cost, then by their bytes, and
+// limited to the first limit edges (0 is unlimited). The edges are expected to hold CostMetadata, the edges
+// without it are ignored.
+func SummarizeCost(trafficMap TrafficMap, namespaces NamespaceInfoMap, limit int) CostSummary {
+	costs := make(map[string]*NamespaceCost, len(namespaces))
+	for name := range namespaces {
+		costs[name] = &NamespaceCost{Namespace: name, Edges: []CostEdge{}}
+	}
+
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			cost, ok := e.Metadata[Cost].(*CostMetadata)
+			if !ok {
+				continue
+			}
+			nc, ok := costs[e.Source.Namespace]
+			if !ok {
+				if nc, ok = costs[e.Dest.Namespace]; !ok {
+					continue
+				}
+			}
+			protocol, _ := e.Metadata[ProtocolKey].(string)
+			nc.Edges = append(nc.Edges, CostEdge{
+				Source:      nodeReference(e.Source),
+				Dest:        nodeReference(e.Dest),
+				Protocol:    protocol,
+				Locality:    cost.Locality,
+				BytesPerSec: cost.BytesPerSec,
+				CostPerHour: cost.CostPerHour,
+			})
+			nc.BytesPerSec += cost.BytesPerSec
+			nc.CostPerHour += cost.CostPerHour
+		}
+	}
+
+	summary := CostSummary{Namespaces: make([]NamespaceCost, 0, len(costs))}
+	for _, nc := range costs {
+		sort.Slice(nc.Edges, func(i, j int) bool {
+			ei, ej := nc.Edges[i], nc.Edges[j]
+			if ei.CostPerHour != ej.CostPerHour {
+				return ei.CostPerHour > ej.CostPerHour
+			}
+			if ei.BytesPerSec != ej.BytesPerSec {
+				return ei.BytesPerSec > ej.BytesPerSec
+			}
+			if ei.Source.ID != ej.Source.ID {
+				return ei.Source.ID < ej.Source.ID
+			}
+			if ei.Dest.ID != ej.Dest.ID {
+				return ei.Dest.ID < ej.Dest.ID
+			}
+			return ei.Protocol < ej.Protocol
+		})
+		if limit > 0 && len(nc.Edges) > limit {
+			nc.Edges = nc.Edges[:limit]
+			nc.Truncated = true
+		}
+		summary.Namespaces = append(summary.Namespaces, *nc)
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		return summary.Namespaces[i].Namespace < summary.Namespaces[j].Namespace
+	})
+
+	return summary
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// costTestTraffic is a graph of two namespaces, the ingress calling productpage from istio-system:
+//
+//	ingress -> productpage -> reviews (cross-zone)
+//	                       -> details (intra-zone)
+//	                       -> db (cross-cluster, other namespace)
+func costTestTraffic() TrafficMap {
+	trafficMap := NewTrafficMap()
+	node := func(cluster, namespace, workload string) *Node {
+		n := NewNode(cluster, namespace, "", namespace, workload, workload, "v1", GraphTypeWorkload)
+		trafficMap[n.ID] = &n
+		return &n
+	}
+	edge := func(source, dest *Node, locality string, bytesPerSec, costPerHour float64) {
+		e := source.AddEdge(dest)
+		e.Metadata[ProtocolKey] = "http"
+		e.Metadata[Cost] = &CostMetadata{BytesPerSec: bytesPerSec, CostPerHour: costPerHour, Locality: locality}
+	}
+
+	ingress := node("east", "istio-system", "ingress")
+	productpage := node("east", "bookinfo", "productpage")
+	reviews := node("east", "bookinfo", "reviews")
+	details := node("east", "bookinfo", "details")
+	db := node("west", "data", "db")
+
+	edge(ingress, productpage, LocalityUnknown, 1000, 0)
+	edge(productpage, reviews, LocalityCrossZone, 5000, 0.18)
+	edge(productpage, details, LocalityIntraZone, 8000, 0)
+	edge(productpage, db, LocalityCrossCluster, 2000, 0.14)
+	// an edge without cost, e.g. from an idle node
+	productpage.AddEdge(db).Metadata[ProtocolKey] = "tcp"
+	return trafficMap
+}
+
+func TestWidestLocality(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(LocalityIntraZone, WidestLocality("", LocalityIntraZone))
+	assert.Equal(LocalityUnknown, WidestLocality(LocalityIntraZone, LocalityUnknown))
+	assert.Equal(LocalityCrossZone, WidestLocality(LocalityCrossZone, LocalityUnknown))
+	assert.Equal(LocalityCrossCluster, WidestLocality(LocalityCrossZone, LocalityCrossCluster))
+}
+
+func TestSummarizeCost(t *testing.T) {
+	assert := assert.New(t)
+
+	namespaces := NamespaceInfoMap{
+		"bookinfo": NamespaceInfo{Name: "bookinfo", Duration: time.Minute},
+		"data":     NamespaceInfo{Name: "data", Duration: time.Minute},
+	}
+	summary := SummarizeCost(costTestTraffic(), namespaces, 0)
+	require.Len(t, summary.Namespaces, 2)
+
+	// the edges leave bookinfo, or enter it from istio-system which is not in the graph
+	bookinfo := summary.Namespaces[0]
+	assert.Equal("bookinfo", bookinfo.Namespace)
+	assert.InDelta(16000.0, bookinfo.BytesPerSec, 0.001)
+	assert.InDelta(0.32, bookinfo.CostPerHour, 0.001)
+	assert.False(bookinfo.Truncated)
+	// ranked by cost, then by bytes
+	workloads := []string{}
+	for _, e := range bookinfo.Edges {
+		workloads = append(workloads, e.Source.Workload+" "+e.Dest.Workload)
+	}
+	assert.Equal([]string{"productpage reviews", "productpage db", "productpage details", "ingress productpage"}, workloads)
+	assert.Equal(LocalityCrossZone, bookinfo.Edges[0].Locality)
+	assert.Equal("http", bookinfo.Edges[0].Protocol)
+
+	// the edge from bookinfo belongs to bookinfo
+	data := summary.Namespaces[1]
+	assert.Equal("data", data.Namespace)
+	assert.Empty(data.Edges)
+	assert.Zero(data.BytesPerSec)
+
+	// the totals account for the edges beyond the limit
+	summary = SummarizeCost(costTestTraffic(), namespaces, 1)
+	bookinfo = summary.Namespaces[0]
+	assert.Len(bookinfo.Edges, 1)
+	assert.True(bookinfo.Truncated)
+	assert.InDelta(16000.0, bookinfo.BytesPerSec, 0.001)
+}
@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"math"
+)
+
+// The status of a node or edge in a diff graph
+const (
+	DiffAdded     string = "added"     // only in the current graph
+	DiffChanged   string = "changed"   // telemetry changed by more than the threshold, or for a node, any of its edges
+	DiffRemoved   string = "removed"   // only in the baseline graph
+	DiffUnchanged string = "unchanged" // in both graphs, without significant change
+)
+
+// DiffMetadata describes how a node or edge of a diff graph changed from the baseline graph. The deltas
+// are the current value minus the baseline value, and are only set for edges.
+type DiffMetadata struct {
+	Status          string
+	Rate            float64 // delta of the total rate
+	PercentErr      float64 // delta of the error percentage, in percentage points
+	ResponseTime    float64 // delta of the response time, in millis
+	HasResponseTime bool    // true when both graphs report the response time of the edge
+}
+
+// edgeTelemetry holds the values compared by a diff for an edge
+type edgeTelemetry struct {
+	rate         float64
+	errRate      float64
+	responseTime float64
+	hasRT        bool
+}
+
+// DiffTrafficMaps merges the baseline and current traffic maps of the same graph, taken from two time windows,
+// annotating every node and edge with a DiffMetadata. The current map is updated and returned, adding the nodes and
+// edges only found in the baseline, with their baseline telemetry. An edge is changed when its rate, error rate or
+// response time changes by more than threshold percent.
+func DiffTrafficMaps(baseline, current TrafficMap, threshold float64) TrafficMap {
+	diff := current
+
+	for id, n := range current {
+		if _, ok := baseline[id]; ok {
+			n.Metadata[Diff] = &DiffMetadata{Status: DiffUnchanged}
+		} else {
+			n.Metadata[Diff] = &DiffMetadata{Status: DiffAdded}
+		}
+	}
+
+	// nodes only in the baseline are added first, the removed edges may use them
+	for id, n := range baseline {
+		if _, ok := diff[id]; ok {
+			continue
+		}
+		removed := *n
+		removed.Edges = []*Edge{}
+		removed.Metadata = NewMetadata()
+		for k, v := range n.Metadata {
+			removed.Metadata[k] = v
+		}
+		removed.Metadata[Diff] = &DiffMetadata{Status: DiffRemoved}
+		diff[id] = &removed
+	}
+
+	for id, n := range current {
+		baselineNode, inBaseline := baseline[id]
+		for _, e := range n.Edges {
+			var baselineEdge *Edge
+			if inBaseline {
+				baselineEdge = findEdge(baselineNode, e.Dest.ID, e.Metadata[ProtocolKey])
+			}
+			if baselineEdge == nil {
+				ct := getEdgeTelemetry(e)
+				e.Metadata[Diff] = &DiffMetadata{
+					Status:     DiffAdded,
+					Rate:       ct.rate,
+					PercentErr: percentErr(ct),
+				}
+				continue
+			}
+			e.Metadata[Diff] = diffEdge(baselineEdge, e, threshold)
+		}
+	}
+
+	for id, n := range baseline {
+		source := diff[id]
+		for _, e := range n.Edges {
+			if findEdge(source, e.Dest.ID, e.Metadata[ProtocolKey]) != nil {
+				continue
+			}
+			removed := source.AddEdge(diff[e.Dest.ID])
+			for k, v := range e.Metadata {
+				removed.Metadata[k] = v
+			}
+			bt := getEdgeTelemetry(e)
+			removed.Metadata[Diff] = &DiffMetadata{
+				Status:     DiffRemoved,
+				Rate:       -bt.rate,
+				PercentErr: -percentErr(bt),
+			}
+		}
+	}
+
+	// a node that is in both graphs is changed when any of its edges is not unchanged
+	for _, n := range diff {
+		for _, e := range n.Edges {
+			if e.Metadata[Diff].(*DiffMetadata).Status == DiffUnchanged {
+				continue
+			}
+			for _, endpoint := range []*Node{n, e.Dest} {
+				if md := endpoint.Metadata[Diff].(*DiffMetadata); md.Status == DiffUnchanged {
+					md.Status = DiffChanged
+				}
+			}
+		}
+	}
+
+	return diff
+}
+
+func findEdge(source *Node, destID string, protocol interface{}) *Edge {
+	for _, e := range source.Edges {
+		if e.Dest.ID == destID && e.Metadata[ProtocolKey] == protocol {
+			return e
+		}
+	}
+	return nil
+}
+
+func diffEdge(baseline, current *Edge, threshold float64) *DiffMetadata {
+	bt := getEdgeTelemetry(baseline)
+	ct := getEdgeTelemetry(current)
+	md := &DiffMetadata{
+		Status:     DiffUnchanged,
+		Rate:       ct.rate - bt.rate,
+		PercentErr: percentErr(ct) - percentErr(bt),
+	}
+	if ct.hasRT && bt.hasRT {
+		md.ResponseTime = ct.responseTime - bt.responseTime
+		md.HasResponseTime = true
+	}
+
+	if exceeds(bt.rate, ct.rate, threshold) || exceeds(bt.errRate, ct.errRate, threshold) ||
+		(md.HasResponseTime && exceeds(bt.responseTime, ct.responseTime, threshold)) {
+		md.Status = DiffChanged
+	}
+	return md
+}
+
+// exceeds returns true when the value changed by more than threshold percent of the baseline value
+func exceeds(baseline, current, threshold float64) bool {
+	if baseline == 0 {
+		return current != 0
+	}
+	return math.Abs(current-baseline)/baseline*100 > threshold
+}
+
+func getEdgeTelemetry(e *Edge) edgeTelemetry {
+	et := edgeTelemetry{}
+	if rt, ok := e.Metadata[ResponseTime]; ok {
+		et.responseTime = rt.(float64)
+		et.hasRT = true
+	}
+	protocol, _ := e.Metadata[ProtocolKey].(string)
+	for _, p := range Protocols {
+		if p.Name != protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			rate, ok := e.Metadata[r.Name].(float64)
+			if !ok {
+				continue
+			}
+			switch {
+			case r.IsTotal:
+				et.rate = rate
+			case r.IsErr:
+				et.errRate += rate
+			}
+		}
+	}
+	return et
+}
+
+func percentErr(et edgeTelemetry) float64 {
+	if et.rate == 0 {
+		return 0
+	}
+	return et.errRate / et.rate * 100
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func addHTTPEdge(source, dest *Node, rate, errRate float64) *Edge {
+	e := source.AddEdge(dest)
+	e.Metadata[ProtocolKey] = "http"
+	e.Metadata[MetadataKey("http")] = rate
+	if errRate > 0 {
+		e.Metadata[MetadataKey("http5xx")] = errRate
+	}
+	e.Metadata[HTTP.EdgeResponses] = Responses{}
+	return e
+}
+
+func diffStatus(md Metadata) string {
+	return md[Diff].(*DiffMetadata).Status
+}
+
+func TestDiffTrafficMaps(t *testing.T) {
+	assert := assert.New(t)
+
+	newNode := func(workload string) *Node {
+		n := NewNode("east", "bookinfo", "", "bookinfo", workload, "", "", GraphTypeWorkload)
+		return &n
+	}
+
+	baseline := NewTrafficMap()
+	bProductpage, bReviews, bDetails, bRatings := newNode("productpage"), newNode("reviews"), newNode("details"), newNode("ratings")
+	for _, n := range []*Node{bProductpage, bReviews, bDetails, bRatings} {
+		baseline[n.ID] = n
+	}
+	addHTTPEdge(bProductpage, bReviews, 10, 0)
+	addHTTPEdge(bProductpage, bDetails, 10, 0).Metadata[ResponseTime] = 100.0
+	addHTTPEdge(bReviews, bRatings, 5, 0)
+
+	current := NewTrafficMap()
+	cProductpage, cReviews, cDetails, cDB := newNode("productpage"), newNode("reviews"), newNode("details"), newNode("db")
+	for _, n := range []*Node{cProductpage, cReviews, cDetails, cDB} {
+		current[n.ID] = n
+	}
+	unchanged := addHTTPEdge(cProductpage, cReviews, 10.5, 0)
+	slower := addHTTPEdge(cProductpage, cDetails, 10, 1)
+	slower.Metadata[ResponseTime] = 150.0
+	added := addHTTPEdge(cReviews, cDB, 4, 2)
+
+	diff := DiffTrafficMaps(baseline, current, 10)
+
+	assert.Len(diff, 5)
+	assert.Equal(DiffChanged, diffStatus(diff[cProductpage.ID].Metadata))
+	assert.Equal(DiffChanged, diffStatus(diff[cReviews.ID].Metadata))
+	assert.Equal(DiffChanged, diffStatus(diff[cDetails.ID].Metadata))
+	assert.Equal(DiffAdded, diffStatus(diff[cDB.ID].Metadata))
+	assert.Equal(DiffRemoved, diffStatus(diff[bRatings.ID].Metadata))
+
+	md := unchanged.Metadata[Diff].(*DiffMetadata)
+	assert.Equal(DiffUnchanged, md.Status)
+	assert.InDelta(0.5, md.Rate, 0.001)
+
+	md = slower.Metadata[Diff].(*DiffMetadata)
+	assert.Equal(DiffChanged, md.Status)
+	assert.Equal(0.0, md.Rate)
+	assert.InDelta(10.0, md.PercentErr, 0.001)
+	assert.True(md.HasResponseTime)
+	assert.Equal(50.0, md.ResponseTime)
+
+	md = added.Metadata[Diff].(*DiffMetadata)
+	assert.Equal(DiffAdded, md.Status)
+	assert.Equal(4.0, md.Rate)
+	assert.InDelta(50.0, md.PercentErr, 0.001)
+
+	// the removed edge links the current reviews node to the removed ratings node, with the baseline telemetry
+	assert.Len(diff[cReviews.ID].Edges, 2)
+	removed := diff[cReviews.ID].Edges[1]
+	assert.Same(diff[bRatings.ID], removed.Dest)
+	assert.Equal(5.0, removed.Metadata[MetadataKey("http")])
+	md = removed.Metadata[Diff].(*DiffMetadata)
+	assert.Equal(DiffRemoved, md.Status)
+	assert.Equal(-5.0, md.Rate)
+
+	// the baseline map is not modified
+	assert.NotContains(bRatings.Metadata, Diff)
+	assert.NotContains(bReviews.Edges[0].Metadata, Diff)
+}
+
+func TestDiffTrafficMapsUnchanged(t *testing.T) {
+	assert := assert.New(t)
+
+	newMap := func(rate float64) (TrafficMap, *Edge) {
+		tm := NewTrafficMap()
+		source := NewNode("east", "bookinfo", "", "bookinfo", "productpage", "", "", GraphTypeWorkload)
+		dest := NewNode("east", "bookinfo", "", "bookinfo", "reviews", "", "", GraphTypeWorkload)
+		tm[source.ID] = &source
+		tm[dest.ID] = &dest
+		return tm, addHTTPEdge(&source, &dest, rate, 0)
+	}
+	baseline, _ := newMap(10)
+	current, e := newMap(11)
+
+	diff := DiffTrafficMaps(baseline, current, 20)
+	for _, n := range diff {
+		assert.Equal(DiffUnchanged, diffStatus(n.Metadata))
+	}
+	assert.Equal(DiffUnchanged, diffStatus(e.Metadata))
+
+	baseline, _ = newMap(10)
+	current, e = newMap(11)
+	DiffTrafficMaps(baseline, current, 5)
+	assert.Equal(DiffChanged, diffStatus(e.Metadata))
+}
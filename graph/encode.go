@@ -0,0 +1,125 @@
+package graph
+
+// Encode.go holds the binary encoding of the traffic maps, persisted by the graph snapshots so that they can be
+// rendered again by any config vendor.
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/kiali/kiali/models"
+)
+
+// The metadata values are interfaces, gob needs their concrete types. The basic types, like bool, float64, string
+// and []string, are known to gob.
+func init() {
+	gob.Register(&AnomalyMetadata{})
+	gob.Register(&CostMetadata{})
+	gob.Register(&DiffMetadata{})
+	gob.Register(&SEInfo{})
+	gob.Register(&TracesMetadata{})
+	gob.Register(&models.AppHealth{})
+	gob.Register(&models.ServiceHealth{})
+	gob.Register(&models.WorkloadHealth{})
+	gob.Register([]WEInfo{})
+	gob.Register(DestServicesMetadata{})
+	gob.Register(GatewaysMetadata{})
+	gob.Register(LabelsMetadata{})
+	gob.Register(Responses{})
+	gob.Register(VirtualServicesMetadata{})
+	gob.Register(map[string]bool{})
+	gob.Register(map[string]string{})
+}
+
+// encodedNode is a node with its outgoing edges, the edges refer to their destination by node ID
+type encodedNode struct {
+	ID        string
+	NodeType  string
+	Cluster   string
+	Namespace string
+	Workload  string
+	App       string
+	Version   string
+	Service   string
+	Edges     []encodedEdge
+	Metadata  Metadata
+}
+
+type encodedEdge struct {
+	Dest     string
+	Metadata Metadata
+}
+
+// EncodeTrafficMap returns the binary encoding of the traffic map, decoded by DecodeTrafficMap. It fails when a
+// metadata value is of a type unknown to the encoding.
+func EncodeTrafficMap(trafficMap TrafficMap) ([]byte, error) {
+	nodes := make([]encodedNode, 0, len(trafficMap))
+	for _, n := range trafficMap {
+		edges := make([]encodedEdge, 0, len(n.Edges))
+		for _, e := range n.Edges {
+			edges = append(edges, encodedEdge{Dest: e.Dest.ID, Metadata: e.Metadata})
+		}
+		nodes = append(nodes, encodedNode{
+			ID:        n.ID,
+			NodeType:  n.NodeType,
+			Cluster:   n.Cluster,
+			Namespace: n.Namespace,
+			Workload:  n.Workload,
+			App:       n.App,
+			Version:   n.Version,
+			Service:   n.Service,
+			Edges:     edges,
+			Metadata:  n.Metadata,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(nodes); err != nil {
+		return nil, fmt.Errorf("unable to encode the traffic map: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeTrafficMap returns the traffic map of a binary encoding returned by EncodeTrafficMap
+func DecodeTrafficMap(data []byte) (TrafficMap, error) {
+	nodes := []encodedNode{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("unable to decode the traffic map: %v", err)
+	}
+
+	trafficMap := NewTrafficMap()
+	for _, en := range nodes {
+		trafficMap[en.ID] = &Node{
+			ID:        en.ID,
+			NodeType:  en.NodeType,
+			Cluster:   en.Cluster,
+			Namespace: en.Namespace,
+			Workload:  en.Workload,
+			App:       en.App,
+			Version:   en.Version,
+			Service:   en.Service,
+			Edges:     []*Edge{},
+			Metadata:  decodedMetadata(en.Metadata),
+		}
+	}
+	for _, en := range nodes {
+		source := trafficMap[en.ID]
+		for _, ee := range en.Edges {
+			dest, ok := trafficMap[ee.Dest]
+			if !ok {
+				return nil, fmt.Errorf("unable to decode the traffic map: edge of node [%s] to unknown node [%s]", en.ID, ee.Dest)
+			}
+			source.Edges = append(source.Edges, &Edge{Source: source, Dest: dest, Metadata: decodedMetadata(ee.Metadata)})
+		}
+	}
+	return trafficMap, nil
+}
+
+// decodedMetadata returns the decoded metadata, gob omits the empty maps
+func decodedMetadata(metadata Metadata) Metadata {
+	if metadata == nil {
+		return NewMetadata()
+	}
+	return metadata
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/models"
+)
+
+// assertMetadata asserts that the decoded metadata holds the values of the same types, rendered as the same JSON.
+// The unexported fields, like the aggregation state of the health, are not encoded.
+func assertMetadata(t *testing.T, expected, actual Metadata) {
+	assert.Len(t, actual, len(expected))
+	for k, v := range expected {
+		assert.IsType(t, v, actual[k], string(k))
+	}
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestEncodeTrafficMap(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	newNode := func(service, workload string) *Node {
+		n := NewNode("east", "bookinfo", service, "bookinfo", workload, "", "", GraphTypeWorkload)
+		return &n
+	}
+
+	trafficMap := NewTrafficMap()
+	ingress, reviewsService, reviews, ratings := newNode("", "ingress"), newNode("reviews", ""), newNode("", "reviews-v1"), newNode("", "ratings-v1")
+	for _, n := range []*Node{ingress, reviewsService, reviews, ratings} {
+		trafficMap[n.ID] = n
+	}
+	ingress.Metadata[IsIngressGateway] = GatewaysMetadata{"bookinfo-gateway": []string{"*"}}
+	ingress.Metadata[IsRoot] = true
+	reviewsService.Metadata[HasVS] = VirtualServicesMetadata{"reviews": []string{"reviews"}}
+	reviews.Metadata[HasWorkloadEntry] = []WEInfo{{Name: "reviews-v1"}}
+	reviews.Metadata[HealthData] = models.EmptyWorkloadHealth()
+	reviews.Metadata[Labels] = LabelsMetadata{"team": "frontend"}
+	serviceHealth := models.EmptyServiceHealth()
+	reviewsService.Metadata[HealthData] = &serviceHealth
+
+	addHTTPEdge(ingress, reviewsService, 10, 1).Metadata[ResponseTime] = 100.0
+	e := addHTTPEdge(reviewsService, reviews, 10, 1)
+	e.Metadata[Cost] = &CostMetadata{BytesPerSec: 2048, Locality: "cross-zone"}
+	e.Metadata[HTTP.EdgeResponses] = Responses{"200": &ResponseDetail{Flags: ResponseFlags{"-": 9}, Hosts: ResponseHosts{"reviews": 9}}}
+	reviews.AddEdge(ratings).Metadata[DestServices] = DestServicesMetadata{"east bookinfo ratings": ServiceName{Cluster: "east", Namespace: "bookinfo", Name: "ratings"}}
+
+	data, err := EncodeTrafficMap(trafficMap)
+	require.NoError(err)
+	decoded, err := DecodeTrafficMap(data)
+	require.NoError(err)
+
+	require.Len(decoded, 4)
+	for id, n := range trafficMap {
+		d := decoded[id]
+		require.NotNil(d)
+		assert.Equal(n.NodeType, d.NodeType)
+		assert.Equal(n.Workload, d.Workload)
+		assert.Equal(n.Service, d.Service)
+		assertMetadata(t, n.Metadata, d.Metadata)
+		require.Len(d.Edges, len(n.Edges))
+		for i, e := range n.Edges {
+			assert.Same(d, d.Edges[i].Source)
+			assert.Same(decoded[e.Dest.ID], d.Edges[i].Dest)
+			assertMetadata(t, e.Metadata, d.Edges[i].Metadata)
+		}
+	}
+	// the empty metadata is decoded as an empty map, not nil
+	assert.NotNil(decoded[ratings.ID].Metadata)
+
+	_, err = DecodeTrafficMap([]byte("not a traffic map"))
+	assert.Error(err)
+
+	// a metadata value of an unknown type fails the encoding
+	ratings.Metadata[MetadataKey("unknown")] = struct{ Value int }{Value: 1}
+	_, err = EncodeTrafficMap(trafficMap)
+	assert.Error(err)
+}
@@ -0,0 +1,490 @@
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FindExpression is a parsed graph find/hide expression. The language is the one of the find and hide fields of the
+// graph page, see config.GraphUIDefaults, e.g. "rt > 1000", "%httperr > 5 OR node = service", "ns = foo AND ! idle".
+// The expression is a disjunction of clauses, each one a conjunction of node conditions or of edge conditions.
+type FindExpression struct {
+	clauses []findClause
+}
+
+type findClause struct {
+	isEdge     bool
+	conditions []findCondition
+}
+
+type findCondition struct {
+	matchNode func(n *Node) bool
+	matchEdge func(e *Edge) bool
+}
+
+var (
+	doubleSpaces = regexp.MustCompile(` +`)
+	// the replacements applied to the expression before parsing, in order
+	findReplacements = []struct {
+		re   *regexp.Regexp
+		with string
+	}{
+		{regexp.MustCompile(`(?i) is `), " "},
+		{regexp.MustCompile(`(?i) has `), " "},
+		{regexp.MustCompile(`(?i) !\s*is `), " ! "},
+		{regexp.MustCompile(`(?i) !\s*has `), " ! "},
+		{regexp.MustCompile(`(?i) not `), " !"},
+		{regexp.MustCompile(`(?i) !\s*contains `), " !*= "},
+		{regexp.MustCompile(`(?i) !\s*startswith `), " !^= "},
+		{regexp.MustCompile(`(?i) !\s*endswith `), " !$= "},
+		{regexp.MustCompile(`(?i) contains `), " *= "},
+		{regexp.MustCompile(`(?i) startswith `), " ^= "},
+		{regexp.MustCompile(`(?i) endswith `), " $= "},
+		{regexp.MustCompile(`(?i) and `), " AND "},
+		{regexp.MustCompile(`(?i) or `), " OR "},
+	}
+	// the operators, ordered so that an operator is found before the operators it contains
+	findOperators = []string{"!=", "!*=", "!$=", "!^=", ">=", "<=", "*=", "$=", "^=", "=", ">", "<", "!"}
+	// the node metadata holding the rates of the numeric node operands
+	findNodeRates = map[string]MetadataKey{
+		"grpcin":  "grpcIn",
+		"grpcout": "grpcOut",
+		"httpin":  "httpIn",
+		"httpout": "httpOut",
+		"tcpin":   "tcpIn",
+		"tcpout":  "tcpOut",
+	}
+	// the node metadata of the unary node operands
+	findNodeFlags = map[string]MetadataKey{
+		"cb":                 HasCB,
+		"circuitbreaker":     HasCB,
+		"dead":               IsDead,
+		"fi":                 HasFaultInjection,
+		"faultinjection":     HasFaultInjection,
+		"inaccessible":       IsInaccessible,
+		"idle":               IsIdle,
+		"mirroring":          HasMirroring,
+		"outside":            IsOutside,
+		"outsider":           IsOutside,
+		"rr":                 HasRequestRouting,
+		"requestrouting":     HasRequestRouting,
+		"rto":                HasRequestTimeout,
+		"requesttimeout":     HasRequestTimeout,
+		"se":                 IsServiceEntry,
+		"serviceentry":       IsServiceEntry,
+		"tcpts":              HasTCPTrafficShifting,
+		"tcptrafficshifting": HasTCPTrafficShifting,
+		"ts":                 HasTrafficShifting,
+		"trafficshifting":    HasTrafficShifting,
+		"trafficsource":      IsRoot,
+		"root":               IsRoot,
+		"vs":                 HasVS,
+		"virtualservice":     HasVS,
+		"we":                 HasWorkloadEntry,
+		"workloadentry":      HasWorkloadEntry,
+	}
+)
+
+// ParseFindExpression parses a find/hide expression. The operands that are only known by the UI, like
+// health and rank, are not supported.
+func ParseFindExpression(expression string) (*FindExpression, error) {
+	prepared := prepareFindExpression(expression)
+	if prepared == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	fe := &FindExpression{}
+	for _, orClause := range strings.Split(prepared, " OR ") {
+		expressions := strings.Split(orClause, " AND ")
+		conjunctive := len(expressions) > 1
+		clause := findClause{}
+		for i, expr := range expressions {
+			condition, isEdge, err := parseFindCondition(expr, conjunctive)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 && isEdge != clause.isEdge {
+				return nil, fmt.Errorf("invalid expression, can not AND node and edge criteria")
+			}
+			clause.isEdge = isEdge
+			clause.conditions = append(clause.conditions, condition)
+		}
+		fe.clauses = append(fe.clauses, clause)
+	}
+	return fe, nil
+}
+
+// MatchesNode returns true when the node matches any of the node clauses of the expression
+func (fe *FindExpression) MatchesNode(n *Node) bool {
+	return fe.matches(false, func(c findCondition) bool { return c.matchNode(n) })
+}
+
+// MatchesEdge returns true when the edge matches any of the edge clauses of the expression
+func (fe *FindExpression) MatchesEdge(e *Edge) bool {
+	return fe.matches(true, func(c findCondition) bool { return c.matchEdge(e) })
+}
+
+func (fe *FindExpression) matches(isEdge bool, match func(c findCondition) bool) bool {
+	for _, clause := range fe.clauses {
+		if clause.isEdge != isEdge {
+			continue
+		}
+		matched := true
+		for _, c := range clause.conditions {
+			if !match(c) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func prepareFindExpression(expression string) string {
+	expression = " " + doubleSpaces.ReplaceAllString(expression, " ")
+	for _, r := range findReplacements {
+		expression = r.re.ReplaceAllString(expression, r.with)
+	}
+	return strings.TrimSpace(expression)
+}
+
+// parseFindCondition returns the condition of an expression, and true if it is an edge condition
+func parseFindCondition(expression string, conjunctive bool) (findCondition, bool, error) {
+	op := ""
+	for _, o := range findOperators {
+		if strings.Contains(expression, o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		if len(strings.Split(expression, " ")) > 1 {
+			return findCondition{}, false, fmt.Errorf("no valid operator found in expression [%s]", expression)
+		}
+		return parseUnaryFindCondition(strings.TrimSpace(expression), false)
+	}
+
+	tokens := strings.SplitN(expression, op, 2)
+	if op == "!" {
+		return parseUnaryFindCondition(strings.TrimSpace(tokens[1]), true)
+	}
+	field := strings.TrimSpace(tokens[0])
+	val := strings.TrimSpace(tokens[1])
+
+	nodeString := func(value func(n *Node) string) (findCondition, bool, error) {
+		return findCondition{matchNode: func(n *Node) bool { return matchFindString(value(n), op, val) }}, false, nil
+	}
+	edgeString := func(key MetadataKey) (findCondition, bool, error) {
+		return findCondition{matchEdge: func(e *Edge) bool {
+			s, _ := e.Metadata[key].(string)
+			return matchFindString(s, op, val)
+		}}, true, nil
+	}
+	edgeNumber := func(value func(e *Edge) (float64, bool)) (findCondition, bool, error) {
+		match, err := findNumberMatcher(op, val)
+		if err != nil {
+			return findCondition{}, true, err
+		}
+		return findCondition{matchEdge: func(e *Edge) bool { return match(value(e)) }}, true, nil
+	}
+
+	switch strings.ToLower(field) {
+	//
+	// nodes...
+	//
+	case "app":
+		return nodeString(func(n *Node) string { return n.App })
+	case "cluster":
+		return nodeString(func(n *Node) string { return n.Cluster })
+	case "grpcin", "grpcout", "httpin", "httpout", "tcpin", "tcpout":
+		key := findNodeRates[strings.ToLower(field)]
+		match, err := findNumberMatcher(op, val)
+		if err != nil {
+			return findCondition{}, false, err
+		}
+		return findCondition{matchNode: func(n *Node) bool { return match(metadataNumber(n.Metadata, key)) }}, false, nil
+	case "name":
+		if conjunctive {
+			return findCondition{}, false, fmt.Errorf("can not use 'AND' with 'name' operand")
+		}
+		isNegation := strings.HasPrefix(op, "!")
+		return findCondition{matchNode: func(n *Node) bool {
+			aggregateValue, _ := n.Metadata[AggregateValue].(string)
+			for _, name := range []string{aggregateValue, n.App, n.Service, n.Workload} {
+				matched := matchFindString(name, op, val)
+				if matched != isNegation {
+					return matched
+				}
+			}
+			return isNegation
+		}}, false, nil
+	case "node":
+		nodeType := strings.ToLower(val)
+		switch nodeType {
+		case "op", "operation":
+			nodeType = NodeTypeAggregate
+		case "svc":
+			nodeType = NodeTypeService
+		case "wl":
+			nodeType = NodeTypeWorkload
+		}
+		switch nodeType {
+		case NodeTypeAggregate, NodeTypeApp, NodeTypeService, NodeTypeWorkload, NodeTypeUnknown:
+			return findCondition{matchNode: func(n *Node) bool { return matchFindString(n.NodeType, op, nodeType) }}, false, nil
+		}
+		return findCondition{}, false, fmt.Errorf("invalid node type [%s], expected app | operation | service | unknown | workload", nodeType)
+	case "ns", "namespace":
+		return nodeString(func(n *Node) string { return n.Namespace })
+	case "op", "operation":
+		return nodeString(func(n *Node) string {
+			aggregateValue, _ := n.Metadata[AggregateValue].(string)
+			return aggregateValue
+		})
+	case "rank":
+		return findCondition{}, false, fmt.Errorf("operand [%s] is calculated by the UI and is not supported", field)
+	case "svc", "service":
+		return nodeString(func(n *Node) string { return n.Service })
+	case "version":
+		return nodeString(func(n *Node) string { return n.Version })
+	case "wl", "workload":
+		return nodeString(func(n *Node) string { return n.Workload })
+	//
+	// edges...
+	//
+	case "destprincipal":
+		return edgeString(DestPrincipal)
+	case "sourceprincipal":
+		return edgeString(SourcePrincipal)
+	case "protocol":
+		return edgeString(ProtocolKey)
+	case "grpc", "http", "tcp":
+		key := MetadataKey(strings.ToLower(field))
+		return edgeNumber(func(e *Edge) (float64, bool) { return metadataNumber(e.Metadata, key) })
+	case "%grpcerror", "%grpcerr", "%httperror", "%httperr":
+		protocol := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(field[1:]), "error"), "err")
+		return edgeNumber(func(e *Edge) (float64, bool) { return edgePercentErr(e, protocol) })
+	case "%grpctraffic", "%httptraffic":
+		protocol := strings.TrimSuffix(strings.ToLower(field[1:]), "traffic")
+		return edgeNumber(func(e *Edge) (float64, bool) { return edgePercentReq(e, protocol) })
+	case "rt", "responsetime":
+		return edgeNumber(func(e *Edge) (float64, bool) { return metadataNumber(e.Metadata, ResponseTime) })
+	case "throughput":
+		return edgeNumber(func(e *Edge) (float64, bool) { return metadataNumber(e.Metadata, Throughput) })
+	default:
+		// special node operand
+		if label, ok := findLabel(field); ok {
+			return nodeString(func(n *Node) string {
+				labels, _ := n.Metadata[Labels].(LabelsMetadata)
+				return labels[label]
+			})
+		}
+		return findCondition{}, false, fmt.Errorf("invalid operand [%s]", field)
+	}
+}
+
+// parseUnaryFindCondition returns the condition of a unary operand, and true if it is an edge condition
+func parseUnaryFindCondition(field string, isNegation bool) (findCondition, bool, error) {
+	lowerField := strings.ToLower(field)
+	if key, ok := findNodeFlags[lowerField]; ok {
+		return findCondition{matchNode: func(n *Node) bool { return metadataIsSet(n.Metadata, key) != isNegation }}, false, nil
+	}
+
+	switch lowerField {
+	case "sc", "sidecar":
+		return findCondition{matchNode: func(n *Node) bool { return metadataIsSet(n.Metadata, HasMissingSC) == isNegation }}, false, nil
+	case "healthy":
+		return findCondition{}, false, fmt.Errorf("operand [%s] is calculated by the UI and is not supported", field)
+	case "mtls":
+		return findCondition{matchEdge: func(e *Edge) bool {
+			mtls, _ := metadataNumber(e.Metadata, IsMTLS)
+			return (mtls > 0) != isNegation
+		}}, true, nil
+	case "traffic":
+		return findCondition{matchEdge: func(e *Edge) bool { return edgeHasTraffic(e) != isNegation }}, true, nil
+	default:
+		// special node operand
+		if label, ok := findLabel(field); ok {
+			return findCondition{matchNode: func(n *Node) bool {
+				labels, _ := n.Metadata[Labels].(LabelsMetadata)
+				_, hasLabel := labels[label]
+				return hasLabel != isNegation
+			}}, false, nil
+		}
+		return findCondition{}, false, fmt.Errorf("invalid node or edge operand [%s]", field)
+	}
+}
+
+func findLabel(field string) (string, bool) {
+	if strings.HasPrefix(field, "label:") {
+		return strings.TrimPrefix(field, "label:"), true
+	}
+	return "", false
+}
+
+func matchFindString(value, op, val string) bool {
+	switch op {
+	case "=":
+		return value == val
+	case "!=":
+		return value != val
+	case "*=":
+		return strings.Contains(value, val)
+	case "!*=":
+		return !strings.Contains(value, val)
+	case "^=":
+		return strings.HasPrefix(value, val)
+	case "!^=":
+		return !strings.HasPrefix(value, val)
+	case "$=":
+		return strings.HasSuffix(value, val)
+	case "!$=":
+		return !strings.HasSuffix(value, val)
+	default:
+		// numeric operators compare the strings, as the UI does
+		return compareFind(strings.Compare(value, val), op)
+	}
+}
+
+// findNumberMatcher returns the matcher of a numeric condition. As in the UI, a non numeric value with '=' matches
+// an unset (or zero) value, and with '!=' a set value.
+func findNumberMatcher(op, val string) (func(value float64, ok bool) bool, error) {
+	number, err := strconv.ParseFloat(val, 64)
+	switch op {
+	case ">", "<", ">=", "<=":
+		if err != nil {
+			return nil, fmt.Errorf("invalid value [%s], expected a numeric value (use '.' for decimals)", val)
+		}
+	case "=", "!=":
+		if err != nil {
+			isSet := op == "!="
+			return func(value float64, ok bool) bool { return (ok && value != 0) == isSet }, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid operator [%s] for numeric condition", op)
+	}
+	return func(value float64, ok bool) bool {
+		if !ok {
+			return op == "!="
+		}
+		switch {
+		case value < number:
+			return compareFind(-1, op)
+		case value > number:
+			return compareFind(1, op)
+		default:
+			return compareFind(0, op)
+		}
+	}, nil
+}
+
+func compareFind(cmp int, op string) bool {
+	switch op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+func metadataNumber(md Metadata, key MetadataKey) (float64, bool) {
+	value, ok := md[key].(float64)
+	return value, ok
+}
+
+// metadataIsSet returns true when the metadata is set to a true or non-empty value
+func metadataIsSet(md Metadata, key MetadataKey) bool {
+	value, ok := md[key]
+	if !ok || value == nil {
+		return false
+	}
+	if b, isBool := value.(bool); isBool {
+		return b
+	}
+	return true
+}
+
+func edgeProtocol(e *Edge, protocol string) (Protocol, bool) {
+	if p, _ := e.Metadata[ProtocolKey].(string); p != protocol {
+		return Protocol{}, false
+	}
+	for _, p := range Protocols {
+		if p.Name == protocol {
+			return p, true
+		}
+	}
+	return Protocol{}, false
+}
+
+// edgePercentErr returns the error percentage of an edge of the protocol, as reported in the cytoscape config
+func edgePercentErr(e *Edge, protocol string) (float64, bool) {
+	p, ok := edgeProtocol(e, protocol)
+	if !ok {
+		return 0, false
+	}
+	total, errs := 0.0, 0.0
+	for _, r := range p.EdgeRates {
+		rate, _ := metadataNumber(e.Metadata, r.Name)
+		switch {
+		case r.IsTotal:
+			total = rate
+		case r.IsErr:
+			errs += rate
+		}
+	}
+	if total == 0 || errs == 0 {
+		return 0, false
+	}
+	return errs / total * 100, true
+}
+
+// edgePercentReq returns the percentage of the outgoing traffic of the source node carried by an edge of the protocol
+func edgePercentReq(e *Edge, protocol string) (float64, bool) {
+	p, ok := edgeProtocol(e, protocol)
+	if !ok {
+		return 0, false
+	}
+	total := 0.0
+	for _, r := range p.EdgeRates {
+		if r.IsTotal {
+			total, _ = metadataNumber(e.Metadata, r.Name)
+		}
+	}
+	for _, r := range p.NodeRates {
+		if r.IsOut {
+			if out, _ := metadataNumber(e.Source.Metadata, r.Name); out > 0 && total > 0 {
+				return total / out * 100, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func edgeHasTraffic(e *Edge) bool {
+	protocol, _ := e.Metadata[ProtocolKey].(string)
+	for _, p := range Protocols {
+		if p.Name != protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			if rate, _ := metadataNumber(e.Metadata, r.Name); r.IsTotal && rate > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
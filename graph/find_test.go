@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findTestGraph() (productpage, reviews, ratings *Node, http, tcp *Edge) {
+	pp := NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", GraphTypeVersionedApp)
+	pp.Metadata[IsRoot] = true
+	pp.Metadata[MetadataKey("httpOut")] = 20.0
+	pp.Metadata[Labels] = LabelsMetadata{"team": "frontend"}
+	rv := NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", GraphTypeVersionedApp)
+	rv.Metadata[MetadataKey("httpIn")] = 10.0
+	rv.Metadata[HasMissingSC] = true
+	rt := NewNode("east", "bookinfo", "ratings", "", "", "", "", GraphTypeVersionedApp)
+	rt.Metadata[HasVS] = VirtualServicesMetadata{"ratings": []string{"ratings"}}
+
+	http = pp.AddEdge(&rv)
+	http.Metadata[ProtocolKey] = "http"
+	http.Metadata[MetadataKey("http")] = 10.0
+	http.Metadata[MetadataKey("http5xx")] = 1.0
+	http.Metadata[ResponseTime] = 1500.0
+	http.Metadata[IsMTLS] = 100.0
+	http.Metadata[DestPrincipal] = "spiffe://cluster.local/ns/bookinfo/sa/reviews"
+
+	tcp = rv.AddEdge(&rt)
+	tcp.Metadata[ProtocolKey] = "tcp"
+	tcp.Metadata[MetadataKey("tcp")] = 0.0
+
+	return &pp, &rv, &rt, http, tcp
+}
+
+func TestFindExpressionNodes(t *testing.T) {
+	assert := assert.New(t)
+	productpage, reviews, ratings, _, _ := findTestGraph()
+
+	cases := []struct {
+		expression string
+		matches    []bool // productpage, reviews, ratings
+	}{
+		{"node = service", []bool{false, false, true}},
+		{"node = svc", []bool{false, false, true}},
+		{"node != app", []bool{false, false, true}},
+		{"ns = bookinfo", []bool{true, true, true}},
+		{"namespace != bookinfo", []bool{false, false, false}},
+		{"app startswith prod", []bool{true, false, false}},
+		{"app ^= rev", []bool{false, true, false}},
+		{"wl endswith -v1", []bool{true, true, false}},
+		{"workload contains view", []bool{false, true, false}},
+		{"workload not contains view", []bool{true, false, true}},
+		{"version = v1 AND app = reviews", []bool{false, true, false}},
+		{"name = ratings", []bool{false, false, true}},
+		{"name != ratings", []bool{true, true, false}},
+		{"httpin > 5", []bool{false, true, false}},
+		{"httpout >= 20", []bool{true, false, false}},
+		{"httpin = x", []bool{true, false, true}},
+		{"root", []bool{true, false, false}},
+		{"is trafficsource", []bool{true, false, false}},
+		{"! root", []bool{false, true, true}},
+		{"not root", []bool{false, true, true}},
+		{"has vs", []bool{false, false, true}},
+		{"sc", []bool{true, false, true}},
+		{"!sidecar", []bool{false, true, false}},
+		{"label:team = frontend", []bool{true, false, false}},
+		{"label:team", []bool{true, false, false}},
+		{"app = reviews OR node = service", []bool{false, true, true}},
+		{"rt > 1000", []bool{false, false, false}},
+	}
+	for _, c := range cases {
+		fe, err := ParseFindExpression(c.expression)
+		require.NoError(t, err, c.expression)
+		assert.Equal(c.matches, []bool{fe.MatchesNode(productpage), fe.MatchesNode(reviews), fe.MatchesNode(ratings)}, c.expression)
+	}
+}
+
+func TestFindExpressionEdges(t *testing.T) {
+	assert := assert.New(t)
+	productpage, _, _, http, tcp := findTestGraph()
+
+	cases := []struct {
+		expression string
+		matches    []bool // http, tcp
+	}{
+		{"rt > 1000", []bool{true, false}},
+		{"responseTime < 1000", []bool{false, false}},
+		{"%httperr > 5", []bool{true, false}},
+		{"%httperror <= 10", []bool{true, false}},
+		{"%httptraffic = 50", []bool{true, false}},
+		{"http > 5 AND rt > 1000", []bool{true, false}},
+		{"protocol = tcp", []bool{false, true}},
+		{"tcp = 0", []bool{false, true}},
+		{"traffic", []bool{true, false}},
+		{"! traffic", []bool{false, true}},
+		{"mtls", []bool{true, false}},
+		{"destprincipal contains sa/reviews", []bool{true, false}},
+		{"node = app", []bool{false, false}},
+	}
+	for _, c := range cases {
+		fe, err := ParseFindExpression(c.expression)
+		require.NoError(t, err, c.expression)
+		assert.Equal(c.matches, []bool{fe.MatchesEdge(http), fe.MatchesEdge(tcp)}, c.expression)
+	}
+
+	// an edge expression never matches a node
+	fe, err := ParseFindExpression("rt > 1000")
+	require.NoError(t, err)
+	assert.False(fe.MatchesNode(productpage))
+}
+
+func TestFindExpressionErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, expression := range []string{
+		"",
+		"app reviews",
+		"foo = bar",
+		"unknownflag",
+		"node = pod",
+		"rt > slow",
+		"rt *= 10",
+		"name = reviews AND app = reviews",
+		"app = reviews AND rt > 1000",
+		"healthy",
+		"rank <= 2",
+	} {
+		_, err := ParseFindExpression(expression)
+		assert.Error(err, expression)
+	}
+}
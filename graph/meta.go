@@ -15,8 +15,14 @@ func NewMetadata() Metadata {
 const (
 	Aggregate             MetadataKey = "aggregate" // the prom attribute used for aggregation
 	AggregateValue        MetadataKey = "aggregateValue"
+	Anomaly               MetadataKey = "anomaly"      // *AnomalyMetadata, the metrics deviating from their baseline
+	BoxLabel              MetadataKey = "boxLabel"     // the value of the boxBy label:<key> of a node
+	BurnRate              MetadataKey = "burnRate"     // the error budget burn rate of an SLO
+	ConfiguredBy          MetadataKey = "configuredBy" // []string, the kinds of config defining an edge of a topology graph
+	Cost                  MetadataKey = "cost"         // *CostMetadata, the bytes transferred by an edge and their cost
 	DestPrincipal         MetadataKey = "destPrincipal"
 	DestServices          MetadataKey = "destServices"
+	Diff                  MetadataKey = "diff" // *DiffMetadata, set for diff graphs
 	HealthData            MetadataKey = "healthData"
 	HealthDataApp         MetadataKey = "healthDataApp" // for storing app health on versioned app nodes
 	HasCB                 MetadataKey = "hasCB"
@@ -30,6 +36,7 @@ const (
 	HasRequestTimeout     MetadataKey = "hasRequestTimeout"
 	HasVS                 MetadataKey = "hasVS"
 	HasWorkloadEntry      MetadataKey = "hasWorkloadEntry"
+	IsAmbient             MetadataKey = "isAmbient" // Identifies a node captured by the ambient mesh, instead of a sidecar
 	IsDead                MetadataKey = "isDead"
 	IsEgressCluster       MetadataKey = "isEgressCluster"  // PassthroughCluster or BlackHoleCluster
 	IsEgressGateway       MetadataKey = "isEgressGateway"  // Identifies a node that is an Istio egress gateway
@@ -44,8 +51,10 @@ const (
 	Labels                MetadataKey = "labels"
 	ProtocolKey           MetadataKey = "protocol"
 	ResponseTime          MetadataKey = "responseTime"
+	SLOStatus             MetadataKey = "sloStatus"
 	SourcePrincipal       MetadataKey = "sourcePrincipal"
 	Throughput            MetadataKey = "throughput"
+	Traces                MetadataKey = "traces" // *TracesMetadata, the sampled calls of an edge of a jaeger graph
 )
 
 // DestServicesMetadata key=Service.Key()
@@ -62,6 +71,38 @@ func (dsm DestServicesMetadata) Add(key string, service ServiceName) DestService
 	return dsm
 }
 
+// AnomalyMetadata holds the metrics of a node or edge deviating from their baseline, the same window one day and
+// one week earlier. A nil metric does not deviate, or could not be evaluated.
+type AnomalyMetadata struct {
+	ErrorRate    *AnomalyDelta // in percentage points
+	RequestRate  *AnomalyDelta // in requests per second
+	ResponseTime *AnomalyDelta // in millis
+}
+
+// AnomalyDelta is the deviation of a metric from its baseline
+type AnomalyDelta struct {
+	Delta  float64 // the current value minus the baseline mean
+	ZScore float64 // the delta in standard deviations of the baseline
+}
+
+// CostMetadata holds the bytes transferred by an edge, the request and response bytes or the TCP bytes sent and
+// received, with their locality and estimated cost. The locality is the widest locality of the traffic of the edge.
+type CostMetadata struct {
+	BytesPerSec float64
+	CostPerHour float64 // 0 when no rate is configured for the localities of the traffic
+	Locality    string
+}
+
+// TracesMetadata holds the calls of an edge found in the sampled traces. The counts are the sampled calls, not
+// the traffic, the latencies are in millis.
+type TracesMetadata struct {
+	Calls  int
+	Errors int
+	P50    float64
+	P95    float64
+	P99    float64
+}
+
 type GatewaysMetadata map[string][]string
 type LabelsMetadata map[string]string
 type VirtualServicesMetadata map[string][]string
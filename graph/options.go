@@ -13,18 +13,24 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/business/authentication"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph/snapshot"
 	"github.com/kiali/kiali/log"
 )
 
 // The supported vendors
 const (
 	VendorCytoscape        string = "cytoscape"
+	VendorDOT              string = "dot"
+	VendorGraphML          string = "graphml"
 	VendorIstio            string = "istio"
+	VendorJaeger           string = "jaeger"   // a graph of the calls of the sampled traces
+	VendorTopology         string = "topology" // a graph of the configured routes, built without telemetry
 	defaultConfigVendor    string = VendorCytoscape
 	defaultTelemetryVendor string = VendorIstio
 )
@@ -32,6 +38,7 @@ const (
 const (
 	BoxByApp                  string = "app"
 	BoxByCluster              string = "cluster"
+	BoxByLabel                string = "label" // requested as label:<key>, boxes by the value of the label
 	BoxByNamespace            string = "namespace"
 	BoxByNone                 string = "none"
 	RateNone                  string = "none"
@@ -40,6 +47,7 @@ const (
 	RateSent                  string = "sent"     // tcp bytes sent, grpc request messages, etc
 	RateTotal                 string = "total"    // Sent+Received
 	defaultBoxBy              string = BoxByNone
+	defaultDiffThreshold      string = "10"
 	defaultDuration           string = "10m"
 	defaultGraphType          string = GraphTypeWorkload
 	defaultIncludeIdleEdges   bool   = false
@@ -47,8 +55,19 @@ const (
 	defaultRateGrpc           string = RateRequests
 	defaultRateHttp           string = RateRequests
 	defaultRateTcp            string = RateSent
+	defaultCostLimit          int    = 10
+	defaultPathsLimit         int    = 20
+	defaultPathsMaxDepth      int    = 10
+	defaultRankBy             string = RankByErrorRate
+	defaultRefreshInterval    string = "10s"
+	minRefreshInterval               = 5 * time.Second
 )
 
+// StreamTimeout is the longest time a graph stream is kept open. It is shorter than the write timeout
+// of the server, the clients reconnect to continue the stream. A stream can't be resumed, the new one starts
+// with the full graph, so a stream carries at most StreamTimeout / refresh interval deltas.
+const StreamTimeout = 25 * time.Second
+
 const (
 	graphKindNamespace string = "namespace"
 	graphKindNode      string = "node"
@@ -68,10 +87,11 @@ type NodeOptions struct {
 
 // CommonOptions are those supplied to Telemetry and Config Vendors
 type CommonOptions struct {
-	Duration  time.Duration
-	GraphType string
-	Params    url.Values // make available the raw query params for vendor-specific handling
-	QueryTime int64      // unix time in seconds
+	BoxByLabel string // the label key of boxBy label:<key>, empty if not requested
+	Duration   time.Duration
+	GraphType  string
+	Params     url.Values // make available the raw query params for vendor-specific handling
+	QueryTime  int64      // unix time in seconds
 }
 
 // ConfigOptions are those supplied to Config Vendors
@@ -95,6 +115,7 @@ type RequestedRates struct {
 type TelemetryOptions struct {
 	AccessibleNamespaces map[string]time.Time
 	Appenders            RequestedAppenders // requested appenders, nil if param not supplied
+	Hide                 *FindExpression    // nodes and edges to remove from the graph, nil if param not supplied
 	IncludeIdleEdges     bool               // include edges with request rates of 0
 	InjectServiceNodes   bool               // inject destination service nodes between source and destination nodes.
 	Namespaces           NamespaceInfoMap
@@ -134,6 +155,7 @@ func NewOptions(r *net_http.Request) Options {
 	configVendor := params.Get("configVendor")
 	durationString := params.Get("duration")
 	graphType := params.Get("graphType")
+	hideString := params.Get("hide")
 	includeIdleEdgesString := params.Get("includeIdleEdges")
 	injectServiceNodesString := params.Get("injectServiceNodes")
 	namespaces := params.Get("namespaces") // csl of namespaces
@@ -155,7 +177,7 @@ func NewOptions(r *net_http.Request) Options {
 	}
 	if configVendor == "" {
 		configVendor = defaultConfigVendor
-	} else if configVendor != VendorCytoscape {
+	} else if configVendor != VendorCytoscape && configVendor != VendorDOT && configVendor != VendorGraphML {
 		BadRequest(fmt.Sprintf("Invalid configVendor [%s]", configVendor))
 	}
 	if durationString == "" {
@@ -177,20 +199,21 @@ func NewOptions(r *net_http.Request) Options {
 	if app != "" && graphType != GraphTypeApp && graphType != GraphTypeVersionedApp {
 		BadRequest(fmt.Sprintf("Invalid graphType [%s]. This node detail graph supports only graphType app or versionedApp.", graphType))
 	}
+	boxByLabel := ""
 	if boxBy == "" {
 		boxBy = defaultBoxBy
 	} else {
-		for _, box := range strings.Split(boxBy, ",") {
-			switch strings.TrimSpace(box) {
-			case BoxByApp:
-				continue
-			case BoxByCluster:
-				continue
-			case BoxByNamespace:
-				continue
-			default:
-				BadRequest(fmt.Sprintf("Invalid boxBy [%s]", boxBy))
-			}
+		var ok bool
+		if boxByLabel, ok = ParseBoxBy(boxBy); !ok {
+			BadRequest(fmt.Sprintf("Invalid boxBy [%s]", boxBy))
+		}
+	}
+	var hide *FindExpression
+	if strings.TrimSpace(hideString) != "" {
+		var hideErr error
+		hide, hideErr = ParseFindExpression(hideString)
+		if hideErr != nil {
+			BadRequest(fmt.Sprintf("Invalid hide [%s]: %s", hideString, hideErr))
 		}
 	}
 	if includeIdleEdgesString == "" {
@@ -222,9 +245,18 @@ func NewOptions(r *net_http.Request) Options {
 	}
 	if telemetryVendor == "" {
 		telemetryVendor = defaultTelemetryVendor
-	} else if telemetryVendor != VendorIstio {
+	} else if telemetryVendor != VendorIstio && telemetryVendor != VendorJaeger && telemetryVendor != VendorTopology {
 		BadRequest(fmt.Sprintf("Invalid telemetryVendor [%s]", telemetryVendor))
 	}
+	if telemetryVendor == VendorJaeger {
+		if !config.Get().ExternalServices.Tracing.Enabled {
+			BadRequest("Invalid telemetryVendor [jaeger]. Tracing is not enabled.")
+		}
+		// the spans identify the traced apps, not their workloads
+		if graphType != GraphTypeApp && graphType != GraphTypeVersionedApp {
+			BadRequest(fmt.Sprintf("Invalid graphType [%s]. telemetryVendor jaeger supports only graphType app or versionedApp.", graphType))
+		}
+	}
 
 	// Process namespaces options:
 	namespaceMap := NewNamespaceInfoMap()
@@ -330,24 +362,27 @@ func NewOptions(r *net_http.Request) Options {
 		ConfigOptions: ConfigOptions{
 			BoxBy: boxBy,
 			CommonOptions: CommonOptions{
-				Duration:  time.Duration(duration),
-				GraphType: graphType,
-				Params:    params,
-				QueryTime: queryTime,
+				BoxByLabel: boxByLabel,
+				Duration:   time.Duration(duration),
+				GraphType:  graphType,
+				Params:     params,
+				QueryTime:  queryTime,
 			},
 		},
 		TelemetryOptions: TelemetryOptions{
 			AccessibleNamespaces: accessibleNamespaces,
 			Appenders:            appenders,
+			Hide:                 hide,
 			IncludeIdleEdges:     includeIdleEdges,
 			InjectServiceNodes:   injectServiceNodes,
 			Namespaces:           namespaceMap,
 			Rates:                rates,
 			CommonOptions: CommonOptions{
-				Duration:  time.Duration(duration),
-				GraphType: graphType,
-				Params:    params,
-				QueryTime: queryTime,
+				BoxByLabel: boxByLabel,
+				Duration:   time.Duration(duration),
+				GraphType:  graphType,
+				Params:     params,
+				QueryTime:  queryTime,
 			},
 			NodeOptions: NodeOptions{
 				Aggregate:      aggregate,
@@ -365,6 +400,339 @@ func NewOptions(r *net_http.Request) Options {
 	return options
 }
 
+// DiffOptions comprises the options of a diff graph, comparing the graph requested by the Options with
+// a baseline graph of the same namespaces, in another time window.
+type DiffOptions struct {
+	Baseline  TelemetryOptions // the options of the baseline graph, only the time window differs
+	Threshold float64          // percentage of change of the edge telemetry to consider an edge changed
+	Options
+}
+
+// NewDiffOptions returns the options of a diff graph. The baseline time window is set by the baselineQueryTime
+// and baselineDuration query params, by default it is the window preceding the one of the graph.
+func NewDiffOptions(r *net_http.Request) DiffOptions {
+	o := NewOptions(r)
+
+	if o.TelemetryVendor != VendorIstio {
+		BadRequest(fmt.Sprintf("Invalid telemetryVendor [%s]. Diff graphs support only telemetryVendor istio.", o.TelemetryVendor))
+	}
+
+	params := r.URL.Query()
+	baselineDurationString := params.Get("baselineDuration")
+	baselineQueryTimeString := params.Get("baselineQueryTime")
+	thresholdString := params.Get("diffThreshold")
+
+	baselineDuration := o.TelemetryOptions.Duration
+	if baselineDurationString != "" {
+		duration, err := model.ParseDuration(baselineDurationString)
+		if err != nil {
+			BadRequest(fmt.Sprintf("Invalid baselineDuration [%s]", baselineDurationString))
+		}
+		baselineDuration = time.Duration(duration)
+	}
+	baselineQueryTime := o.TelemetryOptions.QueryTime - int64(o.TelemetryOptions.Duration.Seconds())
+	if baselineQueryTimeString != "" {
+		var err error
+		baselineQueryTime, err = strconv.ParseInt(baselineQueryTimeString, 10, 64)
+		if err != nil {
+			BadRequest(fmt.Sprintf("Invalid baselineQueryTime [%s]", baselineQueryTimeString))
+		}
+	}
+	if baselineQueryTime == o.TelemetryOptions.QueryTime && baselineDuration == o.TelemetryOptions.Duration {
+		BadRequest("The baseline time window must differ from the graph time window")
+	}
+	threshold := parseDiffThreshold(thresholdString)
+
+	baseline := o.TelemetryOptions
+	baseline.Duration = baselineDuration
+	baseline.QueryTime = baselineQueryTime
+	baseline.Namespaces = NewNamespaceInfoMap()
+	for name, ns := range o.TelemetryOptions.Namespaces {
+		ns.Duration = getSafeNamespaceDuration(name, o.AccessibleNamespaces[name], baselineDuration, baselineQueryTime)
+		baseline.Namespaces[name] = ns
+	}
+
+	return DiffOptions{
+		Baseline:  baseline,
+		Threshold: threshold,
+		Options:   o,
+	}
+}
+
+// StreamOptions comprises the options of a graph stream, regenerating the graph requested by the Options
+// on every refresh interval, for the time window ending at the refresh time.
+type StreamOptions struct {
+	RefreshInterval time.Duration
+	Options
+}
+
+// NewStreamOptions returns the options of a graph stream. The refresh interval is set by the refreshInterval
+// query param, the queryTime param is not supported as the stream always queries up to the current time.
+func NewStreamOptions(r *net_http.Request) StreamOptions {
+	o := NewOptions(r)
+
+	params := r.URL.Query()
+	refreshIntervalString := params.Get("refreshInterval")
+
+	if params.Get("queryTime") != "" {
+		BadRequest("The queryTime query parameter is not supported by graph streams")
+	}
+	if o.TelemetryVendor != VendorIstio {
+		BadRequest(fmt.Sprintf("Invalid telemetryVendor [%s]. Graph streams support only telemetryVendor istio.", o.TelemetryVendor))
+	}
+	if o.ConfigVendor != VendorCytoscape {
+		BadRequest(fmt.Sprintf("Invalid configVendor [%s]. Graph streams support only configVendor cytoscape.", o.ConfigVendor))
+	}
+	if refreshIntervalString == "" {
+		refreshIntervalString = defaultRefreshInterval
+	}
+	refreshInterval, err := model.ParseDuration(refreshIntervalString)
+	if err != nil {
+		BadRequest(fmt.Sprintf("Invalid refreshInterval [%s]", refreshIntervalString))
+	}
+	if time.Duration(refreshInterval) < minRefreshInterval || time.Duration(refreshInterval) >= StreamTimeout {
+		BadRequest(fmt.Sprintf("Invalid refreshInterval [%s]. It must be at least %s and less than %s.", refreshIntervalString, minRefreshInterval, StreamTimeout))
+	}
+
+	return StreamOptions{
+		RefreshInterval: time.Duration(refreshInterval),
+		Options:         o,
+	}
+}
+
+// DependenciesOptions comprises the options of a dependencies query, on the graph requested by the Options
+type DependenciesOptions struct {
+	DownstreamDepth int // 0 is unlimited
+	Node            NodeSelector
+	UpstreamDepth   int // 0 is unlimited
+	Options
+}
+
+// NewDependenciesOptions returns the options of a dependencies query. The node is set by the node query param,
+// the depths by the upstreamDepth and downstreamDepth query params.
+func NewDependenciesOptions(r *net_http.Request) DependenciesOptions {
+	o := NewOptions(r)
+
+	params := r.URL.Query()
+
+	return DependenciesOptions{
+		DownstreamDepth: parseNonNegative(params, "downstreamDepth", 0),
+		Node:            parseNodeSelector(params, "node"),
+		UpstreamDepth:   parseNonNegative(params, "upstreamDepth", 0),
+		Options:         o,
+	}
+}
+
+// PathsOptions comprises the options of a paths query, on the graph requested by the Options
+type PathsOptions struct {
+	Destination NodeSelector
+	Limit       int // 0 is unlimited
+	MaxDepth    int // 0 is unlimited
+	RankBy      string
+	Source      NodeSelector
+	Options
+}
+
+// NewPathsOptions returns the options of a paths query. The nodes are set by the source and destination query
+// params, the ranking by the rankBy, maxDepth and limit query params.
+func NewPathsOptions(r *net_http.Request) PathsOptions {
+	o := NewOptions(r)
+
+	params := r.URL.Query()
+	rankBy := params.Get("rankBy")
+
+	if rankBy == "" {
+		rankBy = defaultRankBy
+	} else if rankBy != RankByErrorRate && rankBy != RankByResponseTime {
+		BadRequest(fmt.Sprintf("Invalid rankBy [%s]", rankBy))
+	}
+
+	return PathsOptions{
+		Destination: parseNodeSelector(params, "destination"),
+		Limit:       parseNonNegative(params, "limit", defaultPathsLimit),
+		MaxDepth:    parseNonNegative(params, "maxDepth", defaultPathsMaxDepth),
+		RankBy:      rankBy,
+		Source:      parseNodeSelector(params, "source"),
+		Options:     o,
+	}
+}
+
+// CostOptions comprises the options of a cost summary, on the graph requested by the Options
+type CostOptions struct {
+	Limit int // 0 is unlimited
+	Options
+}
+
+// NewCostOptions returns the options of a cost summary. The number of edges of each namespace is set by the limit
+// query param. The cost is reported by the istio cost appender, the appenders query param is ignored.
+func NewCostOptions(r *net_http.Request) CostOptions {
+	o := NewOptions(r)
+
+	params := r.URL.Query()
+
+	if o.TelemetryVendor != VendorIstio {
+		BadRequest(fmt.Sprintf("Invalid telemetryVendor [%s]. Cost summaries support only telemetryVendor istio.", o.TelemetryVendor))
+	}
+
+	return CostOptions{
+		Limit:   parseNonNegative(params, "limit", defaultCostLimit),
+		Options: o,
+	}
+}
+
+// SnapshotOptions comprises the options of a graph snapshot, saving the graph requested by the Options
+type SnapshotOptions struct {
+	Name string
+	Options
+}
+
+// NewSnapshotOptions returns the options of a graph snapshot. The name is set by the snapshot path variable,
+// it must be a DNS label as it names the file or ConfigMap storing the snapshot.
+func NewSnapshotOptions(r *net_http.Request) SnapshotOptions {
+	o := NewOptions(r)
+
+	name := mux.Vars(r)["snapshot"]
+	if !snapshot.IsValidName(name) {
+		BadRequest(fmt.Sprintf("Invalid snapshot name [%s]. It must consist of at most 63 lower case alphanumeric characters or '-', and start and end with an alphanumeric character.", name))
+	}
+
+	return SnapshotOptions{
+		Name:    name,
+		Options: o,
+	}
+}
+
+// SnapshotReadOptions comprises the options of reading a graph snapshot, rendering its traffic map
+type SnapshotReadOptions struct {
+	Baseline      string // the name of the snapshot diffed with the read snapshot, empty for no diff
+	BoxBy         string // the boxing of the rendered graph, empty for the boxing of the snapshot
+	ConfigVendor  string
+	DiffThreshold float64 // percentage of change of the edge telemetry to consider an edge changed
+	Name          string
+}
+
+// NewSnapshotReadOptions returns the options of reading a graph snapshot. The name is set by the snapshot path
+// variable, the snapshot is diffed with the snapshot named by the baseline query param, if set.
+func NewSnapshotReadOptions(r *net_http.Request) SnapshotReadOptions {
+	params := r.URL.Query()
+	baseline := params.Get("baseline")
+	boxBy := params.Get("boxBy")
+	configVendor := params.Get("configVendor")
+
+	if baseline != "" && !snapshot.IsValidName(baseline) {
+		BadRequest(fmt.Sprintf("Invalid baseline [%s]", baseline))
+	}
+	if _, ok := ParseBoxBy(boxBy); boxBy != "" && !ok {
+		BadRequest(fmt.Sprintf("Invalid boxBy [%s]", boxBy))
+	}
+	if configVendor == "" {
+		configVendor = defaultConfigVendor
+	} else if configVendor != VendorCytoscape && configVendor != VendorDOT && configVendor != VendorGraphML {
+		BadRequest(fmt.Sprintf("Invalid configVendor [%s]", configVendor))
+	}
+
+	return SnapshotReadOptions{
+		Baseline:      baseline,
+		BoxBy:         boxBy,
+		ConfigVendor:  configVendor,
+		DiffThreshold: parseDiffThreshold(params.Get("diffThreshold")),
+		Name:          mux.Vars(r)["snapshot"],
+	}
+}
+
+// ParseBoxBy returns the label key of the label:<key> boxing of a boxBy, empty when not requested, and false if
+// the boxBy is invalid. At most one label boxing is valid.
+func ParseBoxBy(boxBy string) (string, bool) {
+	boxByLabel := ""
+	for _, box := range strings.Split(boxBy, ",") {
+		box = strings.TrimSpace(box)
+		switch box {
+		case BoxByApp, BoxByCluster, BoxByNamespace, BoxByNone:
+			continue
+		default:
+			key, ok := parseBoxByLabel(box)
+			if !ok || boxByLabel != "" {
+				return "", false
+			}
+			boxByLabel = key
+		}
+	}
+	return boxByLabel, true
+}
+
+// parseDiffThreshold returns the diff threshold of a diffThreshold query param, the default when it is empty
+func parseDiffThreshold(thresholdString string) float64 {
+	if thresholdString == "" {
+		thresholdString = defaultDiffThreshold
+	}
+	threshold, err := strconv.ParseFloat(thresholdString, 64)
+	if err != nil || threshold < 0 {
+		BadRequest(fmt.Sprintf("Invalid diffThreshold [%s]", thresholdString))
+	}
+	return threshold
+}
+
+// parseBoxByLabel returns the label key of a label:<key> boxBy, false if the boxBy is not a valid label boxing
+func parseBoxByLabel(box string) (string, bool) {
+	key := strings.TrimPrefix(box, BoxByLabel+":")
+	if key == box || len(validation.IsQualifiedName(key)) > 0 {
+		return "", false
+	}
+	return key, true
+}
+
+// HasBoxBy returns true if the boxing is requested. The label boxing is requested as label:<key>.
+func (o ConfigOptions) HasBoxBy(boxBy string) bool {
+	if boxBy == BoxByLabel {
+		return o.BoxByLabel != ""
+	}
+	for _, box := range strings.Split(o.BoxBy, ",") {
+		if strings.TrimSpace(box) == boxBy {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNodeSelector returns the required node selector of a query param
+func parseNodeSelector(params url.Values, name string) NodeSelector {
+	selectorString := params.Get(name)
+	if selectorString == "" {
+		BadRequest(fmt.Sprintf("The %s query parameter is required", name))
+	}
+	selector, err := ParseNodeSelector(selectorString)
+	if err != nil {
+		BadRequest(fmt.Sprintf("Invalid %s [%s]: %s", name, selectorString, err))
+	}
+	return selector
+}
+
+// parseNonNegative returns the non-negative integer of a query param, or its default value
+func parseNonNegative(params url.Values, name string, defaultValue int) int {
+	valueString := params.Get(name)
+	if valueString == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueString)
+	if err != nil || value < 0 {
+		BadRequest(fmt.Sprintf("Invalid %s [%s]", name, valueString))
+	}
+	return value
+}
+
+// WithQueryTime returns a copy of the options for the time window ending at queryTime, with the same duration.
+func (o Options) WithQueryTime(queryTime int64) Options {
+	o.ConfigOptions.QueryTime = queryTime
+	o.TelemetryOptions.QueryTime = queryTime
+	namespaces := NewNamespaceInfoMap()
+	for name, ns := range o.TelemetryOptions.Namespaces {
+		ns.Duration = getSafeNamespaceDuration(name, o.AccessibleNamespaces[name], o.TelemetryOptions.Duration, queryTime)
+		namespaces[name] = ns
+	}
+	o.TelemetryOptions.Namespaces = namespaces
+	return o
+}
+
 // GetGraphKind will return the kind of graph represented by the options.
 func (o *TelemetryOptions) GetGraphKind() string {
 	if o.NodeOptions.App != "" ||
@@ -0,0 +1,318 @@
+package graph
+
+// Paths.go holds the traversals of a TrafficMap answering dependency questions: the transitive callers and
+// dependencies of a node, and the paths between two nodes.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// The node kinds of a NodeSelector
+const (
+	NodeSelectorApp      string = "app"
+	NodeSelectorService  string = "service"
+	NodeSelectorWorkload string = "workload"
+)
+
+// The ranking of the paths between two nodes
+const (
+	RankByErrorRate    string = "errorRate"
+	RankByResponseTime string = "responseTime"
+)
+
+// maxEnumeratedPaths caps the paths enumerated between two nodes, dense graphs can hold a huge number of them
+const maxEnumeratedPaths = 10000
+
+// maxEnumerationSteps caps the edges walked while enumerating the paths between two nodes. The simple paths
+// of a dense graph grow exponentially with their length, even when few of them reach a destination.
+const maxEnumerationSteps = 1000000
+
+// NodeSelector selects the nodes of a workload, app or service, written as <kind>:<namespace>/<name>
+type NodeSelector struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// ParseNodeSelector parses a selector written as <kind>:<namespace>/<name>, e.g. workload:bookinfo/reviews-v1
+func ParseNodeSelector(selector string) (NodeSelector, error) {
+	kind, namespacedName, ok := strings.Cut(strings.TrimSpace(selector), ":")
+	if !ok {
+		return NodeSelector{}, fmt.Errorf("expected <kind>:<namespace>/<name>")
+	}
+	namespace, name, ok := strings.Cut(namespacedName, "/")
+	if !ok || namespace == "" || name == "" {
+		return NodeSelector{}, fmt.Errorf("expected <kind>:<namespace>/<name>")
+	}
+	switch kind {
+	case NodeSelectorApp, NodeSelectorService, NodeSelectorWorkload:
+		return NodeSelector{Kind: kind, Name: name, Namespace: namespace}, nil
+	default:
+		return NodeSelector{}, fmt.Errorf("kind [%s] is not one of app, service or workload", kind)
+	}
+}
+
+// String returns the selector as <kind>:<namespace>/<name>
+func (s NodeSelector) String() string {
+	return fmt.Sprintf("%s:%s/%s", s.Kind, s.Namespace, s.Name)
+}
+
+// Matches returns true when the node belongs to the workload, app or service of the selector. An app
+// selects all the nodes of its versions and workloads.
+func (s NodeSelector) Matches(n *Node) bool {
+	if n.Namespace != s.Namespace {
+		return false
+	}
+	switch s.Kind {
+	case NodeSelectorApp:
+		return n.App == s.Name
+	case NodeSelectorService:
+		return n.NodeType == NodeTypeService && n.Service == s.Name
+	case NodeSelectorWorkload:
+		return n.Workload == s.Name
+	default:
+		return false
+	}
+}
+
+// NodeReference identifies a node of the traffic map in a dependency or path result
+type NodeReference struct {
+	ID        string `json:"id"`
+	NodeType  string `json:"nodeType"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload,omitempty"`
+	App       string `json:"app,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Service   string `json:"service,omitempty"`
+}
+
+// Dependency is a node reached from the selected nodes
+type Dependency struct {
+	Node  NodeReference `json:"node"`
+	Depth int           `json:"depth"` // the number of edges from the closest selected node
+}
+
+// Dependencies holds the nodes calling the selected nodes, that fail with them, and the nodes they call,
+// directly or transitively.
+type Dependencies struct {
+	Nodes      []NodeReference `json:"nodes"`      // the selected nodes
+	Upstream   []Dependency    `json:"upstream"`   // the callers, the blast radius of a failure of the selected nodes
+	Downstream []Dependency    `json:"downstream"` // the dependencies of the selected nodes
+}
+
+// PathEdge is an edge of a path, with its telemetry
+type PathEdge struct {
+	Source          string  `json:"source"`
+	Dest            string  `json:"dest"`
+	Protocol        string  `json:"protocol"`
+	Rate            float64 `json:"rate"`
+	PercentErr      float64 `json:"percentErr"`
+	ResponseTime    float64 `json:"responseTime"`
+	HasResponseTime bool    `json:"hasResponseTime"`
+}
+
+// Path is a path of the traffic map between two nodes, following the direction of the traffic
+type Path struct {
+	Nodes        []NodeReference `json:"nodes"`
+	Edges        []PathEdge      `json:"edges"`
+	PercentErr   float64         `json:"percentErr"`   // the percentage of requests failing on any edge, assuming independent failures
+	ResponseTime float64         `json:"responseTime"` // the sum of the response times of the edges, in millis
+}
+
+// Paths holds the paths between the source and destination nodes, ranked by decreasing error rate or
+// response time
+type Paths struct {
+	Sources      []NodeReference `json:"sources"`
+	Destinations []NodeReference `json:"destinations"`
+	Paths        []Path          `json:"paths"`
+	Truncated    bool            `json:"truncated"` // true when there are more paths than returned
+}
+
+// SelectNodes returns the nodes of the traffic map matched by the selector, sorted by ID
+func SelectNodes(trafficMap TrafficMap, selector NodeSelector) []*Node {
+	nodes := []*Node{}
+	for _, n := range trafficMap {
+		if selector.Matches(n) {
+			nodes = append(nodes, n)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// FindDependencies returns the upstream and downstream nodes of the selected nodes, up to the given depths.
+// A depth of 0 is unlimited.
+func FindDependencies(trafficMap TrafficMap, nodes []*Node, upstreamDepth, downstreamDepth int) Dependencies {
+	incoming := make(map[string][]*Edge)
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			incoming[e.Dest.ID] = append(incoming[e.Dest.ID], e)
+		}
+	}
+
+	upstream := traverse(nodes, upstreamDepth, func(n *Node) []*Node {
+		sources := []*Node{}
+		for _, e := range incoming[n.ID] {
+			sources = append(sources, e.Source)
+		}
+		return sources
+	})
+	downstream := traverse(nodes, downstreamDepth, func(n *Node) []*Node {
+		dests := []*Node{}
+		for _, e := range n.Edges {
+			dests = append(dests, e.Dest)
+		}
+		return dests
+	})
+
+	return Dependencies{
+		Nodes:      nodeReferences(nodes),
+		Upstream:   upstream,
+		Downstream: downstream,
+	}
+}
+
+// traverse walks the graph breadth first from the start nodes, returning the reached nodes ordered by depth
+func traverse(start []*Node, maxDepth int, next func(n *Node) []*Node) []Dependency {
+	dependencies := []Dependency{}
+	visited := make(map[string]bool)
+	for _, n := range start {
+		visited[n.ID] = true
+	}
+
+	current := start
+	for depth := 1; len(current) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		reached := []*Node{}
+		for _, n := range current {
+			for _, nn := range next(n) {
+				if visited[nn.ID] {
+					continue
+				}
+				visited[nn.ID] = true
+				reached = append(reached, nn)
+			}
+		}
+		sort.Slice(reached, func(i, j int) bool {
+			return reached[i].ID < reached[j].ID
+		})
+		for _, n := range reached {
+			dependencies = append(dependencies, Dependency{Node: nodeReference(n), Depth: depth})
+		}
+		current = reached
+	}
+	return dependencies
+}
+
+// FindPaths returns the paths from the source nodes to the destination nodes, of at most maxDepth edges
+// (0 is unlimited), ranked by rankBy and limited to the first limit paths (0 is unlimited). The enumeration
+// stops, flagging the result as truncated, after maxEnumeratedPaths paths or maxEnumerationSteps steps.
+func FindPaths(sources, destinations []*Node, maxDepth int, rankBy string, limit int) Paths {
+	isDestination := make(map[string]bool)
+	for _, n := range destinations {
+		isDestination[n.ID] = true
+	}
+
+	result := Paths{
+		Sources:      nodeReferences(sources),
+		Destinations: nodeReferences(destinations),
+		Paths:        []Path{},
+	}
+
+	steps := 0
+	var walk func(n *Node, visited map[string]bool, edges []*Edge)
+	walk = func(n *Node, visited map[string]bool, edges []*Edge) {
+		if len(result.Paths) >= maxEnumeratedPaths || steps >= maxEnumerationSteps {
+			result.Truncated = true
+			return
+		}
+		steps++
+		if isDestination[n.ID] && len(edges) > 0 {
+			result.Paths = append(result.Paths, newPath(edges))
+		}
+		if maxDepth > 0 && len(edges) >= maxDepth {
+			return
+		}
+		for _, e := range n.Edges {
+			if visited[e.Dest.ID] {
+				continue
+			}
+			visited[e.Dest.ID] = true
+			walk(e.Dest, visited, append(edges, e))
+			delete(visited, e.Dest.ID)
+		}
+	}
+	for _, n := range sources {
+		walk(n, map[string]bool{n.ID: true}, []*Edge{})
+	}
+
+	sort.SliceStable(result.Paths, func(i, j int) bool {
+		pi, pj := result.Paths[i], result.Paths[j]
+		if rankBy == RankByResponseTime && pi.ResponseTime != pj.ResponseTime {
+			return pi.ResponseTime > pj.ResponseTime
+		}
+		if pi.PercentErr != pj.PercentErr {
+			return pi.PercentErr > pj.PercentErr
+		}
+		return len(pi.Edges) < len(pj.Edges)
+	})
+	if limit > 0 && len(result.Paths) > limit {
+		result.Paths = result.Paths[:limit]
+		result.Truncated = true
+	}
+
+	return result
+}
+
+func newPath(edges []*Edge) Path {
+	path := Path{
+		Nodes: []NodeReference{nodeReference(edges[0].Source)},
+		Edges: make([]PathEdge, 0, len(edges)),
+	}
+	success := 1.0
+	for _, e := range edges {
+		et := getEdgeTelemetry(e)
+		protocol, _ := e.Metadata[ProtocolKey].(string)
+		pe := PathEdge{
+			Source:          e.Source.ID,
+			Dest:            e.Dest.ID,
+			Protocol:        protocol,
+			Rate:            et.rate,
+			PercentErr:      percentErr(et),
+			ResponseTime:    et.responseTime,
+			HasResponseTime: et.hasRT,
+		}
+		path.Nodes = append(path.Nodes, nodeReference(e.Dest))
+		path.Edges = append(path.Edges, pe)
+		success *= 1 - math.Min(pe.PercentErr, 100)/100
+		path.ResponseTime += et.responseTime
+	}
+	path.PercentErr = (1 - success) * 100
+	return path
+}
+
+func nodeReference(n *Node) NodeReference {
+	return NodeReference{
+		ID:        n.ID,
+		NodeType:  n.NodeType,
+		Cluster:   n.Cluster,
+		Namespace: n.Namespace,
+		Workload:  n.Workload,
+		App:       n.App,
+		Version:   n.Version,
+		Service:   n.Service,
+	}
+}
+
+func nodeReferences(nodes []*Node) []NodeReference {
+	references := make([]NodeReference, 0, len(nodes))
+	for _, n := range nodes {
+		references = append(references, nodeReference(n))
+	}
+	return references
+}
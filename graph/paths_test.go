@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pathsTestTraffic is the bookinfo graph:
+//
+//	ingress -> productpage -> reviews-v1
+//	                       -> reviews-v2 -> ratings -> mongodb
+//	                       -> details
+func pathsTestTraffic() TrafficMap {
+	trafficMap := NewTrafficMap()
+	node := func(workload, app, version string) *Node {
+		n := NewNode("east", "bookinfo", "", "bookinfo", workload, app, version, GraphTypeVersionedApp)
+		trafficMap[n.ID] = &n
+		return &n
+	}
+	edge := func(source, dest *Node, rate, errRate, responseTime float64) {
+		e := source.AddEdge(dest)
+		e.Metadata[ProtocolKey] = "http"
+		e.Metadata[MetadataKey("http")] = rate
+		e.Metadata[MetadataKey("http5xx")] = errRate
+		e.Metadata[ResponseTime] = responseTime
+	}
+
+	ingress := node("ingress-v1", "ingress", "v1")
+	productpage := node("productpage-v1", "productpage", "v1")
+	reviewsV1 := node("reviews-v1", "reviews", "v1")
+	reviewsV2 := node("reviews-v2", "reviews", "v2")
+	ratings := node("ratings-v1", "ratings", "v1")
+	mongodb := node("mongodb-v1", "mongodb", "v1")
+	details := node("details-v1", "details", "v1")
+
+	edge(ingress, productpage, 10, 0, 100)
+	edge(productpage, reviewsV1, 5, 0, 20)
+	edge(productpage, reviewsV2, 5, 1, 60)
+	edge(productpage, details, 10, 0, 10)
+	edge(reviewsV2, ratings, 5, 0, 30)
+	edge(ratings, mongodb, 5, 0.5, 5)
+	return trafficMap
+}
+
+func workloads(dependencies []Dependency) map[string]int {
+	depths := make(map[string]int)
+	for _, d := range dependencies {
+		depths[d.Node.Workload] = d.Depth
+	}
+	return depths
+}
+
+func TestParseNodeSelector(t *testing.T) {
+	assert := assert.New(t)
+
+	selector, err := ParseNodeSelector("workload:bookinfo/reviews-v1")
+	require.NoError(t, err)
+	assert.Equal(NodeSelector{Kind: NodeSelectorWorkload, Namespace: "bookinfo", Name: "reviews-v1"}, selector)
+	assert.Equal("workload:bookinfo/reviews-v1", selector.String())
+
+	for _, invalid := range []string{"", "reviews", "app:reviews", "app:/reviews", "app:bookinfo/", "pod:bookinfo/reviews"} {
+		_, err := ParseNodeSelector(invalid)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestFindDependencies(t *testing.T) {
+	assert := assert.New(t)
+	trafficMap := pathsTestTraffic()
+
+	ratings := SelectNodes(trafficMap, NodeSelector{Kind: NodeSelectorApp, Namespace: "bookinfo", Name: "ratings"})
+	require.Len(t, ratings, 1)
+
+	dependencies := FindDependencies(trafficMap, ratings, 0, 0)
+	assert.Len(dependencies.Nodes, 1)
+	assert.Equal(map[string]int{"reviews-v2": 1, "productpage-v1": 2, "ingress-v1": 3}, workloads(dependencies.Upstream))
+	assert.Equal(map[string]int{"mongodb-v1": 1}, workloads(dependencies.Downstream))
+
+	dependencies = FindDependencies(trafficMap, ratings, 2, 0)
+	assert.Equal(map[string]int{"reviews-v2": 1, "productpage-v1": 2}, workloads(dependencies.Upstream))
+
+	// an app selects all its versions
+	reviews := SelectNodes(trafficMap, NodeSelector{Kind: NodeSelectorApp, Namespace: "bookinfo", Name: "reviews"})
+	require.Len(t, reviews, 2)
+	dependencies = FindDependencies(trafficMap, reviews, 1, 1)
+	assert.Equal(map[string]int{"productpage-v1": 1}, workloads(dependencies.Upstream))
+	assert.Equal(map[string]int{"ratings-v1": 1}, workloads(dependencies.Downstream))
+}
+
+func TestFindPaths(t *testing.T) {
+	assert := assert.New(t)
+	trafficMap := pathsTestTraffic()
+
+	sources := SelectNodes(trafficMap, NodeSelector{Kind: NodeSelectorWorkload, Namespace: "bookinfo", Name: "ingress-v1"})
+	destinations := SelectNodes(trafficMap, NodeSelector{Kind: NodeSelectorApp, Namespace: "bookinfo", Name: "reviews"})
+
+	paths := FindPaths(sources, destinations, 0, RankByErrorRate, 0)
+	require.Len(t, paths.Paths, 2)
+	assert.False(paths.Truncated)
+	worst := paths.Paths[0]
+	assert.Equal("reviews-v2", worst.Nodes[2].Workload)
+	assert.Len(worst.Edges, 2)
+	assert.InDelta(20.0, worst.PercentErr, 0.001)
+	assert.Equal(160.0, worst.ResponseTime)
+	assert.Equal("reviews-v1", paths.Paths[1].Nodes[2].Workload)
+	assert.Equal(0.0, paths.Paths[1].PercentErr)
+
+	// the error rates of the edges are combined
+	mongodb := SelectNodes(trafficMap, NodeSelector{Kind: NodeSelectorApp, Namespace: "bookinfo", Name: "mongodb"})
+	paths = FindPaths(sources, mongodb, 0, RankByResponseTime, 0)
+	require.Len(t, paths.Paths, 1)
+	assert.InDelta(100*(1-0.8*0.9), paths.Paths[0].PercentErr, 0.001)
+	assert.Equal(195.0, paths.Paths[0].ResponseTime)
+
+	// the depth and the limit
+	assert.Empty(FindPaths(sources, mongodb, 3, RankByErrorRate, 0).Paths)
+	paths = FindPaths(sources, destinations, 0, RankByResponseTime, 1)
+	assert.Len(paths.Paths, 1)
+	assert.True(paths.Truncated)
+
+	// no path against the traffic
+	assert.Empty(FindPaths(mongodb, sources, 0, RankByErrorRate, 0).Paths)
+}
+
+func TestFindPathsDenseGraph(t *testing.T) {
+	assert := assert.New(t)
+
+	// a complete graph holds a factorial number of simple paths, none reaching the unconnected destination
+	trafficMap := NewTrafficMap()
+	nodes := []*Node{}
+	for i := 0; i < 12; i++ {
+		n := NewNode("east", "dense", "", "dense", fmt.Sprintf("w%d", i), fmt.Sprintf("a%d", i), "v1", GraphTypeVersionedApp)
+		trafficMap[n.ID] = &n
+		nodes = append(nodes, &n)
+	}
+	for _, source := range nodes {
+		for _, dest := range nodes {
+			if source != dest {
+				source.AddEdge(dest)
+			}
+		}
+	}
+	sink := NewNode("east", "dense", "", "dense", "sink", "sink", "v1", GraphTypeVersionedApp)
+
+	paths := FindPaths(nodes[:1], []*Node{&sink}, 0, RankByErrorRate, 0)
+	assert.Empty(paths.Paths)
+	assert.True(paths.Truncated)
+}
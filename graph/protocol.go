@@ -239,6 +239,15 @@ func AddOutgoingEdgeToMetadata(sourceMetadata, edgeMetadata Metadata) {
 	}
 }
 
+// RemoveTCPEdgeFromMetadata subtracts the traffic of a TCP edge removed from the graph from the outgoing traffic of
+// its source node and the incoming traffic of its dest node
+func RemoveTCPEdgeFromMetadata(sourceMetadata, destMetadata, edgeMetadata Metadata) {
+	if val, valOk := edgeMetadata[tcp]; valOk {
+		subtractFromMetadataValue(sourceMetadata, tcpOut, val.(float64))
+		subtractFromMetadataValue(destMetadata, tcpIn, val.(float64))
+	}
+}
+
 // ResetOutgoingMetadata sets outgoing traffic to zero. This is useful for some graph type manipulations.
 func ResetOutgoingMetadata(sourceMetadata Metadata) {
 	delete(sourceMetadata, grpcOut)
@@ -320,6 +329,19 @@ func addToMetadataValue(md Metadata, k MetadataKey, v float64) {
 	}
 }
 
+// subtractFromMetadataValue removes the value from the metadata value, removed when nothing remains
+func subtractFromMetadataValue(md Metadata, k MetadataKey, v float64) {
+	curr, ok := md[k]
+	if !ok {
+		return
+	}
+	if remaining := curr.(float64) - v; remaining > 0 {
+		md[k] = remaining
+	} else {
+		delete(md, k)
+	}
+}
+
 // The metadata for response codes is two map of maps. Each response code is broken down by responseFlags:percentageOfTraffic and
 // hosts:percentagOfTraffic like this:
 // "200" : {
@@ -0,0 +1,137 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	core_v1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kube "k8s.io/client-go/kubernetes"
+
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	configMapInfoKey       = "kiali.io/graph-snapshot-info" // the annotation holding the Info of the snapshot
+	configMapNamePrefix    = "kiali-graph-snapshot-"
+	configMapSnapshotKey   = "kiali.io/graph-snapshot" // the label of the snapshot ConfigMaps, set to the snapshot name
+	configMapTrafficMapKey = "trafficMap"              // the binary data holding the traffic map of the snapshot
+	// maxConfigMapSize is the size limit of a ConfigMap, keeping some room for its metadata
+	maxConfigMapSize = 1000 * 1024
+)
+
+// ConfigMapStore stores every snapshot in a ConfigMap of a namespace, its Info in an annotation and its traffic
+// map in the binary data. The ConfigMaps are written with the Kiali service account, the users saving and reading
+// the snapshots are not required to access them. The Kiali service account needs a Role rule of the namespace
+// allowing it: apiGroups [""], resources ["configmaps"], verbs ["create", "get", "list", "delete"].
+type ConfigMapStore struct {
+	client    kube.Interface
+	namespace string
+}
+
+// NewConfigMapStore returns a store of the ConfigMaps of the namespace
+func NewConfigMapStore(client kube.Interface, namespace string) *ConfigMapStore {
+	return &ConfigMapStore{
+		client:    client,
+		namespace: namespace,
+	}
+}
+
+// Delete implements Store
+func (s *ConfigMapStore) Delete(name string) error {
+	if !IsValidName(name) {
+		return ErrNotFound
+	}
+	err := s.client.CoreV1().ConfigMaps(s.namespace).Delete(context.TODO(), s.configMapName(name), meta_v1.DeleteOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// Get implements Store
+func (s *ConfigMapStore) Get(name string) (*Snapshot, error) {
+	if !IsValidName(name) {
+		return nil, ErrNotFound
+	}
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), s.configMapName(name), meta_v1.GetOptions{})
+	if k8s_errors.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := decodeConfigMapInfo(cm)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{Info: *info, TrafficMap: cm.BinaryData[configMapTrafficMapKey]}, nil
+}
+
+// List implements Store. It decodes only the Info annotations of the ConfigMaps.
+func (s *ConfigMapStore) List() ([]Info, error) {
+	cms, err := s.client.CoreV1().ConfigMaps(s.namespace).List(context.TODO(), meta_v1.ListOptions{LabelSelector: configMapSnapshotKey})
+	if err != nil {
+		return nil, err
+	}
+	infos := []Info{}
+	for i := range cms.Items {
+		info, err := decodeConfigMapInfo(&cms.Items[i])
+		if err != nil {
+			log.Warningf("Skipping graph snapshot: %v", err)
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos, nil
+}
+
+// Save implements Store
+func (s *ConfigMapStore) Save(snapshot *Snapshot) error {
+	info, err := json.Marshal(snapshot.Info)
+	if err != nil {
+		return err
+	}
+	if size := len(info) + len(snapshot.TrafficMap); size > maxConfigMapSize {
+		return fmt.Errorf("snapshot of [%d] bytes exceeds the size of a ConfigMap, use the filesystem storage", size)
+	}
+
+	cm := &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      s.configMapName(snapshot.Name),
+			Namespace: s.namespace,
+			Annotations: map[string]string{
+				configMapInfoKey: string(info),
+			},
+			Labels: map[string]string{
+				configMapSnapshotKey: snapshot.Name,
+			},
+		},
+		BinaryData: map[string][]byte{
+			configMapTrafficMapKey: snapshot.TrafficMap,
+		},
+	}
+	_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(context.TODO(), cm, meta_v1.CreateOptions{})
+	if k8s_errors.IsAlreadyExists(err) {
+		return ErrExists
+	}
+	return err
+}
+
+func (s *ConfigMapStore) configMapName(name string) string {
+	return configMapNamePrefix + name
+}
+
+func decodeConfigMapInfo(cm *core_v1.ConfigMap) (*Info, error) {
+	info := &Info{}
+	if err := json.Unmarshal([]byte(cm.Annotations[configMapInfoKey]), info); err != nil {
+		return nil, fmt.Errorf("invalid graph snapshot ConfigMap [%s]: %v", cm.Name, err)
+	}
+	return info, nil
+}
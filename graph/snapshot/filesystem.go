@@ -0,0 +1,162 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kiali/kiali/log"
+)
+
+const (
+	infoFileExtension       = ".json"
+	trafficMapFileExtension = ".graph"
+)
+
+// FileStore stores every snapshot in two files of a directory, named after the snapshot: its Info in a JSON file,
+// and its traffic map in a .graph file. The JSON file is written last, a snapshot exists once it is written.
+type FileStore struct {
+	directory string
+	lock      sync.Mutex // serializes the saves and deletes, so that a name is saved once
+}
+
+// NewFileStore returns a store of the directory, created when missing
+func NewFileStore(directory string) (*FileStore, error) {
+	if directory == "" {
+		return nil, errors.New("the graph snapshots directory is not set")
+	}
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, err
+	}
+	return &FileStore{directory: directory}, nil
+}
+
+// Delete implements Store
+func (s *FileStore) Delete(name string) error {
+	if !IsValidName(name) {
+		return ErrNotFound
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := os.Remove(s.path(name, infoFileExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name, trafficMapFileExtension)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Get implements Store
+func (s *FileStore) Get(name string) (*Snapshot, error) {
+	if !IsValidName(name) {
+		return nil, ErrNotFound
+	}
+	info, err := s.readInfo(name)
+	if err != nil {
+		return nil, err
+	}
+	trafficMap, err := os.ReadFile(s.path(name, trafficMapFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{Info: *info, TrafficMap: trafficMap}, nil
+}
+
+// List implements Store. It reads only the JSON files of the snapshots.
+func (s *FileStore) List() ([]Info, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, err
+	}
+	infos := []Info{}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), infoFileExtension)
+		if entry.IsDir() || name == entry.Name() || !IsValidName(name) {
+			continue
+		}
+		info, err := s.readInfo(name)
+		if err != nil {
+			log.Warningf("Skipping graph snapshot [%s]: %v", name, err)
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos, nil
+}
+
+// Save implements Store. The files are written to temporary files renamed once complete, so that a failed save
+// leaves no partial snapshot.
+func (s *FileStore) Save(snapshot *Snapshot) error {
+	info, err := json.Marshal(snapshot.Info)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	infoPath := s.path(snapshot.Name, infoFileExtension)
+	if _, err := os.Stat(infoPath); err == nil {
+		return ErrExists
+	}
+
+	trafficMapPath := s.path(snapshot.Name, trafficMapFileExtension)
+	if err := s.writeFile(trafficMapPath, snapshot.Name, snapshot.TrafficMap); err != nil {
+		return err
+	}
+	if err := s.writeFile(infoPath, snapshot.Name, info); err != nil {
+		os.Remove(trafficMapPath)
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) path(name, extension string) string {
+	return filepath.Join(s.directory, name+extension)
+}
+
+func (s *FileStore) readInfo(name string) (*Info, error) {
+	data, err := os.ReadFile(s.path(name, infoFileExtension))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// writeFile writes the data to a temporary file of the directory, renamed to the path once complete
+func (s *FileStore) writeFile(path, name string, data []byte) error {
+	tmp, err := os.CreateTemp(s.directory, ".tmp-"+name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,120 @@
+// Package snapshot stores the graphs saved under a name, so that they can be replayed later, when re-querying
+// the telemetry is no longer possible: Prometheus retention, relabeling, etc. A snapshot holds the traffic map of
+// the graph, with the appender output, and the options of the graph request. The traffic map is encoded by the
+// graph package, it is rendered when the snapshot is read, by any config vendor and boxing, or diffed with another
+// snapshot. The description of a snapshot, its Info, is stored apart from the traffic map, so that the snapshots
+// are listed without reading their traffic maps.
+//
+// The snapshots are stored in a directory of the local filesystem, or in ConfigMaps, as set by the graph
+// snapshots config of the API.
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+)
+
+// The storages of the snapshots
+const (
+	StorageConfigMap  = "configmap"
+	StorageFilesystem = "filesystem"
+)
+
+var (
+	// ErrExists is returned when saving a snapshot under the name of a stored snapshot
+	ErrExists = errors.New("snapshot already exists")
+	// ErrNotFound is returned when getting a snapshot that is not stored
+	ErrNotFound = errors.New("snapshot not found")
+)
+
+// The names are DNS labels, valid file and ConfigMap names
+var nameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+var (
+	store     Store
+	storeErr  error
+	storeOnce sync.Once
+)
+
+// Info describes a snapshot, with the options of the graph request
+type Info struct {
+	BoxBy      string     `json:"boxBy"` // the boxing of the graph request, the default boxing of the rendered graph
+	Created    time.Time  `json:"created"`
+	Duration   string     `json:"duration"` // the query range of the graph
+	GraphType  string     `json:"graphType"`
+	Name       string     `json:"name"`
+	Namespaces []string   `json:"namespaces"`
+	Params     url.Values `json:"params"`    // the query params of the graph request
+	QueryTime  int64      `json:"queryTime"` // the end of the query range, unix time in seconds
+}
+
+// Snapshot is a graph saved under a name
+type Snapshot struct {
+	Info
+	TrafficMap []byte // the traffic map of the graph, as encoded by the graph package
+}
+
+// Store persists the snapshots
+type Store interface {
+	// Delete removes the snapshot of the name, ErrNotFound when it is not stored
+	Delete(name string) error
+	// Get returns the snapshot of the name, ErrNotFound when it is not stored
+	Get(name string) (*Snapshot, error)
+	// List returns the descriptions of the stored snapshots, sorted by name. The snapshots whose description
+	// can't be read are skipped with a warning.
+	List() ([]Info, error)
+	// Save stores the snapshot, ErrExists when a snapshot of the same name is stored
+	Save(s *Snapshot) error
+}
+
+// IsValidName returns true when the name is a DNS label, at most 63 lowercase alphanumeric characters or '-'
+func IsValidName(name string) bool {
+	return nameRegexp.MatchString(name)
+}
+
+// GetStore returns the store configured by the graph snapshots config, an error when the snapshots are disabled
+// or the store can't be initialized
+func GetStore() (Store, error) {
+	storeOnce.Do(func() {
+		snapshotsConfig := config.Get().API.Graph.Snapshots
+		if !snapshotsConfig.Enabled {
+			storeErr = errors.New("graph snapshots are disabled")
+			return
+		}
+
+		switch snapshotsConfig.Storage {
+		case StorageConfigMap:
+			namespace := snapshotsConfig.Namespace
+			if namespace == "" {
+				namespace = config.Get().Deployment.Namespace
+			}
+			restConfig, err := kubernetes.ConfigClient()
+			if err != nil {
+				storeErr = err
+				return
+			}
+			client, err := kubernetes.NewClientFromConfig(restConfig)
+			if err != nil {
+				storeErr = err
+				return
+			}
+			store = NewConfigMapStore(client.GetK8sApi(), namespace)
+		case StorageFilesystem, "":
+			store, storeErr = NewFileStore(snapshotsConfig.Directory)
+		default:
+			storeErr = fmt.Errorf("invalid graph snapshots storage [%s]", snapshotsConfig.Storage)
+		}
+		if storeErr != nil {
+			log.Errorf("Failed to initialize the graph snapshots store: %s", storeErr)
+		}
+	})
+	return store, storeErr
+}
@@ -0,0 +1,155 @@
+package snapshot
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newSnapshot(name string) *Snapshot {
+	return &Snapshot{
+		Info: Info{
+			BoxBy:      "app",
+			Created:    time.Unix(1650000000, 0).UTC(),
+			Duration:   "10m0s",
+			GraphType:  "versionedApp",
+			Name:       name,
+			Namespaces: []string{"bookinfo"},
+			Params:     url.Values{"namespaces": []string{"bookinfo"}},
+			QueryTime:  1650000000,
+		},
+		TrafficMap: []byte("traffic map of " + name),
+	}
+}
+
+func testStore(t *testing.T, store Store) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	infos, err := store.List()
+	require.NoError(err)
+	assert.Empty(infos)
+
+	_, err = store.Get("incident")
+	assert.Equal(ErrNotFound, err)
+
+	require.NoError(store.Save(newSnapshot("incident")))
+	require.NoError(store.Save(newSnapshot("baseline")))
+	assert.Equal(ErrExists, store.Save(newSnapshot("incident")))
+
+	s, err := store.Get("incident")
+	require.NoError(err)
+	assert.Equal(newSnapshot("incident").Info, s.Info)
+	assert.Equal(newSnapshot("incident").TrafficMap, s.TrafficMap)
+
+	infos, err = store.List()
+	require.NoError(err)
+	require.Len(infos, 2)
+	assert.Equal("baseline", infos[0].Name)
+	assert.Equal("incident", infos[1].Name)
+
+	require.NoError(store.Delete("incident"))
+	assert.Equal(ErrNotFound, store.Delete("incident"))
+	_, err = store.Get("incident")
+	assert.Equal(ErrNotFound, err)
+	infos, err = store.List()
+	require.NoError(err)
+	require.Len(infos, 1)
+	assert.Equal("baseline", infos[0].Name)
+
+	// a deleted name can be saved again
+	require.NoError(store.Save(newSnapshot("incident")))
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir() + "/snapshots")
+	require.NoError(t, err)
+
+	testStore(t, store)
+}
+
+func TestFileStoreListSkipsInvalidSnapshots(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	directory := t.TempDir()
+	store, err := NewFileStore(directory)
+	require.NoError(err)
+	require.NoError(store.Save(newSnapshot("incident")))
+	require.NoError(os.WriteFile(filepath.Join(directory, "broken.json"), []byte("{"), 0o600))
+	// the traffic maps are not read by the listing
+	require.NoError(os.Remove(filepath.Join(directory, "incident.graph")))
+
+	infos, err := store.List()
+	require.NoError(err)
+	require.Len(infos, 1)
+	assert.Equal(newSnapshot("incident").Info, infos[0])
+}
+
+func TestConfigMapStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := NewConfigMapStore(client, "istio-system")
+
+	testStore(t, store)
+
+	_, err := client.CoreV1().ConfigMaps("istio-system").Get(context.TODO(), "kiali-graph-snapshot-incident", meta_v1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestConfigMapStoreListSkipsInvalidSnapshots(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	broken := &core_v1.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        "kiali-graph-snapshot-broken",
+			Namespace:   "istio-system",
+			Annotations: map[string]string{configMapInfoKey: "{"},
+			Labels:      map[string]string{configMapSnapshotKey: "broken"},
+		},
+	}
+	store := NewConfigMapStore(fake.NewSimpleClientset(broken), "istio-system")
+	require.NoError(store.Save(newSnapshot("incident")))
+
+	infos, err := store.List()
+	require.NoError(err)
+	require.Len(infos, 1)
+	assert.Equal(newSnapshot("incident").Info, infos[0])
+
+	_, err = store.Get("broken")
+	assert.Error(err)
+}
+
+func TestConfigMapStoreSizeLimit(t *testing.T) {
+	store := NewConfigMapStore(fake.NewSimpleClientset(), "istio-system")
+
+	s := newSnapshot("huge")
+	s.TrafficMap = []byte(strings.Repeat("x", maxConfigMapSize))
+	assert.Error(t, store.Save(s))
+
+	_, err := store.Get("huge")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestIsValidName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsValidName("incident-2022-04-15"))
+	assert.True(IsValidName("a"))
+	assert.True(IsValidName(strings.Repeat("a", 63)))
+	assert.False(IsValidName(""))
+	assert.False(IsValidName(strings.Repeat("a", 64)))
+	assert.False(IsValidName("Incident"))
+	assert.False(IsValidName("-incident"))
+	assert.False(IsValidName("../incident"))
+}
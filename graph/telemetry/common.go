@@ -5,7 +5,9 @@ package telemetry
 import (
 	"fmt"
 
+	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
 	"github.com/kiali/kiali/log"
 )
 
@@ -46,6 +48,71 @@ func MergeTrafficMaps(trafficMap graph.TrafficMap, ns string, nsTrafficMap graph
 	}
 }
 
+// ParseSupportedAppenders returns the requested appenders and finalizers supported by a vendor. It is used by the
+// vendors not providing the telemetry required by some appenders, the other requested appenders are skipped.
+func ParseSupportedAppenders(o graph.TelemetryOptions, supported map[string]bool) (appenders []graph.Appender, finalizers []graph.Appender) {
+	requestedAppenders, requestedFinalizers := appender.ParseAppenders(o)
+	for _, a := range requestedAppenders {
+		if supported[a.Name()] {
+			appenders = append(appenders, a)
+		}
+	}
+	for _, f := range requestedFinalizers {
+		if supported[f.Name()] {
+			finalizers = append(finalizers, f)
+		}
+	}
+	return appenders, finalizers
+}
+
+// HomeCluster returns the cluster of the Kiali control plane, resolved once and kept in the global info
+func HomeCluster(globalInfo *graph.AppenderGlobalInfo) string {
+	if globalInfo.HomeCluster == "" {
+		globalInfo.HomeCluster = business.DefaultClusterID
+		c, err := globalInfo.Business.Mesh.ResolveKialiControlPlaneCluster(nil)
+		graph.CheckError(err)
+		if c != nil {
+			globalInfo.HomeCluster = c.Name
+		}
+	}
+	return globalInfo.HomeCluster
+}
+
+// ReduceToNode reduces the traffic map to the node, the nodes of its incoming and outgoing edges, and these edges.
+// It is used by the vendors building the node graph from the namespace graph.
+func ReduceToNode(trafficMap graph.TrafficMap, id string) graph.TrafficMap {
+	nodeTrafficMap := graph.NewTrafficMap()
+	n, ok := trafficMap[id]
+	if !ok {
+		return nodeTrafficMap
+	}
+	nodeTrafficMap[id] = n
+	for _, e := range n.Edges {
+		nodeTrafficMap[e.Dest.ID] = e.Dest
+	}
+	for sourceID, source := range trafficMap {
+		for _, e := range source.Edges {
+			if e.Dest.ID == id {
+				nodeTrafficMap[sourceID] = source
+			}
+		}
+	}
+
+	for nodeID, node := range nodeTrafficMap {
+		if nodeID == id {
+			continue
+		}
+		edges := []*graph.Edge{}
+		for _, e := range node.Edges {
+			if e.Dest.ID == id {
+				edges = append(edges, e)
+			}
+		}
+		node.Edges = edges
+	}
+	return nodeTrafficMap
+}
+
 // ReduceToServiceGraph compresses a [service-injected workload] graph by removing
 // the workload nodes such that, with exception of non-service root nodes, the resulting
 // graph has edges only from and to service nodes.  It is typically the last thing called
@@ -0,0 +1,299 @@
+package appender
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	// AnomalyAppenderName uniquely identifies the appender: anomaly
+	AnomalyAppenderName = "anomaly"
+)
+
+const (
+	anomalyResolution = "1m" // the resolution of the baseline samples
+
+	// the minimum standard deviations, a steady baseline would flag any change
+	minErrorRateStddev    = 0.1  // in percentage points
+	minRequestRateStddev  = 0.01 // in requests per second
+	minResponseTimeStddev = 1.0  // in millis
+	minStddevRatio        = 0.1  // the fraction of the baseline mean
+)
+
+// anomalyOffsets are the offsets of the baseline windows
+var anomalyOffsets = []string{"1d", "1w"}
+
+// anomalyBaseline holds the mean and standard deviation of a metric over each baseline window, key=offset
+type anomalyBaseline struct {
+	means   map[string]float64
+	stddevs map[string]float64
+}
+
+// AnomalyAppender is responsible for flagging the traffic deviating from its baseline. The request rate, error
+// rate and average response time of the request edges are compared to the same window one day and one week
+// earlier: the baseline is sampled every minute of both windows and the deviation is measured as a z-score, the
+// number of standard deviations from the baseline mean. The metrics beyond the thresholds of the anomaly config
+// are added to the edges, and to their destination nodes with the largest deviation of their incoming edges:
+//   - anomaly: the delta from the baseline mean and the z-score of each deviating metric
+//
+// Like response times, the baseline is reported using destination proxy telemetry, when available. Edges are
+// evaluated with the namespace of their destination.
+// Name: anomaly
+type AnomalyAppender struct {
+	GraphType          string
+	InjectServiceNodes bool
+	Namespaces         graph.NamespaceInfoMap
+	QueryTime          int64 // unix time in seconds
+	Rates              graph.RequestedRates
+}
+
+// Name implements Appender
+func (a AnomalyAppender) Name() string {
+	return AnomalyAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a AnomalyAppender) IsFinalizer() bool {
+	return false
+}
+
+// AppendGraph implements Appender
+func (a AnomalyAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 {
+		return
+	}
+
+	// Anomalies only apply to request traffic (not TCP or gRPC-message traffic)
+	if a.Rates.Grpc != graph.RateRequests && a.Rates.Http != graph.RateRequests {
+		return
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		graph.CheckError(err)
+	}
+
+	a.appendGraph(trafficMap, namespaceInfo.Namespace, globalInfo.PromClient)
+}
+
+func (a AnomalyAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, client *prometheus.Client) {
+	log.Tracef("Generating anomalies; namespace = %v", namespace)
+
+	conf := config.Get().API.Graph.Anomaly
+
+	// the same queries as the responseTime appender provide the current average response time of the edges,
+	// and map the baseline time series to the edges
+	rta := ResponseTimeAppender{
+		GraphType:          a.GraphType,
+		InjectServiceNodes: a.InjectServiceNodes,
+		Namespaces:         a.Namespaces,
+		Quantile:           0.0,
+		QueryTime:          a.QueryTime,
+		Rates:              a.Rates,
+	}
+
+	var requestRates, errorRates, responseTimes map[string]*anomalyBaseline
+	var currentResponseTimes map[string]float64
+	if conf.RequestRateThreshold > 0 {
+		requestRates = a.getBaselines(namespace, requestRateExpr, rta, client)
+	}
+	if conf.ErrorRateThreshold > 0 {
+		errorRates = a.getBaselines(namespace, errorRateExpr, rta, client)
+	}
+	if conf.ResponseTimeThreshold > 0 {
+		responseTimes = a.getBaselines(namespace, responseTimeExpr, rta, client)
+		currentResponseTimes = rta.getResponseTimeMap(namespace, client)
+	}
+
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			// evaluate edges with the namespace of their destination, or of their source when the destination
+			// namespace is not requested
+			_, isRequestedDest := a.Namespaces[e.Dest.Namespace]
+			if e.Dest.Namespace != namespace && (isRequestedDest || e.Source.Namespace != namespace) {
+				continue
+			}
+			protocol := e.Metadata[graph.ProtocolKey].(string)
+			if protocol != graph.HTTP.Name && protocol != graph.GRPC.Name {
+				continue
+			}
+
+			key := fmt.Sprintf("%s %s %s", e.Source.ID, e.Dest.ID, protocol)
+			total, errs := edgeRequestRates(e)
+			anomaly := graph.AnomalyMetadata{
+				RequestRate: evaluateAnomaly(total, requestRates[key], conf.RequestRateThreshold, minRequestRateStddev),
+			}
+			if total > 0 {
+				anomaly.ErrorRate = evaluateAnomaly(100*errs/total, errorRates[key], conf.ErrorRateThreshold, minErrorRateStddev)
+			}
+			if responseTime, ok := currentResponseTimes[key]; ok {
+				anomaly.ResponseTime = evaluateAnomaly(responseTime, responseTimes[key], conf.ResponseTimeThreshold, minResponseTimeStddev)
+			}
+
+			if anomaly.ErrorRate != nil || anomaly.RequestRate != nil || anomaly.ResponseTime != nil {
+				e.Metadata[graph.Anomaly] = &anomaly
+				addNodeAnomaly(e.Dest, &anomaly)
+			}
+		}
+	}
+}
+
+// requestRateExpr returns the request rate of the edges for the selector, in requests per second
+func requestRateExpr(selector, groupBy string) string {
+	return fmt.Sprintf(`sum(rate(istio_requests_total{%s}[%s])) by (%s)`, selector, anomalyResolution, groupBy)
+}
+
+// errorRateExpr returns the error rate of the edges for the selector, in percentage of the requests
+func errorRateExpr(selector, groupBy string) string {
+	total := requestRateExpr(selector, groupBy)
+	errs := fmt.Sprintf(`sum(rate(istio_requests_total{%s,request_protocol="http",response_code=~"^0$|^[4-5]\\d\\d$"}[%s])) by (%s) or sum(rate(istio_requests_total{%s,request_protocol="grpc",grpc_response_status=~"^[1-9]$|^1[0-6]$"}[%s])) by (%s)`,
+		selector, anomalyResolution, groupBy, selector, anomalyResolution, groupBy)
+	// the samples without traffic are dropped, not to average NaN values
+	return fmt.Sprintf(`(%s or %s * 0) / (%s > 0) * 100`, errs, total, total)
+}
+
+// responseTimeExpr returns the average response time of the edges for the selector, in millis
+func responseTimeExpr(selector, groupBy string) string {
+	return fmt.Sprintf(`sum(rate(istio_request_duration_milliseconds_sum{%s}[%s])) by (%s) / (sum(rate(istio_request_duration_milliseconds_count{%s}[%s])) by (%s) > 0)`,
+		selector, anomalyResolution, groupBy, selector, anomalyResolution, groupBy)
+}
+
+// getBaselines returns the baselines of a metric for the namespace edges, keyed by "<sourceID> <destID> <protocol>"
+func (a AnomalyAppender) getBaselines(namespace string, expr func(selector, groupBy string) string, rta ResponseTimeAppender, client *prometheus.Client) map[string]*anomalyBaseline {
+	duration := a.Namespaces[namespace].Duration
+	groupBy := "source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol"
+
+	// the query order is important as both queries may have overlapping results for edges within the namespace,
+	// the destination proxy telemetry must come first.
+	selectors := []string{
+		fmt.Sprintf(`reporter="destination",destination_service_namespace="%s"`, namespace),
+		fmt.Sprintf(`reporter="source",source_workload_namespace="%s"`, namespace),
+	}
+
+	// the values of each offset and stat are mapped to the edges like the response times, key=offset stat
+	valueMaps := make(map[string]map[string]float64)
+	for _, selector := range selectors {
+		query := baselineQuery(expr(selector, groupBy), duration)
+		vector := promQuery(query, time.Unix(a.QueryTime, 0), client.GetContext(), client.API(), a)
+
+		vectors := make(map[string]model.Vector)
+		for _, s := range vector {
+			statKey := fmt.Sprintf("%s %s", s.Metric["offset"], s.Metric["stat"])
+			vectors[statKey] = append(vectors[statKey], s)
+		}
+		for statKey, statVector := range vectors {
+			valueMap, ok := valueMaps[statKey]
+			if !ok {
+				valueMap = make(map[string]float64)
+				valueMaps[statKey] = valueMap
+			}
+			rta.populateResponseTimeMap(valueMap, &statVector)
+		}
+	}
+
+	baselines := make(map[string]*anomalyBaseline)
+	for _, offset := range anomalyOffsets {
+		for key, mean := range valueMaps[offset+" mean"] {
+			b, ok := baselines[key]
+			if !ok {
+				b = &anomalyBaseline{means: map[string]float64{}, stddevs: map[string]float64{}}
+				baselines[key] = b
+			}
+			b.means[offset] = mean
+			b.stddevs[offset] = valueMaps[offset+" stddev"][key]
+		}
+	}
+	return baselines
+}
+
+// baselineQuery returns the query of the mean and standard deviation of the expression over each baseline window,
+// labeled with the offset and the stat of the window.
+func baselineQuery(expr string, duration time.Duration) string {
+	query := ""
+	for _, offset := range anomalyOffsets {
+		for _, stat := range []struct{ name, fn string }{{"mean", "avg_over_time"}, {"stddev", "stddev_over_time"}} {
+			term := fmt.Sprintf(`label_replace(label_replace(%s((%s)[%vs:%s] offset %s), "offset", "%s", "", ""), "stat", "%s", "", "")`,
+				stat.fn,
+				expr,
+				int(duration.Seconds()), // range duration for the query
+				anomalyResolution,
+				offset,
+				offset,
+				stat.name)
+			if query == "" {
+				query = term
+			} else {
+				query = fmt.Sprintf("%s or %s", query, term)
+			}
+		}
+	}
+	return query
+}
+
+// stats combines the baseline windows into a mean and standard deviation, false if no window has traffic
+func (b *anomalyBaseline) stats() (mean, stddev float64, ok bool) {
+	if b == nil || len(b.means) == 0 {
+		return 0, 0, false
+	}
+
+	for _, m := range b.means {
+		mean += m
+	}
+	mean /= float64(len(b.means))
+
+	// the variance of the windows, plus the variance of their means
+	variance := 0.0
+	for offset, m := range b.means {
+		variance += b.stddevs[offset]*b.stddevs[offset] + (m-mean)*(m-mean)
+	}
+	variance /= float64(len(b.means))
+
+	return mean, math.Sqrt(variance), true
+}
+
+// evaluateAnomaly returns the deviation of the value from the baseline, nil when below the z-score threshold
+func evaluateAnomaly(value float64, b *anomalyBaseline, threshold, minStddev float64) *graph.AnomalyDelta {
+	if threshold <= 0 || math.IsNaN(value) {
+		return nil
+	}
+	mean, stddev, ok := b.stats()
+	if !ok {
+		return nil
+	}
+
+	stddev = math.Max(stddev, math.Max(minStddev, minStddevRatio*math.Abs(mean)))
+	zScore := (value - mean) / stddev
+	if math.Abs(zScore) < threshold {
+		return nil
+	}
+	return &graph.AnomalyDelta{Delta: value - mean, ZScore: zScore}
+}
+
+// addNodeAnomaly keeps on the node, for each metric, the largest deviation of its incoming edges
+func addNodeAnomaly(n *graph.Node, edgeAnomaly *graph.AnomalyMetadata) {
+	nodeAnomaly, ok := n.Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	if !ok {
+		nodeAnomaly = &graph.AnomalyMetadata{}
+		n.Metadata[graph.Anomaly] = nodeAnomaly
+	}
+
+	largest := func(nodeDelta, edgeDelta *graph.AnomalyDelta) *graph.AnomalyDelta {
+		if edgeDelta == nil || (nodeDelta != nil && math.Abs(nodeDelta.ZScore) >= math.Abs(edgeDelta.ZScore)) {
+			return nodeDelta
+		}
+		delta := *edgeDelta
+		return &delta
+	}
+	nodeAnomaly.ErrorRate = largest(nodeAnomaly.ErrorRate, edgeAnomaly.ErrorRate)
+	nodeAnomaly.RequestRate = largest(nodeAnomaly.RequestRate, edgeAnomaly.RequestRate)
+	nodeAnomaly.ResponseTime = largest(nodeAnomaly.ResponseTime, edgeAnomaly.ResponseTime)
+}
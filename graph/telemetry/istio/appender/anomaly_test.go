@@ -0,0 +1,140 @@
+package appender
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+)
+
+func TestAnomaly(t *testing.T) {
+	assert := assert.New(t)
+
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// only the error rate is evaluated
+	conf := config.NewConfig()
+	conf.API.Graph.Anomaly = config.ApiGraphAnomalyConfig{ErrorRateThreshold: 3}
+	config.Set(conf)
+
+	// productpage to reviews had no errors, reviews to ratings had 2% of errors
+	baseline := model.Vector{}
+	for _, offset := range anomalyOffsets {
+		baseline = append(baseline,
+			anomalyTestSample("productpage", "reviews", offset, "mean", 0),
+			anomalyTestSample("productpage", "reviews", offset, "stddev", 0),
+			anomalyTestSample("reviews", "ratings", offset, "mean", 2),
+			anomalyTestSample("reviews", "ratings", offset, "stddev", 0.5),
+		)
+	}
+	api.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `reporter="destination"`)
+	}), mock.Anything).Return(baseline)
+	api.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `reporter="source"`)
+	}), mock.Anything).Return(model.Vector{})
+
+	trafficMap := graph.NewTrafficMap()
+	productpage := anomalyTestNode(trafficMap, "productpage")
+	reviews := anomalyTestNode(trafficMap, "reviews")
+	ratings := anomalyTestNode(trafficMap, "ratings")
+	// 1% of errors, 2.1% of errors
+	addSLOTestEdge(productpage, reviews, 10.0, 0.1)
+	addSLOTestEdge(reviews, ratings, 10.0, 0.21)
+
+	a := AnomalyAppender{
+		GraphType:  graph.GraphTypeWorkload,
+		Namespaces: graph.NamespaceInfoMap{"bookinfo": graph.NamespaceInfo{Name: "bookinfo", Duration: time.Minute}},
+		QueryTime:  time.Now().Unix(),
+		Rates:      graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests, Tcp: graph.RateSent},
+	}
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.PromClient = client
+	a.AppendGraph(trafficMap, globalInfo, graph.NewAppenderNamespaceInfo("bookinfo"))
+
+	anomaly, ok := productpage.Edges[0].Metadata[graph.Anomaly].(*graph.AnomalyMetadata)
+	if assert.True(ok) {
+		assert.Nil(anomaly.RequestRate)
+		assert.Nil(anomaly.ResponseTime)
+		if assert.NotNil(anomaly.ErrorRate) {
+			assert.InDelta(1.0, anomaly.ErrorRate.Delta, 0.0001)
+			assert.InDelta(10.0, anomaly.ErrorRate.ZScore, 0.0001)
+		}
+	}
+	assert.Equal(anomaly, reviews.Metadata[graph.Anomaly])
+
+	assert.NotContains(reviews.Edges[0].Metadata, graph.Anomaly)
+	assert.NotContains(ratings.Metadata, graph.Anomaly)
+	assert.NotContains(productpage.Metadata, graph.Anomaly)
+}
+
+func TestEvaluateAnomaly(t *testing.T) {
+	assert := assert.New(t)
+
+	steady := &anomalyBaseline{
+		means:   map[string]float64{"1d": 100, "1w": 100},
+		stddevs: map[string]float64{"1d": 0, "1w": 0},
+	}
+	// the standard deviation is at least 10% of the mean
+	assert.Nil(evaluateAnomaly(120, steady, 3, minRequestRateStddev))
+	if delta := evaluateAnomaly(40, steady, 3, minRequestRateStddev); assert.NotNil(delta) {
+		assert.Equal(-60.0, delta.Delta)
+		assert.Equal(-6.0, delta.ZScore)
+	}
+
+	// the windows differ, their means add to the variance
+	diverging := &anomalyBaseline{
+		means:   map[string]float64{"1d": 50, "1w": 150},
+		stddevs: map[string]float64{"1d": 0, "1w": 0},
+	}
+	mean, stddev, ok := diverging.stats()
+	assert.True(ok)
+	assert.Equal(100.0, mean)
+	assert.Equal(50.0, stddev)
+	assert.Nil(evaluateAnomaly(40, diverging, 3, minRequestRateStddev))
+
+	// no baseline, or a disabled threshold
+	assert.Nil(evaluateAnomaly(40, nil, 3, minRequestRateStddev))
+	assert.Nil(evaluateAnomaly(40, steady, 0, minRequestRateStddev))
+}
+
+func anomalyTestNode(trafficMap graph.TrafficMap, app string) *graph.Node {
+	node := graph.NewNode(business.DefaultClusterID, "bookinfo", app, "bookinfo", app+"-v1", app, "v1", graph.GraphTypeWorkload)
+	trafficMap[node.ID] = &node
+	return &node
+}
+
+func anomalyTestSample(source, dest, offset, stat string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			"source_cluster":                 business.DefaultClusterID,
+			"source_workload_namespace":      "bookinfo",
+			"source_workload":                model.LabelValue(source + "-v1"),
+			"source_canonical_service":       model.LabelValue(source),
+			"source_canonical_revision":      "v1",
+			"destination_cluster":            business.DefaultClusterID,
+			"destination_service_namespace":  "bookinfo",
+			"destination_service":            model.LabelValue(dest + ".bookinfo.svc.cluster.local"),
+			"destination_service_name":       model.LabelValue(dest),
+			"destination_workload_namespace": "bookinfo",
+			"destination_workload":           model.LabelValue(dest + "-v1"),
+			"destination_canonical_service":  model.LabelValue(dest),
+			"destination_canonical_revision": "v1",
+			"request_protocol":               "http",
+			"offset":                         model.LabelValue(offset),
+			"stat":                           model.LabelValue(stat),
+		},
+		Value: model.SampleValue(value),
+	}
+}
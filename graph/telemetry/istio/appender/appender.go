@@ -29,6 +29,10 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 			// namespace appenders
 			case AggregateNodeAppenderName:
 				requestedAppenders[AggregateNodeAppenderName] = true
+			case AnomalyAppenderName:
+				requestedAppenders[AnomalyAppenderName] = true
+			case CostAppenderName:
+				requestedAppenders[CostAppenderName] = true
 			case DeadNodeAppenderName:
 				requestedAppenders[DeadNodeAppenderName] = true
 			case IdleNodeAppenderName:
@@ -43,6 +47,8 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 				requestedAppenders[ServiceEntryAppenderName] = true
 			case SidecarsCheckAppenderName:
 				requestedAppenders[SidecarsCheckAppenderName] = true
+			case SLOAppenderName:
+				requestedAppenders[SLOAppenderName] = true
 			case ThroughputAppenderName:
 				requestedAppenders[ThroughputAppenderName] = true
 			case WorkloadEntryAppenderName:
@@ -59,6 +65,10 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 				requestedFinalizers[LabelerAppenderName] = true
 			case OutsiderAppenderName, TrafficGeneratorAppenderName:
 				// skip - these are always run, ignore if specified
+			case BoxLabelAppenderName:
+				// skip - this is run when boxBy label:<key> is supplied, ignore if specified
+			case HideAppenderName:
+				// skip - this is run when the hide query param is supplied, ignore if specified
 			case "":
 				// skip
 			default:
@@ -147,6 +157,38 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 		}
 		appenders = append(appenders, a)
 	}
+	// the SLO appender is run only when requested, it is not part of the default appenders
+	if _, ok := requestedAppenders[SLOAppenderName]; ok {
+		a := SLOAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			QueryTime:          o.QueryTime,
+			Rates:              o.Rates,
+		}
+		appenders = append(appenders, a)
+	}
+	// the anomaly appender is run only when requested, it is not part of the default appenders
+	if _, ok := requestedAppenders[AnomalyAppenderName]; ok {
+		a := AnomalyAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			QueryTime:          o.QueryTime,
+			Rates:              o.Rates,
+		}
+		appenders = append(appenders, a)
+	}
+	// the cost appender is run only when requested, it is not part of the default appenders
+	if _, ok := requestedAppenders[CostAppenderName]; ok {
+		a := CostAppender{
+			GraphType:          o.GraphType,
+			InjectServiceNodes: o.InjectServiceNodes,
+			Namespaces:         o.Namespaces,
+			QueryTime:          o.QueryTime,
+		}
+		appenders = append(appenders, a)
+	}
 	if _, ok := requestedAppenders[AggregateNodeAppenderName]; ok || o.Appenders.All {
 		aggregate := o.NodeOptions.Aggregate
 		if aggregate == "" {
@@ -209,14 +251,29 @@ func ParseAppenders(o graph.TelemetryOptions) (appenders []graph.Appender, final
 		finalizers = append(finalizers, &LabelerAppender{})
 	}
 
+	// if label boxing is requested, run the boxLabel finalizer after the outsider finalizer
+	if o.BoxByLabel != "" {
+		finalizers = append(finalizers, &BoxLabelAppender{
+			Key: o.BoxByLabel,
+		})
+	}
+
 	// always run the traffic generator finalizer
 	finalizers = append(finalizers, &TrafficGeneratorAppender{})
 
+	// if hide finalizer is to be run, do it last, the expression may use the information added by the other finalizers
+	if o.Hide != nil {
+		finalizers = append(finalizers, &HideAppender{
+			Hide: o.Hide,
+		})
+	}
+
 	return appenders, finalizers
 }
 
 const (
 	appsMapKey           = "appsMapKey"           // global vendor info map[namespace]appsMap
+	namespaceLabelsKey   = "namespaceLabelsKey"   // global vendor info map[namespace]labels
 	serviceEntryHostsKey = "serviceEntryHostsKey" // global vendor info service entries for all accessible namespaces
 	serviceListKey       = "serviceListKey"       // global vendor info map[namespace]serviceDefinitionList
 	workloadListKey      = "workloadListKey"      // global vendor info map[namespace]workloadListKey
@@ -353,6 +410,26 @@ func getAppWorkloads(namespace, app, version string, gi *graph.AppenderGlobalInf
 	return result
 }
 
+func getNamespaceLabels(namespace string, gi *graph.AppenderGlobalInfo) map[string]string {
+	var namespaceLabelsMap map[string]map[string]string
+	if existingNamespaceLabelsMap, ok := gi.Vendor[namespaceLabelsKey]; ok {
+		namespaceLabelsMap = existingNamespaceLabelsMap.(map[string]map[string]string)
+	} else {
+		namespaceLabelsMap = make(map[string]map[string]string)
+		gi.Vendor[namespaceLabelsKey] = namespaceLabelsMap
+	}
+
+	if labels, ok := namespaceLabelsMap[namespace]; ok {
+		return labels
+	}
+
+	ns, err := gi.Business.Namespace.GetNamespace(context.TODO(), namespace)
+	graph.CheckError(err)
+	namespaceLabelsMap[namespace] = ns.Labels
+
+	return ns.Labels
+}
+
 func getApp(namespace, appName string, gi *graph.AppenderGlobalInfo) (*models.AppListItem, bool) {
 	if appName == "" || appName == graph.Unknown {
 		return nil, false
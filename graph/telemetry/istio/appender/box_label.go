@@ -0,0 +1,86 @@
+package appender
+
+import (
+	"github.com/kiali/kiali/graph"
+)
+
+const BoxLabelAppenderName = "boxLabel"
+
+// BoxLabelAppender is responsible for setting the value of the boxBy label on the nodes, so that the config
+// vendors can box the nodes by label. The value is the label of the backing workloads of the node, or the label
+// of its namespace when the workloads don't set it. The nodes without a value are not boxed.
+// Name: boxLabel
+type BoxLabelAppender struct {
+	Key string // the label key
+}
+
+// Name implements Appender
+func (a *BoxLabelAppender) Name() string {
+	return BoxLabelAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a BoxLabelAppender) IsFinalizer() bool {
+	return true
+}
+
+// AppendGraph implements Appender
+func (a *BoxLabelAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, _namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 || a.Key == "" {
+		return
+	}
+
+	a.labelNodes(trafficMap, globalInfo)
+}
+
+func (a *BoxLabelAppender) labelNodes(trafficMap graph.TrafficMap, gi *graph.AppenderGlobalInfo) {
+	for _, n := range trafficMap {
+		// can't get labels for nodes on the outside or inaccessible nodes, so just go to the next and ignore this one.
+		if b, ok := n.Metadata[graph.IsOutside]; ok && b.(bool) {
+			continue
+		}
+		if b, ok := n.Metadata[graph.IsInaccessible]; ok && b.(bool) {
+			continue
+		}
+		if !graph.IsOK(n.Namespace) {
+			continue
+		}
+
+		value := a.workloadsValue(n, gi)
+		if value == "" {
+			value = getNamespaceLabels(n.Namespace, gi)[a.Key]
+		}
+		if value != "" {
+			n.Metadata[graph.BoxLabel] = value
+		}
+	}
+}
+
+// workloadsValue returns the label value shared by the backing workloads of the node, empty if the workloads
+// don't set it or set different values.
+func (a *BoxLabelAppender) workloadsValue(n *graph.Node, gi *graph.AppenderGlobalInfo) string {
+	switch n.NodeType {
+	case graph.NodeTypeApp:
+		if graph.IsOK(n.Workload) {
+			// the node is a "versioned-app" node
+			if wl, ok := getWorkload(n.Namespace, n.Workload, gi); ok {
+				return wl.Labels[a.Key]
+			}
+			return ""
+		}
+		value := ""
+		for _, wl := range getAppWorkloads(n.Namespace, n.App, n.Version, gi) {
+			wlValue := wl.Labels[a.Key]
+			if wlValue == "" || (value != "" && wlValue != value) {
+				return ""
+			}
+			value = wlValue
+		}
+		return value
+	case graph.NodeTypeWorkload:
+		if wl, ok := getWorkload(n.Namespace, n.Workload, gi); ok {
+			return wl.Labels[a.Key]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+)
+
+func TestBoxLabelWorkload(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildWorkloadTrafficMap()
+	businessLayer := setupSidecarsCheckWorkloads(buildFakeWorkloadDeployments(), buildFakeWorkloadPods())
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = businessLayer
+
+	a := BoxLabelAppender{Key: "wk"}
+	a.AppendGraph(trafficMap, globalInfo, nil)
+
+	for _, node := range trafficMap {
+		assert.Equal(t, "wk-1", node.Metadata[graph.BoxLabel])
+	}
+}
+
+func TestBoxLabelApp(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildAppTrafficMap()
+	businessLayer := setupSidecarsCheckWorkloads(buildFakeWorkloadDeployments(), buildFakeWorkloadPods())
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = businessLayer
+
+	a := BoxLabelAppender{Key: "wk"}
+	a.AppendGraph(trafficMap, globalInfo, nil)
+
+	for _, node := range trafficMap {
+		assert.Equal(t, "wk-1", node.Metadata[graph.BoxLabel])
+	}
+}
+
+func TestBoxLabelSkipsOutsideNodes(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildWorkloadTrafficMap()
+	for _, node := range trafficMap {
+		node.Metadata[graph.IsOutside] = true
+	}
+
+	a := BoxLabelAppender{Key: "wk"}
+	a.AppendGraph(trafficMap, graph.NewAppenderGlobalInfo(), nil)
+
+	for _, node := range trafficMap {
+		_, ok := node.Metadata[graph.BoxLabel]
+		assert.False(t, ok)
+	}
+}
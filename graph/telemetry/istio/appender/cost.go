@@ -0,0 +1,300 @@
+package appender
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry/istio/util"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	// CostAppenderName uniquely identifies the appender: cost
+	CostAppenderName = "cost"
+)
+
+const (
+	bytesPerGB       = 1e9
+	nodeZonesKey     = "nodeZonesKey"     // global vendor info map[node]zone
+	workloadZonesKey = "workloadZonesKey" // global vendor info map[namespace]map[workload]zones
+)
+
+// costBytesMetrics are the byte counters of the edges: request and response bytes of the HTTP and gRPC edges,
+// bytes sent and received of the TCP edges
+var costBytesMetrics = []string{"istio_request_bytes_sum", "istio_response_bytes_sum", "istio_tcp_sent_bytes_total", "istio_tcp_received_bytes_total"}
+
+// edgeCost accumulates the traffic of an edge, which may cross several localities
+type edgeCost struct {
+	bytesPerSec float64
+	costPerHour float64
+	locality    string
+}
+
+// CostAppender is responsible for adding the bytes transferred by the edges, with the locality of the traffic and
+// its estimated cost. The locality compares the clusters of the source and destination workloads, and the zones
+// of the nodes running their pods: intraZone, crossZone or crossCluster, unknown when the zone of a workload is
+// unknown. The zones are known for the workloads of the Kiali cluster, whose nodes are listed with the Kiali service
+// account: it needs the cluster-wide permission to list nodes. The cost is estimated from the rates of
+// the cost config, per GB transferred in each locality:
+//   - cost: the bytes/sec, the widest locality and the cost per hour of the edge
+//
+// The bytes are the request and response bytes of the HTTP and gRPC edges, and the bytes sent and received of
+// the TCP edges. Like throughput, the traffic leaving a namespace is reported using source proxy telemetry, the
+// traffic entering a namespace from another namespace using destination proxy telemetry. With injected service
+// nodes the bytes are set on the edge to the service node, as it carries the traffic of its source workload.
+// Name: cost
+type CostAppender struct {
+	GraphType          string
+	InjectServiceNodes bool
+	Namespaces         graph.NamespaceInfoMap
+	QueryTime          int64 // unix time in seconds
+}
+
+// Name implements Appender
+func (a CostAppender) Name() string {
+	return CostAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a CostAppender) IsFinalizer() bool {
+	return false
+}
+
+// AppendGraph implements Appender
+func (a CostAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 {
+		return
+	}
+
+	if globalInfo.HomeCluster == "" {
+		globalInfo.HomeCluster = business.DefaultClusterID
+		c, err := globalInfo.Business.Mesh.ResolveKialiControlPlaneCluster(nil)
+		graph.CheckError(err)
+		if c != nil {
+			globalInfo.HomeCluster = c.Name
+		}
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		graph.CheckError(err)
+	}
+
+	a.appendGraph(trafficMap, namespaceInfo.Namespace, globalInfo)
+}
+
+func (a CostAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, gi *graph.AppenderGlobalInfo) {
+	log.Tracef("Generating cost; namespace = %v", namespace)
+
+	client := gi.PromClient
+	costMap := make(map[string]*edgeCost)
+	duration := a.Namespaces[namespace].Duration
+
+	// query prometheus for the bytes in two queries:
+	// 1) query for traffic originating from a workload outside the namespace
+	selector := fmt.Sprintf(`reporter="destination",source_workload_namespace!="%s",destination_service_namespace="%s"`, namespace, namespace)
+	vector := promQuery(costBytesQuery(selector, duration), time.Unix(a.QueryTime, 0), client.GetContext(), client.API(), a)
+	a.populateCostMap(costMap, &vector, gi)
+
+	// 2) query for traffic originating from a workload inside of the namespace
+	selector = fmt.Sprintf(`reporter="source",source_workload_namespace="%s"`, namespace)
+	vector = promQuery(costBytesQuery(selector, duration), time.Unix(a.QueryTime, 0), client.GetContext(), client.API(), a)
+	a.populateCostMap(costMap, &vector, gi)
+
+	applyCost(trafficMap, costMap)
+}
+
+// costBytesQuery returns the query summing the byte counters of the edges. The counters are told apart by a
+// "bytes" label, as the rates of different metrics with the same labels can't be in the same vector.
+func costBytesQuery(selector string, duration time.Duration) string {
+	groupBy := "source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol"
+
+	counters := make([]string, 0, len(costBytesMetrics))
+	for _, metric := range costBytesMetrics {
+		counters = append(counters, fmt.Sprintf(`label_replace(sum(rate(%s{%s}[%vs])) by (%s), "bytes", "%s", "", "")`,
+			metric,
+			selector,
+			int(duration.Seconds()), // range duration for the query
+			groupBy,
+			metric))
+	}
+	return fmt.Sprintf(`sum(%s) by (%s) > 0`, strings.Join(counters, " or "), groupBy)
+}
+
+func applyCost(trafficMap graph.TrafficMap, costMap map[string]*edgeCost) {
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			key := fmt.Sprintf("%s %s %s", e.Source.ID, e.Dest.ID, e.Metadata[graph.ProtocolKey].(string))
+			if c, ok := costMap[key]; ok {
+				e.Metadata[graph.Cost] = &graph.CostMetadata{
+					BytesPerSec: c.bytesPerSec,
+					CostPerHour: c.costPerHour,
+					Locality:    c.locality,
+				}
+			}
+		}
+	}
+}
+
+func (a CostAppender) populateCostMap(costMap map[string]*edgeCost, vector *model.Vector, gi *graph.AppenderGlobalInfo) {
+	conf := config.Get().API.Graph.Cost
+	rates := map[string]float64{
+		graph.LocalityCrossCluster: conf.CrossClusterRate,
+		graph.LocalityCrossZone:    conf.CrossZoneRate,
+		graph.LocalityIntraZone:    conf.IntraZoneRate,
+	}
+
+	for _, s := range *vector {
+		m := s.Metric
+		lSourceCluster, sourceClusterOk := m["source_cluster"]
+		lSourceWlNs, sourceWlNsOk := m["source_workload_namespace"]
+		lSourceWl, sourceWlOk := m["source_workload"]
+		lSourceApp, sourceAppOk := m["source_canonical_service"]
+		lSourceVer, sourceVerOk := m["source_canonical_revision"]
+		lDestCluster, destClusterOk := m["destination_cluster"]
+		lDestSvcNs, destSvcNsOk := m["destination_service_namespace"]
+		lDestSvc, destSvcOk := m["destination_service"]
+		lDestSvcName, destSvcNameOk := m["destination_service_name"]
+		lDestWlNs, destWlNsOk := m["destination_workload_namespace"]
+		lDestWl, destWlOk := m["destination_workload"]
+		lDestApp, destAppOk := m["destination_canonical_service"]
+		lDestVer, destVerOk := m["destination_canonical_revision"]
+		lProtocol, protocolOk := m["request_protocol"]
+
+		if !sourceWlNsOk || !sourceWlOk || !sourceAppOk || !sourceVerOk || !destSvcNsOk || !destSvcNameOk || !destSvcOk || !destWlNsOk || !destWlOk || !destAppOk || !destVerOk || !protocolOk {
+			log.Warningf("populateCostMap: Skipping %s, missing expected labels", m.String())
+			continue
+		}
+
+		sourceWlNs := string(lSourceWlNs)
+		sourceWl := string(lSourceWl)
+		sourceApp := string(lSourceApp)
+		sourceVer := string(lSourceVer)
+		destSvc := string(lDestSvc)
+		protocol := string(lProtocol)
+
+		// handle clusters
+		sourceCluster, destCluster := util.HandleClusters(lSourceCluster, sourceClusterOk, lDestCluster, destClusterOk)
+
+		if util.IsBadSourceTelemetry(sourceCluster, sourceClusterOk, sourceWlNs, sourceWl, sourceApp) {
+			continue
+		}
+
+		val := float64(s.Value)
+
+		// handle unusual destinations
+		destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, _ := util.HandleDestination(sourceCluster, sourceWlNs, sourceWl, destCluster, string(lDestSvcNs), string(lDestSvc), string(lDestSvcName), string(lDestWlNs), string(lDestWl), string(lDestApp), string(lDestVer))
+
+		if util.IsBadDestTelemetry(destCluster, destClusterOk, destSvcNs, destSvc, destSvcName, destWl) {
+			continue
+		}
+
+		// Should not happen but if NaN for any reason, Just skip it
+		if math.IsNaN(val) {
+			continue
+		}
+
+		// the traffic goes from the source workload to the destination workload, an injected service node is
+		// on its way
+		locality := trafficLocality(sourceCluster, a.getZones(sourceCluster, sourceWlNs, sourceWl, gi), destCluster, a.getZones(destCluster, destWlNs, destWl, gi))
+		cost := val * 3600 / bytesPerGB * rates[locality]
+
+		// don't inject a service node if any of:
+		// - destSvcName is not set
+		// - destSvcName is PassthroughCluster (see https://github.com/kiali/kiali/issues/4488)
+		// - dest node is already a service node
+		inject := false
+		if a.InjectServiceNodes && graph.IsOK(destSvcName) && destSvcName != graph.PassthroughCluster {
+			_, destNodeType := graph.Id(destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, a.GraphType)
+			inject = (graph.NodeTypeService != destNodeType)
+		}
+
+		sourceID, _ := graph.Id(sourceCluster, sourceWlNs, "", sourceWlNs, sourceWl, sourceApp, sourceVer, a.GraphType)
+		var destID string
+		if inject {
+			destID, _ = graph.Id(destCluster, destSvcNs, destSvcName, "", "", "", "", a.GraphType)
+		} else {
+			destID, _ = graph.Id(destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, a.GraphType)
+		}
+		key := fmt.Sprintf("%s %s %s", sourceID, destID, protocol)
+
+		c, ok := costMap[key]
+		if !ok {
+			c = &edgeCost{}
+			costMap[key] = c
+		}
+		c.bytesPerSec += val
+		c.costPerHour += cost
+		c.locality = graph.WidestLocality(c.locality, locality)
+	}
+}
+
+// getZones returns the zones of the pods of a workload, nil when unknown. The zones are known for the workloads
+// of the home cluster, they are fetched once per namespace from the zones of the nodes, read once per request.
+func (a CostAppender) getZones(cluster, namespace, workload string, gi *graph.AppenderGlobalInfo) []string {
+	if cluster != gi.HomeCluster || !graph.IsOK(namespace) || !graph.IsOK(workload) {
+		return nil
+	}
+
+	var zonesMap map[string]map[string][]string
+	if existingZonesMap, ok := gi.Vendor[workloadZonesKey]; ok {
+		zonesMap = existingZonesMap.(map[string]map[string][]string)
+	} else {
+		zonesMap = make(map[string]map[string][]string)
+		gi.Vendor[workloadZonesKey] = zonesMap
+	}
+
+	zones, ok := zonesMap[namespace]
+	if !ok {
+		// without the zones of the nodes, the zones of the workloads are unknown
+		if nodeZones := getNodeZones(gi); len(nodeZones) > 0 {
+			var err error
+			if zones, err = gi.Business.Workload.GetWorkloadZones(context.TODO(), namespace, nodeZones); err != nil {
+				log.Warningf("Unable to get the zones of the workloads of namespace [%s]: %s", namespace, err)
+			}
+		}
+		zonesMap[namespace] = zones
+	}
+
+	return zones[workload]
+}
+
+// getNodeZones returns the zones of the nodes of the home cluster, read once per request. A failure to read them,
+// usually because the Kiali service account is not allowed to list the nodes, leaves the zones unknown.
+func getNodeZones(gi *graph.AppenderGlobalInfo) map[string]string {
+	if nodeZones, ok := gi.Vendor[nodeZonesKey]; ok {
+		return nodeZones.(map[string]string)
+	}
+
+	nodeZones, err := gi.Business.Workload.GetNodeZones(context.TODO())
+	if err != nil {
+		log.Warningf("Unable to get the zones of the nodes, the locality of the traffic is unknown: %s", err)
+	}
+	gi.Vendor[nodeZonesKey] = nodeZones
+	return nodeZones
+}
+
+// trafficLocality returns the locality of the traffic between two workloads. The traffic is intra-zone only when
+// both workloads run in the same single zone, the traffic of workloads spread over several zones may cross them.
+func trafficLocality(sourceCluster string, sourceZones []string, destCluster string, destZones []string) string {
+	if graph.IsOK(sourceCluster) && graph.IsOK(destCluster) && sourceCluster != destCluster {
+		return graph.LocalityCrossCluster
+	}
+	if len(sourceZones) == 0 || len(destZones) == 0 {
+		return graph.LocalityUnknown
+	}
+	if len(sourceZones) == 1 && len(destZones) == 1 && sourceZones[0] == destZones[0] {
+		return graph.LocalityIntraZone
+	}
+	return graph.LocalityCrossZone
+}
@@ -0,0 +1,123 @@
+package appender
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+)
+
+func TestCost(t *testing.T) {
+	assert := assert.New(t)
+
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conf := config.NewConfig()
+	conf.API.Graph.Cost = config.ApiGraphCostConfig{CrossClusterRate: 0.02, CrossZoneRate: 0.01}
+	config.Set(conf)
+
+	api.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `reporter="source"`)
+	}), mock.Anything).Return(model.Vector{
+		costTestSample("reviews", business.DefaultClusterID, "http", 1e6),
+		costTestSample("details", business.DefaultClusterID, "http", 2e6),
+		costTestSample("db", "west", "tcp", 1e6),
+	})
+	api.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `reporter="destination"`)
+	}), mock.Anything).Return(model.Vector{})
+
+	trafficMap := graph.NewTrafficMap()
+	node := func(cluster, workload string) *graph.Node {
+		n := graph.NewNode(cluster, "bookinfo", workload, "bookinfo", workload+"-v1", workload, "v1", graph.GraphTypeWorkload)
+		trafficMap[n.ID] = &n
+		return &n
+	}
+	productpage := node(business.DefaultClusterID, "productpage")
+	reviews := node(business.DefaultClusterID, "reviews")
+	details := node(business.DefaultClusterID, "details")
+	db := node("west", "db")
+	productpage.AddEdge(reviews).Metadata[graph.ProtocolKey] = "http"
+	productpage.AddEdge(details).Metadata[graph.ProtocolKey] = "http"
+	productpage.AddEdge(db).Metadata[graph.ProtocolKey] = "tcp"
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.HomeCluster = business.DefaultClusterID
+	globalInfo.PromClient = client
+	globalInfo.Vendor[workloadZonesKey] = map[string]map[string][]string{
+		"bookinfo": {
+			"productpage-v1": {"zone-a"},
+			"reviews-v1":     {"zone-b"},
+			"details-v1":     {"zone-a"},
+		},
+	}
+
+	a := CostAppender{
+		GraphType:  graph.GraphTypeWorkload,
+		Namespaces: graph.NamespaceInfoMap{"bookinfo": graph.NamespaceInfo{Name: "bookinfo", Duration: time.Minute}},
+		QueryTime:  time.Now().Unix(),
+	}
+	a.AppendGraph(trafficMap, globalInfo, graph.NewAppenderNamespaceInfo("bookinfo"))
+
+	costs := map[string]*graph.CostMetadata{}
+	for _, e := range productpage.Edges {
+		if cost, ok := e.Metadata[graph.Cost].(*graph.CostMetadata); assert.True(ok) {
+			costs[e.Dest.Workload] = cost
+		}
+	}
+	assert.Equal(graph.LocalityCrossZone, costs["reviews-v1"].Locality)
+	assert.Equal(1e6, costs["reviews-v1"].BytesPerSec)
+	assert.InDelta(0.036, costs["reviews-v1"].CostPerHour, 0.000001)
+
+	assert.Equal(graph.LocalityIntraZone, costs["details-v1"].Locality)
+	assert.Equal(2e6, costs["details-v1"].BytesPerSec)
+	assert.Zero(costs["details-v1"].CostPerHour)
+
+	assert.Equal(graph.LocalityCrossCluster, costs["db-v1"].Locality)
+	assert.InDelta(0.072, costs["db-v1"].CostPerHour, 0.000001)
+}
+
+func TestTrafficLocality(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(graph.LocalityIntraZone, trafficLocality("east", []string{"a"}, "east", []string{"a"}))
+	assert.Equal(graph.LocalityCrossZone, trafficLocality("east", []string{"a"}, "east", []string{"b"}))
+	// the traffic of a workload spread over several zones may cross them
+	assert.Equal(graph.LocalityCrossZone, trafficLocality("east", []string{"a"}, "east", []string{"a", "b"}))
+	assert.Equal(graph.LocalityUnknown, trafficLocality("east", []string{"a"}, "east", nil))
+	assert.Equal(graph.LocalityCrossCluster, trafficLocality("east", nil, "west", nil))
+	assert.Equal(graph.LocalityUnknown, trafficLocality(graph.Unknown, nil, "east", []string{"a"}))
+}
+
+func costTestSample(dest, destCluster, protocol string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			"source_cluster":                 business.DefaultClusterID,
+			"source_workload_namespace":      "bookinfo",
+			"source_workload":                "productpage-v1",
+			"source_canonical_service":       "productpage",
+			"source_canonical_revision":      "v1",
+			"destination_cluster":            model.LabelValue(destCluster),
+			"destination_service_namespace":  "bookinfo",
+			"destination_service":            model.LabelValue(dest + ".bookinfo.svc.cluster.local"),
+			"destination_service_name":       model.LabelValue(dest),
+			"destination_workload_namespace": "bookinfo",
+			"destination_workload":           model.LabelValue(dest + "-v1"),
+			"destination_canonical_service":  model.LabelValue(dest),
+			"destination_canonical_revision": "v1",
+			"request_protocol":               model.LabelValue(protocol),
+		},
+		Value: model.SampleValue(value),
+	}
+}
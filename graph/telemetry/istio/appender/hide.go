@@ -0,0 +1,80 @@
+package appender
+
+import (
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+)
+
+const HideAppenderName = "hide"
+
+// HideAppender is responsible for removing the nodes and edges matching the hide expression, and the nodes
+// left without edges by the removal (idle nodes are kept, as they are an explicit option). It runs the
+// same expression language as the graph hide field of the UI, so that large graphs can be reduced before
+// they are returned.
+// Name: hide
+type HideAppender struct {
+	Hide *graph.FindExpression
+}
+
+// Name implements Appender
+func (a *HideAppender) Name() string {
+	return HideAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a HideAppender) IsFinalizer() bool {
+	return true
+}
+
+// AppendGraph implements Appender
+func (a *HideAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, _namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 || a.Hide == nil {
+		return
+	}
+
+	a.hide(trafficMap)
+}
+
+func (a *HideAppender) hide(trafficMap graph.TrafficMap) {
+	hasEdges := make(map[string]bool)
+	for id, n := range trafficMap {
+		for _, e := range n.Edges {
+			hasEdges[id] = true
+			hasEdges[e.Dest.ID] = true
+		}
+	}
+
+	hiddenNodes := 0
+	for id, n := range trafficMap {
+		if a.Hide.MatchesNode(n) {
+			delete(trafficMap, id)
+			hiddenNodes++
+		}
+	}
+
+	hiddenEdges := 0
+	stillHasEdges := make(map[string]bool)
+	for id, n := range trafficMap {
+		edges := make([]*graph.Edge, 0, len(n.Edges))
+		for _, e := range n.Edges {
+			if _, ok := trafficMap[e.Dest.ID]; !ok || a.Hide.MatchesEdge(e) {
+				hiddenEdges++
+				continue
+			}
+			edges = append(edges, e)
+			stillHasEdges[id] = true
+			stillHasEdges[e.Dest.ID] = true
+		}
+		n.Edges = edges
+	}
+
+	// remove the orphans, the nodes that only had hidden edges
+	for id, n := range trafficMap {
+		if hasEdges[id] && !stillHasEdges[id] && n.Metadata[graph.IsIdle] != true {
+			delete(trafficMap, id)
+			hiddenNodes++
+		}
+	}
+
+	log.Tracef("Hide removed [%d] nodes and [%d] edges", hiddenNodes, hiddenEdges)
+}
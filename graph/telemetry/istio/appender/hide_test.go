@@ -0,0 +1,93 @@
+package appender
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/graph"
+)
+
+func hideTestTraffic() graph.TrafficMap {
+	trafficMap := graph.NewTrafficMap()
+	productpage := graph.NewNode("east", "bookinfo", "", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviews := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	ratings := graph.NewNode("east", "bookinfo", "", "bookinfo", "ratings-v1", "ratings", "v1", graph.GraphTypeVersionedApp)
+	details := graph.NewNode("east", "bookinfo", "", "bookinfo", "details-v1", "details", "v1", graph.GraphTypeVersionedApp)
+	idle := graph.NewNode("east", "bookinfo", "", "bookinfo", "mongodb-v1", "mongodb", "v1", graph.GraphTypeVersionedApp)
+	idle.Metadata[graph.IsIdle] = true
+	for _, n := range []*graph.Node{&productpage, &reviews, &ratings, &details, &idle} {
+		trafficMap[n.ID] = n
+	}
+
+	e := productpage.AddEdge(&reviews)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.ResponseTime] = 100.0
+	e = productpage.AddEdge(&details)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.ResponseTime] = 2000.0
+	e = reviews.AddEdge(&ratings)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata[graph.ResponseTime] = 50.0
+
+	return trafficMap
+}
+
+func apps(trafficMap graph.TrafficMap) []string {
+	apps := []string{}
+	for _, n := range trafficMap {
+		apps = append(apps, n.App)
+	}
+	return apps
+}
+
+func TestHideNodes(t *testing.T) {
+	assert := assert.New(t)
+
+	hide, err := graph.ParseFindExpression("app = reviews")
+	require.NoError(t, err)
+	trafficMap := hideTestTraffic()
+	a := HideAppender{Hide: hide}
+	a.AppendGraph(trafficMap, graph.NewAppenderGlobalInfo(), nil)
+
+	// ratings is orphaned by the removal of reviews, the idle mongodb is kept
+	assert.ElementsMatch([]string{"productpage", "details", "mongodb"}, apps(trafficMap))
+	for _, n := range trafficMap {
+		if n.App == "productpage" {
+			assert.Len(n.Edges, 1)
+			assert.Equal("details", n.Edges[0].Dest.App)
+		}
+	}
+}
+
+func TestHideEdges(t *testing.T) {
+	assert := assert.New(t)
+
+	hide, err := graph.ParseFindExpression("rt > 1000")
+	require.NoError(t, err)
+	trafficMap := hideTestTraffic()
+	a := HideAppender{Hide: hide}
+	a.AppendGraph(trafficMap, graph.NewAppenderGlobalInfo(), nil)
+
+	// details is only reached by the slow edge
+	assert.ElementsMatch([]string{"productpage", "reviews", "ratings", "mongodb"}, apps(trafficMap))
+	for _, n := range trafficMap {
+		if n.App == "productpage" {
+			assert.Len(n.Edges, 1)
+			assert.Equal("reviews", n.Edges[0].Dest.App)
+		}
+	}
+}
+
+func TestHideNoMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	hide, err := graph.ParseFindExpression("ns = travel")
+	require.NoError(t, err)
+	trafficMap := hideTestTraffic()
+	a := HideAppender{Hide: hide}
+	a.AppendGraph(trafficMap, graph.NewAppenderGlobalInfo(), nil)
+
+	assert.Len(trafficMap, 5)
+}
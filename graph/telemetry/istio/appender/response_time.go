@@ -66,6 +66,11 @@ func (a ResponseTimeAppender) AppendGraph(trafficMap graph.TrafficMap, globalInf
 }
 
 func (a ResponseTimeAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, client *prometheus.Client) {
+	applyResponseTime(trafficMap, a.getResponseTimeMap(namespace, client))
+}
+
+// getResponseTimeMap returns the response times of the namespace edges, keyed by "<sourceID> <destID> <protocol>"
+func (a ResponseTimeAppender) getResponseTimeMap(namespace string, client *prometheus.Client) map[string]float64 {
 	// create map to quickly look up responseTime
 	responseTimeMap := make(map[string]float64)
 	duration := a.Namespaces[namespace].Duration
@@ -134,7 +139,7 @@ func (a ResponseTimeAppender) appendGraph(trafficMap graph.TrafficMap, namespace
 		a.populateResponseTimeMap(responseTimeMap, &outgoingVector)
 	}
 
-	applyResponseTime(trafficMap, responseTimeMap)
+	return responseTimeMap
 }
 
 func applyResponseTime(trafficMap graph.TrafficMap, responseTimeMap map[string]float64) {
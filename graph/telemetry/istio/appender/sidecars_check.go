@@ -10,7 +10,8 @@ import (
 const SidecarsCheckAppenderName = "sidecarsCheck"
 
 // SidecarsCheckAppender flags nodes whose backing workloads are missing at least one Envoy sidecar. Note that
-// a node with no backing workloads is not flagged.
+// a node with no backing workloads is not flagged. The workloads captured by the ambient mesh are not missing
+// a sidecar, their nodes are flagged as ambient instead.
 // Name: sidecarsCheck
 type SidecarsCheckAppender struct {
 	AccessibleNamespaces map[string]time.Time
@@ -63,20 +64,21 @@ func (a *SidecarsCheckAppender) applySidecarsChecks(trafficMap graph.TrafficMap,
 		// if there are no workloads/pods we don't flag it as missing sidecars.  No pods means
 		// no missing sidecars.  (In most cases this means it was flagged as dead, and handled above)
 		hasIstioSidecar := true
+		isAmbient := false
 		switch n.NodeType {
 		case graph.NodeTypeWorkload:
 			if workload, found := getWorkload(n.Namespace, n.Workload, globalInfo); found {
-				hasIstioSidecar = workload.IstioSidecar
+				hasIstioSidecar = workload.IstioSidecar || workload.IsAmbient
+				isAmbient = workload.IsAmbient
 			}
 		case graph.NodeTypeApp:
 			workloads := getAppWorkloads(n.Namespace, n.App, n.Version, globalInfo)
-			if len(workloads) > 0 {
-				for _, workload := range workloads {
-					if !workload.IstioSidecar {
-						hasIstioSidecar = false
-						break
-					}
+			isAmbient = len(workloads) > 0
+			for _, workload := range workloads {
+				if !workload.IstioSidecar && !workload.IsAmbient {
+					hasIstioSidecar = false
 				}
+				isAmbient = isAmbient && workload.IsAmbient
 			}
 		default:
 			continue
@@ -85,6 +87,9 @@ func (a *SidecarsCheckAppender) applySidecarsChecks(trafficMap graph.TrafficMap,
 		if !hasIstioSidecar {
 			n.Metadata[graph.HasMissingSC] = true
 		}
+		if isAmbient {
+			n.Metadata[graph.IsAmbient] = true
+		}
 	}
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
 )
 
 func TestWorkloadSidecarsPasses(t *testing.T) {
@@ -60,6 +61,29 @@ func TestWorkloadWithMissingSidecarsIsFlagged(t *testing.T) {
 	}
 }
 
+func TestAmbientWorkloadIsNotFlagged(t *testing.T) {
+	config.Set(config.NewConfig())
+	trafficMap := buildWorkloadTrafficMap()
+	businessLayer := setupSidecarsCheckWorkloads(buildFakeWorkloadDeployments(), buildFakeWorkloadPodsAmbient())
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Business = businessLayer
+	namespaceInfo := graph.NewAppenderNamespaceInfo("testNamespace")
+
+	a := SidecarsCheckAppender{
+		AccessibleNamespaces: map[string]time.Time{"testNamespace": time.Now()},
+	}
+	a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+
+	for _, node := range trafficMap {
+		_, ok := node.Metadata[graph.HasMissingSC].(bool)
+		assert.False(t, ok)
+		flag, ok := node.Metadata[graph.IsAmbient].(bool)
+		assert.True(t, ok)
+		assert.True(t, flag)
+	}
+}
+
 func TestInaccessibleWorkload(t *testing.T) {
 	config.Set(config.NewConfig())
 	trafficMap := buildInaccessibleWorkloadTrafficMap()
@@ -239,6 +263,15 @@ func buildFakeWorkloadPodsNoSidecar() []core_v1.Pod {
 	return podList
 }
 
+func buildFakeWorkloadPodsAmbient() []core_v1.Pod {
+	podList := buildFakeWorkloadPodsNoSidecar()
+	for i := range podList {
+		podList[i].ObjectMeta.Annotations[models.AmbientRedirectionAnnotation] = models.AmbientRedirectionEnabled
+	}
+
+	return podList
+}
+
 func setupSidecarsCheckWorkloads(deployments []apps_v1.Deployment, pods []core_v1.Pod) *business.Layer {
 	k8s := kubetest.NewK8SClientMock()
 
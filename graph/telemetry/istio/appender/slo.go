@@ -0,0 +1,345 @@
+package appender
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	// SLOAppenderName uniquely identifies the appender: slo
+	SLOAppenderName = "slo"
+)
+
+// The SLO status of a node or edge
+const (
+	SLOStatusAtRisk   = "atRisk"
+	SLOStatusMet      = "met"
+	SLOStatusViolated = "violated"
+)
+
+const (
+	sloQuantile    = 0.99
+	sloAtRiskRatio = 0.8 // the fraction of an objective above which the SLO is at risk
+)
+
+// sloTarget holds the objectives of a service, a zero value has no objective
+type sloTarget struct {
+	availability float64 // percentage of successful requests
+	latencyP99   float64 // in millis
+}
+
+// SLOAppender is responsible for evaluating the service level objectives of the services over the graph
+// duration. The objectives, an availability and a p99 response time, are read from the health config and
+// can be overridden by the slo.kiali.io annotations of the service. The appender adds to the nodes and edges
+// of the services with objectives:
+//   - burnRate: the error budget burn rate, the error rate divided by the error rate allowed by the
+//     availability objective. Errors are the error rates of the graph.
+//   - sloStatus: violated when the burn rate is above 1 or the p99 response time above its objective, atRisk
+//     when one of them is above 80% of its objective, met otherwise.
+//
+// Response times are reported using destination proxy telemetry, when available. Edges are evaluated against
+// the objectives of their destination.
+// Name: slo
+type SLOAppender struct {
+	GraphType          string
+	InjectServiceNodes bool
+	Namespaces         graph.NamespaceInfoMap
+	QueryTime          int64 // unix time in seconds
+	Rates              graph.RequestedRates
+}
+
+// Name implements Appender
+func (a SLOAppender) Name() string {
+	return SLOAppenderName
+}
+
+// IsFinalizer implements Appender
+func (a SLOAppender) IsFinalizer() bool {
+	return false
+}
+
+// AppendGraph implements Appender
+func (a SLOAppender) AppendGraph(trafficMap graph.TrafficMap, globalInfo *graph.AppenderGlobalInfo, namespaceInfo *graph.AppenderNamespaceInfo) {
+	if len(trafficMap) == 0 {
+		return
+	}
+
+	// SLOs only apply to request traffic (not TCP or gRPC-message traffic)
+	if a.Rates.Grpc != graph.RateRequests && a.Rates.Http != graph.RateRequests {
+		return
+	}
+
+	if globalInfo.PromClient == nil {
+		var err error
+		globalInfo.PromClient, err = prometheus.NewClient()
+		graph.CheckError(err)
+	}
+
+	a.appendGraph(trafficMap, namespaceInfo.Namespace, globalInfo)
+}
+
+func (a SLOAppender) appendGraph(trafficMap graph.TrafficMap, namespace string, globalInfo *graph.AppenderGlobalInfo) {
+	log.Tracef("Generating SLO status; namespace = %v", namespace)
+
+	client := globalInfo.PromClient
+	duration := a.Namespaces[namespace].Duration
+
+	// query prometheus for the p99 response time of the namespace services
+	query := fmt.Sprintf(`histogram_quantile(%.2f, sum(rate(%s{reporter="destination",destination_service_namespace="%s"}[%vs])) by (le,destination_cluster,destination_service_namespace,destination_service_name)) > 0`,
+		sloQuantile,
+		"istio_request_duration_milliseconds_bucket",
+		namespace,
+		int(duration.Seconds())) // range duration for the query
+	vector := promQuery(query, time.Unix(a.QueryTime, 0), client.GetContext(), client.API(), a)
+	serviceLatencyMap := populateServiceLatencyMap(&vector)
+
+	// the same queries as the responseTime appender provide the p99 response time of the edges
+	rta := ResponseTimeAppender{
+		GraphType:          a.GraphType,
+		InjectServiceNodes: a.InjectServiceNodes,
+		Namespaces:         a.Namespaces,
+		Quantile:           sloQuantile,
+		QueryTime:          a.QueryTime,
+		Rates:              a.Rates,
+	}
+	edgeLatencyMap := rta.getResponseTimeMap(namespace, client)
+
+	targets := make(map[graph.ServiceName]sloTarget)
+	getTarget := func(svc graph.ServiceName) sloTarget {
+		target, ok := targets[svc]
+		if !ok {
+			target = a.getServiceTarget(svc, namespace, globalInfo)
+			targets[svc] = target
+		}
+		return target
+	}
+
+	incoming := make(map[string][]*graph.Edge)
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			incoming[e.Dest.ID] = append(incoming[e.Dest.ID], e)
+		}
+	}
+
+	for _, n := range trafficMap {
+		if n.Namespace == namespace {
+			if target, ok := a.getNodeTarget(n, getTarget); ok {
+				p99, hasP99 := nodeLatency(n, serviceLatencyMap)
+				total, errs := 0.0, 0.0
+				for _, e := range incoming[n.ID] {
+					edgeTotal, edgeErrs := edgeRequestRates(e)
+					total += edgeTotal
+					errs += edgeErrs
+				}
+				applySLO(n.Metadata, target, total, errs, p99, hasP99)
+			}
+		}
+
+		for _, e := range n.Edges {
+			// evaluate edges with the namespace of their destination, or of their source when the destination
+			// namespace is not requested
+			_, isRequestedDest := a.Namespaces[e.Dest.Namespace]
+			if e.Dest.Namespace != namespace && (isRequestedDest || e.Source.Namespace != namespace) {
+				continue
+			}
+			target, ok := a.getNodeTarget(e.Dest, getTarget)
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s %s %s", e.Source.ID, e.Dest.ID, e.Metadata[graph.ProtocolKey].(string))
+			p99, hasP99 := edgeLatencyMap[key]
+			total, errs := edgeRequestRates(e)
+			applySLO(e.Metadata, target, total, errs, p99, hasP99)
+		}
+	}
+}
+
+// getNodeTarget returns the objectives of a service node, or the strictest objectives of the requested
+// services of another node
+func (a SLOAppender) getNodeTarget(n *graph.Node, getTarget func(svc graph.ServiceName) sloTarget) (sloTarget, bool) {
+	if n.NodeType == graph.NodeTypeService {
+		target := getTarget(graph.ServiceName{Cluster: n.Cluster, Namespace: n.Namespace, Name: n.Service})
+		return target, target.availability > 0 || target.latencyP99 > 0
+	}
+
+	target := sloTarget{}
+	destServices, ok := n.Metadata[graph.DestServices].(graph.DestServicesMetadata)
+	if !ok {
+		return target, false
+	}
+	for _, svc := range destServices {
+		svcTarget := getTarget(svc)
+		target.availability = math.Max(target.availability, svcTarget.availability)
+		if svcTarget.latencyP99 > 0 && (target.latencyP99 == 0 || svcTarget.latencyP99 < target.latencyP99) {
+			target.latencyP99 = svcTarget.latencyP99
+		}
+	}
+	return target, target.availability > 0 || target.latencyP99 > 0
+}
+
+// getServiceTarget returns the objectives of the first SLO config matching the service, overridden by the
+// annotations of the service. The annotations are read for the services of the appender namespace.
+func (a SLOAppender) getServiceTarget(svc graph.ServiceName, namespace string, globalInfo *graph.AppenderGlobalInfo) sloTarget {
+	target := sloTarget{}
+	for _, slo := range config.Get().HealthConfig.SLO {
+		if matchesSLO(slo.Namespace, svc.Namespace) && matchesSLO(slo.Name, svc.Name) {
+			target = sloTarget{availability: slo.Availability, latencyP99: slo.LatencyP99}
+			break
+		}
+	}
+
+	if svc.Namespace == namespace {
+		if srv, ok := getServiceDefinition(svc.Namespace, svc.Name, globalInfo); ok {
+			if availability, ok := srv.SLOAnnotations[string(models.AvailabilitySLOAnnotation)]; ok {
+				target.availability = parseSLOValue(models.AvailabilitySLOAnnotation, availability)
+			}
+			if latencyP99, ok := srv.SLOAnnotations[string(models.LatencyP99SLOAnnotation)]; ok {
+				target.latencyP99 = parseSLOValue(models.LatencyP99SLOAnnotation, latencyP99)
+			}
+		}
+	}
+
+	if target.availability <= 0 || target.availability >= 100 {
+		target.availability = 0
+	}
+	if target.latencyP99 < 0 {
+		target.latencyP99 = 0
+	}
+	return target
+}
+
+// matchesSLO returns true when the regex of an SLO config matches the whole value, an empty regex matches
+// any value
+func matchesSLO(regex, value string) bool {
+	if regex == "" {
+		return true
+	}
+	match, err := regexp.MatchString(fmt.Sprintf("^(?:%s)$", regex), value)
+	if err != nil {
+		log.Warningf("Ignoring SLO config, invalid regex [%s]: %v", regex, err)
+		return false
+	}
+	return match
+}
+
+func parseSLOValue(annotation models.AnnotationKey, value string) float64 {
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Warningf("Ignoring SLO annotation [%s], invalid value [%s]: %v", annotation, value, err)
+		return 0
+	}
+	return val
+}
+
+// edgeRequestRates returns the total and error request rates of an edge
+func edgeRequestRates(e *graph.Edge) (total, errs float64) {
+	protocol, _ := e.Metadata[graph.ProtocolKey].(string)
+	for _, p := range graph.Protocols {
+		if p.Name != protocol {
+			continue
+		}
+		for _, r := range p.EdgeRates {
+			rate, ok := e.Metadata[r.Name].(float64)
+			if !ok {
+				continue
+			}
+			switch {
+			case r.IsTotal:
+				total = rate
+			case r.IsErr:
+				errs += rate
+			}
+		}
+	}
+	return total, errs
+}
+
+// nodeLatency returns the p99 response time of a service node, or the highest p99 response time of the
+// requested services of another node
+func nodeLatency(n *graph.Node, serviceLatencyMap map[graph.ServiceName]float64) (float64, bool) {
+	if n.NodeType == graph.NodeTypeService {
+		p99, ok := serviceLatencyMap[graph.ServiceName{Cluster: n.Cluster, Namespace: n.Namespace, Name: n.Service}]
+		return p99, ok
+	}
+
+	p99, hasP99 := 0.0, false
+	if destServices, ok := n.Metadata[graph.DestServices].(graph.DestServicesMetadata); ok {
+		for _, svc := range destServices {
+			if val, ok := serviceLatencyMap[svc]; ok {
+				p99 = math.Max(p99, val)
+				hasP99 = true
+			}
+		}
+	}
+	return p99, hasP99
+}
+
+// applySLO sets the burn rate and SLO status, when the traffic allows evaluating an objective
+func applySLO(metadata graph.Metadata, target sloTarget, total, errs, p99 float64, hasP99 bool) {
+	ratio := 0.0
+	evaluated := false
+
+	if target.availability > 0 && total > 0 {
+		burnRate := (errs / total) / (1 - target.availability/100)
+		metadata[graph.BurnRate] = burnRate
+		ratio = burnRate
+		evaluated = true
+	}
+	if target.latencyP99 > 0 && hasP99 {
+		ratio = math.Max(ratio, p99/target.latencyP99)
+		evaluated = true
+	}
+	if !evaluated {
+		return
+	}
+
+	switch {
+	case ratio > 1:
+		metadata[graph.SLOStatus] = SLOStatusViolated
+	case ratio > sloAtRiskRatio:
+		metadata[graph.SLOStatus] = SLOStatusAtRisk
+	default:
+		metadata[graph.SLOStatus] = SLOStatusMet
+	}
+}
+
+func populateServiceLatencyMap(vector *model.Vector) map[graph.ServiceName]float64 {
+	serviceLatencyMap := make(map[graph.ServiceName]float64)
+	for _, s := range *vector {
+		m := s.Metric
+		lDestCluster, destClusterOk := m["destination_cluster"]
+		lDestSvcNs, destSvcNsOk := m["destination_service_namespace"]
+		lDestSvcName, destSvcNameOk := m["destination_service_name"]
+
+		if !destSvcNsOk || !destSvcNameOk {
+			log.Warningf("populateServiceLatencyMap: Skipping %s, missing expected labels", m.String())
+			continue
+		}
+
+		val := float64(s.Value)
+
+		// Should not happen but if NaN for any reason, Just skip it
+		if math.IsNaN(val) {
+			continue
+		}
+
+		destCluster := graph.Unknown
+		if destClusterOk {
+			destCluster = string(lDestCluster)
+		}
+		svc := graph.ServiceName{Cluster: destCluster, Namespace: string(lDestSvcNs), Name: string(lDestSvcName)}
+		serviceLatencyMap[svc] = val
+	}
+	return serviceLatencyMap
+}
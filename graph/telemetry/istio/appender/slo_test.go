@@ -0,0 +1,227 @@
+package appender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+func TestSLO(t *testing.T) {
+	assert := assert.New(t)
+
+	q0 := `round(histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{reporter="destination",destination_service_namespace="bookinfo"}[60s])) by (le,destination_cluster,destination_service_namespace,destination_service_name)) > 0,0.001)`
+	v0 := model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"destination_cluster":           business.DefaultClusterID,
+				"destination_service_namespace": "bookinfo",
+				"destination_service_name":      "productpage"},
+			Value: 90},
+		&model.Sample{
+			Metric: model.Metric{
+				"destination_cluster":           business.DefaultClusterID,
+				"destination_service_namespace": "bookinfo",
+				"destination_service_name":      "reviews"},
+			Value: 20},
+	}
+
+	q1 := `round(histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{reporter="destination",destination_service_namespace="bookinfo"}[60s])) by (le,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol)) > 0,0.001)`
+	v1 := model.Vector{
+		&model.Sample{
+			Metric: model.Metric{
+				"source_cluster":                 business.DefaultClusterID,
+				"source_workload_namespace":      "istio-system",
+				"source_workload":                "ingressgateway-unknown",
+				"source_canonical_service":       "ingressgateway",
+				"source_canonical_revision":      model.LabelValue(graph.Unknown),
+				"destination_cluster":            business.DefaultClusterID,
+				"destination_service_namespace":  "bookinfo",
+				"destination_service":            "productpage.bookinfo.svc.cluster.local",
+				"destination_service_name":       "productpage",
+				"destination_workload_namespace": "bookinfo",
+				"destination_workload":           "productpage-v1",
+				"destination_canonical_service":  "productpage",
+				"destination_canonical_revision": "v1",
+				"request_protocol":               "http"},
+			Value: 120},
+	}
+
+	q2 := `round(histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{reporter="source",source_workload_namespace="bookinfo"}[60s])) by (le,source_cluster,source_workload_namespace,source_workload,source_canonical_service,source_canonical_revision,destination_cluster,destination_service_namespace,destination_service,destination_service_name,destination_workload_namespace,destination_workload,destination_canonical_service,destination_canonical_revision,request_protocol)) > 0,0.001)`
+	v2 := model.Vector{}
+
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	api.On("Query", mock.Anything, q0, mock.Anything).Return(v0, nil)
+	api.On("Query", mock.Anything, q1, mock.Anything).Return(v1, nil)
+	api.On("Query", mock.Anything, q2, mock.Anything).Return(v2, nil)
+
+	conf := config.Get()
+	conf.HealthConfig.SLO = []config.SLO{
+		{Namespace: "bookinfo", Name: "productpage|reviews", Availability: 99, LatencyP99: 100},
+	}
+	config.Set(conf)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.PromClient = client
+	globalInfo.Vendor[serviceListKey] = map[string]*models.ServiceList{
+		"bookinfo": {
+			Services: []models.ServiceOverview{
+				{Name: "productpage"},
+				{Name: "ratings"},
+				{Name: "reviews", SLOAnnotations: map[string]string{string(models.AvailabilitySLOAnnotation): "99.9"}},
+			},
+		},
+	}
+
+	trafficMap := sloTestTraffic()
+	duration, _ := time.ParseDuration("60s")
+	appender := SLOAppender{
+		GraphType:          graph.GraphTypeVersionedApp,
+		InjectServiceNodes: true,
+		Namespaces: map[string]graph.NamespaceInfo{
+			"bookinfo": {
+				Name:     "bookinfo",
+				Duration: duration,
+			},
+		},
+		QueryTime: time.Now().Unix(),
+		Rates: graph.RequestedRates{
+			Grpc: graph.RateRequests,
+			Http: graph.RateRequests,
+			Tcp:  graph.RateTotal,
+		},
+	}
+
+	appender.appendGraph(trafficMap, "bookinfo", globalInfo)
+
+	// productpage: 0.5% errors of a 1% budget, p99 at 90% of its objective
+	productpageService := trafficMap[sloTestID("productpage", "", "")]
+	assert.InDelta(0.5, productpageService.Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusAtRisk, productpageService.Metadata[graph.SLOStatus])
+
+	// with injected service nodes the response time is reported on the outgoing edge of the service, and exceeds
+	// the objective
+	ingress := trafficMap[sloTestID("", "ingressgateway", graph.Unknown)]
+	assert.InDelta(0.5, ingress.Edges[0].Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusMet, ingress.Edges[0].Metadata[graph.SLOStatus])
+	assert.InDelta(0.5, productpageService.Edges[0].Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusViolated, productpageService.Edges[0].Metadata[graph.SLOStatus])
+
+	// productpage-v1 is evaluated against the objectives of its requested service
+	productpage := trafficMap[sloTestID("", "productpage", "v1")]
+	assert.InDelta(0.5, productpage.Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusAtRisk, productpage.Metadata[graph.SLOStatus])
+
+	// reviews: the annotation overrides the availability, 0.5% errors of a 0.1% budget
+	reviewsService := trafficMap[sloTestID("reviews", "", "")]
+	assert.InDelta(5.0, reviewsService.Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusViolated, reviewsService.Metadata[graph.SLOStatus])
+
+	// reviews-v1 and its incoming edge are evaluated against the reviews objectives
+	reviewsV1 := trafficMap[sloTestID("", "reviews", "v1")]
+	assert.InDelta(0.0, reviewsV1.Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusMet, reviewsV1.Metadata[graph.SLOStatus])
+	assert.InDelta(0.0, reviewsService.Edges[0].Metadata[graph.BurnRate], 0.001)
+	assert.Equal(SLOStatusMet, reviewsService.Edges[0].Metadata[graph.SLOStatus])
+
+	// ratings has no objective
+	ratingsService := trafficMap[sloTestID("ratings", "", "")]
+	_, ok := ratingsService.Metadata[graph.SLOStatus]
+	assert.False(ok)
+	_, ok = reviewsV1.Edges[0].Metadata[graph.BurnRate]
+	assert.False(ok)
+}
+
+func TestSLOInvalidTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.SLO = []config.SLO{
+		{Namespace: "bookinfo", Name: "[", Availability: 99},
+		{Namespace: "bookinfo", Availability: 100, LatencyP99: 100},
+	}
+	config.Set(conf)
+
+	globalInfo := graph.NewAppenderGlobalInfo()
+	globalInfo.Vendor[serviceListKey] = map[string]*models.ServiceList{
+		"bookinfo": {
+			Services: []models.ServiceOverview{
+				{Name: "reviews", SLOAnnotations: map[string]string{string(models.LatencyP99SLOAnnotation): "fast"}},
+			},
+		},
+	}
+
+	appender := SLOAppender{}
+
+	// the invalid regex is skipped, an availability of 100% has no error budget
+	target := appender.getServiceTarget(graph.ServiceName{Namespace: "bookinfo", Name: "ratings"}, "bookinfo", globalInfo)
+	assert.Equal(sloTarget{latencyP99: 100}, target)
+
+	// an invalid annotation removes the objective
+	target = appender.getServiceTarget(graph.ServiceName{Namespace: "bookinfo", Name: "reviews"}, "bookinfo", globalInfo)
+	assert.Equal(sloTarget{}, target)
+}
+
+func sloTestID(service, app, version string) string {
+	if service != "" {
+		id, _ := graph.Id(business.DefaultClusterID, "bookinfo", service, "", "", "", "", graph.GraphTypeVersionedApp)
+		return id
+	}
+	namespace := "bookinfo"
+	workload := app + "-" + version
+	if app == "ingressgateway" {
+		namespace = "istio-system"
+		workload = "ingressgateway-unknown"
+	}
+	id, _ := graph.Id(business.DefaultClusterID, namespace, "", namespace, workload, app, version, graph.GraphTypeVersionedApp)
+	return id
+}
+
+func sloTestTraffic() graph.TrafficMap {
+	ingress := graph.NewNode(business.DefaultClusterID, "istio-system", "", "istio-system", "ingressgateway-unknown", "ingressgateway", graph.Unknown, graph.GraphTypeVersionedApp)
+	productpageService := graph.NewNode(business.DefaultClusterID, "bookinfo", "productpage", "", "", "", "", graph.GraphTypeVersionedApp)
+	productpage := graph.NewNode(business.DefaultClusterID, "bookinfo", "productpage", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeVersionedApp)
+	reviewsService := graph.NewNode(business.DefaultClusterID, "bookinfo", "reviews", "", "", "", "", graph.GraphTypeVersionedApp)
+	reviewsV1 := graph.NewNode(business.DefaultClusterID, "bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeVersionedApp)
+	ratingsService := graph.NewNode(business.DefaultClusterID, "bookinfo", "ratings", "", "", "", "", graph.GraphTypeVersionedApp)
+	productpageName := graph.ServiceName{Cluster: business.DefaultClusterID, Namespace: "bookinfo", Name: "productpage"}
+	productpage.Metadata[graph.DestServices] = graph.DestServicesMetadata{productpageName.Key(): productpageName}
+	reviewsName := graph.ServiceName{Cluster: business.DefaultClusterID, Namespace: "bookinfo", Name: "reviews"}
+	reviewsV1.Metadata[graph.DestServices] = graph.DestServicesMetadata{reviewsName.Key(): reviewsName}
+	trafficMap := graph.NewTrafficMap()
+
+	trafficMap[ingress.ID] = &ingress
+	trafficMap[productpageService.ID] = &productpageService
+	trafficMap[productpage.ID] = &productpage
+	trafficMap[reviewsService.ID] = &reviewsService
+	trafficMap[reviewsV1.ID] = &reviewsV1
+	trafficMap[ratingsService.ID] = &ratingsService
+
+	addSLOTestEdge(&ingress, &productpageService, 10.0, 0.05)
+	addSLOTestEdge(&productpageService, &productpage, 10.0, 0.05)
+	addSLOTestEdge(&productpage, &reviewsService, 10.0, 0.05)
+	addSLOTestEdge(&reviewsService, &reviewsV1, 10.0, 0.0)
+	addSLOTestEdge(&reviewsV1, &ratingsService, 10.0, 0.0)
+
+	return trafficMap
+}
+
+func addSLOTestEdge(source, dest *graph.Node, rate, rate5xx float64) {
+	e := source.AddEdge(dest)
+	e.Metadata[graph.ProtocolKey] = "http"
+	e.Metadata["http"] = rate
+	if rate5xx > 0 {
+		e.Metadata["http5xx"] = rate5xx
+	}
+}
@@ -8,6 +8,11 @@ package istio
 //   Step 1) For each namespace:
 //     a) Query Prometheus (istio-requests-total metric) to retrieve the source-destination
 //        dependencies. Build a traffic map to provide a full representation of nodes and edges.
+//        When the ambient mesh is enabled, also query the L7 telemetry reported by the waypoint
+//        proxies. The L4 telemetry reported by ztunnel is captured by the tcp queries.
+//        When each cluster has its own Prometheus, the queries are federated: they run concurrently
+//        on the Prometheus of the clusters and the vectors are merged. The cross-cluster traffic is
+//        reported to both sides, the duplicate time series are discarded when building the map.
 //
 //     b) Apply any requested appenders to alter or append-to the namespace traffic-map.
 //
@@ -34,6 +39,7 @@ import (
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/telemetry"
 	"github.com/kiali/kiali/graph/telemetry/istio/appender"
@@ -47,8 +53,12 @@ import (
 const (
 	tsHash    graph.MetadataKey = "tsHash"
 	tsHashMap graph.MetadataKey = "tsHashMap"
+	waypoint  graph.MetadataKey = "waypoint" // set on the edges with traffic reported by a waypoint proxy
 )
 
+// reporterWaypoint is the reporter of the L7 telemetry of the ambient mesh
+const reporterWaypoint = "waypoint"
+
 var grpcMetric = regexp.MustCompile(`istio_.*_messages`)
 
 // BuildNamespacesTrafficMap is required by the graph/TelemtryVendor interface
@@ -150,6 +160,9 @@ func buildNamespaceTrafficMap(ctx context.Context, namespace string, o graph.Tel
 			idleCondition)
 		outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
 		populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+
+		// 3) Ambient: query waypoint telemetry, reporting the traffic of the ambient workloads
+		populateWaypointTrafficMap(trafficMap, namespace, metric, groupBy, idleCondition, o, client)
 	}
 
 	// GRPC Message traffic
@@ -199,6 +212,9 @@ func buildNamespaceTrafficMap(ctx context.Context, namespace string, o graph.Tel
 				idleCondition)
 			outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
 			populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+
+			// 3) Ambient: query waypoint telemetry, reporting the traffic of the ambient workloads
+			populateWaypointTrafficMap(trafficMap, namespace, metric, groupBy, idleCondition, o, client)
 		}
 	}
 
@@ -252,9 +268,42 @@ func buildNamespaceTrafficMap(ctx context.Context, namespace string, o graph.Tel
 		}
 	}
 
+	removeWaypointTunnelTraffic(trafficMap)
+
 	return trafficMap
 }
 
+// populateWaypointTrafficMap queries the telemetry reported by the waypoint proxies, for the incoming and outgoing
+// traffic of the namespace, when the ambient mesh is enabled. The ambient workloads have no sidecar, their L7
+// traffic is reported only by the waypoint proxies.
+func populateWaypointTrafficMap(trafficMap graph.TrafficMap, namespace, metric, groupBy, idleCondition string, o graph.TelemetryOptions, client *prometheus.Client) {
+	if !config.Get().ExternalServices.Istio.AmbientEnabled {
+		return
+	}
+	duration := o.Namespaces[namespace].Duration
+
+	// the reporter is kept to flag the edges reported by a waypoint proxy
+	query := fmt.Sprintf(`sum(rate(%s{reporter="%s",destination_workload_namespace="%s"} [%vs])) by (%s,reporter) %s`,
+		metric,
+		reporterWaypoint,
+		namespace,
+		int(duration.Seconds()), // range duration for the query
+		groupBy,
+		idleCondition)
+	incomingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+	populateTrafficMap(trafficMap, &incomingVector, metric, o)
+
+	query = fmt.Sprintf(`sum(rate(%s{reporter="%s",source_workload_namespace="%s"} [%vs])) by (%s,reporter) %s`,
+		metric,
+		reporterWaypoint,
+		namespace,
+		int(duration.Seconds()), // range duration for the query
+		groupBy,
+		idleCondition)
+	outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+	populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+}
+
 func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metric string, o graph.TelemetryOptions) {
 	isRequests := true
 	protocol := ""
@@ -297,6 +346,7 @@ func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metri
 		sourceApp := string(lSourceApp)
 		sourceVer := string(lSourceVer)
 		destSvc := string(lDestSvc)
+		isWaypoint := string(m["reporter"]) == reporterWaypoint
 
 		flags := ""
 		if isRequests || protocol == graph.TCP.Name {
@@ -354,11 +404,11 @@ func populateTrafficMap(trafficMap graph.TrafficMap, vector *model.Vector, metri
 			_, destNodeType := graph.Id(destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, o.GraphType)
 			inject = (graph.NodeTypeService != destNodeType)
 		}
-		addTraffic(trafficMap, metric, inject, val, protocol, code, flags, host, sourceCluster, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, o)
+		addTraffic(trafficMap, metric, inject, isWaypoint, val, protocol, code, flags, host, sourceCluster, sourceWlNs, "", sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, o)
 	}
 }
 
-func addTraffic(trafficMap graph.TrafficMap, metric string, inject bool, val float64, protocol, code, flags, host, sourceCluster, sourceNs, sourceSvc, sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer string, o graph.TelemetryOptions) {
+func addTraffic(trafficMap graph.TrafficMap, metric string, inject, isWaypoint bool, val float64, protocol, code, flags, host, sourceCluster, sourceNs, sourceSvc, sourceWl, sourceApp, sourceVer, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer string, o graph.TelemetryOptions) {
 	source, _ := addNode(trafficMap, sourceCluster, sourceNs, sourceSvc, sourceNs, sourceWl, sourceApp, sourceVer, o)
 	dest, _ := addNode(trafficMap, destCluster, destSvcNs, destSvcName, destWlNs, destWl, destApp, destVer, o)
 
@@ -368,16 +418,21 @@ func addTraffic(trafficMap graph.TrafficMap, metric string, inject bool, val flo
 	// and uses that unique TS has to protect against applying the same intomation twice.
 	edgeTSHash := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%s:%s:%s:%s:%s:%s", metric, source.Metadata[tsHash], dest.Metadata[tsHash], code, flags, host))))
 
+	// a waypoint proxy serves only workloads captured by the ambient mesh
+	if isWaypoint && dest.NodeType != graph.NodeTypeService {
+		dest.Metadata[graph.IsAmbient] = true
+	}
+
 	if inject {
 		injectedService, _ := addNode(trafficMap, destCluster, destSvcNs, destSvcName, "", "", "", "", o)
-		if addEdgeTraffic(trafficMap, val, protocol, code, flags, host, source, injectedService, edgeTSHash, o) {
+		if addEdgeTraffic(trafficMap, val, protocol, code, flags, host, source, injectedService, edgeTSHash, isWaypoint, o) {
 			addToDestServices(injectedService.Metadata, destCluster, destSvcNs, destSvcName)
 
-			addEdgeTraffic(trafficMap, val, protocol, code, flags, host, injectedService, dest, edgeTSHash, o)
+			addEdgeTraffic(trafficMap, val, protocol, code, flags, host, injectedService, dest, edgeTSHash, isWaypoint, o)
 			addToDestServices(dest.Metadata, destCluster, destSvcNs, destSvcName)
 		}
 	} else {
-		if addEdgeTraffic(trafficMap, val, protocol, code, flags, host, source, dest, edgeTSHash, o) {
+		if addEdgeTraffic(trafficMap, val, protocol, code, flags, host, source, dest, edgeTSHash, isWaypoint, o) {
 			addToDestServices(dest.Metadata, destCluster, destSvcNs, destSvcName)
 		}
 	}
@@ -385,7 +440,7 @@ func addTraffic(trafficMap graph.TrafficMap, metric string, inject bool, val flo
 
 // addEdgeTraffic uses edgeTSHash that the metric information has not been applied to the edge. Returns true
 // if the the metric information is applied, false if it determined to be a duplicate.
-func addEdgeTraffic(trafficMap graph.TrafficMap, val float64, protocol, code, flags, host string, source, dest *graph.Node, edgeTSHash string, o graph.TelemetryOptions) bool {
+func addEdgeTraffic(trafficMap graph.TrafficMap, val float64, protocol, code, flags, host string, source, dest *graph.Node, edgeTSHash string, isWaypoint bool, o graph.TelemetryOptions) bool {
 	var edge *graph.Edge
 	for _, e := range source.Edges {
 		if dest.ID == e.Dest.ID && e.Metadata[graph.ProtocolKey] == protocol {
@@ -399,6 +454,11 @@ func addEdgeTraffic(trafficMap graph.TrafficMap, val float64, protocol, code, fl
 		edge.Metadata[tsHashMap] = make(map[string]bool)
 	}
 
+	// flag the edge even for a duplicate, the traffic can be reported by the source proxy and the waypoint proxy
+	if isWaypoint {
+		edge.Metadata[waypoint] = true
+	}
+
 	if _, ok := edge.Metadata[tsHashMap].(map[string]bool)[edgeTSHash]; !ok {
 		edge.Metadata[tsHashMap].(map[string]bool)[edgeTSHash] = true
 		graph.AddToMetadata(protocol, val, code, flags, host, source.Metadata, dest.Metadata, edge.Metadata)
@@ -408,6 +468,33 @@ func addEdgeTraffic(trafficMap graph.TrafficMap, val float64, protocol, code, fl
 	return false
 }
 
+// removeWaypointTunnelTraffic removes the TCP edges between nodes with traffic reported by a waypoint proxy, they
+// are duplicates: the TCP traffic is the ztunnel tunnel of the same requests. It runs once all the metrics are read,
+// the edges are reported by queries run in any order.
+func removeWaypointTunnelTraffic(trafficMap graph.TrafficMap) {
+	for _, n := range trafficMap {
+		waypointDests := map[string]bool{}
+		for _, e := range n.Edges {
+			if e.Metadata[waypoint] == true {
+				waypointDests[e.Dest.ID] = true
+			}
+		}
+		if len(waypointDests) == 0 {
+			continue
+		}
+
+		edges := make([]*graph.Edge, 0, len(n.Edges))
+		for _, e := range n.Edges {
+			if e.Metadata[graph.ProtocolKey] == graph.TCP.Name && waypointDests[e.Dest.ID] {
+				graph.RemoveTCPEdgeFromMetadata(n.Metadata, e.Dest.Metadata, e.Metadata)
+				continue
+			}
+			edges = append(edges, e)
+		}
+		n.Edges = edges
+	}
+}
+
 func addToDestServices(md graph.Metadata, cluster, namespace, service string) {
 	if !graph.IsOK(service) {
 		return
@@ -601,6 +688,9 @@ func buildNodeTrafficMap(cluster, namespace string, n graph.Node, o graph.Teleme
 		}
 		outVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
 		populateTrafficMap(trafficMap, &outVector, metric, o)
+
+		// 3) Ambient: query waypoint telemetry, reporting the traffic of the ambient workloads
+		populateNodeWaypointTrafficMap(trafficMap, n, namespace, sourceCluster, destCluster, metric, groupBy, idleCondition, o, client)
 	}
 
 	// gRPC message traffic
@@ -709,6 +799,9 @@ func buildNodeTrafficMap(cluster, namespace string, n graph.Node, o graph.Teleme
 			}
 			outgoingVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
 			populateTrafficMap(trafficMap, &outgoingVector, metric, o)
+
+			// 3) Ambient: query waypoint telemetry, reporting the traffic of the ambient workloads
+			populateNodeWaypointTrafficMap(trafficMap, n, namespace, sourceCluster, destCluster, metric, groupBy, idleCondition, o, client)
 		}
 	}
 
@@ -821,9 +914,64 @@ func buildNodeTrafficMap(cluster, namespace string, n graph.Node, o graph.Teleme
 		}
 	}
 
+	removeWaypointTunnelTraffic(trafficMap)
+
 	return trafficMap
 }
 
+// populateNodeWaypointTrafficMap queries the telemetry reported by the waypoint proxies, for the incoming and
+// outgoing traffic of the node, when the ambient mesh is enabled. The service nodes have only incoming traffic.
+func populateNodeWaypointTrafficMap(trafficMap graph.TrafficMap, n graph.Node, namespace, sourceCluster, destCluster, metric, groupBy, idleCondition string, o graph.TelemetryOptions, client *prometheus.Client) {
+	if !config.Get().ExternalServices.Istio.AmbientEnabled {
+		return
+	}
+	duration := o.Namespaces[namespace].Duration
+
+	var inSelector, outSelector string
+	switch n.NodeType {
+	case graph.NodeTypeWorkload:
+		inSelector = fmt.Sprintf(`destination_workload_namespace="%s",destination_workload="%s"`, namespace, n.Workload)
+		outSelector = fmt.Sprintf(`source_workload_namespace="%s",source_workload="%s"`, namespace, n.Workload)
+	case graph.NodeTypeApp:
+		inSelector = fmt.Sprintf(`destination_service_namespace="%s",destination_canonical_service="%s"`, namespace, n.App)
+		outSelector = fmt.Sprintf(`source_workload_namespace="%s",source_canonical_service="%s"`, namespace, n.App)
+		if graph.IsOK(n.Version) {
+			inSelector += fmt.Sprintf(`,destination_canonical_revision="%s"`, n.Version)
+			outSelector += fmt.Sprintf(`,source_canonical_revision="%s"`, n.Version)
+		}
+	case graph.NodeTypeService:
+		inSelector = fmt.Sprintf(`destination_service_namespace="%s",destination_service=~"^%s\\.%s\\..*$"`, namespace, n.Service, namespace)
+	default:
+		graph.Error(fmt.Sprintf("NodeType [%s] not supported", n.NodeType))
+	}
+
+	// the reporter is kept to flag the edges reported by a waypoint proxy
+	query := fmt.Sprintf(`sum(rate(%s{reporter="%s"%s,%s} [%vs])) by (%s,reporter) %s`,
+		metric,
+		reporterWaypoint,
+		destCluster,
+		inSelector,
+		int(duration.Seconds()), // range duration for the query
+		groupBy,
+		idleCondition)
+	inVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+	populateTrafficMap(trafficMap, &inVector, metric, o)
+
+	if outSelector == "" {
+		return
+	}
+	query = fmt.Sprintf(`sum(rate(%s{reporter="%s"%s,%s} [%vs])) by (%s,reporter) %s`,
+		metric,
+		reporterWaypoint,
+		sourceCluster,
+		outSelector,
+		int(duration.Seconds()), // range duration for the query
+		groupBy,
+		idleCondition)
+	outVector := promQuery(query, time.Unix(o.QueryTime, 0), client.API())
+	populateTrafficMap(trafficMap, &outVector, metric, o)
+}
+
 func handleAggregateNodeTrafficMap(o graph.TelemetryOptions, client *prometheus.Client, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
 	n := graph.NewAggregateNode(o.NodeOptions.Cluster, o.NodeOptions.Namespace, o.NodeOptions.Aggregate, o.NodeOptions.AggregateValue, o.NodeOptions.Service, o.NodeOptions.App)
 
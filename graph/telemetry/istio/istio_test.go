@@ -0,0 +1,199 @@
+package istio
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func ambientMetric(reporter string) model.Metric {
+	m := model.Metric{
+		"source_cluster":                 "east",
+		"source_workload_namespace":      "bookinfo",
+		"source_workload":                "productpage-v1",
+		"source_canonical_service":       "productpage",
+		"source_canonical_revision":      "v1",
+		"destination_cluster":            "east",
+		"destination_service_namespace":  "bookinfo",
+		"destination_service":            "reviews.bookinfo.svc.cluster.local",
+		"destination_service_name":       "reviews",
+		"destination_workload_namespace": "bookinfo",
+		"destination_workload":           "reviews-v1",
+		"destination_canonical_service":  "reviews",
+		"destination_canonical_revision": "v1",
+		"response_flags":                 "-",
+	}
+	if reporter != "" {
+		m["reporter"] = model.LabelValue(reporter)
+	}
+	return m
+}
+
+func TestPopulateTrafficMapAmbient(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	o := graph.TelemetryOptions{
+		Rates: graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests, Tcp: graph.RateSent},
+	}
+	o.GraphType = graph.GraphTypeWorkload
+
+	// the requests are reported by the source proxy and the waypoint proxy, and tunneled by ztunnel
+	source := ambientMetric("")
+	source["request_protocol"] = "http"
+	source["response_code"] = "200"
+	source["grpc_response_status"] = ""
+	waypoint := ambientMetric(reporterWaypoint)
+	waypoint["request_protocol"] = "http"
+	waypoint["response_code"] = "200"
+	waypoint["grpc_response_status"] = ""
+
+	populates := map[string]func(trafficMap graph.TrafficMap){
+		"source": func(trafficMap graph.TrafficMap) {
+			populateTrafficMap(trafficMap, &model.Vector{{Metric: source, Value: 10}}, "istio_requests_total", o)
+		},
+		"waypoint": func(trafficMap graph.TrafficMap) {
+			populateTrafficMap(trafficMap, &model.Vector{{Metric: waypoint, Value: 10}}, "istio_requests_total", o)
+		},
+		"tcp": func(trafficMap graph.TrafficMap) {
+			populateTrafficMap(trafficMap, &model.Vector{{Metric: ambientMetric(""), Value: 800}}, "istio_tcp_sent_bytes_total", o)
+		},
+	}
+
+	// the ztunnel TCP traffic is removed whatever the order of the queries
+	for _, order := range [][]string{{"source", "waypoint", "tcp"}, {"tcp", "source", "waypoint"}, {"tcp", "waypoint", "source"}} {
+		trafficMap := graph.NewTrafficMap()
+		for _, name := range order {
+			populates[name](trafficMap)
+		}
+		removeWaypointTunnelTraffic(trafficMap)
+
+		assert.Len(trafficMap, 2, order)
+		productpageID, _ := graph.Id("east", "bookinfo", "reviews", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+		reviewsID, _ := graph.Id("east", "bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+		productpage, reviews := trafficMap[productpageID], trafficMap[reviewsID]
+		if assert.NotNil(productpage, order) && assert.NotNil(reviews, order) {
+			assert.Len(productpage.Edges, 1, order)
+			assert.Equal(graph.HTTP.Name, productpage.Edges[0].Metadata[graph.ProtocolKey], order)
+			assert.Equal(10.0, productpage.Edges[0].Metadata[graph.HTTP.EdgeResponses].(graph.Responses)["200"].Flags["-"], order)
+			assert.Equal(true, reviews.Metadata[graph.IsAmbient], order)
+			assert.Nil(productpage.Metadata[graph.IsAmbient], order)
+			// the TCP traffic of the removed edge is removed from the nodes
+			assert.Nil(productpage.Metadata[graph.TCP.NodeRates[1].Name], order)
+			assert.Nil(reviews.Metadata[graph.TCP.NodeRates[0].Name], order)
+		}
+	}
+}
+
+func TestBuildNodeTrafficMapAmbient(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.Istio.AmbientEnabled = true
+	config.Set(conf)
+
+	api := new(prometheustest.PromAPIMock)
+	client, err := prometheus.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Inject(api)
+
+	// the ambient workload has no sidecar, its requests are reported only by the waypoint proxy
+	waypointMetric := ambientMetric(reporterWaypoint)
+	waypointMetric["request_protocol"] = "http"
+	waypointMetric["response_code"] = "200"
+	waypointMetric["grpc_response_status"] = ""
+	isWaypointQuery := func(query string) bool {
+		return strings.Contains(query, `reporter="waypoint"`) && strings.Contains(query, `destination_workload="reviews-v1"`)
+	}
+	api.On("Query", mock.Anything, mock.MatchedBy(isWaypointQuery), mock.Anything).Return(model.Vector{{Metric: waypointMetric, Value: 10}}, nil)
+	api.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(model.Vector{}, nil)
+
+	o := graph.TelemetryOptions{
+		Namespaces: graph.NamespaceInfoMap{"bookinfo": {Name: "bookinfo", Duration: 60 * time.Second}},
+		Rates:      graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests, Tcp: graph.RateSent},
+	}
+	o.GraphType = graph.GraphTypeWorkload
+	o.QueryTime = time.Now().Unix()
+	reviews := graph.NewNode("east", "bookinfo", "", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+
+	trafficMap := buildNodeTrafficMap("east", "bookinfo", reviews, o, client)
+
+	assert.Len(trafficMap, 2)
+	productpageID, _ := graph.Id("east", "bookinfo", "reviews", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	if productpage := trafficMap[productpageID]; assert.NotNil(productpage) {
+		assert.Len(productpage.Edges, 1)
+		assert.Equal(reviews.ID, productpage.Edges[0].Dest.ID)
+		assert.Equal(true, productpage.Edges[0].Metadata[waypoint])
+		assert.Equal(true, productpage.Edges[0].Dest.Metadata[graph.IsAmbient])
+	}
+	api.AssertCalled(t, "Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `reporter="waypoint"`) && strings.Contains(query, `source_workload="reviews-v1"`)
+	}), mock.Anything)
+}
+
+func TestPopulateTrafficMapZtunnel(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	o := graph.TelemetryOptions{
+		Rates: graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests, Tcp: graph.RateSent},
+	}
+	o.GraphType = graph.GraphTypeWorkload
+
+	// without a waypoint proxy, the ztunnel L4 traffic is reported by the source and destination ztunnel
+	trafficMap := graph.NewTrafficMap()
+	populateTrafficMap(trafficMap, &model.Vector{{Metric: ambientMetric(""), Value: 800}}, "istio_tcp_sent_bytes_total", o)
+	populateTrafficMap(trafficMap, &model.Vector{{Metric: ambientMetric(""), Value: 800}}, "istio_tcp_sent_bytes_total", o)
+
+	assert.Len(trafficMap, 2)
+	for _, n := range trafficMap {
+		for _, e := range n.Edges {
+			assert.Equal(graph.TCP.Name, e.Metadata[graph.ProtocolKey])
+			assert.Equal(800.0, e.Metadata[graph.TCP.EdgeResponses].(graph.Responses)["-"].Flags["-"])
+		}
+	}
+}
+
+func TestPopulateTrafficMapCrossCluster(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	o := graph.TelemetryOptions{
+		Rates: graph.RequestedRates{Grpc: graph.RateRequests, Http: graph.RateRequests, Tcp: graph.RateSent},
+	}
+	o.GraphType = graph.GraphTypeWorkload
+
+	// the requests are reported by the source proxy to the Prometheus of its cluster, and by the destination
+	// proxy to the Prometheus of the other cluster, the federated vectors hold both.
+	source := ambientMetric("")
+	source["destination_cluster"] = "west"
+	source["request_protocol"] = "http"
+	source["response_code"] = "200"
+	source["grpc_response_status"] = ""
+	dest := source.Clone()
+
+	trafficMap := graph.NewTrafficMap()
+	populateTrafficMap(trafficMap, &model.Vector{{Metric: dest, Value: 10}}, "istio_requests_total", o)
+	populateTrafficMap(trafficMap, &model.Vector{{Metric: source, Value: 10}}, "istio_requests_total", o)
+
+	assert.Len(trafficMap, 2)
+	productpageID, _ := graph.Id("east", "bookinfo", "reviews", "bookinfo", "productpage-v1", "productpage", "v1", graph.GraphTypeWorkload)
+	reviewsID, _ := graph.Id("west", "bookinfo", "reviews", "bookinfo", "reviews-v1", "reviews", "v1", graph.GraphTypeWorkload)
+	productpage, reviews := trafficMap[productpageID], trafficMap[reviewsID]
+	if assert.NotNil(productpage) && assert.NotNil(reviews) {
+		assert.Len(productpage.Edges, 1)
+		assert.Equal(reviews, productpage.Edges[0].Dest)
+		assert.Equal(10.0, productpage.Edges[0].Metadata[graph.HTTP.EdgeResponses].(graph.Responses)["200"].Flags["-"])
+	}
+}
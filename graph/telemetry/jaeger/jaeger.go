@@ -0,0 +1,442 @@
+// Package jaeger provides a graph/TelemetryVendor building the graph from the Jaeger traces.
+package jaeger
+
+// Jaeger.go is responsible for generating TrafficMaps from the sampled traces of the requested namespaces. It
+// does not query Prometheus. The traces show the calls Istio telemetry can not see, like the calls of the services
+// outside of the mesh, and give a graph for the clusters not collecting the Istio metrics.
+//
+// A span is attributed to the app reporting it, identified by the Istio tags of the span when set by a proxy, or
+// else by the Jaeger service name of its process. A call is a span of an app whose parent span, the span it is the
+// child of or follows from, belongs to another app. The spans of the same app are the in-process hops of the app,
+// they are not calls but they are followed, as the parent of the next call leaving the app is its in-process span.
+// The duration and the error of a call are the ones of the called span.
+//
+// The traces are sampled, so the edges report the number of sampled calls, errors and latency percentiles rather
+// than traffic rates. The spans identify apps, not workloads, so only the app and versionedApp graph types are
+// supported.
+//
+// The algorithm:
+//   Step 1) Fetch the traces of the apps of the requested namespaces, and collect the calls of their spans
+//
+//   Step 2) For each namespace:
+//     a) Build the namespace traffic map from the calls from or to the namespace
+//
+//     b) Apply the requested appenders not requiring telemetry
+//
+//     c) Merge the namespace traffic map into the final traffic map
+//
+//   Step 3) For the global traffic map, apply the finalizers not requiring telemetry
+//
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	jaegerModels "github.com/kiali/kiali/jaeger/model/json"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// defaultTraceLimit is the number of traces fetched per app, overridden by the traceLimit query param
+const defaultTraceLimit = 100
+
+// supportedAppenders are the appenders not requiring telemetry, the other requested appenders are skipped
+var supportedAppenders = map[string]bool{
+	appender.BoxLabelAppenderName:      true,
+	appender.HideAppenderName:          true,
+	appender.IstioAppenderName:         true,
+	appender.LabelerAppenderName:       true,
+	appender.OutsiderAppenderName:      true, // also the name of the traffic generator finalizer
+	appender.SidecarsCheckAppenderName: true,
+}
+
+// traceSource provides the traces the traffic map is built from
+type traceSource interface {
+	// namespaceTraces returns the traces of the apps of a namespace
+	namespaceTraces(namespace string) []jaegerModels.Trace
+}
+
+// app identifies the app reporting a span
+type app struct {
+	namespace string
+	name      string
+	version   string
+}
+
+// call is a call between two apps found in a trace
+type call struct {
+	source   app
+	dest     app
+	protocol string
+	duration float64 // millis
+	isErr    bool
+}
+
+// BuildNamespacesTrafficMap is required by the graph/TelemtryVendor interface
+func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "BuildNamespacesTrafficMap",
+		observability.Attribute("package", "jaeger"),
+	)
+	defer end()
+
+	log.Tracef("Build [%s] trace graph for [%d] namespaces [%v]", o.GraphType, len(o.Namespaces), o.Namespaces)
+
+	source := newBusinessSource(ctx, globalInfo.Business, o)
+	namespaces := make([]string, 0, len(o.Namespaces))
+	for namespace := range o.Namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	apps, calls := collectCalls(source, namespaces)
+
+	appenders, finalizers := telemetry.ParseSupportedAppenders(o, supportedAppenders)
+	cluster := telemetry.HomeCluster(globalInfo)
+	trafficMap := graph.NewTrafficMap()
+
+	for _, namespace := range o.Namespaces {
+		log.Tracef("Build trace traffic map for namespace [%v]", namespace)
+		namespaceTrafficMap := buildNamespaceTrafficMap(namespace.Name, cluster, o.GraphType, apps, calls)
+
+		// The appenders can add/remove/alter nodes for the namespace
+		namespaceInfo := graph.NewAppenderNamespaceInfo(namespace.Name)
+		for _, a := range appenders {
+			a.AppendGraph(namespaceTrafficMap, globalInfo, namespaceInfo)
+		}
+
+		// Merge this namespace into the final TrafficMap
+		telemetry.MergeTrafficMaps(trafficMap, namespace.Name, namespaceTrafficMap)
+	}
+
+	// The finalizers can perform final manipulations on the complete graph
+	for _, f := range finalizers {
+		f.AppendGraph(trafficMap, globalInfo, nil)
+	}
+
+	return trafficMap
+}
+
+// BuildNodeTrafficMap is required by the graph/TelemtryVendor interface
+func BuildNodeTrafficMap(ctx context.Context, o graph.TelemetryOptions, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
+	if o.NodeOptions.Aggregate != "" {
+		graph.BadRequest(fmt.Sprintf("Aggregate node graphs are not supported by telemetryVendor [%s]", graph.VendorJaeger))
+	}
+
+	cluster := telemetry.HomeCluster(globalInfo)
+	if o.NodeOptions.Cluster != graph.Unknown {
+		cluster = o.NodeOptions.Cluster
+	}
+	n := graph.NewNode(cluster, o.NodeOptions.Namespace, o.NodeOptions.Service, o.NodeOptions.Namespace, o.NodeOptions.Workload, o.NodeOptions.App, o.NodeOptions.Version, o.GraphType)
+
+	log.Tracef("Build trace graph for node [%+v]", n)
+
+	apps, calls := collectCalls(newBusinessSource(ctx, globalInfo.Business, o), []string{o.NodeOptions.Namespace})
+	trafficMap := telemetry.ReduceToNode(buildNamespaceTrafficMap(o.NodeOptions.Namespace, cluster, o.GraphType, apps, calls), n.ID)
+
+	appenders, finalizers := telemetry.ParseSupportedAppenders(o, supportedAppenders)
+	namespaceInfo := graph.NewAppenderNamespaceInfo(o.NodeOptions.Namespace)
+	for _, a := range appenders {
+		a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+	}
+
+	// The finalizers can perform final manipulations on the complete graph
+	for _, f := range finalizers {
+		f.AppendGraph(trafficMap, globalInfo, nil)
+	}
+
+	return trafficMap
+}
+
+// collectCalls returns the apps reporting the spans of the traces of the namespaces, and the calls between them.
+// A trace reaching several of the namespaces is read once.
+func collectCalls(source traceSource, namespaces []string) (map[app]bool, []call) {
+	apps := make(map[app]bool)
+	calls := []call{}
+	seen := make(map[jaegerModels.TraceID]bool)
+
+	for _, namespace := range namespaces {
+		for _, trace := range source.namespaceTraces(namespace) {
+			if seen[trace.TraceID] {
+				continue
+			}
+			seen[trace.TraceID] = true
+
+			spans := make(map[jaegerModels.SpanID]*jaegerModels.Span, len(trace.Spans))
+			for i := range trace.Spans {
+				spans[trace.Spans[i].SpanID] = &trace.Spans[i]
+			}
+			spanApps := getSpanApps(&trace, spans)
+			for _, spanApp := range spanApps {
+				apps[spanApp] = true
+			}
+
+			for _, span := range spans {
+				parent, ok := spans[getParentSpanID(span)]
+				if !ok {
+					continue
+				}
+				sourceApp, destApp := spanApps[parent.SpanID], spanApps[span.SpanID]
+				if sourceApp == destApp {
+					continue
+				}
+				protocol := getSpanProtocol(span)
+				if protocol == graph.TCP.Name {
+					protocol = getSpanProtocol(parent)
+				}
+				calls = append(calls, call{
+					source:   sourceApp,
+					dest:     destApp,
+					protocol: protocol,
+					duration: float64(span.Duration) / 1000.0,
+					isErr:    isSpanErr(span),
+				})
+			}
+		}
+	}
+
+	log.Tracef("Found [%d] calls between [%d] apps in the traces", len(calls), len(apps))
+	return apps, calls
+}
+
+// buildNamespaceTrafficMap returns a map of the apps of the namespace, and of the apps calling or called by them
+// (key=id)
+func buildNamespaceTrafficMap(namespace, cluster, graphType string, apps map[app]bool, calls []call) graph.TrafficMap {
+	trafficMap := graph.NewTrafficMap()
+	addNode := func(a app) *graph.Node {
+		n := graph.NewNode(cluster, a.namespace, "", a.namespace, "", a.name, a.version, graphType)
+		if node, found := trafficMap[n.ID]; found {
+			return node
+		}
+		trafficMap[n.ID] = &n
+		return &n
+	}
+
+	// the apps of the namespace without calls
+	for a := range apps {
+		if a.namespace == namespace {
+			addNode(a)
+		}
+	}
+
+	type edgeCalls struct {
+		edge      *graph.Edge
+		durations []float64
+		errors    int
+	}
+	edges := make(map[string]*edgeCalls)
+	for _, c := range calls {
+		if c.source.namespace != namespace && c.dest.namespace != namespace {
+			continue
+		}
+		source, dest := addNode(c.source), addNode(c.dest)
+		if source.ID == dest.ID {
+			// versions of the same app in an app graph
+			continue
+		}
+		key := fmt.Sprintf("%s %s %s", source.ID, dest.ID, c.protocol)
+		ec, found := edges[key]
+		if !found {
+			ec = &edgeCalls{edge: source.AddEdge(dest)}
+			ec.edge.Metadata[graph.ProtocolKey] = c.protocol
+			edges[key] = ec
+		}
+		ec.durations = append(ec.durations, c.duration)
+		if c.isErr {
+			ec.errors++
+		}
+	}
+
+	for _, ec := range edges {
+		sort.Float64s(ec.durations)
+		traces := &graph.TracesMetadata{
+			Calls:  len(ec.durations),
+			Errors: ec.errors,
+			P50:    percentile(ec.durations, 0.5),
+			P95:    percentile(ec.durations, 0.95),
+			P99:    percentile(ec.durations, 0.99),
+		}
+		ec.edge.Metadata[graph.Traces] = traces
+		ec.edge.Metadata[graph.ResponseTime] = traces.P95
+	}
+
+	return trafficMap
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func getProcess(trace *jaegerModels.Trace, span *jaegerModels.Span) *jaegerModels.Process {
+	if span.Process != nil {
+		return span.Process
+	}
+	if process, ok := trace.Processes[span.ProcessID]; ok {
+		return &process
+	}
+	return nil
+}
+
+// getSpanApps returns the apps of the spans of a trace. The in-process spans of an app are usually reported by
+// the app, without the Istio tags of its proxy, they get the version of the proxy span they are the child of.
+func getSpanApps(trace *jaegerModels.Trace, spans map[jaegerModels.SpanID]*jaegerModels.Span) map[jaegerModels.SpanID]app {
+	spanApps := make(map[jaegerModels.SpanID]app, len(spans))
+	var resolve func(span *jaegerModels.Span, depth int) app
+	resolve = func(span *jaegerModels.Span, depth int) app {
+		if spanApp, ok := spanApps[span.SpanID]; ok {
+			return spanApp
+		}
+		spanApp := getSpanApp(span, getProcess(trace, span))
+		// the depth guards against the malformed traces with cyclic references
+		if parent, ok := spans[getParentSpanID(span)]; ok && spanApp.version == graph.Unknown && depth < len(spans) {
+			if parentApp := resolve(parent, depth+1); parentApp.namespace == spanApp.namespace && parentApp.name == spanApp.name {
+				spanApp = parentApp
+			}
+		}
+		spanApps[span.SpanID] = spanApp
+		return spanApp
+	}
+	for _, span := range spans {
+		resolve(span, 0)
+	}
+	return spanApps
+}
+
+// getParentSpanID returns the span the span is the child of, or else the span it follows from
+func getParentSpanID(span *jaegerModels.Span) jaegerModels.SpanID {
+	parentID := span.ParentSpanID
+	for _, ref := range span.References {
+		if ref.TraceID != span.TraceID {
+			continue
+		}
+		if ref.RefType == jaegerModels.ChildOf {
+			return ref.SpanID
+		}
+		if parentID == "" {
+			parentID = ref.SpanID
+		}
+	}
+	return parentID
+}
+
+// getSpanApp returns the app of a span, from the Istio tags of the proxy spans, or else from the Jaeger service
+// name, <app> or <app>.<namespace> when the tracing namespace selector is enabled
+func getSpanApp(span *jaegerModels.Span, process *jaegerModels.Process) app {
+	a := app{
+		namespace: getTag(span, process, "istio.namespace"),
+		name:      getTag(span, process, "istio.canonical_service"),
+		version:   getTag(span, process, "istio.canonical_revision"),
+	}
+	if a.name == "" && process != nil {
+		a.name = process.ServiceName
+		if a.namespace != "" {
+			a.name = strings.TrimSuffix(a.name, "."+a.namespace)
+		} else if config.Get().ExternalServices.Tracing.NamespaceSelector {
+			if i := strings.LastIndex(a.name, "."); i > 0 {
+				a.name, a.namespace = a.name[:i], a.name[i+1:]
+			}
+		}
+	}
+	if a.name == "" {
+		a.name = graph.Unknown
+	}
+	if a.namespace == "" {
+		a.namespace = graph.Unknown
+	}
+	if a.version == "" {
+		a.version = graph.Unknown
+	}
+	return a
+}
+
+func getSpanProtocol(span *jaegerModels.Span) string {
+	if getTag(span, nil, "rpc.system") == "grpc" || getTag(span, nil, "grpc.status_code") != "" || getTag(span, nil, "rpc.grpc.status_code") != "" {
+		return graph.GRPC.Name
+	}
+	if getTag(span, nil, "http.method") != "" || getTag(span, nil, "http.status_code") != "" {
+		return graph.HTTP.Name
+	}
+	return graph.TCP.Name
+}
+
+func isSpanErr(span *jaegerModels.Span) bool {
+	if getTag(span, nil, "error") == "true" || getTag(span, nil, "otel.status_code") == "ERROR" {
+		return true
+	}
+	code, err := strconv.Atoi(getTag(span, nil, "http.status_code"))
+	return err == nil && code >= 500
+}
+
+// getTag returns the value of a tag of the span, or else of its process, "" when not set
+func getTag(span *jaegerModels.Span, process *jaegerModels.Process, key string) string {
+	for _, tag := range span.Tags {
+		if tag.Key == key {
+			return fmt.Sprint(tag.Value)
+		}
+	}
+	if process != nil {
+		for _, tag := range process.Tags {
+			if tag.Key == key {
+				return fmt.Sprint(tag.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// businessSource provides the traces of the apps from the business layer
+type businessSource struct {
+	business *business.Layer
+	ctx      context.Context
+	query    models.TracingQuery
+}
+
+func newBusinessSource(ctx context.Context, businessLayer *business.Layer, o graph.TelemetryOptions) *businessSource {
+	limit := defaultTraceLimit
+	if limitString := o.Params.Get("traceLimit"); limitString != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitString); err != nil || limit <= 0 {
+			graph.BadRequest(fmt.Sprintf("Invalid traceLimit [%s]", limitString))
+		}
+	}
+	end := time.Unix(o.QueryTime, 0)
+
+	return &businessSource{
+		business: businessLayer,
+		ctx:      ctx,
+		query: models.TracingQuery{
+			Start: end.Add(-o.Duration),
+			End:   end,
+			Limit: limit,
+		},
+	}
+}
+
+func (s *businessSource) namespaceTraces(namespace string) []jaegerModels.Trace {
+	appList, err := s.business.App.GetAppList(s.ctx, business.AppCriteria{Namespace: namespace})
+	graph.CheckError(err)
+
+	traces := []jaegerModels.Trace{}
+	for _, a := range appList.Apps {
+		r, err := s.business.Jaeger.GetAppTraces(namespace, a.Name, s.query)
+		graph.CheckError(err)
+		traces = append(traces, r.Data...)
+	}
+	return traces
+}
@@ -0,0 +1,167 @@
+package jaeger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	jaegerModels "github.com/kiali/kiali/jaeger/model/json"
+)
+
+const testCluster = "east"
+
+type fixtureSource map[string][]jaegerModels.Trace
+
+func (s fixtureSource) namespaceTraces(namespace string) []jaegerModels.Trace {
+	return s[namespace]
+}
+
+func TestBuildNamespaceTrafficMap(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	apps, calls := collectCalls(testSource(), []string{"bookinfo", "istio-system"})
+	trafficMap := buildNamespaceTrafficMap("bookinfo", testCluster, graph.GraphTypeVersionedApp, apps, calls)
+
+	// the trace fetched for both namespaces is read once
+	ingress := trafficMap[testID("istio-system", "istio-ingressgateway", "latest")]
+	assert.NotNil(ingress)
+	assert.Equal(1, len(ingress.Edges))
+	assert.Equal(testID("bookinfo", "productpage", "v1"), ingress.Edges[0].Dest.ID)
+	assert.Equal(graph.HTTP.Name, ingress.Edges[0].Metadata[graph.ProtocolKey])
+	assert.Equal(&graph.TracesMetadata{Calls: 2, Errors: 0, P50: 20, P95: 30, P99: 30}, ingress.Edges[0].Metadata[graph.Traces])
+	assert.Equal(30.0, ingress.Edges[0].Metadata[graph.ResponseTime])
+
+	// the in-process span of productpage is not a call, the calls leaving it are calls of productpage
+	productpage := trafficMap[testID("bookinfo", "productpage", "v1")]
+	assert.Equal(2, len(productpage.Edges))
+	edges := map[string]*graph.Edge{}
+	for _, e := range productpage.Edges {
+		edges[e.Dest.ID] = e
+	}
+
+	reviews := edges[testID("bookinfo", "reviews", "v2")]
+	assert.NotNil(reviews)
+	assert.Equal(graph.HTTP.Name, reviews.Metadata[graph.ProtocolKey])
+	assert.Equal(&graph.TracesMetadata{Calls: 2, Errors: 1, P50: 5, P95: 8, P99: 8}, reviews.Metadata[graph.Traces])
+
+	// the service outside of the mesh is reported with its Jaeger service name
+	cache := edges[testID(graph.Unknown, "redis", graph.Unknown)]
+	assert.NotNil(cache)
+	assert.Equal(graph.TCP.Name, cache.Metadata[graph.ProtocolKey])
+	assert.Equal(1, cache.Metadata[graph.Traces].(*graph.TracesMetadata).Calls)
+}
+
+func TestBuildNamespaceTrafficMapApp(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	apps, calls := collectCalls(testSource(), []string{"bookinfo"})
+	trafficMap := buildNamespaceTrafficMap("bookinfo", testCluster, graph.GraphTypeApp, apps, calls)
+
+	id, _ := graph.Id(testCluster, "bookinfo", "", "bookinfo", "", "reviews", "", graph.GraphTypeApp)
+	reviews := trafficMap[id]
+	assert.NotNil(reviews)
+	assert.Equal(0, len(reviews.Edges))
+
+	// the app nodes without calls are kept
+	id, _ = graph.Id(testCluster, "bookinfo", "", "bookinfo", "", "details", "", graph.GraphTypeApp)
+	_, ok := trafficMap[id]
+	assert.True(ok)
+}
+
+func TestGetSpanApp(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	conf.ExternalServices.Tracing.NamespaceSelector = false
+	config.Set(conf)
+
+	process := &jaegerModels.Process{ServiceName: "ratings"}
+	assert.Equal(app{namespace: graph.Unknown, name: "ratings", version: graph.Unknown}, getSpanApp(&jaegerModels.Span{}, process))
+
+	process = &jaegerModels.Process{ServiceName: "ratings.bookinfo", Tags: []jaegerModels.KeyValue{{Key: "istio.namespace", Value: "bookinfo"}}}
+	assert.Equal(app{namespace: "bookinfo", name: "ratings", version: graph.Unknown}, getSpanApp(&jaegerModels.Span{}, process))
+
+	conf.ExternalServices.Tracing.NamespaceSelector = true
+	config.Set(conf)
+	process = &jaegerModels.Process{ServiceName: "ratings.bookinfo"}
+	assert.Equal(app{namespace: "bookinfo", name: "ratings", version: graph.Unknown}, getSpanApp(&jaegerModels.Span{}, process))
+}
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(5.0, percentile(values, 0.5))
+	assert.Equal(10.0, percentile(values, 0.95))
+	assert.Equal(1.0, percentile(values, 0))
+	assert.Equal(0.0, percentile([]float64{}, 0.5))
+}
+
+func testID(namespace, app, version string) string {
+	id, _ := graph.Id(testCluster, namespace, "", namespace, "", app, version, graph.GraphTypeVersionedApp)
+	return id
+}
+
+func istioTags(namespace, app, version string, tags ...jaegerModels.KeyValue) []jaegerModels.KeyValue {
+	return append(tags,
+		jaegerModels.KeyValue{Key: "istio.namespace", Value: namespace},
+		jaegerModels.KeyValue{Key: "istio.canonical_service", Value: app},
+		jaegerModels.KeyValue{Key: "istio.canonical_revision", Value: version},
+	)
+}
+
+func childOf(parent jaegerModels.SpanID) []jaegerModels.Reference {
+	return []jaegerModels.Reference{{RefType: jaegerModels.ChildOf, TraceID: "t1", SpanID: parent}}
+}
+
+func testSource() fixtureSource {
+	httpGet := jaegerModels.KeyValue{Key: "http.method", Value: "GET"}
+	processes := map[jaegerModels.ProcessID]jaegerModels.Process{
+		"p1": {ServiceName: "istio-ingressgateway.istio-system"},
+		"p2": {ServiceName: "productpage.bookinfo"},
+		"p3": {ServiceName: "reviews.bookinfo"},
+		"p4": {ServiceName: "redis"},
+	}
+
+	t1 := jaegerModels.Trace{
+		TraceID:   "t1",
+		Processes: processes,
+		Spans: []jaegerModels.Span{
+			{TraceID: "t1", SpanID: "s1", ProcessID: "p1", Duration: 40000, Tags: istioTags("istio-system", "istio-ingressgateway", "latest", httpGet)},
+			{TraceID: "t1", SpanID: "s2", ProcessID: "p2", Duration: 30000, References: childOf("s1"), Tags: istioTags("bookinfo", "productpage", "v1", httpGet)},
+			// the in-process span of productpage, without the Istio tags
+			{TraceID: "t1", SpanID: "s3", ProcessID: "p2", Duration: 25000, References: childOf("s2")},
+			{TraceID: "t1", SpanID: "s4", ProcessID: "p3", Duration: 5000, References: childOf("s3"), Tags: istioTags("bookinfo", "reviews", "v2", httpGet)},
+			{TraceID: "t1", SpanID: "s5", ProcessID: "p4", Duration: 1000, References: childOf("s3")},
+		},
+	}
+
+	t2 := jaegerModels.Trace{
+		TraceID:   "t2",
+		Processes: processes,
+		Spans: []jaegerModels.Span{
+			{TraceID: "t2", SpanID: "s1", ProcessID: "p1", Duration: 40000, Tags: istioTags("istio-system", "istio-ingressgateway", "latest", httpGet)},
+			{TraceID: "t2", SpanID: "s2", ProcessID: "p2", Duration: 20000, ParentSpanID: "s1", Tags: istioTags("bookinfo", "productpage", "v1", httpGet)},
+			{TraceID: "t2", SpanID: "s3", ProcessID: "p3", Duration: 8000, ParentSpanID: "s2", Tags: istioTags("bookinfo", "reviews", "v2",
+				jaegerModels.KeyValue{Key: "http.status_code", Value: int64(503)})},
+		},
+	}
+
+	t3 := jaegerModels.Trace{
+		TraceID: "t3",
+		Processes: map[jaegerModels.ProcessID]jaegerModels.Process{
+			"p1": {ServiceName: "details.bookinfo"},
+		},
+		Spans: []jaegerModels.Span{
+			{TraceID: "t3", SpanID: "s1", ProcessID: "p1", Duration: 1000},
+		},
+	}
+
+	return fixtureSource{
+		"bookinfo":     []jaegerModels.Trace{t1, t2, t3},
+		"istio-system": []jaegerModels.Trace{t1},
+	}
+}
@@ -0,0 +1,647 @@
+// Package topology provides a graph/TelemetryVendor building the graph from the mesh configuration only.
+package topology
+
+// Topology.go is responsible for generating TrafficMaps from the configuration of the mesh, without telemetry.
+// It is useful when Prometheus is unavailable, or for new namespaces that have no traffic yet. The edges are
+// the configured routes, rather than observed traffic, and have no traffic rates:
+//   - selector:       a service to the workloads selected by its selector
+//   - virtualService: a VirtualService host to the destinations of its routes, when they differ
+//   - gateway:        a gateway workload to the destinations of the VirtualServices attached to its Gateway
+//   - sidecar:        a workload to the hosts of its Sidecar egress listeners
+//
+// The destination hosts are resolved to the Kubernetes services, or to the ServiceEntries defining them.
+// Wildcard hosts are not resolved. The graph always holds the service nodes, as the routes lead to services.
+//
+// The algorithm:
+//   Step 1) For each namespace:
+//     a) Build the namespace traffic map from the namespace configuration
+//
+//     b) Apply the requested appenders not requiring telemetry
+//
+//     c) Merge the namespace traffic map into the final traffic map
+//
+//   Step 2) For the global traffic map, apply the finalizers not requiring telemetry
+//
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// The kinds of config defining an edge
+const (
+	ConfiguredByGateway        = "gateway"
+	ConfiguredBySelector       = "selector"
+	ConfiguredBySidecar        = "sidecar"
+	ConfiguredByVirtualService = "virtualService"
+)
+
+// supportedAppenders are the appenders not requiring telemetry, the other requested appenders are skipped
+var supportedAppenders = map[string]bool{
+	appender.BoxLabelAppenderName:      true,
+	appender.HideAppenderName:          true,
+	appender.IstioAppenderName:         true,
+	appender.LabelerAppenderName:       true,
+	appender.OutsiderAppenderName:      true, // also the name of the traffic generator finalizer
+	appender.SidecarsCheckAppenderName: true,
+	appender.WorkloadEntryAppenderName: true,
+}
+
+// namespaceConfig holds the configuration of a namespace
+type namespaceConfig struct {
+	services        []models.ServiceOverview
+	sidecars        []*networking_v1beta1.Sidecar
+	virtualServices []*networking_v1beta1.VirtualService
+	workloads       []models.WorkloadListItem
+}
+
+// configSource provides the configuration the traffic map is built from
+type configSource interface {
+	// gateways returns the Gateways of the accessible namespaces
+	gateways() []*networking_v1beta1.Gateway
+	// namespaceConfig returns the configuration of a namespace, false when it is not accessible
+	namespaceConfig(namespace string) (*namespaceConfig, bool)
+	// serviceEntries returns the ServiceEntries of the accessible namespaces
+	serviceEntries() []*networking_v1beta1.ServiceEntry
+}
+
+// BuildNamespacesTrafficMap is required by the graph/TelemtryVendor interface
+func BuildNamespacesTrafficMap(ctx context.Context, o graph.TelemetryOptions, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "BuildNamespacesTrafficMap",
+		observability.Attribute("package", "topology"),
+	)
+	defer end()
+
+	log.Tracef("Build [%s] topology graph for [%d] namespaces [%v]", o.GraphType, len(o.Namespaces), o.Namespaces)
+
+	appenders, finalizers := telemetry.ParseSupportedAppenders(o, supportedAppenders)
+	b := newBuilder(o.GraphType, telemetry.HomeCluster(globalInfo), newBusinessSource(ctx, globalInfo.Business, o.AccessibleNamespaces))
+	trafficMap := graph.NewTrafficMap()
+
+	for _, namespace := range o.Namespaces {
+		log.Tracef("Build topology traffic map for namespace [%v]", namespace)
+		namespaceTrafficMap := b.buildNamespaceTrafficMap(namespace.Name)
+
+		// The appenders can add/remove/alter nodes for the namespace
+		namespaceInfo := graph.NewAppenderNamespaceInfo(namespace.Name)
+		for _, a := range appenders {
+			a.AppendGraph(namespaceTrafficMap, globalInfo, namespaceInfo)
+		}
+
+		// Merge this namespace into the final TrafficMap
+		telemetry.MergeTrafficMaps(trafficMap, namespace.Name, namespaceTrafficMap)
+	}
+
+	// The finalizers can perform final manipulations on the complete graph
+	for _, f := range finalizers {
+		f.AppendGraph(trafficMap, globalInfo, nil)
+	}
+
+	if graph.GraphTypeService == o.GraphType {
+		trafficMap = reduceToServiceGraph(trafficMap)
+	}
+
+	return trafficMap
+}
+
+// BuildNodeTrafficMap is required by the graph/TelemtryVendor interface
+func BuildNodeTrafficMap(ctx context.Context, o graph.TelemetryOptions, globalInfo *graph.AppenderGlobalInfo) graph.TrafficMap {
+	if o.NodeOptions.Aggregate != "" {
+		graph.BadRequest(fmt.Sprintf("Aggregate node graphs are not supported by telemetryVendor [%s]", graph.VendorTopology))
+	}
+
+	b := newBuilder(o.GraphType, telemetry.HomeCluster(globalInfo), newBusinessSource(ctx, globalInfo.Business, o.AccessibleNamespaces))
+	cluster := o.NodeOptions.Cluster
+	if cluster == graph.Unknown {
+		cluster = b.cluster
+	}
+	n := graph.NewNode(cluster, o.NodeOptions.Namespace, o.NodeOptions.Service, o.NodeOptions.Namespace, o.NodeOptions.Workload, o.NodeOptions.App, o.NodeOptions.Version, o.GraphType)
+
+	log.Tracef("Build topology graph for node [%+v]", n)
+
+	appenders, finalizers := telemetry.ParseSupportedAppenders(o, supportedAppenders)
+	trafficMap := telemetry.ReduceToNode(b.buildNamespaceTrafficMap(o.NodeOptions.Namespace), n.ID)
+
+	namespaceInfo := graph.NewAppenderNamespaceInfo(o.NodeOptions.Namespace)
+	for _, a := range appenders {
+		a.AppendGraph(trafficMap, globalInfo, namespaceInfo)
+	}
+
+	// The finalizers can perform final manipulations on the complete graph
+	for _, f := range finalizers {
+		f.AppendGraph(trafficMap, globalInfo, nil)
+	}
+
+	return trafficMap
+}
+
+// builder builds the traffic maps of the configured routes
+type builder struct {
+	cluster   string
+	graphType string
+	loaded    map[string]*namespaceConfig // nil for the inaccessible namespaces
+	source    configSource
+}
+
+func newBuilder(graphType, cluster string, source configSource) *builder {
+	return &builder{
+		cluster:   cluster,
+		graphType: graphType,
+		loaded:    make(map[string]*namespaceConfig),
+		source:    source,
+	}
+}
+
+func (b *builder) getNamespaceConfig(namespace string) (*namespaceConfig, bool) {
+	nc, found := b.loaded[namespace]
+	if !found {
+		nc, _ = b.source.namespaceConfig(namespace)
+		b.loaded[namespace] = nc
+	}
+	return nc, nc != nil
+}
+
+// buildNamespaceTrafficMap returns a map of the namespace nodes, and of the nodes of the routes from or to the
+// namespace (key=id)
+func (b *builder) buildNamespaceTrafficMap(namespace string) graph.TrafficMap {
+	trafficMap := graph.NewTrafficMap()
+	nc, ok := b.getNamespaceConfig(namespace)
+	if !ok {
+		return trafficMap
+	}
+
+	for _, svc := range nc.services {
+		svcNode := b.addServiceNode(trafficMap, namespace, svc.Name)
+		if len(svc.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Selector)
+		protocol := serviceProtocol(svc.Ports)
+		for _, wl := range nc.workloads {
+			if selector.Matches(labels.Set(wl.Labels)) {
+				wlNode := b.addWorkloadNode(trafficMap, namespace, wl)
+				addToDestServices(wlNode.Metadata, b.cluster, namespace, svc.Name)
+				addEdge(svcNode, wlNode, protocol, ConfiguredBySelector)
+			}
+		}
+	}
+
+	// the workloads not selected by a service
+	for _, wl := range nc.workloads {
+		b.addWorkloadNode(trafficMap, namespace, wl)
+	}
+
+	for _, se := range b.source.serviceEntries() {
+		if se.Namespace == namespace {
+			b.addServiceEntryNode(trafficMap, se)
+		}
+	}
+
+	for _, vs := range nc.virtualServices {
+		b.addVirtualServiceRoutes(trafficMap, vs)
+	}
+
+	for _, sc := range nc.sidecars {
+		b.addSidecarRoutes(trafficMap, nc, sc)
+	}
+
+	return trafficMap
+}
+
+type route struct {
+	host     string
+	protocol string
+}
+
+// addVirtualServiceRoutes adds the edges from the VirtualService hosts, for the mesh, and from the gateway
+// workloads, for the attached Gateways, to the route destinations
+func (b *builder) addVirtualServiceRoutes(trafficMap graph.TrafficMap, vs *networking_v1beta1.VirtualService) {
+	routes := []route{}
+	for _, r := range vs.Spec.Http {
+		for _, d := range r.Route {
+			if d.Destination != nil {
+				routes = append(routes, route{host: d.Destination.Host, protocol: graph.HTTP.Name})
+			}
+		}
+		if r.Mirror != nil {
+			routes = append(routes, route{host: r.Mirror.Host, protocol: graph.HTTP.Name})
+		}
+	}
+	for _, r := range vs.Spec.Tls {
+		for _, d := range r.Route {
+			if d.Destination != nil {
+				routes = append(routes, route{host: d.Destination.Host, protocol: graph.TCP.Name})
+			}
+		}
+	}
+	for _, r := range vs.Spec.Tcp {
+		for _, d := range r.Route {
+			if d.Destination != nil {
+				routes = append(routes, route{host: d.Destination.Host, protocol: graph.TCP.Name})
+			}
+		}
+	}
+
+	destinations := make([]*graph.Node, len(routes))
+	for i, r := range routes {
+		destinations[i], _ = b.resolveHost(trafficMap, r.host, vs.Namespace)
+	}
+
+	isMesh := len(vs.Spec.Gateways) == 0
+	for _, gatewayRef := range vs.Spec.Gateways {
+		if gatewayRef == "mesh" {
+			isMesh = true
+			continue
+		}
+		gatewayNamespace, gatewayName, found := strings.Cut(gatewayRef, "/")
+		if !found {
+			gatewayNamespace, gatewayName = vs.Namespace, gatewayRef
+		}
+		for _, gw := range b.source.gateways() {
+			if gw.Namespace != gatewayNamespace || gw.Name != gatewayName {
+				continue
+			}
+			for _, gwNode := range b.addGatewayWorkloadNodes(trafficMap, gw) {
+				for i, dest := range destinations {
+					if dest != nil {
+						addEdge(gwNode, dest, routes[i].protocol, ConfiguredByGateway)
+					}
+				}
+			}
+		}
+	}
+
+	if !isMesh {
+		return
+	}
+	for _, host := range vs.Spec.Hosts {
+		source, _ := b.resolveHost(trafficMap, host, vs.Namespace)
+		if source == nil {
+			continue
+		}
+		for i, dest := range destinations {
+			if dest != nil && dest.ID != source.ID {
+				addEdge(source, dest, routes[i].protocol, ConfiguredByVirtualService)
+			}
+		}
+	}
+}
+
+// addGatewayWorkloadNodes adds the workloads selected by a Gateway. They are looked up in the namespace of the
+// Gateway and in the Istio namespace.
+func (b *builder) addGatewayWorkloadNodes(trafficMap graph.TrafficMap, gw *networking_v1beta1.Gateway) []*graph.Node {
+	nodes := []*graph.Node{}
+	if len(gw.Spec.Selector) == 0 {
+		return nodes
+	}
+	selector := labels.SelectorFromSet(gw.Spec.Selector)
+	namespaces := []string{gw.Namespace}
+	if istioNamespace := config.Get().IstioNamespace; istioNamespace != gw.Namespace {
+		namespaces = append(namespaces, istioNamespace)
+	}
+	for _, namespace := range namespaces {
+		nc, ok := b.getNamespaceConfig(namespace)
+		if !ok {
+			continue
+		}
+		for _, wl := range nc.workloads {
+			if selector.Matches(labels.Set(wl.Labels)) {
+				nodes = append(nodes, b.addWorkloadNode(trafficMap, namespace, wl))
+			}
+		}
+	}
+	return nodes
+}
+
+// addSidecarRoutes adds the edges from the workloads of a Sidecar to the hosts of its egress listeners
+func (b *builder) addSidecarRoutes(trafficMap graph.TrafficMap, nc *namespaceConfig, sc *networking_v1beta1.Sidecar) {
+	var selector labels.Selector
+	if sc.Spec.WorkloadSelector != nil {
+		selector = labels.SelectorFromSet(sc.Spec.WorkloadSelector.Labels)
+	}
+	sources := []*graph.Node{}
+	for _, wl := range nc.workloads {
+		if selector == nil || selector.Matches(labels.Set(wl.Labels)) {
+			sources = append(sources, b.addWorkloadNode(trafficMap, sc.Namespace, wl))
+		}
+	}
+	if len(sources) == 0 {
+		return
+	}
+
+	for _, egress := range sc.Spec.Egress {
+		for _, egressHost := range egress.Hosts {
+			namespace, host, found := strings.Cut(egressHost, "/")
+			if !found || namespace == "~" || strings.Contains(host, "*") {
+				continue
+			}
+			// a host of any namespace is resolved as the hosts of the Sidecar namespace
+			if namespace == "." || namespace == "*" {
+				namespace = sc.Namespace
+			}
+			dest, protocol := b.resolveHost(trafficMap, host, namespace)
+			if dest == nil {
+				continue
+			}
+			for _, source := range sources {
+				addEdge(source, dest, protocol, ConfiguredBySidecar)
+			}
+		}
+	}
+}
+
+// resolveHost returns the node and protocol of the ServiceEntry defining a host, or of the Kubernetes service
+// of the host, relative to the namespace. It returns a nil node when the host is not resolved.
+func (b *builder) resolveHost(trafficMap graph.TrafficMap, host, namespace string) (*graph.Node, string) {
+	for _, se := range b.source.serviceEntries() {
+		for _, seHost := range se.Spec.Hosts {
+			if seHost == host || (strings.HasPrefix(seHost, "*.") && strings.HasSuffix(host, seHost[1:])) {
+				return b.addServiceEntryNode(trafficMap, se), serviceEntryProtocol(se)
+			}
+		}
+	}
+
+	// <name>[.<namespace>[.svc[.<domain>]]]
+	parts := strings.Split(host, ".")
+	if len(parts) > 2 && parts[2] != "svc" {
+		log.Tracef("Topology graph ignoring unresolved host [%s]", host)
+		return nil, ""
+	}
+	name := parts[0]
+	if len(parts) > 1 {
+		namespace = parts[1]
+	}
+	nc, ok := b.getNamespaceConfig(namespace)
+	if !ok {
+		return nil, ""
+	}
+	for _, svc := range nc.services {
+		if svc.Name == name {
+			return b.addServiceNode(trafficMap, namespace, name), serviceProtocol(svc.Ports)
+		}
+	}
+	log.Tracef("Topology graph ignoring unresolved host [%s]", host)
+	return nil, ""
+}
+
+func (b *builder) addServiceNode(trafficMap graph.TrafficMap, namespace, service string) *graph.Node {
+	n := b.addNode(trafficMap, graph.NewNode(b.cluster, namespace, service, "", "", "", "", b.graphType))
+	addToDestServices(n.Metadata, b.cluster, namespace, service)
+	return n
+}
+
+func (b *builder) addServiceEntryNode(trafficMap graph.TrafficMap, se *networking_v1beta1.ServiceEntry) *graph.Node {
+	n := b.addNode(trafficMap, graph.NewNode(b.cluster, se.Namespace, se.Name, "", "", "", "", b.graphType))
+	location := "MESH_EXTERNAL"
+	if se.Spec.Location.String() == "MESH_INTERNAL" {
+		location = "MESH_INTERNAL"
+	}
+	n.Metadata[graph.IsServiceEntry] = &graph.SEInfo{
+		Hosts:     se.Spec.Hosts,
+		Location:  location,
+		Namespace: se.Namespace,
+	}
+	return n
+}
+
+func (b *builder) addWorkloadNode(trafficMap graph.TrafficMap, namespace string, wl models.WorkloadListItem) *graph.Node {
+	cfg := config.Get()
+	app := wl.Labels[cfg.IstioLabels.AppLabelName]
+	version := wl.Labels[cfg.IstioLabels.VersionLabelName]
+	return b.addNode(trafficMap, graph.NewNode(b.cluster, namespace, "", namespace, wl.Name, app, version, b.graphType))
+}
+
+func (b *builder) addNode(trafficMap graph.TrafficMap, n graph.Node) *graph.Node {
+	if node, found := trafficMap[n.ID]; found {
+		return node
+	}
+	trafficMap[n.ID] = &n
+	return &n
+}
+
+// addEdge adds a configured route, merging the kinds of config defining the same route
+func addEdge(source, dest *graph.Node, protocol string, kinds ...string) {
+	var edge *graph.Edge
+	for _, e := range source.Edges {
+		if e.Dest.ID == dest.ID && e.Metadata[graph.ProtocolKey] == protocol {
+			edge = e
+			break
+		}
+	}
+	if edge == nil {
+		edge = source.AddEdge(dest)
+		edge.Metadata[graph.ProtocolKey] = protocol
+		edge.Metadata[graph.ConfiguredBy] = []string{}
+	}
+
+	configuredBy := edge.Metadata[graph.ConfiguredBy].([]string)
+	for _, kind := range kinds {
+		found := false
+		for _, k := range configuredBy {
+			if k == kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			configuredBy = append(configuredBy, kind)
+		}
+	}
+	sort.Strings(configuredBy)
+	edge.Metadata[graph.ConfiguredBy] = configuredBy
+}
+
+func addToDestServices(md graph.Metadata, cluster, namespace, service string) {
+	destServices, ok := md[graph.DestServices]
+	if !ok {
+		destServices = graph.NewDestServicesMetadata()
+		md[graph.DestServices] = destServices
+	}
+	destService := graph.ServiceName{Cluster: cluster, Namespace: namespace, Name: service}
+	destServices.(graph.DestServicesMetadata)[destService.Key()] = destService
+}
+
+// serviceProtocol returns the protocol of a service from its port names, following the Istio protocol
+// selection: grpc, then http, and tcp by default
+func serviceProtocol(ports map[string]int) string {
+	protocol := graph.TCP.Name
+	for name := range ports {
+		prefix, _, _ := strings.Cut(strings.ToLower(name), "-")
+		switch prefix {
+		case "grpc":
+			return graph.GRPC.Name
+		case "http", "http2":
+			protocol = graph.HTTP.Name
+		}
+	}
+	return protocol
+}
+
+// serviceEntryProtocol returns the protocol of a ServiceEntry from its port protocols: grpc, then http, and tcp
+// by default
+func serviceEntryProtocol(se *networking_v1beta1.ServiceEntry) string {
+	protocol := graph.TCP.Name
+	for _, port := range se.Spec.Ports {
+		switch strings.ToUpper(port.Protocol) {
+		case "GRPC":
+			return graph.GRPC.Name
+		case "HTTP", "HTTP2":
+			protocol = graph.HTTP.Name
+		}
+	}
+	return protocol
+}
+
+// reduceToServiceGraph removes the workload nodes of a workload graph, with the exception of the root nodes.
+// The routes through a workload, from the services selecting it to the services it calls, become routes
+// between the services.
+func reduceToServiceGraph(trafficMap graph.TrafficMap) graph.TrafficMap {
+	reducedTrafficMap := graph.NewTrafficMap()
+
+	// the routes through the workloads are read from the original edges
+	edgesByNode := make(map[string][]*graph.Edge, len(trafficMap))
+	for id, n := range trafficMap {
+		edgesByNode[id] = n.Edges
+		n.Edges = []*graph.Edge{}
+	}
+
+	for id, n := range trafficMap {
+		edges := edgesByNode[id]
+
+		if n.NodeType != graph.NodeTypeService {
+			// keep the root nodes, with their routes to services, to better understand the traffic flow
+			if isRoot, ok := n.Metadata[graph.IsRoot].(bool); !ok || !isRoot {
+				continue
+			}
+			for _, e := range edges {
+				if e.Dest.NodeType == graph.NodeTypeService {
+					addEdge(n, e.Dest, e.Metadata[graph.ProtocolKey].(string), e.Metadata[graph.ConfiguredBy].([]string)...)
+				}
+			}
+			if len(n.Edges) > 0 {
+				reducedTrafficMap[id] = n
+			}
+			continue
+		}
+
+		reducedTrafficMap[id] = n
+		for _, e := range edges {
+			if e.Dest.NodeType == graph.NodeTypeService {
+				addEdge(n, e.Dest, e.Metadata[graph.ProtocolKey].(string), e.Metadata[graph.ConfiguredBy].([]string)...)
+				continue
+			}
+			for _, workloadEdge := range edgesByNode[e.Dest.ID] {
+				if workloadEdge.Dest.NodeType == graph.NodeTypeService && workloadEdge.Dest.ID != id {
+					addEdge(n, workloadEdge.Dest, workloadEdge.Metadata[graph.ProtocolKey].(string), workloadEdge.Metadata[graph.ConfiguredBy].([]string)...)
+				}
+			}
+		}
+	}
+
+	return reducedTrafficMap
+}
+
+// businessSource provides the configuration of the accessible namespaces from the business layer
+type businessSource struct {
+	accessibleNamespaces map[string]bool
+	business             *business.Layer
+	ctx                  context.Context
+	istioConfig          *models.IstioConfigList // the Gateways and ServiceEntries of all the namespaces
+}
+
+func newBusinessSource(ctx context.Context, businessLayer *business.Layer, accessibleNamespaces map[string]time.Time) *businessSource {
+	s := &businessSource{
+		accessibleNamespaces: make(map[string]bool, len(accessibleNamespaces)),
+		business:             businessLayer,
+		ctx:                  ctx,
+	}
+	for namespace := range accessibleNamespaces {
+		s.accessibleNamespaces[namespace] = true
+	}
+	return s
+}
+
+func (s *businessSource) gateways() []*networking_v1beta1.Gateway {
+	gateways := []*networking_v1beta1.Gateway{}
+	for _, gw := range s.getIstioConfig().Gateways {
+		if s.accessibleNamespaces[gw.Namespace] {
+			gateways = append(gateways, gw)
+		}
+	}
+	return gateways
+}
+
+func (s *businessSource) serviceEntries() []*networking_v1beta1.ServiceEntry {
+	serviceEntries := []*networking_v1beta1.ServiceEntry{}
+	for _, se := range s.getIstioConfig().ServiceEntries {
+		if s.accessibleNamespaces[se.Namespace] {
+			serviceEntries = append(serviceEntries, se)
+		}
+	}
+	return serviceEntries
+}
+
+func (s *businessSource) getIstioConfig() *models.IstioConfigList {
+	if s.istioConfig == nil {
+		istioConfig, err := s.business.IstioConfig.GetIstioConfigList(s.ctx, business.IstioConfigCriteria{
+			AllNamespaces:         true,
+			IncludeGateways:       true,
+			IncludeServiceEntries: true,
+		})
+		graph.CheckError(err)
+		s.istioConfig = &istioConfig
+	}
+	return s.istioConfig
+}
+
+func (s *businessSource) namespaceConfig(namespace string) (*namespaceConfig, bool) {
+	if !s.accessibleNamespaces[namespace] {
+		return nil, false
+	}
+
+	serviceList, err := s.business.Svc.GetServiceList(s.ctx, business.ServiceCriteria{
+		Namespace:              namespace,
+		IncludeOnlyDefinitions: true,
+	})
+	graph.CheckError(err)
+	workloadList, err := s.business.Workload.GetWorkloadList(s.ctx, business.WorkloadCriteria{
+		Namespace:             namespace,
+		IncludeIstioResources: false,
+		IncludeHealth:         false,
+	})
+	graph.CheckError(err)
+	istioConfig, err := s.business.IstioConfig.GetIstioConfigList(s.ctx, business.IstioConfigCriteria{
+		Namespace:              namespace,
+		IncludeSidecars:        true,
+		IncludeVirtualServices: true,
+	})
+	graph.CheckError(err)
+
+	nc := &namespaceConfig{
+		sidecars:        istioConfig.Sidecars,
+		virtualServices: istioConfig.VirtualServices,
+		workloads:       workloadList.Workloads,
+	}
+	// the services of the registry are defined by ServiceEntries, resolved separately
+	for _, svc := range serviceList.Services {
+		if svc.ServiceRegistry == "Kubernetes" {
+			nc.services = append(nc.services, svc)
+		}
+	}
+	return nc, true
+}
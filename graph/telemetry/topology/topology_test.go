@@ -0,0 +1,265 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/telemetry"
+	"github.com/kiali/kiali/models"
+)
+
+const testCluster = "east"
+
+type fixtureSource struct {
+	gws        []*networking_v1beta1.Gateway
+	namespaces map[string]*namespaceConfig
+	ses        []*networking_v1beta1.ServiceEntry
+}
+
+func (s *fixtureSource) gateways() []*networking_v1beta1.Gateway {
+	return s.gws
+}
+
+func (s *fixtureSource) namespaceConfig(namespace string) (*namespaceConfig, bool) {
+	nc, ok := s.namespaces[namespace]
+	return nc, ok
+}
+
+func (s *fixtureSource) serviceEntries() []*networking_v1beta1.ServiceEntry {
+	return s.ses
+}
+
+func TestBuildNamespaceTrafficMap(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	b := newBuilder(graph.GraphTypeVersionedApp, testCluster, testSource())
+	trafficMap := b.buildNamespaceTrafficMap("bookinfo")
+
+	// the gateway workload is added from the Istio namespace
+	ingress, ok := trafficMap[testWorkloadID("istio-system", "istio-ingressgateway", "istio-ingressgateway", "")]
+	assert.True(ok)
+	assert.Equal(1, len(ingress.Edges))
+	assert.Equal(testServiceID("bookinfo", "productpage"), ingress.Edges[0].Dest.ID)
+	assert.Equal([]string{ConfiguredByGateway}, ingress.Edges[0].Metadata[graph.ConfiguredBy])
+	assert.Equal(graph.HTTP.Name, ingress.Edges[0].Metadata[graph.ProtocolKey])
+
+	// the service selects its workloads, of its port protocol
+	reviews := trafficMap[testServiceID("bookinfo", "reviews")]
+	assert.Equal(3, len(reviews.Edges))
+	for _, e := range reviews.Edges {
+		assert.Equal(graph.NodeTypeApp, e.Dest.NodeType)
+		assert.Equal(graph.GRPC.Name, e.Metadata[graph.ProtocolKey])
+	}
+
+	// the mesh VirtualService routes reviews to its own subsets, it adds no route
+	assert.Equal([]string{ConfiguredBySelector}, reviews.Edges[0].Metadata[graph.ConfiguredBy])
+
+	// the VirtualService of ratings routes to the service of another namespace
+	ratings := trafficMap[testServiceID("bookinfo", "ratings")]
+	var ratingsEdges []string
+	for _, e := range ratings.Edges {
+		if e.Dest.NodeType == graph.NodeTypeService {
+			ratingsEdges = append(ratingsEdges, e.Dest.ID)
+			assert.Equal([]string{ConfiguredByVirtualService}, e.Metadata[graph.ConfiguredBy])
+		}
+	}
+	assert.Equal([]string{testServiceID("ratings", "ratings")}, ratingsEdges)
+
+	// the Sidecar egress hosts of productpage, the wildcard host is not resolved
+	productpage := trafficMap[testWorkloadID("bookinfo", "productpage-v1", "productpage", "v1")]
+	assert.Equal(2, len(productpage.Edges))
+	dests := map[string]*graph.Edge{}
+	for _, e := range productpage.Edges {
+		dests[e.Dest.ID] = e
+	}
+	assert.Contains(dests, testServiceID("bookinfo", "reviews"))
+	external := dests[testServiceID("bookinfo", "external-api")]
+	assert.NotNil(external)
+	assert.Equal(graph.HTTP.Name, external.Metadata[graph.ProtocolKey])
+	assert.Equal([]string{ConfiguredBySidecar}, external.Metadata[graph.ConfiguredBy])
+	seInfo, ok := external.Dest.Metadata[graph.IsServiceEntry].(*graph.SEInfo)
+	assert.True(ok)
+	assert.Equal("MESH_EXTERNAL", seInfo.Location)
+
+	// details-v1 is not selected by a service
+	_, ok = trafficMap[testServiceID("bookinfo", "details")]
+	assert.False(ok)
+	details := trafficMap[testWorkloadID("bookinfo", "details-v1", "details", "v1")]
+	assert.NotNil(details)
+	_, ok = details.Metadata[graph.DestServices]
+	assert.False(ok)
+
+	// the inaccessible namespace is not resolved
+	_, ok = trafficMap[testServiceID("secret", "vault")]
+	assert.False(ok)
+}
+
+func TestAddEdgeMergesConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	source := graph.NewNode(testCluster, "bookinfo", "productpage", "", "", "", "", graph.GraphTypeVersionedApp)
+	dest := graph.NewNode(testCluster, "bookinfo", "reviews", "", "", "", "", graph.GraphTypeVersionedApp)
+
+	addEdge(&source, &dest, graph.HTTP.Name, ConfiguredByVirtualService)
+	addEdge(&source, &dest, graph.HTTP.Name, ConfiguredBySidecar, ConfiguredByVirtualService)
+	addEdge(&source, &dest, graph.TCP.Name, ConfiguredBySidecar)
+
+	assert.Equal(2, len(source.Edges))
+	assert.Equal([]string{ConfiguredBySidecar, ConfiguredByVirtualService}, source.Edges[0].Metadata[graph.ConfiguredBy])
+	assert.Equal([]string{ConfiguredBySidecar}, source.Edges[1].Metadata[graph.ConfiguredBy])
+}
+
+func TestReduceToServiceGraph(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	b := newBuilder(graph.GraphTypeService, testCluster, testSource())
+	trafficMap := reduceToServiceGraph(b.buildNamespaceTrafficMap("bookinfo"))
+
+	for _, n := range trafficMap {
+		if n.NodeType != graph.NodeTypeService {
+			isRoot, _ := n.Metadata[graph.IsRoot].(bool)
+			assert.True(isRoot, n.ID)
+		}
+		for _, e := range n.Edges {
+			assert.Equal(graph.NodeTypeService, e.Dest.NodeType)
+			assert.NotEqual(n.ID, e.Dest.ID)
+		}
+	}
+
+	// the sidecar route of productpage-v1 becomes a route of the productpage service
+	productpage := trafficMap[testServiceID("bookinfo", "productpage")]
+	dests := []string{}
+	for _, e := range productpage.Edges {
+		dests = append(dests, e.Dest.ID)
+	}
+	assert.ElementsMatch([]string{testServiceID("bookinfo", "reviews"), testServiceID("bookinfo", "external-api")}, dests)
+}
+
+func TestReduceToNode(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+
+	b := newBuilder(graph.GraphTypeVersionedApp, testCluster, testSource())
+	trafficMap := telemetry.ReduceToNode(b.buildNamespaceTrafficMap("bookinfo"), testServiceID("bookinfo", "productpage"))
+
+	// productpage, its gateway and its workload
+	assert.Equal(3, len(trafficMap))
+	ingress := trafficMap[testWorkloadID("istio-system", "istio-ingressgateway", "istio-ingressgateway", "")]
+	assert.Equal(1, len(ingress.Edges))
+	productpageV1 := trafficMap[testWorkloadID("bookinfo", "productpage-v1", "productpage", "v1")]
+	assert.Equal(0, len(productpageV1.Edges))
+}
+
+func testServiceID(namespace, service string) string {
+	id, _ := graph.Id(testCluster, namespace, service, "", "", "", "", graph.GraphTypeVersionedApp)
+	return id
+}
+
+func testWorkloadID(namespace, workload, app, version string) string {
+	id, _ := graph.Id(testCluster, namespace, "", namespace, workload, app, version, graph.GraphTypeVersionedApp)
+	return id
+}
+
+func testWorkload(name, app, version string) models.WorkloadListItem {
+	wl := models.WorkloadListItem{Name: name, Labels: map[string]string{"app": app}}
+	if version != "" {
+		wl.Labels["version"] = version
+	}
+	return wl
+}
+
+func testSource() *fixtureSource {
+	gateway := &networking_v1beta1.Gateway{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo-gateway", Namespace: "bookinfo"},
+	}
+	gateway.Spec.Selector = map[string]string{"app": "istio-ingressgateway"}
+
+	productpageVS := &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo", Namespace: "bookinfo"},
+	}
+	productpageVS.Spec.Hosts = []string{"*"}
+	productpageVS.Spec.Gateways = []string{"bookinfo-gateway"}
+	productpageVS.Spec.Http = []*api_networking_v1beta1.HTTPRoute{
+		{Route: []*api_networking_v1beta1.HTTPRouteDestination{{Destination: &api_networking_v1beta1.Destination{Host: "productpage"}}}},
+	}
+
+	reviewsVS := &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"},
+	}
+	reviewsVS.Spec.Hosts = []string{"reviews"}
+	reviewsVS.Spec.Http = []*api_networking_v1beta1.HTTPRoute{
+		{Route: []*api_networking_v1beta1.HTTPRouteDestination{
+			{Destination: &api_networking_v1beta1.Destination{Host: "reviews", Subset: "v1"}},
+			{Destination: &api_networking_v1beta1.Destination{Host: "reviews.bookinfo.svc.cluster.local", Subset: "v2"}},
+		}},
+	}
+
+	ratingsVS := &networking_v1beta1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "bookinfo"},
+	}
+	ratingsVS.Spec.Hosts = []string{"ratings"}
+	ratingsVS.Spec.Gateways = []string{"mesh"}
+	ratingsVS.Spec.Tcp = []*api_networking_v1beta1.TCPRoute{
+		{Route: []*api_networking_v1beta1.RouteDestination{
+			{Destination: &api_networking_v1beta1.Destination{Host: "ratings.ratings"}},
+			{Destination: &api_networking_v1beta1.Destination{Host: "vault.secret"}},
+		}},
+	}
+
+	sidecar := &networking_v1beta1.Sidecar{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "productpage", Namespace: "bookinfo"},
+	}
+	sidecar.Spec.WorkloadSelector = &api_networking_v1beta1.WorkloadSelector{Labels: map[string]string{"app": "productpage"}}
+	sidecar.Spec.Egress = []*api_networking_v1beta1.IstioEgressListener{
+		{Hosts: []string{"./reviews.bookinfo.svc.cluster.local", "bookinfo/api.example.com", "istio-system/*"}},
+	}
+
+	externalAPI := &networking_v1beta1.ServiceEntry{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "external-api", Namespace: "bookinfo"},
+	}
+	externalAPI.Spec.Hosts = []string{"*.example.com"}
+	externalAPI.Spec.Location = api_networking_v1beta1.ServiceEntry_MESH_EXTERNAL
+	externalAPI.Spec.Ports = []*api_networking_v1beta1.Port{{Number: 443, Protocol: "HTTP", Name: "http"}}
+
+	return &fixtureSource{
+		gws: []*networking_v1beta1.Gateway{gateway},
+		namespaces: map[string]*namespaceConfig{
+			"bookinfo": {
+				services: []models.ServiceOverview{
+					{Name: "productpage", Selector: map[string]string{"app": "productpage"}, Ports: map[string]int{"http": 9080}, ServiceRegistry: "Kubernetes"},
+					{Name: "ratings", Selector: map[string]string{"app": "ratings"}, Ports: map[string]int{"tcp": 9080}, ServiceRegistry: "Kubernetes"},
+					{Name: "reviews", Selector: map[string]string{"app": "reviews"}, Ports: map[string]int{"grpc-web": 9080}, ServiceRegistry: "Kubernetes"},
+				},
+				sidecars:        []*networking_v1beta1.Sidecar{sidecar},
+				virtualServices: []*networking_v1beta1.VirtualService{productpageVS, reviewsVS, ratingsVS},
+				workloads: []models.WorkloadListItem{
+					testWorkload("details-v1", "details", "v1"),
+					testWorkload("productpage-v1", "productpage", "v1"),
+					testWorkload("ratings-v1", "ratings", "v1"),
+					testWorkload("reviews-v1", "reviews", "v1"),
+					testWorkload("reviews-v2", "reviews", "v2"),
+					testWorkload("reviews-v3", "reviews", "v3"),
+				},
+			},
+			"istio-system": {
+				workloads: []models.WorkloadListItem{
+					testWorkload("istio-ingressgateway", "istio-ingressgateway", ""),
+				},
+			},
+			"ratings": {
+				services: []models.ServiceOverview{
+					{Name: "ratings", Selector: map[string]string{"app": "ratings"}, Ports: map[string]int{"http": 9080}, ServiceRegistry: "Kubernetes"},
+				},
+			},
+		},
+		ses: []*networking_v1beta1.ServiceEntry{externalAPI},
+	}
+}
@@ -15,25 +15,78 @@ package handlers
 // The current Handlers:
 //   GraphNamespaces: Generate a graph for one or more requested namespaces.
 //   GraphNode:       Generate a graph for a specific node, detailing the immediate incoming and outgoing traffic.
+//   GraphNamespacesDiff: Generate a graph for one or more requested namespaces, annotated with the changes from
+//                        the graph of a baseline time window.
+//   GraphDependencies: Return the transitive callers and dependencies of a node of the graph of one or more
+//                      requested namespaces.
+//   GraphPaths:       Return the paths between two nodes of the graph of one or more requested namespaces.
+//   GraphCost:        Return the edges of each requested namespace transferring the costliest bytes, from their
+//                     locality (intra-zone, cross-zone or cross-cluster) and the configured rates.
+//   GraphNamespacesStream: Stream the graph of one or more requested namespaces as Server-Sent Events, sending
+//                          the changes from the previous graph on every refresh.
+//   GraphSnapshotSave: Save the traffic map of the graph of one or more requested namespaces under a name, with its
+//                      options.
+//   GraphSnapshots:    List the saved graph snapshots of the namespaces accessible to the user.
+//   GraphSnapshot:     Return the graph of a saved snapshot, rendered from the traffic map saved with the snapshot.
+//   GraphSnapshotDelete: Delete a saved graph snapshot.
 //
 // The handlers accept the following query parameters (see notes below)
 //   appenders:       Comma-separated list of TelemetryVendor-specific appenders to run. (default: all)
-//   configVendor:    default: cytoscape
+//   configVendor:    cytoscape | dot | graphml (default: cytoscape)
 //   duration:        time.Duration indicating desired query range duration, (default: 10m)
 //   graphType:       Determines how to present the telemetry data. app | service | versionedApp | workload (default: workload)
-//   boxBy:           If supported by vendor, visually box by a specified node attribute (default: none)
+//   hide:            Find/hide expression of the nodes and edges to remove from the graph, see the graph hide field of the UI (default: none)
+//   boxBy:           If supported by vendor, visually box by a specified node attribute, or label:<key> to box by a workload or namespace label (default: none)
 //   namespaces:      Comma-separated list of namespace names to use in the graph. Will override namespace path param
 //   queryTime:       Unix time (seconds) for query such that range is queryTime-duration..queryTime (default now)
-//   TelemetryVendor: default: istio
+//   TelemetryVendor: istio | jaeger, the graph of the sampled traces | topology, the graph of the configured routes built without telemetry (default: istio)
+//   traceLimit:      The number of traces fetched per app by telemetryVendor jaeger (default: 100)
+//
+// GraphNamespacesDiff also accepts:
+//   baselineDuration:  time.Duration of the baseline query range (default: duration)
+//   baselineQueryTime: Unix time (seconds) for the baseline query (default: queryTime-duration)
+//   diffThreshold:     Percentage of change of the edge rates or response time to report an edge as changed (default: 10)
+//
+// GraphDependencies also accepts:
+//   node:            Selector of the node, as <kind>:<namespace>/<name> with kind app | service | workload
+//   upstreamDepth:   Maximum number of edges from the node to its callers, 0 is unlimited (default: 0)
+//   downstreamDepth: Maximum number of edges from the node to its dependencies, 0 is unlimited (default: 0)
+//
+// GraphPaths also accepts:
+//   source:          Selector of the source nodes, as <kind>:<namespace>/<name> with kind app | service | workload
+//   destination:     Selector of the destination nodes, same format as source
+//   maxDepth:        Maximum number of edges of a path, 0 is unlimited (default: 10)
+//   rankBy:          errorRate | responseTime (default: errorRate)
+//   limit:           Maximum number of paths returned, 0 is unlimited (default: 20)
+//
+// GraphCost also accepts:
+//   limit:           Maximum number of edges of each namespace, 0 is unlimited (default: 10)
+//
+// GraphNamespacesStream also accepts:
+//   refreshInterval: time.Duration between two graphs of the stream, at least 5s and less than 25s (default: 10s)
+//                    A stream lasts 25s, the reconnections start again with the full graph.
+//
+// GraphSnapshotSave, GraphSnapshot and GraphSnapshotDelete take the snapshot name as a path param, a DNS label.
+//
+// GraphSnapshot accepts only the configVendor and boxBy query parameters (default boxBy: the boxBy of the snapshot),
+// the label boxing must be the one of the snapshot. It also accepts:
+//   baseline:        Name of a saved snapshot, the graph is the diff of the baseline snapshot and the snapshot
+//   diffThreshold:   Percentage of change of the edge rates or response time to report an edge as changed (default: 10)
 //
 //  Note: some handlers may ignore some query parameters.
+//  Note: when the graph cache is enabled, the refreshInterval query parameter of any graph request sets the time
+//        bucket of the shared graphs (default: 10s, at most the cache TTL).
 //  Note: vendors may support additional, vendor-specific query parameters.
 //
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"runtime/debug"
 
+	"github.com/gorilla/mux"
+
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/graph/api"
 	"github.com/kiali/kiali/log"
@@ -52,6 +105,159 @@ func GraphNamespaces(w http.ResponseWriter, r *http.Request) {
 	respond(w, code, payload)
 }
 
+// GraphNamespacesDiff is a REST http.HandlerFunc handling diff graph generation for 1 or more namespaces
+func GraphNamespacesDiff(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewDiffOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphNamespacesDiff(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphDependencies is a REST http.HandlerFunc returning the transitive callers and dependencies of a node
+func GraphDependencies(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewDependenciesOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphDependencies(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphPaths is a REST http.HandlerFunc returning the ranked paths between two nodes
+func GraphPaths(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewPathsOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphPaths(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphCost is a REST http.HandlerFunc returning the costliest edges of each namespace
+func GraphCost(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewCostOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphCost(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphSnapshotSave is a REST http.HandlerFunc saving the graph of 1 or more namespaces under a name
+func GraphSnapshotSave(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewSnapshotOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphSnapshotSave(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphSnapshots is a REST http.HandlerFunc listing the saved graph snapshots
+func GraphSnapshots(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphSnapshots(r.Context(), business)
+	respond(w, code, payload)
+}
+
+// GraphSnapshot is a REST http.HandlerFunc returning the graph of a saved snapshot
+func GraphSnapshot(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewSnapshotReadOptions(r)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphSnapshot(r.Context(), business, o)
+	respond(w, code, payload)
+}
+
+// GraphSnapshotDelete is a REST http.HandlerFunc deleting a saved graph snapshot
+func GraphSnapshotDelete(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	code, payload := api.GraphSnapshotDelete(r.Context(), business, mux.Vars(r)["snapshot"])
+	respond(w, code, payload)
+}
+
+// GraphNamespacesStream is a REST http.HandlerFunc streaming the graph of 1 or more namespaces as Server-Sent
+// Events. The stream ends after graph.StreamTimeout (25s), before the write timeout of the server, clients like the
+// browser EventSource reconnect and get a new stream. There is no resume: a stream carries at most a couple of
+// deltas, 2 with the default 10s refresh interval, and each new stream starts again with the full graph.
+func GraphNamespacesStream(w http.ResponseWriter, r *http.Request) {
+	defer handlePanic(w)
+
+	o := graph.NewStreamOptions(r)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		graph.Error("Graph streams are not supported by the connection")
+	}
+
+	business, err := getBusiness(r)
+	graph.CheckError(err)
+
+	ctx, cancel := context.WithTimeout(r.Context(), graph.StreamTimeout)
+	defer cancel()
+
+	stream := &eventStream{w: w, flusher: flusher}
+	if err := api.GraphNamespacesStream(ctx, business, o, stream.send); err != nil {
+		log.Debugf("Graph stream closed: %s", err)
+	}
+}
+
+// eventStream writes Server-Sent Events, the headers are written with the first event so that an error
+// before it is still returned as the response of the request.
+type eventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	started bool
+}
+
+func (s *eventStream) send(event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream")
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.Header().Set("X-Accel-Buffering", "no")
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
 // GraphNode is a REST http.HandlerFunc handling node-detail graph config generation.
 func GraphNode(w http.ResponseWriter, r *http.Request) {
 	defer handlePanic(w)
@@ -93,6 +299,12 @@ func handlePanic(w http.ResponseWriter) {
 }
 
 func respond(w http.ResponseWriter, code int, payload interface{}) {
+	if encoded, ok := payload.(graph.EncodedConfig); ok && code == http.StatusOK {
+		w.Header().Set("Content-Type", encoded.ContentType())
+		w.WriteHeader(code)
+		_, _ = w.Write(encoded.Bytes())
+		return
+	}
 	if code == http.StatusOK {
 		RespondWithJSONIndent(w, code, payload)
 		return
@@ -38,7 +38,7 @@ func TestNamespaceAppHealth(t *testing.T) {
 	k8s.MockEmptyWorkloads("ns")
 
 	// Test 17s on rate interval to check that rate interval is adjusted correctly.
-	prom.On("GetAllRequestRates", "ns", "17s", util.Clock.Now()).Return(model.Vector{}, nil)
+	prom.On("GetAllRequestRates", "ns", "", "17s", util.Clock.Now()).Return(model.Vector{}, nil)
 
 	resp, err := http.Get(url)
 	if err != nil {
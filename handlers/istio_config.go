@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
@@ -285,6 +287,142 @@ func IstioConfigCreate(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 }
 
+// IstioConfigCreateDryRun returns the validations the mesh would have after creating the Istio object, without creating it.
+func IstioConfigCreateDryRun(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+
+	if !business.GetIstioAPI(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Create request could not be read: "+err.Error())
+		return
+	}
+
+	dryRun, err := business.Validations.GetCreateDryRunValidations(r.Context(), namespace, objectType, body)
+	if err != nil {
+		handleDryRunErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, dryRun)
+}
+
+// IstioConfigUpdateDryRun returns the validations the mesh would have after patching the Istio object, without updating it.
+func IstioConfigUpdateDryRun(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !business.GetIstioAPI(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+objectType)
+		return
+	}
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
+		return
+	}
+
+	dryRun, err := business.Validations.GetUpdateDryRunValidations(r.Context(), namespace, objectType, object, string(body))
+	if err != nil {
+		handleDryRunErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, dryRun)
+}
+
+// AuthorizationPoliciesSimulate evaluates the AuthorizationPolicies for a request sent to a workload of the namespace
+func AuthorizationPoliciesSimulate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	request := models.AuthorizationSimulationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Simulation request could not be read: "+err.Error())
+		return
+	}
+	if request.Destination.Workload == "" && len(request.Destination.Labels) == 0 {
+		RespondWithError(w, http.StatusBadRequest, "Simulation request requires the destination workload or its labels")
+		return
+	}
+
+	result, err := business.IstioConfig.SimulateAuthorization(r.Context(), namespace, request)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// VirtualServicesSimulate resolves the route of a request sent from the namespace to a host
+func VirtualServicesSimulate(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+
+	// Get business layer
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	request := models.RouteSimulationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Simulation request could not be read: "+err.Error())
+		return
+	}
+	if request.Host == "" {
+		RespondWithError(w, http.StatusBadRequest, "Simulation request requires a host")
+		return
+	}
+
+	result, err := business.IstioConfig.SimulateRoute(r.Context(), namespace, request)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, result)
+}
+
+// handleDryRunErrorResponse responds with the errors of the proposed object as bad requests and conflicts
+func handleDryRunErrorResponse(w http.ResponseWriter, err error) {
+	if errors.IsBadRequest(err) {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+	} else if errors.IsAlreadyExists(err) {
+		RespondWithError(w, http.StatusConflict, err.Error())
+	} else {
+		handleErrorResponse(w, err)
+	}
+}
+
 func checkObjectType(objectType string) bool {
 	return business.GetIstioAPI(objectType)
 }
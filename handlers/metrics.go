@@ -221,6 +221,9 @@ func extractIstioMetricsQueryParams(r *http.Request, q *models.IstioMetricsQuery
 		}
 		q.Direction = dir
 	}
+	if cluster := queryParams.Get("cluster"); cluster != "" {
+		q.Cluster = cluster
+	}
 	requestProtocol := queryParams.Get("requestProtocol")
 	if requestProtocol != "" {
 		q.RequestProtocol = requestProtocol
@@ -26,6 +26,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/kiali/kiali/business/authentication"
+	"github.com/kiali/kiali/business/checkers/customrules"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
@@ -175,6 +176,12 @@ func validateConfig() error {
 		log.Infof("Some validation errors will be ignored %v. If these errors do occur, they will still be logged. If you think the validation errors you see are incorrect, please report them to the Kiali team if you have not done so already and provide the details of your scenario. This will keep Kiali validations strong for the whole community.", cfg.KialiFeatureFlags.Validations.Ignore)
 	}
 
+	// compile the custom validation rules at startup so invalid expressions are reported early
+	if len(cfg.KialiFeatureFlags.Validations.CustomRules) > 0 {
+		rules, errs := customrules.CompileAll(cfg.KialiFeatureFlags.Validations.CustomRules)
+		log.Infof("%d of %d custom validation rules are enabled, %d are invalid", len(rules), len(cfg.KialiFeatureFlags.Validations.CustomRules), len(errs))
+	}
+
 	// log a info message if the user is disabling some features
 	if len(cfg.KialiFeatureFlags.DisabledFeatures) > 0 {
 		log.Infof("Some features are disabled: [%v]", strings.Join(cfg.KialiFeatureFlags.DisabledFeatures, ","))
@@ -87,21 +87,25 @@ type (
 		statefulSetLister apps_v1_listers.StatefulSetLister
 
 		// Istio listers
-		authzLister           istiosec_v1beta1_listers.AuthorizationPolicyLister
-		destinationRuleLister istionet_v1beta1_listers.DestinationRuleLister
-		envoyFilterLister     istionet_v1alpha3_listers.EnvoyFilterLister
-		gatewayLister         istionet_v1beta1_listers.GatewayLister
-		k8sgatewayLister      k8s_v1alpha2_listers.GatewayLister
-		k8shttprouteLister    k8s_v1alpha2_listers.HTTPRouteLister
-		peerAuthnLister       istiosec_v1beta1_listers.PeerAuthenticationLister
-		requestAuthnLister    istiosec_v1beta1_listers.RequestAuthenticationLister
-		serviceEntryLister    istionet_v1beta1_listers.ServiceEntryLister
-		sidecarLister         istionet_v1beta1_listers.SidecarLister
-		telemetryLister       istiotelem_v1alpha1_listers.TelemetryLister
-		virtualServiceLister  istionet_v1beta1_listers.VirtualServiceLister
-		wasmPluginLister      istioext_v1alpha1_listers.WasmPluginLister
-		workloadEntryLister   istionet_v1beta1_listers.WorkloadEntryLister
-		workloadGroupLister   istionet_v1beta1_listers.WorkloadGroupLister
+		authzLister             istiosec_v1beta1_listers.AuthorizationPolicyLister
+		destinationRuleLister   istionet_v1beta1_listers.DestinationRuleLister
+		envoyFilterLister       istionet_v1alpha3_listers.EnvoyFilterLister
+		gatewayLister           istionet_v1beta1_listers.GatewayLister
+		k8sgatewayLister        k8s_v1alpha2_listers.GatewayLister
+		k8sgrpcrouteLister      k8s_v1alpha2_listers.GRPCRouteLister
+		k8shttprouteLister      k8s_v1alpha2_listers.HTTPRouteLister
+		k8sreferencegrantLister k8s_v1alpha2_listers.ReferenceGrantLister
+		k8stcprouteLister       k8s_v1alpha2_listers.TCPRouteLister
+		k8stlsrouteLister       k8s_v1alpha2_listers.TLSRouteLister
+		peerAuthnLister         istiosec_v1beta1_listers.PeerAuthenticationLister
+		requestAuthnLister      istiosec_v1beta1_listers.RequestAuthenticationLister
+		serviceEntryLister      istionet_v1beta1_listers.ServiceEntryLister
+		sidecarLister           istionet_v1beta1_listers.SidecarLister
+		telemetryLister         istiotelem_v1alpha1_listers.TelemetryLister
+		virtualServiceLister    istionet_v1beta1_listers.VirtualServiceLister
+		wasmPluginLister        istioext_v1alpha1_listers.WasmPluginLister
+		workloadEntryLister     istionet_v1beta1_listers.WorkloadEntryLister
+		workloadGroupLister     istionet_v1beta1_listers.WorkloadGroupLister
 	}
 
 	kialiCacheImpl struct {
@@ -134,6 +138,16 @@ type (
 	}
 )
 
+// gatewayAPIKinds maps the cached K8s Gateway API types to their kinds
+var gatewayAPIKinds = map[string]string{
+	kubernetes.K8sGatewayType:        kubernetes.K8sActualGatewayType,
+	kubernetes.K8sHTTPRouteType:      kubernetes.K8sActualHTTPRouteType,
+	kubernetes.K8sGRPCRouteType:      kubernetes.K8sActualGRPCRouteType,
+	kubernetes.K8sTCPRouteType:       kubernetes.K8sActualTCPRouteType,
+	kubernetes.K8sTLSRouteType:       kubernetes.K8sActualTLSRouteType,
+	kubernetes.K8sReferenceGrantType: kubernetes.K8sActualReferenceGrantType,
+}
+
 func NewKialiCache(namespaceSeedList ...string) (KialiCache, error) {
 	config, err := kubernetes.ConfigClient()
 	if err != nil {
@@ -175,6 +189,13 @@ func NewKialiCache(namespaceSeedList ...string) (KialiCache, error) {
 	for _, iType := range kConfig.KubernetesConfig.CacheIstioTypes {
 		cacheIstioTypes[iType] = true
 	}
+	// The informers of the K8s Gateway API kinds not served by the cluster would never sync
+	for iType, kind := range gatewayAPIKinds {
+		if cacheIstioTypes[iType] && !istioClient.IsGatewayAPIResource(kind) {
+			log.Debugf("[Kiali Cache] %s is not served by the cluster, it won't be cached", kind)
+			delete(cacheIstioTypes, iType)
+		}
+	}
 	log.Tracef("[Kiali Cache] cacheIstioTypes %v", cacheIstioTypes)
 
 	cacheNamespacesRegexps := make([]regexp.Regexp, len(cacheNamespaces))
@@ -45,6 +45,14 @@ type (
 		GetK8sGateways(namespace, labelSelector string) ([]*gatewayapi.Gateway, error)
 		GetK8sHTTPRoute(namespace, name string) (*gatewayapi.HTTPRoute, error)
 		GetK8sHTTPRoutes(namespace, labelSelector string) ([]*gatewayapi.HTTPRoute, error)
+		GetK8sGRPCRoute(namespace, name string) (*gatewayapi.GRPCRoute, error)
+		GetK8sGRPCRoutes(namespace, labelSelector string) ([]*gatewayapi.GRPCRoute, error)
+		GetK8sTCPRoute(namespace, name string) (*gatewayapi.TCPRoute, error)
+		GetK8sTCPRoutes(namespace, labelSelector string) ([]*gatewayapi.TCPRoute, error)
+		GetK8sTLSRoute(namespace, name string) (*gatewayapi.TLSRoute, error)
+		GetK8sTLSRoutes(namespace, labelSelector string) ([]*gatewayapi.TLSRoute, error)
+		GetK8sReferenceGrant(namespace, name string) (*gatewayapi.ReferenceGrant, error)
+		GetK8sReferenceGrants(namespace, labelSelector string) ([]*gatewayapi.ReferenceGrant, error)
 
 		GetAuthorizationPolicy(namespace, name string) (*security_v1beta1.AuthorizationPolicy, error)
 		GetAuthorizationPolicies(namespace, labelSelector string) ([]*security_v1beta1.AuthorizationPolicy, error)
@@ -138,6 +146,22 @@ func (c *kialiCacheImpl) createGatewayInformers(namespace string) gateway.Shared
 			lister.k8shttprouteLister = sharedInformers.Gateway().V1alpha2().HTTPRoutes().Lister()
 			sharedInformers.Gateway().V1alpha2().Gateways().Informer().AddEventHandler(c.registryRefreshHandler)
 		}
+		if c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+			lister.k8sgrpcrouteLister = sharedInformers.Gateway().V1alpha2().GRPCRoutes().Lister()
+			sharedInformers.Gateway().V1alpha2().GRPCRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+			lister.k8stcprouteLister = sharedInformers.Gateway().V1alpha2().TCPRoutes().Lister()
+			sharedInformers.Gateway().V1alpha2().TCPRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+			lister.k8stlsrouteLister = sharedInformers.Gateway().V1alpha2().TLSRoutes().Lister()
+			sharedInformers.Gateway().V1alpha2().TLSRoutes().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
+		if c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+			lister.k8sreferencegrantLister = sharedInformers.Gateway().V1alpha2().ReferenceGrants().Lister()
+			sharedInformers.Gateway().V1alpha2().ReferenceGrants().Informer().AddEventHandler(c.registryRefreshHandler)
+		}
 	}
 	return sharedInformers
 }
@@ -777,6 +801,222 @@ func (c *kialiCacheImpl) GetK8sHTTPRoutes(namespace, labelSelector string) ([]*g
 	return retRoutes, nil
 }
 
+func (c *kialiCacheImpl) GetK8sGRPCRoute(namespace, name string) (*gatewayapi.GRPCRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sGRPCRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	g, err := c.getCacheLister(namespace).k8sgrpcrouteLister.GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retG := g.DeepCopy()
+	retG.Kind = kubernetes.K8sGRPCRouteType
+	return retG, nil
+}
+
+func (c *kialiCacheImpl) GetK8sGRPCRoutes(namespace, labelSelector string) ([]*gatewayapi.GRPCRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sGRPCRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sGRPCRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sgrpcrouteLister.GRPCRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi.GRPCRoute{}, nil
+	}
+
+	var retRoutes []*gatewayapi.GRPCRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sGRPCRouteType
+		retRoutes = append(retRoutes, ww)
+	}
+
+	return retRoutes, nil
+}
+
+func (c *kialiCacheImpl) GetK8sTCPRoute(namespace, name string) (*gatewayapi.TCPRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTCPRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	g, err := c.getCacheLister(namespace).k8stcprouteLister.TCPRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retG := g.DeepCopy()
+	retG.Kind = kubernetes.K8sTCPRouteType
+	return retG, nil
+}
+
+func (c *kialiCacheImpl) GetK8sTCPRoutes(namespace, labelSelector string) ([]*gatewayapi.TCPRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTCPRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTCPRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stcprouteLister.TCPRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi.TCPRoute{}, nil
+	}
+
+	var retRoutes []*gatewayapi.TCPRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sTCPRouteType
+		retRoutes = append(retRoutes, ww)
+	}
+
+	return retRoutes, nil
+}
+
+func (c *kialiCacheImpl) GetK8sTLSRoute(namespace, name string) (*gatewayapi.TLSRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTLSRouteType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	g, err := c.getCacheLister(namespace).k8stlsrouteLister.TLSRoutes(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retG := g.DeepCopy()
+	retG.Kind = kubernetes.K8sTLSRouteType
+	return retG, nil
+}
+
+func (c *kialiCacheImpl) GetK8sTLSRoutes(namespace, labelSelector string) ([]*gatewayapi.TLSRoute, error) {
+	if !c.CheckIstioResource(kubernetes.K8sTLSRoutes) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sTLSRoutes)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8stlsrouteLister.TLSRoutes(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi.TLSRoute{}, nil
+	}
+
+	var retRoutes []*gatewayapi.TLSRoute
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sTLSRouteType
+		retRoutes = append(retRoutes, ww)
+	}
+
+	return retRoutes, nil
+}
+
+func (c *kialiCacheImpl) GetK8sReferenceGrant(namespace, name string) (*gatewayapi.ReferenceGrant, error) {
+	if !c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sReferenceGrantType)
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	g, err := c.getCacheLister(namespace).k8sreferencegrantLister.ReferenceGrants(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	retG := g.DeepCopy()
+	retG.Kind = kubernetes.K8sReferenceGrantType
+	return retG, nil
+}
+
+func (c *kialiCacheImpl) GetK8sReferenceGrants(namespace, labelSelector string) ([]*gatewayapi.ReferenceGrant, error) {
+	if !c.CheckIstioResource(kubernetes.K8sReferenceGrants) {
+		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.K8sReferenceGrants)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read lock will prevent the cache from being refreshed while we are reading from the lister
+	// but it won't prevent other routines from reading from the lister.
+	defer c.cacheLock.RUnlock()
+	c.cacheLock.RLock()
+	r, err := c.getCacheLister(namespace).k8sreferencegrantLister.ReferenceGrants(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lister returns nil when there are no results but callers of the cache expect an empty array
+	// so keeping the behavior the same since it matters for json marshalling.
+	if r == nil {
+		return []*gatewayapi.ReferenceGrant{}, nil
+	}
+
+	var retGrants []*gatewayapi.ReferenceGrant
+	for _, w := range r {
+		ww := w.DeepCopy()
+		ww.Kind = kubernetes.K8sReferenceGrantType
+		retGrants = append(retGrants, ww)
+	}
+
+	return retGrants, nil
+}
+
 func (c *kialiCacheImpl) GetAuthorizationPolicy(namespace, name string) (*security_v1beta1.AuthorizationPolicy, error) {
 	if !c.CheckIstioResource(kubernetes.AuthorizationPolicies) {
 		return nil, fmt.Errorf("Kiali cache doesn't support [resourceType: %s]", kubernetes.AuthorizationPoliciesType)
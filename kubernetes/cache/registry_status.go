@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"math"
 	"time"
 
 	"github.com/kiali/kiali/kubernetes"
@@ -47,3 +48,17 @@ func (c *kialiCacheImpl) RefreshRegistryStatus() {
 	c.registryStatusCreated = nil
 	c.registryStatus = nil
 }
+
+// NewRegistryStatusKialiCache returns a KialiCache that only serves the given registry status.
+// It doesn't create informers nor poll istiod, so the registry status never expires.
+// It is used to run the business layer without a cluster, i.e. for offline validations.
+func NewRegistryStatusKialiCache(registryStatus *kubernetes.RegistryStatus) KialiCache {
+	kialiCacheImpl := kialiCacheImpl{
+		tokenNamespaces:       make(map[string]namespaceCache),
+		proxyStatusNamespaces: make(map[string]map[string]podProxyStatus),
+		refreshDuration:       time.Duration(math.MaxInt64),
+		stopPolling:           func() {},
+	}
+	kialiCacheImpl.SetRegistryStatus(registryStatus)
+	return &kialiCacheImpl
+}
@@ -60,7 +60,9 @@ type K8SClient struct {
 	isOpenShift *bool
 	// isGatewayAPI private variable will check if K8s Gateway API CRD exists on cluster or not
 	isGatewayAPI *bool
-	gatewayapi   gatewayapiclient.Interface
+	// gatewayAPIKinds holds the K8s Gateway API kinds served by the cluster, the experimental channel ones may be missing
+	gatewayAPIKinds map[string]bool
+	gatewayapi      gatewayapiclient.Interface
 
 	// Separated out for testing purposes
 	getPodPortForwarderFunc func(namespace, name, portMap string) (httputil.PortForwarder, error)
@@ -456,8 +456,12 @@ func ParseRegistryConfig(config map[string][]byte) (*RegistryConfiguration, erro
 		Telemetries:      []*v1alpha1.Telemetry{},
 
 		// K8s Networking Gateways
-		K8sGateways:   []*k8s_networking_v1alpha2.Gateway{},
-		K8sHTTPRoutes: []*k8s_networking_v1alpha2.HTTPRoute{},
+		K8sGateways:        []*k8s_networking_v1alpha2.Gateway{},
+		K8sHTTPRoutes:      []*k8s_networking_v1alpha2.HTTPRoute{},
+		K8sGRPCRoutes:      []*k8s_networking_v1alpha2.GRPCRoute{},
+		K8sTCPRoutes:       []*k8s_networking_v1alpha2.TCPRoute{},
+		K8sTLSRoutes:       []*k8s_networking_v1alpha2.TLSRoute{},
+		K8sReferenceGrants: []*k8s_networking_v1alpha2.ReferenceGrant{},
 
 		AuthorizationPolicies:  []*security_v1beta1.AuthorizationPolicy{},
 		PeerAuthentications:    []*security_v1beta1.PeerAuthentication{},
@@ -482,7 +486,7 @@ func ParseRegistryConfig(config map[string][]byte) (*RegistryConfiguration, erro
 				if mItem, ok := iItem.(map[string]interface{}); ok {
 					kind := mItem["kind"].(string)
 					switch kind {
-					case "DestinationRule", "EnvoyFilter", "Gateway", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "AuthorizationPolicy", "PeerAuthentication", "RequestAuthentication", "WasmPlugin", "Telemetry", "HTTPRoute":
+					case "DestinationRule", "EnvoyFilter", "Gateway", "ServiceEntry", "Sidecar", "VirtualService", "WorkloadEntry", "WorkloadGroup", "AuthorizationPolicy", "PeerAuthentication", "RequestAuthentication", "WasmPlugin", "Telemetry", "HTTPRoute", "GRPCRoute", "TCPRoute", "TLSRoute", "ReferenceGrant":
 						bItem, err := json.Marshal(iItem)
 						rbItem := bytes.NewReader(bItem)
 						bDec := json.NewDecoder(rbItem)
@@ -529,6 +533,34 @@ func ParseRegistryConfig(config map[string][]byte) (*RegistryConfiguration, erro
 								log.Errorf("Error parsing RegistryConfig results for K8sHTTPRoutes: %s", err)
 							}
 							registry.K8sHTTPRoutes = append(registry.K8sHTTPRoutes, route)
+						case "GRPCRoute":
+							var route *k8s_networking_v1alpha2.GRPCRoute
+							err := bDec.Decode(&route)
+							if err != nil {
+								log.Errorf("Error parsing RegistryConfig results for K8sGRPCRoutes: %s", err)
+							}
+							registry.K8sGRPCRoutes = append(registry.K8sGRPCRoutes, route)
+						case "TCPRoute":
+							var route *k8s_networking_v1alpha2.TCPRoute
+							err := bDec.Decode(&route)
+							if err != nil {
+								log.Errorf("Error parsing RegistryConfig results for K8sTCPRoutes: %s", err)
+							}
+							registry.K8sTCPRoutes = append(registry.K8sTCPRoutes, route)
+						case "TLSRoute":
+							var route *k8s_networking_v1alpha2.TLSRoute
+							err := bDec.Decode(&route)
+							if err != nil {
+								log.Errorf("Error parsing RegistryConfig results for K8sTLSRoutes: %s", err)
+							}
+							registry.K8sTLSRoutes = append(registry.K8sTLSRoutes, route)
+						case "ReferenceGrant":
+							var rg *k8s_networking_v1alpha2.ReferenceGrant
+							err := bDec.Decode(&rg)
+							if err != nil {
+								log.Errorf("Error parsing RegistryConfig results for K8sReferenceGrants: %s", err)
+							}
+							registry.K8sReferenceGrants = append(registry.K8sReferenceGrants, rg)
 						case "ServiceEntry":
 							var se *networking_v1beta1.ServiceEntry
 							err := bDec.Decode(&se)
@@ -48,6 +48,7 @@ type K8SClientInterface interface {
 	GetJobs(namespace string) ([]batch_v1.Job, error)
 	GetNamespace(namespace string) (*core_v1.Namespace, error)
 	GetNamespaces(labelSelector string) ([]core_v1.Namespace, error)
+	GetNodes() ([]core_v1.Node, error)
 	GetPod(namespace, name string) (*core_v1.Pod, error)
 	GetPods(namespace, labelSelector string) ([]core_v1.Pod, error)
 	GetReplicationControllers(namespace string) ([]core_v1.ReplicationController, error)
@@ -161,6 +162,17 @@ func (in *K8SClient) GetNamespaces(labelSelector string) ([]core_v1.Namespace, e
 	return namespaces.Items, nil
 }
 
+// GetNodes returns the nodes of the cluster. The client will need to be created with an account that has
+// cluster-wide privileges to list nodes.
+func (in *K8SClient) GetNodes() ([]core_v1.Node, error) {
+	nodes, err := in.k8s.CoreV1().Nodes().List(in.ctx, emptyListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes.Items, nil
+}
+
 // GetProject fetches and returns the definition of the project with
 // the specified name by querying the cluster API. GetProject will fail
 // if the underlying cluster is not Openshift.
@@ -222,6 +234,27 @@ func (in *K8SClient) IsGatewayAPI() bool {
 	return *in.isGatewayAPI
 }
 
+// IsGatewayAPIResource returns true when the cluster serves the given K8s Gateway API kind, e.g. GRPCRoute.
+// The standard channel CRDs only bring a subset of the kinds.
+func (in *K8SClient) IsGatewayAPIResource(kind string) bool {
+	if !in.IsGatewayAPI() {
+		return false
+	}
+	if in.gatewayAPIKinds == nil {
+		gatewayAPIKinds := make(map[string]bool)
+		resources, err := in.k8s.Discovery().ServerResourcesForGroupVersion(K8sNetworkingGroupVersionV1Alpha2.String())
+		if err == nil {
+			for _, r := range resources.APIResources {
+				gatewayAPIKinds[r.Kind] = true
+			}
+		} else if !errors.IsNotFound(err) {
+			log.Warningf("Error checking Kubernetes Gateway API resources: %v", err)
+		}
+		in.gatewayAPIKinds = gatewayAPIKinds
+	}
+	return in.gatewayAPIKinds[kind]
+}
+
 // GetServices returns a list of services for a given namespace.
 // If selectorLabels is defined the list of services is filtered for those that matches Services selector labels.
 // It returns an error on any problem.
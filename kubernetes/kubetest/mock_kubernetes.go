@@ -70,6 +70,11 @@ func (o *K8SClientMock) GetNamespaces(labelSelector string) ([]core_v1.Namespace
 	return args.Get(0).([]core_v1.Namespace), args.Error(1)
 }
 
+func (o *K8SClientMock) GetNodes() ([]core_v1.Node, error) {
+	args := o.Called()
+	return args.Get(0).([]core_v1.Node), args.Error(1)
+}
+
 func (o *K8SClientMock) GetPods(namespace, labelSelector string) ([]core_v1.Pod, error) {
 	args := o.Called(namespace, labelSelector)
 	return args.Get(0).([]core_v1.Pod), args.Error(1)
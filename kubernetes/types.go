@@ -72,6 +72,26 @@ const (
 	// K8sActualHTTPRouteType There is a naming conflict between Istio and K8s Gateways, keeping here an actual type to show in YAML editor
 	K8sActualHTTPRouteType = "HTTPRoute"
 
+	K8sGRPCRoutes    = "k8sgrpcroutes"
+	K8sGRPCRouteType = "K8sGRPCRoute"
+	// K8sActualGRPCRouteType keeping here an actual type to show in YAML editor
+	K8sActualGRPCRouteType = "GRPCRoute"
+
+	K8sTCPRoutes    = "k8stcproutes"
+	K8sTCPRouteType = "K8sTCPRoute"
+	// K8sActualTCPRouteType keeping here an actual type to show in YAML editor
+	K8sActualTCPRouteType = "TCPRoute"
+
+	K8sTLSRoutes    = "k8stlsroutes"
+	K8sTLSRouteType = "K8sTLSRoute"
+	// K8sActualTLSRouteType keeping here an actual type to show in YAML editor
+	K8sActualTLSRouteType = "TLSRoute"
+
+	K8sReferenceGrants    = "k8sreferencegrants"
+	K8sReferenceGrantType = "K8sReferenceGrant"
+	// K8sActualReferenceGrantType keeping here an actual type to show in YAML editor
+	K8sActualReferenceGrantType = "ReferenceGrant"
+
 	// Authorization PeerAuthentications
 	AuthorizationPolicies     = "authorizationpolicies"
 	AuthorizationPoliciesType = "AuthorizationPolicy"
@@ -142,8 +162,12 @@ var (
 		Telemetries:      TelemetryType,
 
 		// K8s Networking Gateways
-		K8sGateways:   K8sGatewayType,
-		K8sHTTPRoutes: K8sHTTPRouteType,
+		K8sGateways:        K8sGatewayType,
+		K8sHTTPRoutes:      K8sHTTPRouteType,
+		K8sGRPCRoutes:      K8sGRPCRouteType,
+		K8sTCPRoutes:       K8sTCPRouteType,
+		K8sTLSRoutes:       K8sTLSRouteType,
+		K8sReferenceGrants: K8sReferenceGrantType,
 
 		// Security
 		AuthorizationPolicies:  AuthorizationPoliciesType,
@@ -163,8 +187,12 @@ var (
 		WasmPlugins:      ExtensionGroupVersionV1Alpha1.Group,
 		Telemetries:      TelemetryGroupV1Alpha1.Group,
 
-		K8sGateways:   K8sNetworkingGroupVersionV1Alpha2.Group,
-		K8sHTTPRoutes: K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sGateways:        K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sHTTPRoutes:      K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sGRPCRoutes:      K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sTCPRoutes:       K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sTLSRoutes:       K8sNetworkingGroupVersionV1Alpha2.Group,
+		K8sReferenceGrants: K8sNetworkingGroupVersionV1Alpha2.Group,
 
 		AuthorizationPolicies:  SecurityGroupVersion.Group,
 		PeerAuthentications:    SecurityGroupVersion.Group,
@@ -173,8 +201,16 @@ var (
 )
 
 type IstioMeshConfig struct {
-	DisableMixerHttpReports bool  `yaml:"disableMixerHttpReports,omitempty"`
-	EnableAutoMtls          *bool `yaml:"enableAutoMtls,omitempty"`
+	DisableMixerHttpReports bool                     `yaml:"disableMixerHttpReports,omitempty"`
+	EnableAutoMtls          *bool                    `yaml:"enableAutoMtls,omitempty"`
+	ExtensionProviders      []IstioExtensionProvider `yaml:"extensionProviders,omitempty"`
+	TrustDomain             string                   `yaml:"trustDomain,omitempty"`
+}
+
+// IstioExtensionProvider holds the name of an extension provider defined in the MeshConfig.
+// Only the name is needed as the provider settings are not used by Kiali.
+type IstioExtensionProvider struct {
+	Name string `yaml:"name"`
 }
 
 // MTLSDetails is a wrapper to group all Istio objects related to non-local mTLS configurations
@@ -226,8 +262,12 @@ type RegistryConfiguration struct {
 	Telemetries      []*v1alpha1.Telemetry
 
 	// K8s Networking Gateways
-	K8sGateways   []*k8s_networking_v1alpha2.Gateway
-	K8sHTTPRoutes []*k8s_networking_v1alpha2.HTTPRoute
+	K8sGateways        []*k8s_networking_v1alpha2.Gateway
+	K8sHTTPRoutes      []*k8s_networking_v1alpha2.HTTPRoute
+	K8sGRPCRoutes      []*k8s_networking_v1alpha2.GRPCRoute
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute
+	K8sTLSRoutes       []*k8s_networking_v1alpha2.TLSRoute
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant
 
 	// Security
 	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy
@@ -341,3 +381,17 @@ func (imc IstioMeshConfig) GetEnableAutoMtls() bool {
 	}
 	return *imc.EnableAutoMtls
 }
+
+// GetExtensionProviderNames returns the names of the extension providers available in the mesh.
+// Istio always adds the "prometheus", "stackdriver" and "envoy" providers to the MeshConfig, so those are included too.
+func (imc IstioMeshConfig) GetExtensionProviderNames() map[string]bool {
+	names := map[string]bool{
+		"prometheus":  true,
+		"stackdriver": true,
+		"envoy":       true,
+	}
+	for _, ep := range imc.ExtensionProviders {
+		names[ep.Name] = true
+	}
+	return names
+}
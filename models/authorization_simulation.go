@@ -0,0 +1,90 @@
+package models
+
+// AuthorizationSimulationRequest describes a request from a source workload to a destination workload
+// to be evaluated against the AuthorizationPolicies of the mesh.
+//
+// swagger:model AuthorizationSimulationRequest
+type AuthorizationSimulationRequest struct {
+	Source      AuthorizationSimulationSource      `json:"source"`
+	Destination AuthorizationSimulationDestination `json:"destination"`
+	Request     AuthorizationSimulationOperation   `json:"request"`
+}
+
+// AuthorizationSimulationSource identifies the client of the request.
+type AuthorizationSimulationSource struct {
+	// Namespace of the source workload
+	// example: bookinfo
+	Namespace string `json:"namespace"`
+	// ServiceAccount of the source workload, used to build the mTLS principal
+	// example: bookinfo-productpage
+	ServiceAccount string `json:"serviceAccount"`
+	// Labels of the source workload
+	Labels map[string]string `json:"labels,omitempty"`
+	// Principal overrides the one built from the trust domain, namespace and service account
+	// example: cluster.local/ns/bookinfo/sa/bookinfo-productpage
+	Principal string `json:"principal,omitempty"`
+	// RequestPrincipal is the principal of the request JWT, if any
+	// example: issuer.example.com/subject
+	RequestPrincipal string `json:"requestPrincipal,omitempty"`
+	// IP of the source workload
+	IP string `json:"ip,omitempty"`
+}
+
+// AuthorizationSimulationDestination identifies the server of the request.
+type AuthorizationSimulationDestination struct {
+	// Namespace of the destination workload, taken from the endpoint path
+	Namespace string `json:"namespace"`
+	// Workload name, used to fetch the labels when they are not given
+	// example: reviews-v1
+	Workload string `json:"workload,omitempty"`
+	// Labels of the destination workload, matched against the selector of the policies
+	Labels map[string]string `json:"labels,omitempty"`
+	// IP of the destination workload
+	IP string `json:"ip,omitempty"`
+}
+
+// AuthorizationSimulationOperation is the request sent to the destination workload.
+type AuthorizationSimulationOperation struct {
+	// example: GET
+	Method string `json:"method,omitempty"`
+	// example: /reviews/1
+	Path string `json:"path,omitempty"`
+	// example: 9080
+	Port int `json:"port,omitempty"`
+	// example: reviews.bookinfo.svc.cluster.local
+	Host    string            `json:"host,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// AuthorizationSimulationResult is the decision taken for a simulated request.
+//
+// swagger:model AuthorizationSimulationResult
+type AuthorizationSimulationResult struct {
+	// Allowed is true when the request is allowed
+	Allowed bool `json:"allowed"`
+	// Reason explains the decision
+	// example: denied by DENY policy bookinfo/deny-delete
+	Reason string `json:"reason"`
+	// DecidedBy is the policy and rule that decided, nil when the decision is the default one
+	DecidedBy *AuthorizationPolicyMatch `json:"decidedBy,omitempty"`
+	// Custom lists the matching CUSTOM policies, delegated to an external authorizer.
+	// The decision assumes the external authorizer allows the request.
+	Custom []AuthorizationPolicyMatch `json:"custom"`
+	// Audited lists the matching AUDIT policies
+	Audited []AuthorizationPolicyMatch `json:"audited"`
+	// Unsupported lists the conditions that can't be evaluated, these are considered as not matching
+	Unsupported []string `json:"unsupported"`
+}
+
+// AuthorizationPolicyMatch identifies the rule of an AuthorizationPolicy that matched a request.
+type AuthorizationPolicyMatch struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// example: ALLOW
+	Action string `json:"action"`
+	// Rule is the index of the matching rule
+	Rule int `json:"rule"`
+	// Path of the matching rule, in the same format as the validation checks
+	// example: spec/rules[0]
+	Path string `json:"path"`
+}
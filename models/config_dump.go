@@ -46,6 +46,19 @@ type Bootstrap struct {
 	Bootstrap map[string]interface{} `json:"bootstrap,inline"`
 }
 
+// ProxyConfig holds the identity and the Envoy configuration of a single proxy.
+// It is used to validate the Istio objects that patch the proxy configuration, like EnvoyFilters.
+type ProxyConfig struct {
+	Namespace    string
+	Pod          string
+	Labels       map[string]string
+	IstioVersion string
+	// Gateway is true for the proxies of the gateways, false for the sidecars
+	Gateway bool
+	// ConfigDump is nil when the config_dump of the proxy couldn't be fetched
+	ConfigDump *kubernetes.ConfigDump
+}
+
 func (ls *Listeners) Parse(dump *kubernetes.ConfigDump) error {
 	listenersDump, err := dump.GetListeners()
 	if err != nil {
@@ -6,12 +6,20 @@ type AnnotationKey string
 const (
 	AllHealthAnnotation  AnnotationKey = ".*"
 	RateHealthAnnotation AnnotationKey = "health.kiali.io/rate"
+
+	AvailabilitySLOAnnotation AnnotationKey = "slo.kiali.io/availability"
+	LatencyP99SLOAnnotation   AnnotationKey = "slo.kiali.io/latency-p99"
 )
 
 func GetHealthConfigAnnotation() []AnnotationKey {
 	return []AnnotationKey{RateHealthAnnotation}
 }
 
+// GetSLOAnnotation returns the annotations overriding the SLO config of a service
+func GetSLOAnnotation() []AnnotationKey {
+	return []AnnotationKey{AvailabilitySLOAnnotation, LatencyP99SLOAnnotation}
+}
+
 func GetHealthAnnotation(annotations map[string]string, filters []AnnotationKey) map[string]string {
 	var result = map[string]string{}
 	for _, filter := range filters {
@@ -29,8 +29,12 @@ type IstioConfigList struct {
 	WasmPlugins      []*extentions_v1alpha1.WasmPlugin     `json:"wasmPlugins"`
 	Telemetries      []*v1alpha1.Telemetry                 `json:"telemetries"`
 
-	K8sGateways   []*k8s_networking_v1alpha2.Gateway   `json:"k8sGateways"`
-	K8sHTTPRoutes []*k8s_networking_v1alpha2.HTTPRoute `json:"k8sHTTPRoutes"`
+	K8sGateways        []*k8s_networking_v1alpha2.Gateway        `json:"k8sGateways"`
+	K8sHTTPRoutes      []*k8s_networking_v1alpha2.HTTPRoute      `json:"k8sHTTPRoutes"`
+	K8sGRPCRoutes      []*k8s_networking_v1alpha2.GRPCRoute      `json:"k8sGRPCRoutes"`
+	K8sTCPRoutes       []*k8s_networking_v1alpha2.TCPRoute       `json:"k8sTCPRoutes"`
+	K8sTLSRoutes       []*k8s_networking_v1alpha2.TLSRoute       `json:"k8sTLSRoutes"`
+	K8sReferenceGrants []*k8s_networking_v1alpha2.ReferenceGrant `json:"k8sReferenceGrants"`
 
 	AuthorizationPolicies  []*security_v1beta.AuthorizationPolicy   `json:"authorizationPolicies"`
 	PeerAuthentications    []*security_v1beta.PeerAuthentication    `json:"peerAuthentications"`
@@ -56,8 +60,12 @@ type IstioConfigDetails struct {
 	WasmPlugin            *extentions_v1alpha1.WasmPlugin        `json:"wasmPlugin"`
 	Telemetry             *v1alpha1.Telemetry                    `json:"telemetry"`
 
-	K8sGateway   *k8s_networking_v1alpha2.Gateway   `json:"k8sGateway"`
-	K8sHTTPRoute *k8s_networking_v1alpha2.HTTPRoute `json:"k8sHTTPRoute"`
+	K8sGateway        *k8s_networking_v1alpha2.Gateway        `json:"k8sGateway"`
+	K8sHTTPRoute      *k8s_networking_v1alpha2.HTTPRoute      `json:"k8sHTTPRoute"`
+	K8sGRPCRoute      *k8s_networking_v1alpha2.GRPCRoute      `json:"k8sGRPCRoute"`
+	K8sTCPRoute       *k8s_networking_v1alpha2.TCPRoute       `json:"k8sTCPRoute"`
+	K8sTLSRoute       *k8s_networking_v1alpha2.TLSRoute       `json:"k8sTLSRoute"`
+	K8sReferenceGrant *k8s_networking_v1alpha2.ReferenceGrant `json:"k8sReferenceGrant"`
 
 	Permissions           ResourcePermissions `json:"permissions"`
 	IstioValidation       *IstioValidation    `json:"validation"`
@@ -168,6 +176,27 @@ var IstioConfigHelpMessages = map[string][]IstioConfigHelp{
 	"k8shttproutes": { //TODO
 		{ObjectField: "", Message: "Kubernetes Gateway API Configuration Object. HTTPRoute is for multiplexing HTTP or terminated HTTPS connections."},
 	},
+	"k8sgrpcroutes": {
+		{ObjectField: "", Message: "Kubernetes Gateway API Configuration Object. GRPCRoute is for routing gRPC requests to Services."},
+		{ObjectField: "spec.parentRefs", Message: "Define the Gateways this route wants to be attached to."},
+		{ObjectField: "spec.rules", Message: "Define the matching conditions on the gRPC method and headers and the backends to send the requests to."},
+	},
+	"k8stcproutes": {
+		{ObjectField: "", Message: "Kubernetes Gateway API Configuration Object. TCPRoute is for forwarding TCP connections to Services."},
+		{ObjectField: "spec.parentRefs", Message: "Define the Gateways this route wants to be attached to."},
+		{ObjectField: "spec.rules", Message: "Define the backends to forward the connections to."},
+	},
+	"k8stlsroutes": {
+		{ObjectField: "", Message: "Kubernetes Gateway API Configuration Object. TLSRoute is for routing TLS connections by SNI without terminating them."},
+		{ObjectField: "spec.parentRefs", Message: "Define the Gateways this route wants to be attached to."},
+		{ObjectField: "spec.hostnames", Message: "Define the SNI hostnames matched by this route."},
+		{ObjectField: "spec.rules", Message: "Define the backends to forward the connections to."},
+	},
+	"k8sreferencegrants": {
+		{ObjectField: "", Message: "Kubernetes Gateway API Configuration Object. ReferenceGrant allows the objects of other namespaces to reference the objects of this namespace."},
+		{ObjectField: "spec.from", Message: "Define the group, kind and namespace of the objects allowed to reference the objects of this namespace."},
+		{ObjectField: "spec.to", Message: "Define the group and kind, and optionally the name, of the objects of this namespace that can be referenced."},
+	},
 	"internal": {
 		{ObjectField: "", Message: "Internal resources are not editable"},
 	},
@@ -204,6 +233,10 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			filtered[ns].Gateways = []*networking_v1beta1.Gateway{}
 			filtered[ns].K8sGateways = []*k8s_networking_v1alpha2.Gateway{}
 			filtered[ns].K8sHTTPRoutes = []*k8s_networking_v1alpha2.HTTPRoute{}
+			filtered[ns].K8sGRPCRoutes = []*k8s_networking_v1alpha2.GRPCRoute{}
+			filtered[ns].K8sTCPRoutes = []*k8s_networking_v1alpha2.TCPRoute{}
+			filtered[ns].K8sTLSRoutes = []*k8s_networking_v1alpha2.TLSRoute{}
+			filtered[ns].K8sReferenceGrants = []*k8s_networking_v1alpha2.ReferenceGrant{}
 			filtered[ns].VirtualServices = []*networking_v1beta1.VirtualService{}
 			filtered[ns].ServiceEntries = []*networking_v1beta1.ServiceEntry{}
 			filtered[ns].Sidecars = []*networking_v1beta1.Sidecar{}
@@ -245,6 +278,30 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			}
 		}
 
+		for _, o := range configList.K8sGRPCRoutes {
+			if o.Namespace == ns {
+				filtered[ns].K8sGRPCRoutes = append(filtered[ns].K8sGRPCRoutes, o)
+			}
+		}
+
+		for _, o := range configList.K8sTCPRoutes {
+			if o.Namespace == ns {
+				filtered[ns].K8sTCPRoutes = append(filtered[ns].K8sTCPRoutes, o)
+			}
+		}
+
+		for _, o := range configList.K8sTLSRoutes {
+			if o.Namespace == ns {
+				filtered[ns].K8sTLSRoutes = append(filtered[ns].K8sTLSRoutes, o)
+			}
+		}
+
+		for _, o := range configList.K8sReferenceGrants {
+			if o.Namespace == ns {
+				filtered[ns].K8sReferenceGrants = append(filtered[ns].K8sReferenceGrants, o)
+			}
+		}
+
 		for _, se := range configList.ServiceEntries {
 			if se.Namespace == ns {
 				filtered[ns].ServiceEntries = append(filtered[ns].ServiceEntries, se)
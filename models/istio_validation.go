@@ -64,6 +64,18 @@ type IstioValidation struct {
 	References []IstioValidationKey `json:"references"`
 }
 
+// IstioValidationsDryRun represents the validations of a proposed change of an Istio object, which is not persisted.
+// swagger:model
+type IstioValidationsDryRun struct {
+	// Validations of the proposed object
+	// required: true
+	Validations IstioValidations `json:"validations"`
+
+	// Validations of other objects that would get new checks after the change
+	// required: true
+	NewlyBroken IstioValidations `json:"newlyBroken"`
+}
+
 // IstioCheck represents an individual check.
 // swagger:model
 type IstioCheck struct {
@@ -99,6 +111,7 @@ var ObjectTypeSingular = map[string]string{
 	"gateways":               "gateway",
 	"virtualservices":        "virtualservice",
 	"destinationrules":       "destinationrule",
+	"envoyfilters":           "envoyfilter",
 	"serviceentries":         "serviceentry",
 	"rules":                  "rule",
 	"quotaspecs":             "quotaspec",
@@ -112,9 +125,13 @@ var ObjectTypeSingular = map[string]string{
 	"peerauthentications":    "peerauthentication",
 	"requestauthentications": "requestauthentication",
 	"workloads":              "workload",
-	"wasmplugins":            "wasmpluin",
+	"wasmplugins":            "wasmplugin",
 	"telemetries":            "telemetry",
 	"k8shttproutes":          "k8shttproute",
+	"k8sgrpcroutes":          "k8sgrpcroute",
+	"k8stcproutes":           "k8stcproute",
+	"k8stlsroutes":           "k8stlsroute",
+	"k8sreferencegrants":     "k8sreferencegrant",
 	"k8sgateways":            "k8sgateway",
 }
 
@@ -189,6 +206,31 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This subset has not labels",
 		Severity: WarningSeverity,
 	},
+	"envoyfilter.patch.clusternotfound": {
+		Code:     "KIA1802",
+		Message:  "No cluster matching this patch found in the configuration of the selected proxies",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.patch.listenernotfound": {
+		Code:     "KIA1801",
+		Message:  "No listener matching this patch found in the configuration of the selected proxies",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.priority.conflict": {
+		Code:     "KIA1805",
+		Message:  "More than one EnvoyFilter with the same priority patching the same object",
+		Severity: WarningSeverity,
+	},
+	"envoyfilter.proxyversion.invalidregex": {
+		Code:     "KIA1804",
+		Message:  "Proxy version is not a valid regular expression",
+		Severity: ErrorSeverity,
+	},
+	"envoyfilter.proxyversion.nomatch": {
+		Code:     "KIA1803",
+		Message:  "Proxy version doesn't match the version of any selected proxy, the patch is not applied",
+		Severity: WarningSeverity,
+	},
 	"gateways.multimatch": {
 		Code:     "KIA0301",
 		Message:  "More than one Gateway for the same host port combination",
@@ -219,9 +261,19 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "No matching workload found for the selector in this namespace",
 		Severity: WarningSeverity,
 	},
-	"k8shttproutes.nok8sgateway": {
+	"k8sroutes.nok8sgateway": {
 		Code:     "KIA1401",
-		Message:  "HTTPRoute is pointing to a non-existent K8s gateway",
+		Message:  "Route is pointing to a non-existent K8s gateway",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nohost.namenotfound": {
+		Code:     "KIA1402",
+		Message:  "BackendRef on rule doesn't have a valid service (Service name not found)",
+		Severity: ErrorSeverity,
+	},
+	"k8sroutes.nohost.referencegrantnotfound": {
+		Code:     "KIA1403",
+		Message:  "BackendRef to a Service of another namespace is not allowed by any ReferenceGrant",
 		Severity: ErrorSeverity,
 	},
 	"peerauthentication.mtls.destinationrulemissing": {
@@ -274,6 +326,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Global default sidecar should not have workloadSelector",
 		Severity: WarningSeverity,
 	},
+	"telemetry.accesslogging.providernotfound": {
+		Code:     "KIA1602",
+		Message:  "Access logging provider not found in the MeshConfig extension providers",
+		Severity: ErrorSeverity,
+	},
+	"telemetry.meshwide.override": {
+		Code:     "KIA1603",
+		Message:  "Namespace-wide Telemetry overrides the providers of the mesh-wide Telemetry",
+		Severity: WarningSeverity,
+	},
+	"telemetry.tracing.providernotfound": {
+		Code:     "KIA1601",
+		Message:  "Tracing provider not found in the MeshConfig extension providers",
+		Severity: ErrorSeverity,
+	},
 	"virtualservices.gateway.oldnomenclature": {
 		Code:     "KIA1108",
 		Message:  "Preferred nomenclature: <gateway namespace>/<gateway name>",
@@ -299,6 +366,16 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "This host subset combination is already referenced in another route destination",
 		Severity: WarningSeverity,
 	},
+	"virtualservices.route.shadowed": {
+		Code:     "KIA1109",
+		Message:  "This route is unreachable, a previous route matches all its requests",
+		Severity: WarningSeverity,
+	},
+	"virtualservices.route.duplicatematch": {
+		Code:     "KIA1110",
+		Message:  "This match is identical to a previous one",
+		Severity: WarningSeverity,
+	},
 	"virtualservices.singlehost": {
 		Code:     "KIA1106",
 		Message:  "More than one Virtual Service for same host",
@@ -309,6 +386,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Subset not found",
 		Severity: WarningSeverity,
 	},
+	"wasmplugin.imagepullsecret.notfound": {
+		Code:     "KIA1702",
+		Message:  "Image pull secret not found in the namespace",
+		Severity: ErrorSeverity,
+	},
+	"wasmplugin.phasepriority.conflict": {
+		Code:     "KIA1701",
+		Message:  "More than one WasmPlugin with the same phase and priority applied to the same workload",
+		Severity: WarningSeverity,
+	},
+	"wasmplugin.url.invalidscheme": {
+		Code:     "KIA1703",
+		Message:  "URL must be a valid oci://, file://, http:// or https:// URL",
+		Severity: ErrorSeverity,
+	},
 	"workload.authorizationpolicy.needstobecovered": {
 		Code:     "KIA1301",
 		Message:  "This workload is not covered by any authorization policy",
@@ -324,6 +416,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "More than one K8s Gateway for the same address and type combination",
 		Severity: WarningSeverity,
 	},
+	"k8sreferencegrants.from.namespacenotfound": {
+		Code:     "KIA1901",
+		Message:  "Namespace not found for this ReferenceGrant from",
+		Severity: WarningSeverity,
+	},
 }
 
 func Build(checkId string, path string) IstioCheck {
@@ -344,6 +441,16 @@ func CheckMessage(checkId string) string {
 	}
 }
 
+// IsCheckCode returns true when the code belongs to a Kiali check, e.g. KIA0101
+func IsCheckCode(code string) bool {
+	for _, check := range checkDescriptors {
+		if check.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
 func (ic IstioCheck) GetFullMessage() string {
 	return ic.Code + " " + ic.Message
 }
@@ -18,6 +18,7 @@ import (
 // IstioMetricsQuery holds query parameters for a typical metrics query
 type IstioMetricsQuery struct {
 	prometheus.RangeQuery
+	Cluster         string
 	Filters         []string
 	Namespace       string
 	App             string
@@ -9,6 +9,12 @@ import (
 	"github.com/kiali/kiali/config"
 )
 
+// The annotation set by the Istio CNI plugin on the pods captured by the ambient mesh
+const (
+	AmbientRedirectionAnnotation = "ambient.istio.io/redirection"
+	AmbientRedirectionEnabled    = "enabled"
+)
+
 // Pods alias for list of Pod structs
 type Pods []*Pod
 
@@ -29,6 +35,7 @@ type Pod struct {
 	Annotations         map[string]string `json:"annotations"`
 	ProxyStatus         *ProxyStatus      `json:"proxyStatus"`
 	ServiceAccountName  string            `json:"serviceAccountName"`
+	NodeName            string            `json:"nodeName"`
 }
 
 // Reference holds some information on the pod creator
@@ -123,6 +130,7 @@ func (pod *Pod) Parse(p *core_v1.Pod) {
 	_, pod.AppLabel = p.Labels[conf.IstioLabels.AppLabelName]
 	_, pod.VersionLabel = p.Labels[conf.IstioLabels.VersionLabelName]
 	pod.ServiceAccountName = p.Spec.ServiceAccountName
+	pod.NodeName = p.Spec.NodeName
 }
 
 func isIstioProxy(pod *core_v1.Pod, container *core_v1.Container, conf *config.Config) bool {
@@ -187,6 +195,25 @@ func (pod Pod) HasIstioSidecar() bool {
 	return len(pod.IstioContainers) > 0
 }
 
+// IsAmbient returns true if the traffic of the pod is captured by the ambient mesh (ztunnel), as annotated by
+// the Istio CNI plugin
+func (pod Pod) IsAmbient() bool {
+	return pod.Annotations[AmbientRedirectionAnnotation] == AmbientRedirectionEnabled
+}
+
+// IsAmbient returns true if there are pods and all of the pods are captured by the ambient mesh
+func (pods Pods) IsAmbient() bool {
+	if len(pods) == 0 {
+		return false
+	}
+	for _, p := range pods {
+		if !p.IsAmbient() {
+			return false
+		}
+	}
+	return true
+}
+
 // SyncedPodsCount returns the number of Pods with its proxy synced
 // If none of the pods have Istio Sidecar, then return -1
 func (pods Pods) SyncedPodProxiesCount() int32 {
@@ -0,0 +1,88 @@
+package models
+
+import (
+	api_networking_v1beta1 "istio.io/api/networking/v1beta1"
+)
+
+// RouteSimulationRequest describes a request sent to a host, to be resolved against the VirtualServices
+// and DestinationRules of the mesh.
+//
+// swagger:model RouteSimulationRequest
+type RouteSimulationRequest struct {
+	// Host of the request, short names are resolved in the namespace of the endpoint
+	// example: reviews
+	Host string `json:"host"`
+	// Gateway that receives the request, as namespace/name. The sidecars of the mesh are used when it is empty.
+	// example: istio-system/bookinfo-gateway
+	Gateway string `json:"gateway,omitempty"`
+	// example: /reviews/1
+	Uri string `json:"uri,omitempty"`
+	// example: GET
+	Method string `json:"method,omitempty"`
+	// example: http
+	Scheme string `json:"scheme,omitempty"`
+	// example: 9080
+	Port        uint32            `json:"port,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryParams map[string]string `json:"queryParams,omitempty"`
+	// Labels of the source workload
+	SourceLabels map[string]string `json:"sourceLabels,omitempty"`
+	// Namespace of the source workload, taken from the endpoint path
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+}
+
+// RouteSimulationResult is the route taken by a simulated request.
+//
+// swagger:model RouteSimulationResult
+type RouteSimulationResult struct {
+	// VirtualService that handles the host, nil when the request is sent to the host without VirtualService
+	VirtualService *IstioObjectReference `json:"virtualService,omitempty"`
+	// Route that matches the request, nil when no route matches
+	Route *RouteSimulationRoute `json:"route,omitempty"`
+	// Destinations of the matched route, with their weights and traffic policies
+	Destinations []RouteSimulationDestination `json:"destinations"`
+	// Unreachable lists the routes of the VirtualService that can't match any request,
+	// because the match of an earlier route is a superset of theirs
+	Unreachable []RouteSimulationRoute `json:"unreachable"`
+	// Reason explains the result
+	// example: matched spec/http[1]/match[0] of VirtualService bookinfo/reviews
+	Reason string `json:"reason"`
+}
+
+// IstioObjectReference identifies an Istio object.
+type IstioObjectReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// RouteSimulationRoute identifies an http route of a VirtualService.
+type RouteSimulationRoute struct {
+	// Index of the route in the http routes
+	Index int    `json:"index"`
+	Name  string `json:"name,omitempty"`
+	// Match is the index of the match block that matched, -1 when the route has no match blocks
+	Match int `json:"match"`
+	// Path of the route, in the same format as the validation checks
+	// example: spec/http[1]
+	Path string `json:"path"`
+	// ShadowedBy is the path of the route whose match shadows this one, only for unreachable routes
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+	// Redirect is true when the route answers with a redirect instead of forwarding the request
+	Redirect bool `json:"redirect,omitempty"`
+	// DirectResponse is true when the route answers without forwarding the request
+	DirectResponse bool `json:"directResponse,omitempty"`
+}
+
+// RouteSimulationDestination is a weighted destination of the matched route.
+type RouteSimulationDestination struct {
+	// example: reviews.bookinfo.svc.cluster.local
+	Host   string `json:"host"`
+	Subset string `json:"subset,omitempty"`
+	Port   uint32 `json:"port,omitempty"`
+	// example: 80
+	Weight int32 `json:"weight"`
+	// DestinationRule that defines the traffic policy of the destination, if any
+	DestinationRule *IstioObjectReference `json:"destinationRule,omitempty"`
+	// TrafficPolicy resolved from the DestinationRule, its subset and its port level settings
+	TrafficPolicy *api_networking_v1beta1.TrafficPolicy `json:"trafficPolicy,omitempty"`
+}
@@ -31,6 +31,8 @@ type ServiceOverview struct {
 	AdditionalDetailSample *AdditionalItem `json:"additionalDetailSample"`
 	// Annotations of the service
 	HealthAnnotations map[string]string `json:"healthAnnotations"`
+	// SLO annotations of the service, read by the graph and not part of the health of the service
+	SLOAnnotations map[string]string `json:"-"`
 	// Names and Ports of Service
 	Ports map[string]int `json:"ports"`
 	// Labels for Service
@@ -59,6 +59,11 @@ type WorkloadListItem struct {
 	// example: true
 	IstioSidecar bool `json:"istioSidecar"`
 
+	// Define if all Pods related to this Workload are captured by the ambient mesh, instead of a sidecar
+	// required: false
+	// example: false
+	IsAmbient bool `json:"isAmbient"`
+
 	// Additional item sample, such as type of api being served (graphql, grpc, rest)
 	// example: rest
 	// required: false
@@ -148,6 +153,7 @@ func (workload *WorkloadListItem) ParseWorkload(w *Workload) {
 	workload.CreatedAt = w.CreatedAt
 	workload.ResourceVersion = w.ResourceVersion
 	workload.IstioSidecar = w.HasIstioSidecar()
+	workload.IsAmbient = w.Pods.IsAmbient()
 	workload.Labels = w.Labels
 	workload.PodCount = len(w.Pods)
 	workload.ServiceAccountNames = w.Pods.ServiceAccounts()
@@ -381,6 +387,7 @@ func (workload *Workload) ParsePods(controllerName string, controllerType string
 func (workload *Workload) SetPods(pods []core_v1.Pod) {
 	workload.Pods.Parse(pods)
 	workload.IstioSidecar = workload.HasIstioSidecar()
+	workload.IsAmbient = workload.Pods.IsAmbient()
 }
 
 func (workload *Workload) SetServices(svcs *ServiceList) {
@@ -23,16 +23,16 @@ type (
 	}
 
 	PromCache interface {
-		GetAllRequestRates(namespace string, ratesInterval string, queryTime time.Time) (bool, model.Vector)
-		GetAppRequestRates(namespace, app, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector)
-		GetNamespaceServicesRequestRates(namespace string, ratesInterval string, queryTime time.Time) (bool, model.Vector)
-		GetServiceRequestRates(namespace, service, ratesInterval string, queryTime time.Time) (bool, model.Vector)
-		GetWorkloadRequestRates(namespace, workload, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector)
-		SetAllRequestRates(namespace string, ratesInterval string, queryTime time.Time, inResult model.Vector)
-		SetAppRequestRates(namespace, app, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector)
-		SetNamespaceServicesRequestRates(namespace string, ratesInterval string, queryTime time.Time, inResult model.Vector)
-		SetServiceRequestRates(namespace, service, ratesInterval string, queryTime time.Time, inResult model.Vector)
-		SetWorkloadRequestRates(namespace, workload, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector)
+		GetAllRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time) (bool, model.Vector)
+		GetAppRequestRates(namespace, cluster, app, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector)
+		GetNamespaceServicesRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time) (bool, model.Vector)
+		GetServiceRequestRates(namespace, cluster, service, ratesInterval string, queryTime time.Time) (bool, model.Vector)
+		GetWorkloadRequestRates(namespace, cluster, workload, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector)
+		SetAllRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time, inResult model.Vector)
+		SetAppRequestRates(namespace, cluster, app, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector)
+		SetNamespaceServicesRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time, inResult model.Vector)
+		SetServiceRequestRates(namespace, cluster, service, ratesInterval string, queryTime time.Time, inResult model.Vector)
+		SetWorkloadRequestRates(namespace, cluster, workload, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector)
 	}
 
 	promCacheImpl struct {
@@ -71,14 +71,15 @@ func NewPromCache() PromCache {
 	return &promCacheImpl
 }
 
-func (c *promCacheImpl) GetAllRequestRates(namespace string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
+func (c *promCacheImpl) GetAllRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
 	defer c.allRequestRatesLock.RUnlock()
 	c.allRequestRatesLock.RLock()
 
-	if nsRates, okNs := c.cacheAllRequestRates[namespace]; okNs {
+	key := cacheKey(namespace, cluster)
+	if nsRates, okNs := c.cacheAllRequestRates[key]; okNs {
 		if rtInterval, okRt := nsRates[ratesInterval]; okRt {
 			if !queryTime.Before(rtInterval.queryTime) && queryTime.Sub(rtInterval.queryTime) < c.cacheDuration {
-				log.Tracef("[Prom Cache] GetAllRequestRates [namespace: %s] [ratesInterval: %s] [queryTime: %s]", namespace, ratesInterval, queryTime.String())
+				log.Tracef("[Prom Cache] GetAllRequestRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
 				return true, rtInterval.inResult
 			}
 		}
@@ -86,30 +87,32 @@ func (c *promCacheImpl) GetAllRequestRates(namespace string, ratesInterval strin
 	return false, nil
 }
 
-func (c *promCacheImpl) SetAllRequestRates(namespace string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
+func (c *promCacheImpl) SetAllRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
 	defer c.allRequestRatesLock.Unlock()
 	c.allRequestRatesLock.Lock()
 
-	if _, okNs := c.cacheAllRequestRates[namespace]; !okNs {
-		c.cacheAllRequestRates[namespace] = make(map[string]timeInResult)
+	key := cacheKey(namespace, cluster)
+	if _, okNs := c.cacheAllRequestRates[key]; !okNs {
+		c.cacheAllRequestRates[key] = make(map[string]timeInResult)
 	}
 
-	c.cacheAllRequestRates[namespace][ratesInterval] = timeInResult{
+	c.cacheAllRequestRates[key][ratesInterval] = timeInResult{
 		queryTime: queryTime,
 		inResult:  inResult,
 	}
-	log.Tracef("[Prom Cache] SetAllRequestRates [namespace: %s] [ratesInterval: %s] [queryTime: %s]", namespace, ratesInterval, queryTime.String())
+	log.Tracef("[Prom Cache] SetAllRequestRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
 }
 
-func (c *promCacheImpl) GetAppRequestRates(namespace string, app string, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector) {
+func (c *promCacheImpl) GetAppRequestRates(namespace, cluster, app string, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector) {
 	defer c.appRequestRatesLock.RUnlock()
 	c.appRequestRatesLock.RLock()
 
-	if nsRates, okNs := c.cacheAppRequestRates[namespace]; okNs {
+	key := cacheKey(namespace, cluster)
+	if nsRates, okNs := c.cacheAppRequestRates[key]; okNs {
 		if appInterval, okApp := nsRates[app]; okApp {
 			if rtInterval, okRt := appInterval[ratesInterval]; okRt {
 				if !queryTime.Before(rtInterval.queryTime) && queryTime.Sub(rtInterval.queryTime) < c.cacheDuration {
-					log.Tracef("[Prom Cache] GetAppRequestRates [namespace: %s] [app: %s] [ratesInterval: %s] [queryTime: %s]", namespace, app, ratesInterval, queryTime.String())
+					log.Tracef("[Prom Cache] GetAppRequestRates [namespace: %s] [cluster: %s] [app: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, app, ratesInterval, queryTime.String())
 					return true, rtInterval.inResult, rtInterval.outResult
 				}
 			}
@@ -118,34 +121,36 @@ func (c *promCacheImpl) GetAppRequestRates(namespace string, app string, ratesIn
 	return false, nil, nil
 }
 
-func (c *promCacheImpl) SetAppRequestRates(namespace string, app string, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector) {
+func (c *promCacheImpl) SetAppRequestRates(namespace, cluster, app string, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector) {
 	defer c.appRequestRatesLock.Unlock()
 	c.appRequestRatesLock.Lock()
 
-	if _, okNs := c.cacheAppRequestRates[namespace]; !okNs {
-		c.cacheAppRequestRates[namespace] = make(map[string]map[string]timeInOutResult)
+	key := cacheKey(namespace, cluster)
+	if _, okNs := c.cacheAppRequestRates[key]; !okNs {
+		c.cacheAppRequestRates[key] = make(map[string]map[string]timeInOutResult)
 	}
 
-	if _, okApp := c.cacheAppRequestRates[namespace][app]; !okApp {
-		c.cacheAppRequestRates[namespace][app] = make(map[string]timeInOutResult)
+	if _, okApp := c.cacheAppRequestRates[key][app]; !okApp {
+		c.cacheAppRequestRates[key][app] = make(map[string]timeInOutResult)
 	}
 
-	c.cacheAppRequestRates[namespace][app][ratesInterval] = timeInOutResult{
+	c.cacheAppRequestRates[key][app][ratesInterval] = timeInOutResult{
 		queryTime: queryTime,
 		inResult:  inResult,
 		outResult: outResult,
 	}
-	log.Tracef("[Prom Cache] SetAppRequestRates [namespace: %s] [app: %s] [ratesInterval: %s] [queryTime: %s]", namespace, app, ratesInterval, queryTime.String())
+	log.Tracef("[Prom Cache] SetAppRequestRates [namespace: %s] [cluster: %s] [app: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, app, ratesInterval, queryTime.String())
 }
 
-func (c *promCacheImpl) GetNamespaceServicesRequestRates(namespace string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
+func (c *promCacheImpl) GetNamespaceServicesRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
 	defer c.nsSvcRequestRatesLock.RUnlock()
 	c.nsSvcRequestRatesLock.RLock()
 
-	if nsRates, okNs := c.cacheNsSvcRequestRates[namespace]; okNs {
+	key := cacheKey(namespace, cluster)
+	if nsRates, okNs := c.cacheNsSvcRequestRates[key]; okNs {
 		if rtInterval, okRt := nsRates[ratesInterval]; okRt {
 			if !queryTime.Before(rtInterval.queryTime) && queryTime.Sub(rtInterval.queryTime) < c.cacheDuration {
-				log.Tracef("[Prom Cache] GetNamespaceServicesRequestRates [namespace: %s] [ratesInterval: %s] [queryTime: %s]", namespace, ratesInterval, queryTime.String())
+				log.Tracef("[Prom Cache] GetNamespaceServicesRequestRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
 				return true, rtInterval.inResult
 			}
 		}
@@ -153,30 +158,32 @@ func (c *promCacheImpl) GetNamespaceServicesRequestRates(namespace string, rates
 	return false, nil
 }
 
-func (c *promCacheImpl) SetNamespaceServicesRequestRates(namespace string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
+func (c *promCacheImpl) SetNamespaceServicesRequestRates(namespace, cluster string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
 	defer c.nsSvcRequestRatesLock.Unlock()
 	c.nsSvcRequestRatesLock.Lock()
 
-	if _, okNs := c.cacheNsSvcRequestRates[namespace]; !okNs {
-		c.cacheNsSvcRequestRates[namespace] = make(map[string]timeInResult)
+	key := cacheKey(namespace, cluster)
+	if _, okNs := c.cacheNsSvcRequestRates[key]; !okNs {
+		c.cacheNsSvcRequestRates[key] = make(map[string]timeInResult)
 	}
 
-	c.cacheNsSvcRequestRates[namespace][ratesInterval] = timeInResult{
+	c.cacheNsSvcRequestRates[key][ratesInterval] = timeInResult{
 		queryTime: queryTime,
 		inResult:  inResult,
 	}
-	log.Tracef("[Prom Cache] SetNamespaceServicesRequestRates [namespace: %s] [ratesInterval: %s] [queryTime: %s]", namespace, ratesInterval, queryTime.String())
+	log.Tracef("[Prom Cache] SetNamespaceServicesRequestRates [namespace: %s] [cluster: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, ratesInterval, queryTime.String())
 }
 
-func (c *promCacheImpl) GetServiceRequestRates(namespace string, service string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
+func (c *promCacheImpl) GetServiceRequestRates(namespace, cluster, service string, ratesInterval string, queryTime time.Time) (bool, model.Vector) {
 	defer c.svcRequestRatesLock.RUnlock()
 	c.svcRequestRatesLock.RLock()
 
-	if nsRates, okNs := c.cacheSvcRequestRates[namespace]; okNs {
+	key := cacheKey(namespace, cluster)
+	if nsRates, okNs := c.cacheSvcRequestRates[key]; okNs {
 		if svcInterval, okSvc := nsRates[service]; okSvc {
 			if rtInterval, okRt := svcInterval[ratesInterval]; okRt {
 				if !queryTime.Before(rtInterval.queryTime) && queryTime.Sub(rtInterval.queryTime) < c.cacheDuration {
-					log.Tracef("[Prom Cache] GetServiceRequestRates [namespace: %s] [service: %s] [ratesInterval: %s] [queryTime: %s]", namespace, service, ratesInterval, queryTime.String())
+					log.Tracef("[Prom Cache] GetServiceRequestRates [namespace: %s] [cluster: %s] [service: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, service, ratesInterval, queryTime.String())
 					return true, rtInterval.inResult
 				}
 			}
@@ -185,34 +192,36 @@ func (c *promCacheImpl) GetServiceRequestRates(namespace string, service string,
 	return false, nil
 }
 
-func (c *promCacheImpl) SetServiceRequestRates(namespace string, service string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
+func (c *promCacheImpl) SetServiceRequestRates(namespace, cluster, service string, ratesInterval string, queryTime time.Time, inResult model.Vector) {
 	defer c.svcRequestRatesLock.Unlock()
 	c.svcRequestRatesLock.Lock()
 
-	if _, okNs := c.cacheSvcRequestRates[namespace]; !okNs {
-		c.cacheSvcRequestRates[namespace] = make(map[string]map[string]timeInResult)
+	key := cacheKey(namespace, cluster)
+	if _, okNs := c.cacheSvcRequestRates[key]; !okNs {
+		c.cacheSvcRequestRates[key] = make(map[string]map[string]timeInResult)
 	}
 
-	if _, okSvc := c.cacheSvcRequestRates[namespace][service]; !okSvc {
-		c.cacheSvcRequestRates[namespace][service] = make(map[string]timeInResult)
+	if _, okSvc := c.cacheSvcRequestRates[key][service]; !okSvc {
+		c.cacheSvcRequestRates[key][service] = make(map[string]timeInResult)
 	}
 
-	c.cacheSvcRequestRates[namespace][service][ratesInterval] = timeInResult{
+	c.cacheSvcRequestRates[key][service][ratesInterval] = timeInResult{
 		queryTime: queryTime,
 		inResult:  inResult,
 	}
-	log.Tracef("[Prom Cache] SetServiceRequestRates [namespace: %s] [service: %s] [ratesInterval: %s] [queryTime: %s]", namespace, service, ratesInterval, queryTime.String())
+	log.Tracef("[Prom Cache] SetServiceRequestRates [namespace: %s] [cluster: %s] [service: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, service, ratesInterval, queryTime.String())
 }
 
-func (c *promCacheImpl) GetWorkloadRequestRates(namespace string, workload string, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector) {
+func (c *promCacheImpl) GetWorkloadRequestRates(namespace, cluster, workload string, ratesInterval string, queryTime time.Time) (bool, model.Vector, model.Vector) {
 	defer c.wkRequestRatesLock.RUnlock()
 	c.wkRequestRatesLock.RLock()
 
-	if nsRates, okNs := c.cacheWkRequestRates[namespace]; okNs {
+	key := cacheKey(namespace, cluster)
+	if nsRates, okNs := c.cacheWkRequestRates[key]; okNs {
 		if wkInterval, okWk := nsRates[workload]; okWk {
 			if rtInterval, okRt := wkInterval[ratesInterval]; okRt {
 				if !queryTime.Before(rtInterval.queryTime) && queryTime.Sub(rtInterval.queryTime) < c.cacheDuration {
-					log.Tracef("[Prom Cache] GetWorkloadRequestRates [namespace: %s] [workload: %s] [ratesInterval: %s] [queryTime: %s]", namespace, workload, ratesInterval, queryTime.String())
+					log.Tracef("[Prom Cache] GetWorkloadRequestRates [namespace: %s] [cluster: %s] [workload: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, workload, ratesInterval, queryTime.String())
 					return true, rtInterval.inResult, rtInterval.outResult
 				}
 			}
@@ -221,24 +230,33 @@ func (c *promCacheImpl) GetWorkloadRequestRates(namespace string, workload strin
 	return false, nil, nil
 }
 
-func (c *promCacheImpl) SetWorkloadRequestRates(namespace string, workload string, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector) {
+func (c *promCacheImpl) SetWorkloadRequestRates(namespace, cluster, workload string, ratesInterval string, queryTime time.Time, inResult model.Vector, outResult model.Vector) {
 	defer c.wkRequestRatesLock.Unlock()
 	c.wkRequestRatesLock.Lock()
 
-	if _, okNs := c.cacheWkRequestRates[namespace]; !okNs {
-		c.cacheWkRequestRates[namespace] = make(map[string]map[string]timeInOutResult)
+	key := cacheKey(namespace, cluster)
+	if _, okNs := c.cacheWkRequestRates[key]; !okNs {
+		c.cacheWkRequestRates[key] = make(map[string]map[string]timeInOutResult)
 	}
 
-	if _, okApp := c.cacheWkRequestRates[namespace][workload]; !okApp {
-		c.cacheWkRequestRates[namespace][workload] = make(map[string]timeInOutResult)
+	if _, okApp := c.cacheWkRequestRates[key][workload]; !okApp {
+		c.cacheWkRequestRates[key][workload] = make(map[string]timeInOutResult)
 	}
 
-	c.cacheWkRequestRates[namespace][workload][ratesInterval] = timeInOutResult{
+	c.cacheWkRequestRates[key][workload][ratesInterval] = timeInOutResult{
 		queryTime: queryTime,
 		inResult:  inResult,
 		outResult: outResult,
 	}
-	log.Tracef("[Prom Cache] SetAppRequestRates [namespace: %s] [workload: %s] [ratesInterval: %s] [queryTime: %s]", namespace, workload, ratesInterval, queryTime.String())
+	log.Tracef("[Prom Cache] SetAppRequestRates [namespace: %s] [cluster: %s] [workload: %s] [ratesInterval: %s] [queryTime: %s]", namespace, cluster, workload, ratesInterval, queryTime.String())
+}
+
+// cacheKey returns the key of the rates of a namespace, selecting the cluster of the reporting proxies when not empty
+func cacheKey(namespace, cluster string) string {
+	if cluster == "" {
+		return namespace
+	}
+	return cluster + "/" + namespace
 }
 
 // Expiration is done globally, this cache is designed as short term, so in the worst case it would populated the queries
@@ -0,0 +1,282 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+var (
+	reporterRE        = regexp.MustCompile(`reporter="(source|destination)"`)
+	reporterClusterRE = regexp.MustCompile(`(source|destination)_cluster="([^"]*)"`)
+	// aggregationRE matches the aggregation operators, with their grouping clause when it precedes the operands
+	aggregationRE = regexp.MustCompile(`\b(?:sum|avg|min|max|count|stddev|stdvar|topk|bottomk|quantile|count_values|group)\s*(?:(by|without)\s*\(([^)]*)\))?\s*\(`)
+	groupingRE    = regexp.MustCompile(`^\s*(by|without)\s*\(([^)]*)\)`)
+)
+
+// FederatedAPI is a Prometheus V1 HTTP API federating the Prometheus of the clusters of a multi-cluster mesh,
+// when each cluster has its own Prometheus. The queries selecting a single cluster, the one of the reporting proxy
+// when they select a reporter, are routed to the Prometheus of that cluster: its proxies report the requests of
+// its workloads. The other queries are sent concurrently to all of them and their results are merged when each
+// series is computed by a single Prometheus, i.e. when the aggregations keep the cluster of the reporting proxy.
+// The series returned by several Prometheus are kept once. The queries which can't be merged, and the calls not
+// involving series, are sent to the default Prometheus.
+type FederatedAPI struct {
+	prom_v1.API
+	clusters map[string]prom_v1.API
+	names    []string // the sorted cluster names, for a stable merge order
+}
+
+// NewFederatedAPI returns a FederatedAPI for the given API of each cluster (key=cluster name), with defaultAPI
+// serving the calls not involving series.
+func NewFederatedAPI(defaultAPI prom_v1.API, clusters map[string]prom_v1.API) *FederatedAPI {
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &FederatedAPI{API: defaultAPI, clusters: clusters, names: names}
+}
+
+// Query implements prom_v1.API
+func (in *FederatedAPI) Query(ctx context.Context, query string, ts time.Time) (model.Value, prom_v1.Warnings, error) {
+	return in.federate(query, func(api prom_v1.API) (model.Value, prom_v1.Warnings, error) {
+		return api.Query(ctx, query, ts)
+	})
+}
+
+// QueryRange implements prom_v1.API
+func (in *FederatedAPI) QueryRange(ctx context.Context, query string, r prom_v1.Range) (model.Value, prom_v1.Warnings, error) {
+	return in.federate(query, func(api prom_v1.API) (model.Value, prom_v1.Warnings, error) {
+		return api.QueryRange(ctx, query, r)
+	})
+}
+
+// Series implements prom_v1.API
+func (in *FederatedAPI) Series(ctx context.Context, matches []string, startTime time.Time, endTime time.Time) ([]model.LabelSet, prom_v1.Warnings, error) {
+	type seriesResult struct {
+		series   []model.LabelSet
+		warnings prom_v1.Warnings
+		err      error
+	}
+
+	results := make([]seriesResult, len(in.names))
+	wg := sync.WaitGroup{}
+	for i, name := range in.names {
+		wg.Add(1)
+		go func(i int, api prom_v1.API) {
+			defer wg.Done()
+			series, warnings, err := api.Series(ctx, matches, startTime, endTime)
+			results[i] = seriesResult{series: series, warnings: warnings, err: err}
+		}(i, in.clusters[name])
+	}
+	wg.Wait()
+
+	var merged []model.LabelSet
+	var warnings prom_v1.Warnings
+	var err error
+	seen := make(map[model.Fingerprint]bool)
+	failed := 0
+	for i, r := range results {
+		warnings = append(warnings, r.warnings...)
+		if r.err != nil {
+			warnings = append(warnings, fmt.Sprintf("Prometheus of cluster [%s]: %v", in.names[i], r.err))
+			err = r.err
+			failed++
+			continue
+		}
+		for _, ls := range r.series {
+			if fp := ls.Fingerprint(); !seen[fp] {
+				seen[fp] = true
+				merged = append(merged, ls)
+			}
+		}
+	}
+	if failed == len(results) {
+		return nil, warnings, err
+	}
+	return merged, warnings, nil
+}
+
+// federate runs the query on the Prometheus of the selected cluster, concurrently on all of them when their results
+// can be merged, or else on the default Prometheus
+func (in *FederatedAPI) federate(query string, run func(api prom_v1.API) (model.Value, prom_v1.Warnings, error)) (model.Value, prom_v1.Warnings, error) {
+	if api, ok := in.clusters[queryCluster(query)]; ok {
+		return run(api)
+	}
+	if !mergeable(query) {
+		return run(in.API)
+	}
+
+	type queryResult struct {
+		value    model.Value
+		warnings prom_v1.Warnings
+		err      error
+	}
+
+	results := make([]queryResult, len(in.names))
+	wg := sync.WaitGroup{}
+	for i, name := range in.names {
+		wg.Add(1)
+		go func(i int, api prom_v1.API) {
+			defer wg.Done()
+			value, warnings, err := run(api)
+			results[i] = queryResult{value: value, warnings: warnings, err: err}
+		}(i, in.clusters[name])
+	}
+	wg.Wait()
+
+	var values []model.Value
+	var warnings prom_v1.Warnings
+	var err error
+	for i, r := range results {
+		warnings = append(warnings, r.warnings...)
+		if r.err != nil {
+			warnings = append(warnings, fmt.Sprintf("Prometheus of cluster [%s]: %v", in.names[i], r.err))
+			err = r.err
+			continue
+		}
+		values = append(values, r.value)
+	}
+	if len(values) == 0 {
+		return nil, warnings, err
+	}
+	return mergeValues(values), warnings, nil
+}
+
+// queryReporter returns the reporter selected by the query, empty if it doesn't select a single one
+func queryReporter(query string) string {
+	reporter := ""
+	for _, m := range reporterRE.FindAllStringSubmatch(query, -1) {
+		if reporter != "" && reporter != m[1] {
+			return ""
+		}
+		reporter = m[1]
+	}
+	return reporter
+}
+
+// queryCluster returns the cluster selected by the query, the cluster of the reporting proxy when the query
+// selects a reporter, empty if the query doesn't select a single cluster.
+func queryCluster(query string) string {
+	reporter := queryReporter(query)
+	cluster := ""
+	for _, m := range reporterClusterRE.FindAllStringSubmatch(query, -1) {
+		if reporter != "" && m[1] != reporter {
+			continue
+		}
+		if cluster != "" && cluster != m[2] {
+			return ""
+		}
+		cluster = m[2]
+	}
+	return cluster
+}
+
+// mergeable returns true when the results of the query on several Prometheus can be merged, i.e. when every
+// aggregation of the query groups by the cluster of the reporting proxy, so that a series is computed from the
+// samples of a single Prometheus. Without a selected reporter, the reporter and both cluster labels are needed.
+func mergeable(query string) bool {
+	clusterLabels := []string{"reporter", "source_cluster", "destination_cluster"}
+	if reporter := queryReporter(query); reporter != "" {
+		clusterLabels = []string{reporter + "_cluster"}
+	}
+
+	for _, loc := range aggregationRE.FindAllStringSubmatchIndex(query, -1) {
+		var grouping, labels string
+		if loc[2] >= 0 {
+			grouping, labels = query[loc[2]:loc[3]], query[loc[4]:loc[5]]
+		} else if m := groupingRE.FindStringSubmatch(query[closingParen(query, loc[1]):]); m != nil {
+			// the grouping clause follows the operands
+			grouping, labels = m[1], m[2]
+		}
+		if !groupsBy(grouping, labels, clusterLabels) {
+			return false
+		}
+	}
+	return true
+}
+
+// closingParen returns the position following the parenthesis closing the one opened before start
+func closingParen(query string, start int) int {
+	depth, quoted := 1, false
+	for i := start; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '"' && (i == 0 || query[i-1] != '\\'):
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(query)
+}
+
+// groupsBy returns true when the grouping clause keeps all the given labels
+func groupsBy(grouping, labels string, wanted []string) bool {
+	grouped := make(map[string]bool)
+	for _, label := range strings.Split(labels, ",") {
+		grouped[strings.TrimSpace(label)] = true
+	}
+	for _, label := range wanted {
+		if grouping == "by" && !grouped[label] || grouping == "without" && grouped[label] {
+			return false
+		}
+	}
+	return grouping != ""
+}
+
+// mergeValues merges the query results of several Prometheus, keeping once the series returned by several of them,
+// from the first cluster in name order. The values which are not vectors or matrices can't be merged, the first
+// one is returned.
+func mergeValues(values []model.Value) model.Value {
+	switch values[0].(type) {
+	case model.Vector:
+		merged := model.Vector{}
+		seen := make(map[model.Fingerprint]bool)
+		for _, v := range values {
+			vector, ok := v.(model.Vector)
+			if !ok {
+				continue
+			}
+			for _, s := range vector {
+				if fp := s.Metric.Fingerprint(); !seen[fp] {
+					seen[fp] = true
+					merged = append(merged, s)
+				}
+			}
+		}
+		return merged
+	case model.Matrix:
+		merged := model.Matrix{}
+		seen := make(map[model.Fingerprint]bool)
+		for _, v := range values {
+			matrix, ok := v.(model.Matrix)
+			if !ok {
+				continue
+			}
+			for _, s := range matrix {
+				if fp := s.Metric.Fingerprint(); !seen[fp] {
+					seen[fp] = true
+					merged = append(merged, s)
+				}
+			}
+		}
+		return merged
+	default:
+		return values[0]
+	}
+}
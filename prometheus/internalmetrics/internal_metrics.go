@@ -23,6 +23,7 @@ const (
 	labelService          = "service"
 	labelType             = "type"
 	labelName             = "name"
+	labelResult           = "result"
 )
 
 // MetricsType defines all of Kiali's own internal metrics.
@@ -31,6 +32,8 @@ type MetricsType struct {
 	GraphGenerationTime            *prometheus.HistogramVec
 	GraphAppenderTime              *prometheus.HistogramVec
 	GraphMarshalTime               *prometheus.HistogramVec
+	GraphCacheRequests             *prometheus.CounterVec
+	GraphCacheSize                 *prometheus.GaugeVec
 	APIProcessingTime              *prometheus.HistogramVec
 	PrometheusProcessingTime       *prometheus.HistogramVec
 	KubernetesClients              *prometheus.GaugeVec
@@ -72,6 +75,20 @@ var Metrics = MetricsType{
 		},
 		[]string{labelGraphKind, labelGraphType, labelWithServiceNodes},
 	),
+	GraphCacheRequests: prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kiali_graph_cache_requests_total",
+			Help: "Counts the graph requests looked up in the graph cache, by result (hit or miss).",
+		},
+		[]string{labelGraphKind, labelResult},
+	),
+	GraphCacheSize: prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiali_graph_cache_size_bytes",
+			Help: "The estimated size of the graphs held by the graph cache.",
+		},
+		[]string{},
+	),
 	APIProcessingTime: prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "kiali_api_processing_duration_seconds",
@@ -175,6 +192,8 @@ func RegisterInternalMetrics() {
 		Metrics.GraphGenerationTime,
 		Metrics.GraphAppenderTime,
 		Metrics.GraphMarshalTime,
+		Metrics.GraphCacheRequests,
+		Metrics.GraphCacheSize,
 		Metrics.APIProcessingTime,
 		Metrics.PrometheusProcessingTime,
 		Metrics.KubernetesClients,
@@ -351,6 +370,24 @@ func GetSingleValidationProcessingTimePrometheusTimer(namespace string, objectTy
 	return timer
 }
 
+// CountGraphCacheRequest counts a graph request looked up in the graph cache, the hit rate is the
+// rate of the hits divided by the rate of all the requests
+func CountGraphCacheRequest(graphKind string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	Metrics.GraphCacheRequests.With(prometheus.Labels{
+		labelGraphKind: graphKind,
+		labelResult:    result,
+	}).Inc()
+}
+
+// SetGraphCacheSize sets the estimated size of the graph cache, in bytes
+func SetGraphCacheSize(size int64) {
+	Metrics.GraphCacheSize.With(prometheus.Labels{}).Set(float64(size))
+}
+
 func GetAPIFailureMetric(route string) prometheus.Counter {
 	return Metrics.APIFailures.With(prometheus.Labels{
 		labelRoute: route,
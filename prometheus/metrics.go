@@ -110,15 +110,15 @@ func fetchRange(ctx context.Context, api prom_v1.API, query string, bounds prom_
 // getAllRequestRates retrieves traffic rates for requests entering, internal to, or exiting the namespace.
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
-func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
+func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	// traffic originating outside the namespace to destinations inside the namespace
-	lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s"`, namespace, namespace)
+	lbl := fmt.Sprintf(`destination_service_namespace="%s",source_workload_namespace!="%s"`, namespace, namespace) + clusterLabel("destination", cluster)
 	fromOutside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
 	}
 	// traffic originating inside the namespace to destinations inside or outside the namespace
-	lbl = fmt.Sprintf(`source_workload_namespace="%s"`, namespace)
+	lbl = fmt.Sprintf(`source_workload_namespace="%s"`, namespace) + clusterLabel("source", cluster)
 	fromInside, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
@@ -131,9 +131,9 @@ func getAllRequestRates(ctx context.Context, api prom_v1.API, namespace string,
 // getNamespaceServicesRequestRates retrieves traffic rates for requests entering or internal to the namespace.
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
-func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, namespace string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
+func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
 	// traffic for the namespace services
-	lblNs := fmt.Sprintf(`destination_service_namespace="%s"`, namespace)
+	lblNs := fmt.Sprintf(`destination_service_namespace="%s"`, namespace) + clusterLabel("destination", cluster)
 	ns, err := getRequestRatesForLabel(ctx, api, queryTime, lblNs, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
@@ -144,8 +144,8 @@ func getNamespaceServicesRequestRates(ctx context.Context, api prom_v1.API, name
 // getServiceRequestRates retrieves traffic rates for requests entering, or internal to the namespace, for a specific service name
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
-func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, service string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
-	lbl := fmt.Sprintf(`destination_service_name="%s",destination_service_namespace="%s"`, service, namespace)
+func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, service string, queryTime time.Time, ratesInterval string) (model.Vector, error) {
+	lbl := fmt.Sprintf(`destination_service_name="%s",destination_service_namespace="%s"`, service, namespace) + clusterLabel("destination", cluster)
 	in, err := getRequestRatesForLabel(ctx, api, queryTime, lbl, ratesInterval)
 	if err != nil {
 		return model.Vector{}, err
@@ -156,9 +156,9 @@ func getServiceRequestRates(ctx context.Context, api prom_v1.API, namespace, ser
 // getItemRequestRates retrieves traffic rates for requests entering, internal to, or exiting the namespace, for a specific destinatation_<itemLabelSuffix> value
 // Note that it does not discriminate on "reporter", so rates can be inflated due to duplication, and therefore
 // should be used mainly for calculating ratios (e.g total rates / error rates)
-func getItemRequestRates(ctx context.Context, api prom_v1.API, namespace, item, itemLabelSuffix string, queryTime time.Time, ratesInterval string) (model.Vector, model.Vector, error) {
-	lblIn := fmt.Sprintf(`destination_workload_namespace="%s",destination_%s="%s"`, namespace, itemLabelSuffix, item)
-	lblOut := fmt.Sprintf(`source_workload_namespace="%s",source_%s="%s"`, namespace, itemLabelSuffix, item)
+func getItemRequestRates(ctx context.Context, api prom_v1.API, namespace, cluster, item, itemLabelSuffix string, queryTime time.Time, ratesInterval string) (model.Vector, model.Vector, error) {
+	lblIn := fmt.Sprintf(`destination_workload_namespace="%s",destination_%s="%s"`, namespace, itemLabelSuffix, item) + clusterLabel("destination", cluster)
+	lblOut := fmt.Sprintf(`source_workload_namespace="%s",source_%s="%s"`, namespace, itemLabelSuffix, item) + clusterLabel("source", cluster)
 	in, err := getRequestRatesForLabel(ctx, api, queryTime, lblIn, ratesInterval)
 	if err != nil {
 		return model.Vector{}, model.Vector{}, err
@@ -170,6 +170,15 @@ func getItemRequestRates(ctx context.Context, api prom_v1.API, namespace, item,
 	return in, out, nil
 }
 
+// clusterLabel returns the selector of the cluster of the given side, to append to the other selectors, or an
+// empty string when the cluster is unknown
+func clusterLabel(side, cluster string) string {
+	if cluster == "" {
+		return ""
+	}
+	return fmt.Sprintf(`,%s_cluster="%s"`, side, cluster)
+}
+
 func getRequestRatesForLabel(ctx context.Context, api prom_v1.API, time time.Time, labels, ratesInterval string) (model.Vector, error) {
 	query := fmt.Sprintf("rate(istio_requests_total{%s}[%s]) > 0", labels, ratesInterval)
 	log.Tracef("[Prom] getRequestRatesForLabel: %s", query)
@@ -50,7 +50,7 @@ func TestGetAllRequestRates(t *testing.T) {
 	}
 	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace="ns"}[5m]) > 0`, &queryTime, vectorQ2)
 
-	rates, _ := client.GetAllRequestRates("ns", "5m", queryTime)
+	rates, _ := client.GetAllRequestRates("ns", "", "5m", queryTime)
 	assert.Equal(t, 2, rates.Len())
 	assert.Equal(t, vectorQ1[0], rates[0])
 	assert.Equal(t, vectorQ2[0], rates[1])
@@ -82,7 +82,7 @@ func TestGetAllRequestRatesIstioSystem(t *testing.T) {
 	}
 	api.OnQueryTime(`rate(istio_requests_total{source_workload_namespace="istio-system"}[5m]) > 0`, &queryTime, vectorQ2)
 
-	rates, _ := client.GetAllRequestRates("istio-system", "5m", queryTime)
+	rates, _ := client.GetAllRequestRates("istio-system", "", "5m", queryTime)
 	assert.Equal(t, 2, rates.Len())
 	assert.Equal(t, vectorQ1[0], rates[0])
 	assert.Equal(t, vectorQ2[0], rates[1])
@@ -106,7 +106,28 @@ func TestGetNamespaceServicesRequestRates(t *testing.T) {
 	}
 	api.OnQueryTime(`rate(istio_requests_total{destination_service_namespace="ns"}[5m]) > 0`, &queryTime, vectorQ1)
 
-	rates, _ := client.GetNamespaceServicesRequestRates("ns", "5m", queryTime)
+	rates, _ := client.GetNamespaceServicesRequestRates("ns", "", "5m", queryTime)
+	assert.Equal(t, 1, rates.Len())
+	assert.Equal(t, vectorQ1[0], rates[0])
+}
+
+func TestGetNamespaceServicesRequestRatesOfCluster(t *testing.T) {
+	client, api, err := setupMocked()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	queryTime := time.Unix(1000, 0)
+	vectorQ1 := model.Vector{
+		&model.Sample{
+			Timestamp: model.Now(),
+			Value:     model.SampleValue(1),
+			Metric:    model.Metric{"foo": "bar"},
+		},
+	}
+	api.On("Query", mock.Anything, `rate(istio_requests_total{destination_service_namespace="ns",destination_cluster="east"}[5m]) > 0`, queryTime).Return(vectorQ1, nil)
+
+	rates, _ := client.GetNamespaceServicesRequestRates("ns", "east", "5m", queryTime)
 	assert.Equal(t, 1, rates.Len())
 	assert.Equal(t, vectorQ1[0], rates[0])
 }
@@ -0,0 +1,170 @@
+package prometheustest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kiali/kiali/prometheus"
+)
+
+func sample(value float64, labels ...string) *model.Sample {
+	metric := model.Metric{}
+	for i := 0; i < len(labels); i += 2 {
+		metric[model.LabelName(labels[i])] = model.LabelValue(labels[i+1])
+	}
+	return &model.Sample{Metric: metric, Value: model.SampleValue(value)}
+}
+
+// unavailableAPI is the API of a Prometheus that can't be reached
+type unavailableAPI struct {
+	PromAPIMock
+}
+
+func (o *unavailableAPI) Query(ctx context.Context, query string, ts time.Time) (model.Value, prom_v1.Warnings, error) {
+	return nil, nil, errors.New("unavailable")
+}
+
+func setupFederated() (*prometheus.FederatedAPI, *PromAPIMock, *PromAPIMock) {
+	east := new(PromAPIMock)
+	west := new(PromAPIMock)
+	federated := prometheus.NewFederatedAPI(new(PromAPIMock), map[string]prom_v1.API{"east": east, "west": west})
+	return federated, east, west
+}
+
+func TestFederatedQueryMerges(t *testing.T) {
+	assert := assert.New(t)
+	federated, east, west := setupFederated()
+
+	query := `sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="bookinfo"}[60s])) by (destination_cluster)`
+	east.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "destination_cluster", "east")})
+	west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(2, "destination_cluster", "west")})
+
+	value, _, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(1, "destination_cluster", "east"), sample(2, "destination_cluster", "west")}, value)
+}
+
+func TestFederatedQueryKeepsIdenticalSeriesOnce(t *testing.T) {
+	assert := assert.New(t)
+	federated, east, west := setupFederated()
+
+	// the same series, scraped by the Prometheus of both clusters
+	query := `sum(rate(istio_requests_total{reporter="source",source_workload_namespace="bookinfo"}[60s])) by (source_cluster)`
+	east.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "source_cluster", "east")})
+	west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "source_cluster", "east"), sample(3, "source_cluster", "west")})
+
+	value, _, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(1, "source_cluster", "east"), sample(3, "source_cluster", "west")}, value)
+}
+
+func TestFederatedQueryKeepsSeriesOfFirstCluster(t *testing.T) {
+	assert := assert.New(t)
+	federated, east, west := setupFederated()
+
+	// the same series, scraped at different times by the Prometheus of both clusters
+	query := `sum(rate(istio_requests_total{reporter="source",source_workload_namespace="bookinfo"}[60s])) by (source_cluster)`
+	east.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "source_cluster", "east")})
+	west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1.5, "source_cluster", "east")})
+
+	value, _, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(1, "source_cluster", "east")}, value)
+}
+
+func TestFederatedQueryNotMergeable(t *testing.T) {
+	assert := assert.New(t)
+	def := new(PromAPIMock)
+	east := new(PromAPIMock)
+	west := new(PromAPIMock)
+	federated := prometheus.NewFederatedAPI(def, map[string]prom_v1.API{"east": east, "west": west})
+
+	queries := []string{
+		`sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="bookinfo"}[60s]))`,
+		`sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="bookinfo"}[60s])) by (destination_workload)`,
+		`sum by (source_cluster) (rate(istio_requests_total{reporter="destination"}[60s]))`,
+		`histogram_quantile(0.95, sum(rate(istio_request_duration_milliseconds_bucket{reporter="destination"}[60s])) by (le))`,
+		`sum(rate(istio_requests_total{destination_workload_namespace="bookinfo"}[60s])) by (destination_cluster)`,
+	}
+	for _, query := range queries {
+		def.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1)})
+
+		value, _, err := federated.Query(context.TODO(), query, time.Now())
+		assert.NoError(err)
+		assert.Equal(model.Vector{sample(1)}, value)
+	}
+	east.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	west.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFederatedQueryMergeable(t *testing.T) {
+	assert := assert.New(t)
+
+	queries := []string{
+		`rate(istio_requests_total{destination_workload_namespace="bookinfo"}[60s]) > 0`,
+		`sum by (destination_cluster, le) (rate(istio_request_duration_milliseconds_bucket{reporter="destination"}[60s]))`,
+		`histogram_quantile(0.95, sum(rate(istio_request_duration_milliseconds_bucket{reporter="destination"}[60s])) by (le,destination_cluster))`,
+		`sum(rate(istio_requests_total{reporter="source"}[60s])) without (source_workload)`,
+		`sum(rate(istio_requests_total{}[60s])) by (reporter,source_cluster,destination_cluster)`,
+	}
+	for _, query := range queries {
+		federated, east, west := setupFederated()
+		east.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "destination_cluster", "east")})
+		west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(2, "destination_cluster", "west")})
+
+		value, _, err := federated.Query(context.TODO(), query, time.Now())
+		assert.NoError(err)
+		assert.Len(value, 2, query)
+	}
+}
+
+func TestFederatedQueryRoutesBySingleCluster(t *testing.T) {
+	assert := assert.New(t)
+	federated, east, west := setupFederated()
+
+	// no reporter, the proxies of the cluster report the requests of its workloads
+	query := `rate(istio_requests_total{destination_service_namespace="bookinfo",destination_cluster="west"}[60s]) > 0`
+	west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(3)})
+
+	value, _, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(3)}, value)
+	east.AssertNotCalled(t, "Query", mock.Anything, query, mock.Anything)
+}
+
+func TestFederatedQueryRoutesByCluster(t *testing.T) {
+	assert := assert.New(t)
+	federated, east, west := setupFederated()
+
+	query := `sum(rate(istio_requests_total{reporter="source",source_cluster="west",source_workload="productpage-v1"}[60s]))`
+	west.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(3)})
+
+	value, _, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(3)}, value)
+	east.AssertNotCalled(t, "Query", mock.Anything, query, mock.Anything)
+}
+
+func TestFederatedQueryPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+	east := new(PromAPIMock)
+	federated := prometheus.NewFederatedAPI(new(PromAPIMock), map[string]prom_v1.API{"east": east, "west": new(unavailableAPI)})
+
+	query := `sum(rate(istio_requests_total{reporter="destination",destination_workload_namespace="bookinfo"}[60s])) by (destination_cluster)`
+	east.On("Query", mock.Anything, query, mock.Anything).Return(model.Vector{sample(1, "destination_cluster", "east")})
+
+	value, warnings, err := federated.Query(context.TODO(), query, time.Now())
+	assert.NoError(err)
+	assert.Equal(model.Vector{sample(1, "destination_cluster", "east")}, value)
+	assert.Len(warnings, 1)
+
+	_, _, err = prometheus.NewFederatedAPI(new(PromAPIMock), map[string]prom_v1.API{"west": new(unavailableAPI)}).Query(context.TODO(), query, time.Now())
+	assert.Error(err)
+}
@@ -348,6 +348,106 @@ func NewRoutes() (r *Routes) {
 			handlers.IstioConfigCreate,
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/istio/{object_type}/dryrun config istioConfigCreateDryRun
+		// ---
+		// Endpoint to get the validations the mesh would have after creating an Istio object, without creating it
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioValidationsDryRunResponse
+		//
+		{
+			"IstioConfigCreateDryRun",
+			"POST",
+			"/api/namespaces/{namespace}/istio/{object_type}/dryrun",
+			handlers.IstioConfigCreateDryRun,
+			true,
+		},
+		// swagger:route PATCH /namespaces/{namespace}/istio/{object_type}/{object}/dryrun config istioConfigUpdateDryRun
+		// ---
+		// Endpoint to get the validations the mesh would have after updating an Istio object using Json Merge Patch strategy, without updating it
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioValidationsDryRunResponse
+		//
+		{
+			"IstioConfigUpdateDryRun",
+			"PATCH",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/dryrun",
+			handlers.IstioConfigUpdateDryRun,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/authorizationpolicies/simulate config authorizationPoliciesSimulate
+		// ---
+		// Endpoint to evaluate the AuthorizationPolicies for a request from a source workload to a destination workload of the namespace
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: authorizationSimulationResponse
+		//
+		{
+			"AuthorizationPoliciesSimulate",
+			"POST",
+			"/api/namespaces/{namespace}/istio/authorizationpolicies/simulate",
+			handlers.AuthorizationPoliciesSimulate,
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/virtualservices/simulate config virtualServicesSimulate
+		// ---
+		// Endpoint to resolve the route, destinations and traffic policies of a request sent from the namespace to a host
+		//
+		//     Consumes:
+		//	   - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: routeSimulationResponse
+		//
+		{
+			"VirtualServicesSimulate",
+			"POST",
+			"/api/namespaces/{namespace}/istio/virtualservices/simulate",
+			handlers.VirtualServicesSimulate,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/services services serviceList
 		// ---
 		// Endpoint to get the details of a given service
@@ -1084,6 +1184,213 @@ func NewRoutes() (r *Routes) {
 			handlers.GraphNamespaces,
 			true,
 		},
+		// swagger:route GET /namespaces/graph/diff graphs graphNamespacesDiff
+		// ---
+		// The backing JSON for a namespaces graph, with the nodes and edges annotated as added, removed, changed or
+		// unchanged from the graph of a baseline time window.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphResponse
+		//
+		{
+			"GraphNamespacesDiff",
+			"GET",
+			"/api/namespaces/graph/diff",
+			handlers.GraphNamespacesDiff,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/dependencies graphs graphDependencies
+		// ---
+		// The transitive callers of a node of a namespaces graph, affected by its failure, and its transitive
+		// dependencies.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: graphDependenciesResponse
+		//
+		{
+			"GraphDependencies",
+			"GET",
+			"/api/namespaces/graph/dependencies",
+			handlers.GraphDependencies,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/paths graphs graphPaths
+		// ---
+		// The paths between a source and a destination node of a namespaces graph, ranked by their error rate or
+		// response time.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: graphPathsResponse
+		//
+		{
+			"GraphPaths",
+			"GET",
+			"/api/namespaces/graph/paths",
+			handlers.GraphPaths,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/cost graphs graphCost
+		// ---
+		// The edges of each namespace of a namespaces graph ranked by the estimated cost of the bytes they transfer,
+		// from the locality of their traffic: intra-zone, cross-zone or cross-cluster. The zones are read from the nodes
+		// of the Kiali cluster with the Kiali service account, which needs the cluster-wide permission to list nodes,
+		// the locality is unknown otherwise.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphCostResponse
+		//
+		{
+			"GraphCost",
+			"GET",
+			"/api/namespaces/graph/cost",
+			handlers.GraphCost,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/stream graphs graphNamespacesStream
+		// ---
+		// A stream of Server-Sent Events for a namespaces graph. A graph event holds the backing JSON of the graph,
+		// it is followed by a delta event on every refresh, holding the nodes and edges added, changed or removed
+		// since the previous graph. An error event holds the message of a failed refresh. A stream lasts 25 seconds
+		// and can't be resumed: it carries at most a couple of deltas (2 with the default refresh interval), and the
+		// client reconnecting gets a new stream, starting again with the full graph.
+		//
+		//     Produces:
+		//     - text/event-stream
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      200: graphResponse
+		//
+		{
+			"GraphNamespacesStream",
+			"GET",
+			"/api/namespaces/graph/stream",
+			handlers.GraphNamespacesStream,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/snapshots graphs graphSnapshots
+		// ---
+		// The saved graph snapshots of the namespaces accessible to the client.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: graphSnapshotsResponse
+		//
+		{
+			"GraphSnapshots",
+			"GET",
+			"/api/namespaces/graph/snapshots",
+			handlers.GraphSnapshots,
+			true,
+		},
+		// swagger:route POST /namespaces/graph/snapshots/{snapshot} graphs graphSnapshotSave
+		// ---
+		// Saves the traffic map of a namespaces graph under a name, with its options, so that it can be replayed
+		// later. The name of an existing snapshot is a conflict.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: graphSnapshotResponse
+		//
+		{
+			"GraphSnapshotSave",
+			"POST",
+			"/api/namespaces/graph/snapshots/{snapshot}",
+			handlers.GraphSnapshotSave,
+			true,
+		},
+		// swagger:route GET /namespaces/graph/snapshots/{snapshot} graphs graphSnapshot
+		// ---
+		// The backing JSON of a saved graph snapshot, rendered from its saved traffic map with the requested
+		// configVendor and boxBy, or the diff of a baseline snapshot and the snapshot.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: graphResponse
+		//
+		{
+			"GraphSnapshot",
+			"GET",
+			"/api/namespaces/graph/snapshots/{snapshot}",
+			handlers.GraphSnapshot,
+			true,
+		},
+		// swagger:route DELETE /namespaces/graph/snapshots/{snapshot} graphs graphSnapshotDelete
+		// ---
+		// Deletes a saved graph snapshot.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: graphSnapshotResponse
+		//
+		{
+			"GraphSnapshotDelete",
+			"DELETE",
+			"/api/namespaces/graph/snapshots/{snapshot}",
+			handlers.GraphSnapshotDelete,
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/aggregates/{aggregate}/{aggregateValue}/graph graphs graphAggregate
 		// ---
 		// The backing JSON for an aggregate node detail graph. (supported graphTypes: app | versionedApp | workload)
@@ -80,3 +80,13 @@ func CreateAuthorizationPolicyWithPrincipals(name, namespace string, principalsL
 	}
 	return &ap
 }
+
+func AddActionToAuthorizationPolicy(action api_security_v1beta1.AuthorizationPolicy_Action, ap *security_v1beta1.AuthorizationPolicy) *security_v1beta1.AuthorizationPolicy {
+	ap.Spec.Action = action
+	return ap
+}
+
+func AddRuleToAuthorizationPolicy(rule *api_security_v1beta1.Rule, ap *security_v1beta1.AuthorizationPolicy) *security_v1beta1.AuthorizationPolicy {
+	ap.Spec.Rules = append(ap.Spec.Rules, rule)
+	return ap
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	api_networking_v1alpha3 "istio.io/api/networking/v1alpha3"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func CreateEnvoyFilter(name string, namespace string) *networking_v1alpha3.EnvoyFilter {
+	ef := networking_v1alpha3.EnvoyFilter{}
+	ef.Name = name
+	ef.Namespace = namespace
+	return &ef
+}
+
+func AddSelectorToEnvoyFilter(selector map[string]string, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.WorkloadSelector = &api_networking_v1alpha3.WorkloadSelector{
+		Labels: selector,
+	}
+	return ef
+}
+
+func AddPriorityToEnvoyFilter(priority int32, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.Priority = priority
+	return ef
+}
+
+func AddListenerPatchToEnvoyFilter(listenerName string, portNumber uint32, operation api_networking_v1alpha3.EnvoyFilter_Patch_Operation, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.ConfigPatches = append(ef.Spec.ConfigPatches, &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: api_networking_v1alpha3.EnvoyFilter_LISTENER,
+		Match: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			ObjectTypes: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Listener{
+				Listener: &api_networking_v1alpha3.EnvoyFilter_ListenerMatch{
+					Name:       listenerName,
+					PortNumber: portNumber,
+				},
+			},
+		},
+		Patch: &api_networking_v1alpha3.EnvoyFilter_Patch{Operation: operation},
+	})
+	return ef
+}
+
+func AddClusterPatchToEnvoyFilter(service string, portNumber uint32, operation api_networking_v1alpha3.EnvoyFilter_Patch_Operation, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	ef.Spec.ConfigPatches = append(ef.Spec.ConfigPatches, &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectPatch{
+		ApplyTo: api_networking_v1alpha3.EnvoyFilter_CLUSTER,
+		Match: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{
+			ObjectTypes: &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch_Cluster{
+				Cluster: &api_networking_v1alpha3.EnvoyFilter_ClusterMatch{
+					Service:    service,
+					PortNumber: portNumber,
+				},
+			},
+		},
+		Patch: &api_networking_v1alpha3.EnvoyFilter_Patch{Operation: operation},
+	})
+	return ef
+}
+
+// AddProxyVersionToEnvoyFilter sets the proxyVersion match of the last patch of the EnvoyFilter
+func AddProxyVersionToEnvoyFilter(proxyVersion string, ef *networking_v1alpha3.EnvoyFilter) *networking_v1alpha3.EnvoyFilter {
+	if len(ef.Spec.ConfigPatches) == 0 {
+		return ef
+	}
+	cp := ef.Spec.ConfigPatches[len(ef.Spec.ConfigPatches)-1]
+	if cp.Match == nil {
+		cp.Match = &api_networking_v1alpha3.EnvoyFilter_EnvoyConfigObjectMatch{}
+	}
+	cp.Match.Proxy = &api_networking_v1alpha3.EnvoyFilter_ProxyMatch{ProxyVersion: proxyVersion}
+	return ef
+}
+
+// CreateConfigDump returns a proxy config dump with the given listeners, by name and port, and clusters
+func CreateConfigDump(listeners map[string]float64, clusters []string) *kubernetes.ConfigDump {
+	dynamicListeners := make([]interface{}, 0, len(listeners))
+	for name, port := range listeners {
+		dynamicListeners = append(dynamicListeners, map[string]interface{}{
+			"name": name,
+			"active_state": map[string]interface{}{
+				"listener": map[string]interface{}{
+					"name": name,
+					"address": map[string]interface{}{
+						"socket_address": map[string]interface{}{
+							"address":    "0.0.0.0",
+							"port_value": port,
+						},
+					},
+				},
+			},
+		})
+	}
+	dynamicClusters := make([]interface{}, 0, len(clusters))
+	for _, name := range clusters {
+		dynamicClusters = append(dynamicClusters, map[string]interface{}{
+			"cluster": map[string]interface{}{
+				"name": name,
+			},
+		})
+	}
+	return &kubernetes.ConfigDump{
+		Configs: []interface{}{
+			map[string]interface{}{
+				"@type":             "type.googleapis.com/envoy.admin.v3.ListenersConfigDump",
+				"dynamic_listeners": dynamicListeners,
+			},
+			map[string]interface{}{
+				"@type":                   "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+				"dynamic_active_clusters": dynamicClusters,
+			},
+		},
+	}
+}
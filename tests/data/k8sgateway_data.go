@@ -79,3 +79,88 @@ func UpdateConditionWithError(k8sgw *k8s_networking_v1alpha2.Gateway) *k8s_netwo
 
 	return k8sgw
 }
+
+func AddBackendRefToHTTPRoute(name, namespace string, rt *k8s_networking_v1alpha2.HTTPRoute) *k8s_networking_v1alpha2.HTTPRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1alpha2.HTTPRouteRule{
+		BackendRefs: []k8s_networking_v1alpha2.HTTPBackendRef{{BackendRef: CreateBackendRef(name, namespace)}}})
+	return rt
+}
+
+func CreateGRPCRoute(name string, namespace string, gateway string) *k8s_networking_v1alpha2.GRPCRoute {
+	rt := k8s_networking_v1alpha2.GRPCRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToGRPCRoute(name, namespace string, rt *k8s_networking_v1alpha2.GRPCRoute) *k8s_networking_v1alpha2.GRPCRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1alpha2.GRPCRouteRule{
+		BackendRefs: []k8s_networking_v1alpha2.GRPCBackendRef{{BackendRefs: CreateBackendRef(name, namespace)}}})
+	return rt
+}
+
+func CreateTCPRoute(name string, namespace string, gateway string) *k8s_networking_v1alpha2.TCPRoute {
+	rt := k8s_networking_v1alpha2.TCPRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToTCPRoute(name, namespace string, rt *k8s_networking_v1alpha2.TCPRoute) *k8s_networking_v1alpha2.TCPRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1alpha2.TCPRouteRule{
+		BackendRefs: []k8s_networking_v1alpha2.BackendRef{CreateBackendRef(name, namespace)}})
+	return rt
+}
+
+func CreateTLSRoute(name string, namespace string, gateway string, hosts []string) *k8s_networking_v1alpha2.TLSRoute {
+	rt := k8s_networking_v1alpha2.TLSRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	for _, host := range hosts {
+		rt.Spec.Hostnames = append(rt.Spec.Hostnames, k8s_networking_v1alpha2.Hostname(host))
+	}
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, CreateParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToTLSRoute(name, namespace string, rt *k8s_networking_v1alpha2.TLSRoute) *k8s_networking_v1alpha2.TLSRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1alpha2.TLSRouteRule{
+		BackendRefs: []k8s_networking_v1alpha2.BackendRef{CreateBackendRef(name, namespace)}})
+	return rt
+}
+
+func CreateParentRef(name, namespace string) k8s_networking_v1alpha2.ParentReference {
+	ns := k8s_networking_v1alpha2.Namespace(namespace)
+	group := k8s_networking_v1alpha2.Group(kubernetes.K8sNetworkingGroupVersionV1Beta1.Group)
+	kind := k8s_networking_v1alpha2.Kind(kubernetes.K8sActualGatewayType)
+	return k8s_networking_v1alpha2.ParentReference{
+		Name:      k8s_networking_v1alpha2.ObjectName(name),
+		Namespace: &ns,
+		Group:     &group,
+		Kind:      &kind}
+}
+
+func CreateBackendRef(name, namespace string) k8s_networking_v1alpha2.BackendRef {
+	ns := k8s_networking_v1alpha2.Namespace(namespace)
+	port := k8s_networking_v1alpha2.PortNumber(9080)
+	return k8s_networking_v1alpha2.BackendRef{
+		BackendObjectReference: k8s_networking_v1alpha2.BackendObjectReference{
+			Name:      k8s_networking_v1alpha2.ObjectName(name),
+			Namespace: &ns,
+			Port:      &port}}
+}
+
+func CreateReferenceGrant(name string, namespace string, fromNamespace string, fromKind string) *k8s_networking_v1alpha2.ReferenceGrant {
+	rg := k8s_networking_v1alpha2.ReferenceGrant{}
+	rg.Name = name
+	rg.Namespace = namespace
+	rg.Spec.From = append(rg.Spec.From, k8s_networking_v1alpha2.ReferenceGrantFrom{
+		Group:     k8s_networking_v1alpha2.Group(kubernetes.K8sNetworkingGroupVersionV1Alpha2.Group),
+		Kind:      k8s_networking_v1alpha2.Kind(fromKind),
+		Namespace: k8s_networking_v1alpha2.Namespace(fromNamespace)})
+	rg.Spec.To = append(rg.Spec.To, k8s_networking_v1alpha2.ReferenceGrantTo{
+		Kind: k8s_networking_v1alpha2.Kind(kubernetes.ServiceType)})
+	return &rg
+}
@@ -0,0 +1,50 @@
+package data
+
+import (
+	api_telemetry_v1alpha1 "istio.io/api/telemetry/v1alpha1"
+	api_type_v1beta1 "istio.io/api/type/v1beta1"
+	"istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+)
+
+func CreateTelemetry(name string, namespace string) *v1alpha1.Telemetry {
+	tm := v1alpha1.Telemetry{}
+	tm.Name = name
+	tm.Namespace = namespace
+	return &tm
+}
+
+func AddSelectorToTelemetry(selector map[string]string, tm *v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	tm.Spec.Selector = &api_type_v1beta1.WorkloadSelector{
+		MatchLabels: selector,
+	}
+	return tm
+}
+
+func AddTracingToTelemetry(providers []string, tm *v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	tm.Spec.Tracing = append(tm.Spec.Tracing, &api_telemetry_v1alpha1.Tracing{
+		Providers: createProviderRefs(providers),
+	})
+	return tm
+}
+
+func AddAccessLoggingToTelemetry(providers []string, tm *v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	tm.Spec.AccessLogging = append(tm.Spec.AccessLogging, &api_telemetry_v1alpha1.AccessLogging{
+		Providers: createProviderRefs(providers),
+	})
+	return tm
+}
+
+func AddMetricsToTelemetry(providers []string, tm *v1alpha1.Telemetry) *v1alpha1.Telemetry {
+	tm.Spec.Metrics = append(tm.Spec.Metrics, &api_telemetry_v1alpha1.Metrics{
+		Providers: createProviderRefs(providers),
+	})
+	return tm
+}
+
+func createProviderRefs(providers []string) []*api_telemetry_v1alpha1.ProviderRef {
+	refs := make([]*api_telemetry_v1alpha1.ProviderRef, 0, len(providers))
+	for _, p := range providers {
+		refs = append(refs, &api_telemetry_v1alpha1.ProviderRef{Name: p})
+	}
+	return refs
+}
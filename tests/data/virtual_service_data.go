@@ -110,3 +110,36 @@ func CreateVirtualServiceWithServiceEntryTarget() *networking_v1beta1.VirtualSer
 	}
 	return vs
 }
+
+func CreateHttpRoute(name string, matches []*api_networking_v1beta1.HTTPMatchRequest, routes ...*api_networking_v1beta1.HTTPRouteDestination) *api_networking_v1beta1.HTTPRoute {
+	return &api_networking_v1beta1.HTTPRoute{
+		Name:  name,
+		Match: matches,
+		Route: routes,
+	}
+}
+
+func AddHttpRouteToVirtualService(route *api_networking_v1beta1.HTTPRoute, vs *networking_v1beta1.VirtualService) *networking_v1beta1.VirtualService {
+	vs.Spec.Http = append(vs.Spec.Http, route)
+	return vs
+}
+
+func CreateUriPrefixMatch(prefix string) *api_networking_v1beta1.HTTPMatchRequest {
+	return &api_networking_v1beta1.HTTPMatchRequest{
+		Uri: &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Prefix{Prefix: prefix}},
+	}
+}
+
+func CreateUriExactMatch(uri string) *api_networking_v1beta1.HTTPMatchRequest {
+	return &api_networking_v1beta1.HTTPMatchRequest{
+		Uri: &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: uri}},
+	}
+}
+
+func AddHeaderExactToMatch(header, value string, match *api_networking_v1beta1.HTTPMatchRequest) *api_networking_v1beta1.HTTPMatchRequest {
+	if match.Headers == nil {
+		match.Headers = map[string]*api_networking_v1beta1.StringMatch{}
+	}
+	match.Headers[header] = &api_networking_v1beta1.StringMatch{MatchType: &api_networking_v1beta1.StringMatch_Exact{Exact: value}}
+	return match
+}
@@ -0,0 +1,34 @@
+package data
+
+import (
+	"github.com/golang/protobuf/ptypes/wrappers"
+	api_extensions_v1alpha1 "istio.io/api/extensions/v1alpha1"
+	api_type_v1beta1 "istio.io/api/type/v1beta1"
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+)
+
+func CreateWasmPlugin(name string, namespace string, url string) *extentions_v1alpha1.WasmPlugin {
+	wp := extentions_v1alpha1.WasmPlugin{}
+	wp.Name = name
+	wp.Namespace = namespace
+	wp.Spec.Url = url
+	return &wp
+}
+
+func AddSelectorToWasmPlugin(selector map[string]string, wp *extentions_v1alpha1.WasmPlugin) *extentions_v1alpha1.WasmPlugin {
+	wp.Spec.Selector = &api_type_v1beta1.WorkloadSelector{
+		MatchLabels: selector,
+	}
+	return wp
+}
+
+func AddPhaseToWasmPlugin(phase api_extensions_v1alpha1.PluginPhase, priority int64, wp *extentions_v1alpha1.WasmPlugin) *extentions_v1alpha1.WasmPlugin {
+	wp.Spec.Phase = phase
+	wp.Spec.Priority = &wrappers.Int64Value{Value: priority}
+	return wp
+}
+
+func AddImagePullSecretToWasmPlugin(secret string, wp *extentions_v1alpha1.WasmPlugin) *extentions_v1alpha1.WasmPlugin {
+	wp.Spec.ImagePullSecret = secret
+	return wp
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tools/cmd"
+	"github.com/kiali/kiali/tools/validate"
+)
+
+const (
+	exitValid   = 0
+	exitInvalid = 1
+	exitFailure = 2
+)
+
+var (
+	// paths are the files and directories to validate, given before or after the flags
+	paths []string
+
+	configFlag    string
+	failOnFlag    string
+	namespaceFlag string
+	outputFlag    string
+)
+
+func init() {
+	flag.StringVar(&configFlag, "config", "", "path to a Kiali config file, used for the Istio namespace, root namespace, ignored validation codes and custom validation rules")
+	flag.StringVar(&failOnFlag, "fail-on", string(models.ErrorSeverity), "lowest severity that makes the command exit with a non-zero code: error or warning")
+	flag.StringVar(&namespaceFlag, "namespace", "default", "namespace of the objects that don't define one")
+	flag.StringVar(&outputFlag, "output", "text", "output format: text, json or sarif")
+}
+
+func main() {
+	flag.Usage = cmd.Usage("validate", "<file-or-dir>...")
+	paths = parseArgs(flag.CommandLine, os.Args[1:])
+	cmd.ConfigureKialiLogger()
+
+	os.Exit(run())
+}
+
+// parseArgs parses the flags wherever they are placed and returns the positional arguments. The flag package
+// stops at the first positional argument, so the parsing is resumed after each one.
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	positional := []string{}
+	for {
+		// The flag set exits on errors
+		_ = flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func run() int {
+	if len(paths) == 0 {
+		flag.Usage()
+		return exitFailure
+	}
+	failOn := models.SeverityLevel(failOnFlag)
+	if failOn != models.ErrorSeverity && failOn != models.WarningSeverity {
+		log.Errorf("Invalid --fail-on value [%s], use error or warning", failOnFlag)
+		return exitFailure
+	}
+
+	conf := config.NewConfig()
+	if configFlag != "" {
+		var err error
+		if conf, err = config.LoadFromFile(configFlag); err != nil {
+			log.Errorf("Unable to load config file [%s]: %s", configFlag, err)
+			return exitFailure
+		}
+	}
+	// There is no cluster behind, the registry is built from the manifests
+	conf.InCluster = false
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+
+	manifests, err := validate.LoadManifests(namespaceFlag, paths...)
+	if err != nil {
+		log.Errorf("Unable to load manifests: %s", err)
+		return exitFailure
+	}
+
+	validations, err := validate.Validate(context.Background(), manifests)
+	if err != nil {
+		log.Error(err)
+		return exitFailure
+	}
+	validations.StripIgnoredChecks()
+
+	sources := validate.NewSources(manifests)
+	switch outputFlag {
+	case "text":
+		err = validate.WriteText(os.Stdout, validations, sources)
+	case "json":
+		err = validate.WriteJSON(os.Stdout, validations)
+	case "sarif":
+		err = validate.WriteSARIF(os.Stdout, validations, sources)
+	default:
+		err = fmt.Errorf("invalid --output value [%s], use text, json or sarif", outputFlag)
+	}
+	if err != nil {
+		log.Error(err)
+		return exitFailure
+	}
+
+	if validate.HasErrors(validations, failOn) {
+		return exitInvalid
+	}
+	return exitValid
+}
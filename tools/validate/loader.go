@@ -0,0 +1,137 @@
+package validate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	gatewayapischeme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
+
+	"github.com/kiali/kiali/log"
+)
+
+// Manifest is a Kubernetes object loaded from a file.
+type Manifest struct {
+	Object runtime.Object
+	Kind   schema.GroupVersionKind
+	// Source is the path of the file where the object is defined
+	Source string
+}
+
+var decoder runtime.Decoder
+
+func init() {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{kubescheme.AddToScheme, istioscheme.AddToScheme, gatewayapischeme.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			panic(err)
+		}
+	}
+	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+// LoadManifests reads the Istio, Gateway API and Kubernetes objects defined in the given files.
+// Directories are walked recursively looking for .yaml, .yml and .json files.
+// Objects without namespace are set in defaultNamespace. Unknown kinds are skipped.
+func LoadManifests(defaultNamespace string, paths ...string) ([]Manifest, error) {
+	manifests := make([]Manifest, 0)
+	for _, p := range paths {
+		err := filepath.Walk(p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !isManifestFile(path) {
+				return nil
+			}
+			loaded, err := loadFile(path, defaultNamespace)
+			if err != nil {
+				return err
+			}
+			manifests = append(manifests, loaded...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifests, nil
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func loadFile(path, defaultNamespace string) ([]Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	manifests := make([]Manifest, 0)
+	reader := utilyaml.NewYAMLOrJSONDecoder(bufio.NewReader(f), 4096)
+	for {
+		raw := runtime.RawExtension{}
+		if err := reader.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+				log.Debugf("Skipping unsupported object in %s: %s", path, err)
+				continue
+			}
+			return nil, fmt.Errorf("unable to decode %s: %w", path, err)
+		}
+		// List objects are flattened as kubectl does
+		if meta.IsListType(obj) {
+			items, err := meta.ExtractList(obj)
+			if err != nil {
+				return nil, fmt.Errorf("unable to extract %s from %s: %w", gvk.Kind, path, err)
+			}
+			for _, item := range items {
+				itemKind := item.GetObjectKind().GroupVersionKind()
+				if unknown, ok := item.(*runtime.Unknown); ok {
+					var decodedKind *schema.GroupVersionKind
+					if item, decodedKind, err = decoder.Decode(unknown.Raw, nil, nil); err != nil {
+						log.Debugf("Skipping unsupported object in %s: %s", path, err)
+						continue
+					}
+					itemKind = *decodedKind
+				}
+				manifests = append(manifests, newManifest(item, itemKind, path, defaultNamespace))
+			}
+			continue
+		}
+		manifests = append(manifests, newManifest(obj, *gvk, path, defaultNamespace))
+	}
+	return manifests, nil
+}
+
+func newManifest(obj runtime.Object, gvk schema.GroupVersionKind, path, defaultNamespace string) Manifest {
+	if accessor, err := meta.Accessor(obj); err == nil && accessor.GetNamespace() == "" && !isClusterScoped(obj) {
+		accessor.SetNamespace(defaultNamespace)
+	}
+	return Manifest{Object: obj, Kind: gvk, Source: path}
+}
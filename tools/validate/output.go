@@ -0,0 +1,215 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "kiali-validate"
+	toolURI      = "https://kiali.io/docs/features/validations/"
+)
+
+// Sources maps each validated object to the file where it is defined.
+type Sources map[models.IstioValidationKey]string
+
+// NewSources indexes the source file of the given manifests by validation key.
+func NewSources(manifests []Manifest) Sources {
+	sources := make(Sources)
+	for _, m := range manifests {
+		accessor, err := meta.Accessor(m.Object)
+		if err != nil {
+			continue
+		}
+		sources[models.BuildKey(objectType(m), accessor.GetName(), accessor.GetNamespace())] = m.Source
+	}
+	return sources
+}
+
+// objectType returns the Kiali object type of the manifest, i.e. the singular lowercase kind.
+// Gateway API kinds are prefixed with "k8s" to differentiate them from the Istio ones.
+func objectType(m Manifest) string {
+	kind := strings.ToLower(m.Kind.Kind)
+	if m.Kind.Group == "gateway.networking.k8s.io" {
+		return "k8s" + kind
+	}
+	return kind
+}
+
+// sortedKeys returns the validation keys ordered by namespace, object type and name.
+func sortedKeys(validations models.IstioValidations) []models.IstioValidationKey {
+	keys := make([]models.IstioValidationKey, 0, len(validations))
+	for key := range validations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		if keys[i].ObjectType != keys[j].ObjectType {
+			return keys[i].ObjectType < keys[j].ObjectType
+		}
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
+
+// WriteText writes the objects with checks in a human readable format, followed by a summary.
+func WriteText(w io.Writer, validations models.IstioValidations, sources Sources) error {
+	summary := models.IstioValidationSummary{}
+	for _, key := range sortedKeys(validations) {
+		validation := validations[key]
+		summary.ObjectCount++
+		if len(validation.Checks) == 0 {
+			continue
+		}
+		header := fmt.Sprintf("%s %s/%s", key.ObjectType, key.Namespace, key.Name)
+		if source, ok := sources[key]; ok {
+			header += " (" + source + ")"
+		}
+		if _, err := fmt.Fprintln(w, header); err != nil {
+			return err
+		}
+		for _, check := range validation.Checks {
+			switch check.Severity {
+			case models.ErrorSeverity:
+				summary.Errors++
+			case models.WarningSeverity:
+				summary.Warnings++
+			}
+			line := fmt.Sprintf("  %-7s %s %s", check.Severity, check.Code, check.Message)
+			if check.Path != "" {
+				line += " [" + check.Path + "]"
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d objects validated: %d errors, %d warnings\n", summary.ObjectCount, summary.Errors, summary.Warnings)
+	return err
+}
+
+// WriteJSON writes the validations with the same format used by the Kiali API.
+func WriteJSON(w io.Writer, validations models.IstioValidations) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(validations)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// WriteSARIF writes the checks as results of a SARIF 2.1.0 log, one rule per KIA code.
+func WriteSARIF(w io.Writer, validations models.IstioValidations, sources Sources) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{Name: toolName, InformationURI: toolURI, Rules: []sarifRule{}},
+		},
+		Results: []sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, key := range sortedKeys(validations) {
+		for _, check := range validations[key].Checks {
+			if !rules[check.Code] {
+				rules[check.Code] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: check.Code, ShortDescription: sarifMessage{Text: check.Message}})
+			}
+			fqn := fmt.Sprintf("%s/%s/%s", key.Namespace, key.ObjectType, key.Name)
+			if check.Path != "" {
+				fqn += "/" + check.Path
+			}
+			location := sarifLocation{
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: fqn, Kind: "resource"}},
+			}
+			if source, ok := sources[key]; ok {
+				location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: source}}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    check.Code,
+				Level:     sarifLevel(check.Severity),
+				Message:   sarifMessage{Text: fmt.Sprintf("%s %s/%s: %s", key.ObjectType, key.Namespace, key.Name, check.Message)},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}})
+}
+
+func sarifLevel(severity models.SeverityLevel) string {
+	switch severity {
+	case models.ErrorSeverity:
+		return "error"
+	case models.WarningSeverity:
+		return "warning"
+	default:
+		return "note"
+	}
+}
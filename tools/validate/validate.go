@@ -0,0 +1,196 @@
+// Package validate runs the Kiali validations on a set of manifests without a cluster.
+// The manifests are loaded into the fake clients of the kubetest package and the
+// Istio registry is built from them, so the business layer runs the same checkers it runs in a live server.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	extentions_v1alpha1 "istio.io/client-go/pkg/apis/extensions/v1alpha1"
+	networking_v1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	networking_v1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	security_v1beta "istio.io/client-go/pkg/apis/security/v1beta1"
+	telemetry_v1alpha1 "istio.io/client-go/pkg/apis/telemetry/v1alpha1"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_networking_v1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/cache"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+const exportToAnnotation = "networking.istio.io/exportTo"
+
+// Validate runs the Kiali validations on the given manifests.
+// The Kiali config must be set before calling it, as the business layer reads it globally.
+func Validate(ctx context.Context, manifests []Manifest) (models.IstioValidations, error) {
+	conf := config.Get()
+	objects := make([]runtime.Object, 0, len(manifests))
+	namespaces := make(map[string]bool)
+	hasMeshConfig := false
+	for _, m := range manifests {
+		objects = append(objects, m.Object)
+		if accessor, err := meta.Accessor(m.Object); err == nil && accessor.GetNamespace() != "" {
+			namespaces[accessor.GetNamespace()] = true
+		}
+		if cm, ok := m.Object.(*core_v1.ConfigMap); ok && cm.Namespace == conf.IstioNamespace && cm.Name == conf.ExternalServices.Istio.ConfigMapName {
+			hasMeshConfig = true
+		}
+	}
+
+	// The Istio ConfigMap is required by the validations, a default mesh config is used when not provided
+	if !hasMeshConfig {
+		objects = append(objects, &core_v1.ConfigMap{
+			ObjectMeta: meta_v1.ObjectMeta{Name: conf.ExternalServices.Istio.ConfigMapName, Namespace: conf.IstioNamespace},
+			Data:       map[string]string{"mesh": ""},
+		})
+		namespaces[conf.IstioNamespace] = true
+	}
+	// Namespaces not declared in the manifests are created so the workloads can be listed
+	for _, obj := range objects {
+		if ns, ok := obj.(*core_v1.Namespace); ok {
+			delete(namespaces, ns.Name)
+		}
+	}
+	for ns := range namespaces {
+		objects = append(objects, &core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: ns}})
+	}
+
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	k8s.GatewayAPIEnabled = true
+	business.SetKialiCache(cache.NewRegistryStatusKialiCache(newRegistryStatus(objects)))
+	layer := business.NewWithBackends(k8s, nil, nil)
+
+	validations, err := layer.Validations.GetValidations(ctx, "", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate manifests: %w", err)
+	}
+	return validations, nil
+}
+
+// HasErrors returns true when any of the validations has a check with the given severity or a more severe one.
+func HasErrors(validations models.IstioValidations, failOn models.SeverityLevel) bool {
+	for _, validation := range validations {
+		for _, check := range validation.Checks {
+			if check.Severity == models.ErrorSeverity || (failOn == models.WarningSeverity && check.Severity == models.WarningSeverity) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isClusterScoped(obj runtime.Object) bool {
+	_, ok := obj.(*core_v1.Namespace)
+	return ok
+}
+
+// newRegistryStatus builds the Istio registry that istiod would expose for the given objects.
+func newRegistryStatus(objects []runtime.Object) *kubernetes.RegistryStatus {
+	conf := config.Get()
+	registryStatus := &kubernetes.RegistryStatus{
+		Configuration: &kubernetes.RegistryConfiguration{},
+	}
+	rc := registryStatus.Configuration
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *networking_v1beta1.DestinationRule:
+			rc.DestinationRules = append(rc.DestinationRules, o)
+		case *networking_v1alpha3.EnvoyFilter:
+			rc.EnvoyFilters = append(rc.EnvoyFilters, o)
+		case *networking_v1beta1.Gateway:
+			rc.Gateways = append(rc.Gateways, o)
+		case *networking_v1beta1.ServiceEntry:
+			rc.ServiceEntries = append(rc.ServiceEntries, o)
+			registryStatus.Services = append(registryStatus.Services, serviceEntryRegistryServices(o)...)
+		case *networking_v1beta1.Sidecar:
+			rc.Sidecars = append(rc.Sidecars, o)
+		case *networking_v1beta1.VirtualService:
+			rc.VirtualServices = append(rc.VirtualServices, o)
+		case *networking_v1beta1.WorkloadEntry:
+			rc.WorkloadEntries = append(rc.WorkloadEntries, o)
+		case *networking_v1beta1.WorkloadGroup:
+			rc.WorkloadGroups = append(rc.WorkloadGroups, o)
+		case *extentions_v1alpha1.WasmPlugin:
+			rc.WasmPlugins = append(rc.WasmPlugins, o)
+		case *telemetry_v1alpha1.Telemetry:
+			rc.Telemetries = append(rc.Telemetries, o)
+		case *k8s_networking_v1alpha2.Gateway:
+			rc.K8sGateways = append(rc.K8sGateways, o)
+		case *k8s_networking_v1alpha2.HTTPRoute:
+			rc.K8sHTTPRoutes = append(rc.K8sHTTPRoutes, o)
+		case *k8s_networking_v1alpha2.GRPCRoute:
+			rc.K8sGRPCRoutes = append(rc.K8sGRPCRoutes, o)
+		case *k8s_networking_v1alpha2.TCPRoute:
+			rc.K8sTCPRoutes = append(rc.K8sTCPRoutes, o)
+		case *k8s_networking_v1alpha2.TLSRoute:
+			rc.K8sTLSRoutes = append(rc.K8sTLSRoutes, o)
+		case *k8s_networking_v1alpha2.ReferenceGrant:
+			rc.K8sReferenceGrants = append(rc.K8sReferenceGrants, o)
+		case *security_v1beta.AuthorizationPolicy:
+			rc.AuthorizationPolicies = append(rc.AuthorizationPolicies, o)
+		case *security_v1beta.PeerAuthentication:
+			rc.PeerAuthentications = append(rc.PeerAuthentications, o)
+		case *security_v1beta.RequestAuthentication:
+			rc.RequestAuthentications = append(rc.RequestAuthentications, o)
+		case *core_v1.Service:
+			registryStatus.Services = append(registryStatus.Services, serviceRegistryService(o, conf.ExternalServices.Istio.IstioIdentityDomain))
+		}
+	}
+	return registryStatus
+}
+
+func serviceRegistryService(svc *core_v1.Service, domain string) *kubernetes.RegistryService {
+	rs := kubernetes.RegistryService{}
+	rs.Hostname = fmt.Sprintf("%s.%s.%s", svc.Name, svc.Namespace, domain)
+	rs.IstioService.Attributes.ServiceRegistry = "Kubernetes"
+	rs.IstioService.Attributes.Name = svc.Name
+	rs.IstioService.Attributes.Namespace = svc.Namespace
+	rs.IstioService.Attributes.Labels = svc.Labels
+	rs.IstioService.Attributes.LabelSelectors = svc.Spec.Selector
+	rs.IstioService.Attributes.ExportTo = exportTo(strings.Split(svc.Annotations[exportToAnnotation], ","))
+	for _, port := range svc.Spec.Ports {
+		rs.Ports = append(rs.Ports, struct {
+			Name     string `json:"name,omitempty"`
+			Port     int    `json:"port"`
+			Protocol string `json:"protocol,omitempty"`
+		}{Name: port.Name, Port: int(port.Port), Protocol: string(port.Protocol)})
+	}
+	return &rs
+}
+
+func serviceEntryRegistryServices(se *networking_v1beta1.ServiceEntry) []*kubernetes.RegistryService {
+	services := make([]*kubernetes.RegistryService, 0, len(se.Spec.Hosts))
+	for _, host := range se.Spec.Hosts {
+		rs := kubernetes.RegistryService{}
+		rs.Hostname = host
+		rs.IstioService.Attributes.ServiceRegistry = "External"
+		rs.IstioService.Attributes.Name = host
+		rs.IstioService.Attributes.Namespace = se.Namespace
+		rs.IstioService.Attributes.ExportTo = exportTo(se.Spec.ExportTo)
+		services = append(services, &rs)
+	}
+	return services
+}
+
+// exportTo returns the exportTo map of a registry service, objects are exported to all namespaces by default
+func exportTo(namespaces []string) map[string]bool {
+	result := make(map[string]bool)
+	for _, ns := range namespaces {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			result[ns] = true
+		}
+	}
+	if len(result) == 0 {
+		result["*"] = true
+	}
+	return result
+}
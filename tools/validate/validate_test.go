@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+func setupConfig() {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.CacheEnabled = false
+	config.Set(conf)
+}
+
+func TestLoadManifests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	setupConfig()
+
+	manifests, err := LoadManifests("default", "testdata")
+	require.NoError(err)
+	assert.Len(manifests, 7)
+
+	sources := NewSources(manifests)
+	assert.Equal("testdata/bookinfo.yaml", sources[models.BuildKey("virtualservice", "reviews", "bookinfo")])
+	assert.Equal("testdata/invalid.yaml", sources[models.BuildKey("wasmplugin", "openid", "bookinfo")])
+}
+
+func TestValidateValidManifests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	setupConfig()
+
+	manifests, err := LoadManifests("default", "testdata/bookinfo.yaml")
+	require.NoError(err)
+
+	validations, err := Validate(context.TODO(), manifests)
+	require.NoError(err)
+
+	vs, ok := validations[models.BuildKey("virtualservice", "reviews", "bookinfo")]
+	assert.True(ok)
+	assert.True(vs.Valid)
+	assert.Empty(vs.Checks)
+	assert.False(HasErrors(validations, models.ErrorSeverity))
+}
+
+func TestValidateInvalidManifests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	setupConfig()
+
+	manifests, err := LoadManifests("default", "testdata")
+	require.NoError(err)
+
+	validations, err := Validate(context.TODO(), manifests)
+	require.NoError(err)
+
+	wp, ok := validations[models.BuildKey("wasmplugin", "openid", "bookinfo")]
+	assert.True(ok)
+	assert.False(wp.Valid)
+	assert.True(HasErrors(validations, models.ErrorSeverity))
+
+	var text bytes.Buffer
+	require.NoError(WriteText(&text, validations, NewSources(manifests)))
+	assert.Contains(text.String(), "wasmplugin bookinfo/openid (testdata/invalid.yaml)")
+	assert.Contains(text.String(), "KIA1703")
+
+	var sarif bytes.Buffer
+	require.NoError(WriteSARIF(&sarif, validations, NewSources(manifests)))
+	sarifOutput := sarifLog{}
+	require.NoError(json.Unmarshal(sarif.Bytes(), &sarifOutput))
+	require.Len(sarifOutput.Runs, 1)
+	assert.NotEmpty(sarifOutput.Runs[0].Results)
+	found := false
+	for _, result := range sarifOutput.Runs[0].Results {
+		if result.RuleID == "KIA1703" {
+			found = true
+			assert.Equal("error", result.Level)
+			assert.Equal("testdata/invalid.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		}
+	}
+	assert.True(found)
+}